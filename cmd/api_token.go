@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/internal"
+	"github.com/marcbran/versource/internal/database"
+	"github.com/marcbran/versource/internal/http/client"
+	"github.com/marcbran/versource/internal/tui/apitoken"
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/spf13/cobra"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage API tokens",
+	Long:  `Manage the API tokens used to authenticate against the server`,
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all API tokens",
+	Long:  `List all API tokens together with their user and role`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := apitoken.NewTableData(httpClient)
+		return renderTableData(tableData)
+	},
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new API token",
+	Long:  `Create a new API token for a user with the given role. The token is only shown once.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return fmt.Errorf("failed to get name flag: %w", err)
+		}
+
+		user, err := cmd.Flags().GetString("for")
+		if err != nil {
+			return fmt.Errorf("failed to get for flag: %w", err)
+		}
+
+		role, err := cmd.Flags().GetString("role")
+		if err != nil {
+			return fmt.Errorf("failed to get role flag: %w", err)
+		}
+
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+
+		if user == "" {
+			return fmt.Errorf("user is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.CreateApiTokenRequest{
+			Name: name,
+			User: user,
+			Role: versource.Role(role),
+		}
+
+		resp, err := client.CreateApiToken(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "API token %s created successfully: %s\n", resp.ApiToken.Name, resp.Token)
+	},
+}
+
+var tokenBootstrapCmd = &cobra.Command{
+	Use:   "bootstrap",
+	Short: "Issue the first admin API token",
+	Long:  `Issue the first admin API token directly against the database. Further tokens are created through the server with an admin token.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return fmt.Errorf("failed to get name flag: %w", err)
+		}
+
+		user, err := cmd.Flags().GetString("for")
+		if err != nil {
+			return fmt.Errorf("failed to get for flag: %w", err)
+		}
+
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+
+		if user == "" {
+			return fmt.Errorf("user is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		db, err := database.NewGormDb(config.Database)
+		if err != nil {
+			return err
+		}
+
+		bootstrapApiToken := internal.NewBootstrapApiToken(database.NewGormApiTokenRepo(db), database.NewGormTransactionManager(db))
+
+		req := versource.BootstrapApiTokenRequest{
+			Name: name,
+			User: user,
+		}
+
+		resp, err := bootstrapApiToken.Exec(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "API token %s created successfully: %s\n", resp.ApiToken.Name, resp.Token)
+	},
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke [name]",
+	Short: "Revoke an API token",
+	Long:  `Revoke an API token so it can no longer be used to authenticate`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.RevokeApiTokenRequest{
+			Name: args[0],
+		}
+
+		resp, err := client.RevokeApiToken(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "API token %s revoked successfully\n", resp.ApiToken.Name)
+	},
+}
+
+func init() {
+	tokenCreateCmd.Flags().String("name", "", "API token name")
+	tokenCreateCmd.Flags().String("for", "", "User the API token authenticates as")
+	tokenCreateCmd.Flags().String("role", string(versource.RoleViewer), "Role of the API token (viewer, editor, merger, admin)")
+
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenBootstrapCmd.Flags().String("name", "", "API token name")
+	tokenBootstrapCmd.Flags().String("for", "", "User the API token authenticates as")
+
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenBootstrapCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+}
@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+	"strconv"
+
 	"github.com/marcbran/versource/internal/http/client"
 	"github.com/marcbran/versource/internal/tui/apply"
 	"github.com/marcbran/versource/pkg/versource"
@@ -34,6 +38,7 @@ var applyGetCmd = &cobra.Command{
 
 		return waitForTaskCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			detailData,
 			func(resp versource.GetApplyResponse) bool {
@@ -53,8 +58,28 @@ var applyListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return err
+		}
+		state, err := cmd.Flags().GetString("state")
+		if err != nil {
+			return err
+		}
+		createdAfter, err := cmd.Flags().GetString("created-after")
+		if err != nil {
+			return err
+		}
+		createdBefore, err := cmd.Flags().GetString("created-before")
+		if err != nil {
+			return err
+		}
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
 		httpClient := client.New(config)
-		tableData := apply.NewTableData(httpClient)
+		tableData := apply.NewTableData(httpClient, changeset, state, createdAfter, createdBefore, page)
 
 		waitForCompletion, err := cmd.Flags().GetBool("wait-for-completion")
 		if err != nil {
@@ -63,6 +88,7 @@ var applyListCmd = &cobra.Command{
 
 		return waitForTableCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			tableData,
 			func(applies []versource.Apply) bool {
@@ -77,9 +103,55 @@ var applyListCmd = &cobra.Command{
 	},
 }
 
+var applyLogsCmd = &cobra.Command{
+	Use:   "logs [apply-id]",
+	Short: "Show the logs of an apply",
+	Long:  `Show the logs of an apply, optionally following them until the apply has finished`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applyID, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid apply ID: %w", err)
+		}
+
+		follow, err := cmd.Flags().GetBool("follow")
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.GetApplyLogRequest{
+			ApplyID: uint(applyID),
+			Follow:  follow,
+		}
+
+		resp, err := client.GetApplyLog(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+		defer resp.Content.Close()
+
+		_, err = io.Copy(cmd.OutOrStdout(), resp.Content)
+		return err
+	},
+}
+
 func init() {
 	applyGetCmd.Flags().Bool("wait-for-completion", false, "Wait for the apply to reach a terminal state before returning")
 	applyListCmd.Flags().Bool("wait-for-completion", false, "Wait for all applies to reach terminal states before returning")
+	applyListCmd.Flags().String("changeset", "", "Filter applies by changeset name")
+	applyListCmd.Flags().String("state", "", "Filter applies by state")
+	applyListCmd.Flags().String("created-after", "", "Only list applies created at or after this RFC3339 time")
+	applyListCmd.Flags().String("created-before", "", "Only list applies created before this RFC3339 time")
+	addPageFlags(applyListCmd, "id, state, created")
 	applyCmd.AddCommand(applyGetCmd)
+	applyLogsCmd.Flags().BoolP("follow", "f", false, "Stream new log lines until the apply has finished")
 	applyCmd.AddCommand(applyListCmd)
+	applyCmd.AddCommand(applyLogsCmd)
 }
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/internal/http/client"
+	"github.com/marcbran/versource/internal/tui/audit"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the audit log",
+	Long:  `Inspect who changed what and when across main, admin and changeset branches`,
+}
+
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List audit events",
+	Long:  `List audit events, newest first, optionally filtered by branch and author`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		branch, err := cmd.Flags().GetString("branch")
+		if err != nil {
+			return fmt.Errorf("failed to get branch flag: %w", err)
+		}
+
+		author, err := cmd.Flags().GetString("author")
+		if err != nil {
+			return fmt.Errorf("failed to get author flag: %w", err)
+		}
+
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := audit.NewTableData(httpClient, branch, author, page)
+		return renderTableData(tableData)
+	},
+}
+
+func init() {
+	auditListCmd.Flags().String("branch", "", "Only list events of the given branch")
+	auditListCmd.Flags().String("author", "", "Only list events by the given author")
+	addPageFlags(auditListCmd, "date")
+
+	auditCmd.AddCommand(auditListCmd)
+}
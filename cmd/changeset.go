@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/marcbran/versource/internal/http/client"
 	"github.com/marcbran/versource/internal/tui/changeset"
@@ -30,6 +31,11 @@ var changesetCreateCmd = &cobra.Command{
 			return fmt.Errorf("name is required")
 		}
 
+		parent, err := cmd.Flags().GetString("parent")
+		if err != nil {
+			return fmt.Errorf("failed to get parent flag: %w", err)
+		}
+
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
@@ -38,7 +44,8 @@ var changesetCreateCmd = &cobra.Command{
 		client := client.New(config)
 
 		req := versource.CreateChangesetRequest{
-			Name: name,
+			Name:   name,
+			Parent: parent,
 		}
 
 		changeset, err := client.CreateChangeset(cmd.Context(), req)
@@ -55,12 +62,37 @@ var changesetListCmd = &cobra.Command{
 	Short: "List all changesets",
 	Long:  `List all changesets in the system`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		includeClosed, err := cmd.Flags().GetBool("include-closed")
+		if err != nil {
+			return fmt.Errorf("failed to get include-closed flag: %w", err)
+		}
+
+		state, err := cmd.Flags().GetString("state")
+		if err != nil {
+			return fmt.Errorf("failed to get state flag: %w", err)
+		}
+
+		createdAfter, err := cmd.Flags().GetString("created-after")
+		if err != nil {
+			return fmt.Errorf("failed to get created-after flag: %w", err)
+		}
+
+		createdBefore, err := cmd.Flags().GetString("created-before")
+		if err != nil {
+			return fmt.Errorf("failed to get created-before flag: %w", err)
+		}
+
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
 		}
 		httpClient := client.New(config)
-		tableData := changeset.NewTableData(httpClient)
+		tableData := changeset.NewTableData(httpClient, includeClosed, state, createdAfter, createdBefore, page)
 		return renderTableData(tableData)
 	},
 }
@@ -68,7 +100,7 @@ var changesetListCmd = &cobra.Command{
 var changesetMergeCmd = &cobra.Command{
 	Use:   "merge [changeset-name]",
 	Short: "Merge a changeset",
-	Long:  `Merge a changeset branch into main`,
+	Long:  `Enqueue a changeset for merging into main. Queued changesets are rebased onto main and replanned before they are merged`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		changesetName := args[0]
@@ -92,7 +124,7 @@ var changesetMergeCmd = &cobra.Command{
 			return err
 		}
 
-		return formatOutput(merge, "Merge operation created for changeset %s (ID: %d)\n", changesetName, merge.Merge.ID)
+		return formatOutput(merge, "Merge operation queued for changeset %s (ID: %d)\n", changesetName, merge.Merge.ID)
 	},
 }
 
@@ -158,6 +190,155 @@ var changesetDeleteCmd = &cobra.Command{
 	},
 }
 
+var changesetUpdateCmd = &cobra.Command{
+	Use:   "update [changeset-name]",
+	Short: "Update a changeset",
+	Long:  `Update the settings of a changeset`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changesetName := args[0]
+		if changesetName == "" {
+			return fmt.Errorf("changeset name is required")
+		}
+
+		if !cmd.Flags().Changed("auto-rebase") {
+			return fmt.Errorf("at least one field must be provided to update")
+		}
+
+		autoRebase, err := cmd.Flags().GetBool("auto-rebase")
+		if err != nil {
+			return fmt.Errorf("failed to get auto-rebase flag: %w", err)
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.UpdateChangesetRequest{
+			ChangesetName: changesetName,
+			AutoRebase:    &autoRebase,
+		}
+
+		resp, err := client.UpdateChangeset(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Changeset %s updated successfully\n", changesetName)
+	},
+}
+
+var changesetCloseCmd = &cobra.Command{
+	Use:   "close [changeset-name]",
+	Short: "Close a changeset",
+	Long:  `Close a changeset while keeping its branch, plans and logs so that it can be reopened later`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changesetName := args[0]
+		if changesetName == "" {
+			return fmt.Errorf("changeset name is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.CloseChangesetRequest{
+			ChangesetName: changesetName,
+		}
+
+		resp, err := client.CloseChangeset(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Changeset %s closed successfully\n", changesetName)
+	},
+}
+
+var changesetReopenCmd = &cobra.Command{
+	Use:   "reopen [changeset-name]",
+	Short: "Reopen a closed changeset",
+	Long:  `Reopen a closed changeset and report whether it needs to be rebased onto main`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changesetName := args[0]
+		if changesetName == "" {
+			return fmt.Errorf("changeset name is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.ReopenChangesetRequest{
+			ChangesetName: changesetName,
+		}
+
+		resp, err := client.ReopenChangeset(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		if resp.RebaseRequired {
+			return formatOutput(resp, "Changeset %s reopened successfully, main has moved and a rebase is required\n", changesetName)
+		}
+		return formatOutput(resp, "Changeset %s reopened successfully\n", changesetName)
+	},
+}
+
+var changesetRevertCmd = &cobra.Command{
+	Use:   "revert [changeset-name]",
+	Short: "Revert a merged changeset",
+	Long:  `Create a new changeset that restores every component touched by a merged changeset to its state before the merge`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changesetName := args[0]
+		if changesetName == "" {
+			return fmt.Errorf("changeset name is required")
+		}
+
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return fmt.Errorf("failed to get name flag: %w", err)
+		}
+
+		selector, err := cmd.Flags().GetString("selector")
+		if err != nil {
+			return fmt.Errorf("failed to get selector flag: %w", err)
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.RevertChangesetRequest{
+			ChangesetName: changesetName,
+			Name:          name,
+			Selector:      selector,
+		}
+
+		resp, err := client.RevertChangeset(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Changeset %s reverted in new changeset %s\n", changesetName, resp.Changeset.Name)
+	},
+}
+
 var changesetChangeCmd = &cobra.Command{
 	Use:   "change",
 	Short: "Manage changeset changes",
@@ -181,8 +362,16 @@ var changesetChangeListCmd = &cobra.Command{
 		if changesetName == "" {
 			return fmt.Errorf("changeset is required")
 		}
+		selector, err := cmd.Flags().GetString("selector")
+		if err != nil {
+			return fmt.Errorf("failed to get selector flag: %w", err)
+		}
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
 		httpClient := client.New(config)
-		tableData := component.NewChangesetChangesTableData(httpClient, changesetName)
+		tableData := component.NewChangesetChangesTableData(httpClient, changesetName, selector, page)
 
 		waitForCompletion, err := cmd.Flags().GetBool("wait-for-completion")
 		if err != nil {
@@ -191,6 +380,7 @@ var changesetChangeListCmd = &cobra.Command{
 
 		return waitForTableCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			tableData,
 			allPlansCompleted,
@@ -198,6 +388,96 @@ var changesetChangeListCmd = &cobra.Command{
 	},
 }
 
+var changesetConflictCmd = &cobra.Command{
+	Use:   "conflict",
+	Short: "Manage changeset conflicts",
+	Long:  `Manage changeset conflicts`,
+}
+
+var changesetConflictListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List conflicts in a changeset",
+	Long:  `List all components that were changed both in a specific changeset and on main`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		changesetName, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+		if changesetName == "" {
+			return fmt.Errorf("changeset is required")
+		}
+		httpClient := client.New(config)
+		tableData := component.NewConflictsTableData(httpClient, changesetName)
+		return renderTableData(tableData)
+	},
+}
+
+var changesetApproveCmd = &cobra.Command{
+	Use:   "approve [changeset-name]",
+	Short: "Approve a changeset",
+	Long:  `Approve a changeset on behalf of the current user so that components owned by the user's teams can be merged`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changesetName := args[0]
+		if changesetName == "" {
+			return fmt.Errorf("changeset name is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.ApproveChangesetRequest{
+			ChangesetName: changesetName,
+		}
+
+		resp, err := client.ApproveChangeset(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.MissingApprovals) > 0 {
+			return formatOutput(resp, "Changeset %s approved, still requires approval from team %s\n", changesetName, strings.Join(resp.MissingApprovals, ", "))
+		}
+		return formatOutput(resp, "Changeset %s approved successfully\n", changesetName)
+	},
+}
+
+var changesetApprovalCmd = &cobra.Command{
+	Use:   "approval",
+	Short: "Manage changeset approvals",
+	Long:  `Manage changeset approvals`,
+}
+
+var changesetApprovalListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List approvals of a changeset",
+	Long:  `List all approvals that were given for a specific changeset`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		changesetName, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+		if changesetName == "" {
+			return fmt.Errorf("changeset is required")
+		}
+		httpClient := client.New(config)
+		tableData := changeset.NewApprovalsTableData(httpClient, changesetName)
+		return renderTableData(tableData)
+	},
+}
+
 func allPlansCompleted(changes []versource.ComponentChange) bool {
 	for _, change := range changes {
 		if change.Plan == nil {
@@ -213,17 +493,46 @@ func allPlansCompleted(changes []versource.ComponentChange) bool {
 func init() {
 	changesetCreateCmd.Flags().String("name", "", "Changeset name")
 	_ = changesetCreateCmd.MarkFlagRequired("name")
+	changesetCreateCmd.Flags().String("parent", "", "Name of an open changeset to stack the new changeset on")
+
+	changesetListCmd.Flags().Bool("include-closed", false, "Include closed changesets")
+	changesetListCmd.Flags().String("state", "", "Filter changesets by state")
+	changesetListCmd.Flags().String("created-after", "", "Only list changesets created at or after this RFC3339 time")
+	changesetListCmd.Flags().String("created-before", "", "Only list changesets created before this RFC3339 time")
+	addPageFlags(changesetListCmd, "id, name, state, created")
+
+	changesetRevertCmd.Flags().String("name", "", "Name of the revert changeset (defaults to revert-<changeset-name>)")
+	changesetRevertCmd.Flags().String("selector", "", "Only revert components matching the label selector, e.g. env=prod,team!=data")
+
+	changesetUpdateCmd.Flags().Bool("auto-rebase", false, "Automatically rebase the changeset when main moves and no components overlap")
 
 	changesetChangeListCmd.Flags().String("changeset", "", "Changeset name")
+	changesetChangeListCmd.Flags().String("selector", "", "Filter changes by label selector, e.g. env=prod,team!=data")
 	_ = changesetChangeListCmd.MarkFlagRequired("changeset")
 	changesetChangeListCmd.Flags().Bool("wait-for-completion", false, "Wait until all plans in the changeset are completed")
+	addPageFlags(changesetChangeListCmd, "id, name, type")
 
 	changesetChangeCmd.AddCommand(changesetChangeListCmd)
 
+	changesetConflictListCmd.Flags().String("changeset", "", "Changeset name")
+	_ = changesetConflictListCmd.MarkFlagRequired("changeset")
+
+	changesetConflictCmd.AddCommand(changesetConflictListCmd)
+
+	changesetApprovalListCmd.Flags().String("changeset", "", "Changeset name")
+	changesetApprovalCmd.AddCommand(changesetApprovalListCmd)
+
 	changesetCmd.AddCommand(changesetCreateCmd)
 	changesetCmd.AddCommand(changesetListCmd)
 	changesetCmd.AddCommand(changesetChangeCmd)
+	changesetCmd.AddCommand(changesetConflictCmd)
+	changesetCmd.AddCommand(changesetApproveCmd)
+	changesetCmd.AddCommand(changesetApprovalCmd)
 	changesetCmd.AddCommand(changesetMergeCmd)
 	changesetCmd.AddCommand(changesetRebaseCmd)
+	changesetCmd.AddCommand(changesetUpdateCmd)
+	changesetCmd.AddCommand(changesetCloseCmd)
+	changesetCmd.AddCommand(changesetReopenCmd)
+	changesetCmd.AddCommand(changesetRevertCmd)
 	changesetCmd.AddCommand(changesetDeleteCmd)
 }
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/marcbran/versource/internal/http/client"
 	"github.com/marcbran/versource/internal/tui/component"
+	"github.com/marcbran/versource/internal/tui/environment"
 	"github.com/marcbran/versource/pkg/versource"
 	"github.com/spf13/cobra"
 )
@@ -28,12 +32,17 @@ var componentGetCmd = &cobra.Command{
 			return fmt.Errorf("failed to get changeset flag: %w", err)
 		}
 
+		asOf, err := cmd.Flags().GetString("as-of")
+		if err != nil {
+			return fmt.Errorf("failed to get as-of flag: %w", err)
+		}
+
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
 		}
 		httpClient := client.New(config)
-		detailData := component.NewDetailData(httpClient, args[0], changeset)
+		detailData := component.NewDetailData(httpClient, args[0], changeset, asOf)
 		return renderViewportViewData(detailData)
 	},
 }
@@ -58,12 +67,42 @@ var componentListCmd = &cobra.Command{
 			return fmt.Errorf("failed to get changeset flag: %w", err)
 		}
 
+		asOf, err := cmd.Flags().GetString("as-of")
+		if err != nil {
+			return fmt.Errorf("failed to get as-of flag: %w", err)
+		}
+
+		selector, err := cmd.Flags().GetString("selector")
+		if err != nil {
+			return fmt.Errorf("failed to get selector flag: %w", err)
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			return fmt.Errorf("failed to get owner flag: %w", err)
+		}
+
+		template, err := cmd.Flags().GetString("template")
+		if err != nil {
+			return fmt.Errorf("failed to get template flag: %w", err)
+		}
+
+		environment, err := cmd.Flags().GetString("environment")
+		if err != nil {
+			return fmt.Errorf("failed to get environment flag: %w", err)
+		}
+
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
 		}
 		httpClient := client.New(config)
-		tableData := component.NewTableData(httpClient, moduleIDStr, moduleVersionIDStr, changeset)
+		tableData := component.NewTableData(httpClient, moduleIDStr, moduleVersionIDStr, changeset, asOf, selector, owner, template, environment, page)
 		return renderTableData(tableData)
 	},
 }
@@ -92,6 +131,41 @@ var componentCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to get variable flags: %w", err)
 		}
 
+		labels, err := cmd.Flags().GetStringToString("label")
+		if err != nil {
+			return fmt.Errorf("failed to get label flags: %w", err)
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			return fmt.Errorf("failed to get owner flag: %w", err)
+		}
+
+		variableSets, err := cmd.Flags().GetStringSlice("variable-set")
+		if err != nil {
+			return fmt.Errorf("failed to get variable-set flag: %w", err)
+		}
+
+		template, err := cmd.Flags().GetString("template")
+		if err != nil {
+			return fmt.Errorf("failed to get template flag: %w", err)
+		}
+
+		environment, err := cmd.Flags().GetString("environment")
+		if err != nil {
+			return fmt.Errorf("failed to get environment flag: %w", err)
+		}
+
+		overrideMap, err := cmd.Flags().GetStringToString("override")
+		if err != nil {
+			return fmt.Errorf("failed to get override flags: %w", err)
+		}
+
+		secretMap, err := cmd.Flags().GetStringToString("secret")
+		if err != nil {
+			return fmt.Errorf("failed to get secret flags: %w", err)
+		}
+
 		if name == "" {
 			return fmt.Errorf("name is required")
 		}
@@ -112,6 +186,19 @@ var componentCreateCmd = &cobra.Command{
 			return err
 		}
 
+		var overrides map[string]any
+		if len(overrideMap) > 0 {
+			overrides, err = parseVariables(overrideMap)
+			if err != nil {
+				return err
+			}
+		}
+
+		secrets, err := parseVariables(secretMap)
+		if err != nil {
+			return err
+		}
+
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
@@ -124,6 +211,13 @@ var componentCreateCmd = &cobra.Command{
 			ChangesetName: changeset,
 			Name:          name,
 			Variables:     variables,
+			Labels:        labels,
+			Owner:         owner,
+			VariableSets:  variableSets,
+			Template:      template,
+			Environment:   environment,
+			Overrides:     overrides,
+			Secrets:       secrets,
 		}
 
 		component, err := client.CreateComponent(cmd.Context(), req)
@@ -162,10 +256,45 @@ var componentUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to get variable flags: %w", err)
 		}
 
+		labels, err := cmd.Flags().GetStringToString("label")
+		if err != nil {
+			return fmt.Errorf("failed to get label flags: %w", err)
+		}
+
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			return fmt.Errorf("failed to get owner flag: %w", err)
+		}
+
+		variableSets, err := cmd.Flags().GetStringSlice("variable-set")
+		if err != nil {
+			return fmt.Errorf("failed to get variable-set flag: %w", err)
+		}
+
+		overrideMap, err := cmd.Flags().GetStringToString("override")
+		if err != nil {
+			return fmt.Errorf("failed to get override flags: %w", err)
+		}
+
+		secretMap, err := cmd.Flags().GetStringToString("secret")
+		if err != nil {
+			return fmt.Errorf("failed to get secret flags: %w", err)
+		}
+
+		removeSecrets, err := cmd.Flags().GetStringSlice("remove-secret")
+		if err != nil {
+			return fmt.Errorf("failed to get remove-secret flag: %w", err)
+		}
+
+		expectedRevision, err := cmd.Flags().GetString("expected-revision")
+		if err != nil {
+			return fmt.Errorf("failed to get expected-revision flag: %w", err)
+		}
+
 		if changeset == "" {
 			return fmt.Errorf("changeset is required")
 		}
-		if moduleIDStr == "" && len(variableMap) == 0 {
+		if moduleIDStr == "" && len(variableMap) == 0 && len(secretMap) == 0 && len(removeSecrets) == 0 && !cmd.Flags().Changed("label") && !cmd.Flags().Changed("owner") && !cmd.Flags().Changed("variable-set") && !cmd.Flags().Changed("override") {
 			return fmt.Errorf("at least one field must be provided to update")
 		}
 
@@ -196,6 +325,33 @@ var componentUpdateCmd = &cobra.Command{
 			}
 			req.Variables = &variables
 		}
+		if cmd.Flags().Changed("label") {
+			req.Labels = &labels
+		}
+		if cmd.Flags().Changed("owner") {
+			req.Owner = &owner
+		}
+		if cmd.Flags().Changed("variable-set") {
+			req.VariableSets = &variableSets
+		}
+		if cmd.Flags().Changed("override") {
+			overrides, err := parseVariables(overrideMap)
+			if err != nil {
+				return err
+			}
+			req.Overrides = &overrides
+		}
+		if len(secretMap) > 0 {
+			secrets, err := parseVariables(secretMap)
+			if err != nil {
+				return err
+			}
+			req.Secrets = secrets
+		}
+		req.RemoveSecrets = removeSecrets
+		if expectedRevision != "" {
+			req.ExpectedRevision = &expectedRevision
+		}
 
 		component, err := client.UpdateComponent(cmd.Context(), req)
 		if err != nil {
@@ -206,6 +362,115 @@ var componentUpdateCmd = &cobra.Command{
 	},
 }
 
+var componentRenameCmd = &cobra.Command{
+	Use:   "rename [component-id]",
+	Short: "Rename a component",
+	Long:  `Rename a component in a changeset while keeping its state`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		componentID, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid component ID: %w", err)
+		}
+
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return fmt.Errorf("failed to get name flag: %w", err)
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.UpdateComponentRequest{
+			ComponentID:   uint(componentID),
+			ChangesetName: changeset,
+			Name:          &name,
+		}
+
+		component, err := client.UpdateComponent(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(component, "Component %d renamed to %s\n", component.Component.ID, component.Component.Name)
+	},
+}
+
+var componentMoveCmd = &cobra.Command{
+	Use:   "move [component-id]",
+	Short: "Move a component to a different module",
+	Long:  `Switch a component to the latest version of a different module while carrying its state over, using moved blocks for the given resource address mappings`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		componentID, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid component ID: %w", err)
+		}
+
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		moduleID, err := cmd.Flags().GetUint("module-id")
+		if err != nil {
+			return fmt.Errorf("failed to get module-id flag: %w", err)
+		}
+
+		moveFlags, err := cmd.Flags().GetStringArray("move")
+		if err != nil {
+			return fmt.Errorf("failed to get move flags: %w", err)
+		}
+
+		moves, err := parseResourceMoves(moveFlags)
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.UpdateComponentRequest{
+			ComponentID:   uint(componentID),
+			ChangesetName: changeset,
+			ModuleID:      &moduleID,
+			Moves:         moves,
+		}
+
+		component, err := client.UpdateComponent(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(component, "Component %d moved to module %s\n", component.Component.ID, component.Component.ModuleVersion.Module.Name)
+	},
+}
+
+func parseResourceMoves(moveFlags []string) ([]versource.ResourceMove, error) {
+	var moves []versource.ResourceMove
+	for _, moveFlag := range moveFlags {
+		from, to, found := strings.Cut(moveFlag, "=")
+		if !found || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid move %q: expected from=to", moveFlag)
+		}
+		moves = append(moves, versource.ResourceMove{From: from, To: to})
+	}
+	return moves, nil
+}
+
 var componentDeleteCmd = &cobra.Command{
 	Use:   "delete [component-id]",
 	Short: "Delete a component",
@@ -332,6 +597,242 @@ var componentRestoreCmd = &cobra.Command{
 	},
 }
 
+var componentResolveCmd = &cobra.Command{
+	Use:   "resolve [component-id]",
+	Short: "Resolve a component conflict",
+	Long:  `Resolve a conflict between a changeset and main by keeping the changeset version (ours), taking the main version (theirs) or providing variables manually`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		componentIDStr := args[0]
+		componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid component ID: %w", err)
+		}
+
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		resolutionStr, err := cmd.Flags().GetString("resolution")
+		if err != nil {
+			return fmt.Errorf("failed to get resolution flag: %w", err)
+		}
+
+		variableMap, err := cmd.Flags().GetStringToString("variable")
+		if err != nil {
+			return fmt.Errorf("failed to get variable flags: %w", err)
+		}
+
+		otherMap, err := cmd.Flags().GetStringToString("other")
+		if err != nil {
+			return fmt.Errorf("failed to get other flags: %w", err)
+		}
+
+		if changeset == "" {
+			return fmt.Errorf("changeset is required")
+		}
+
+		resolution, err := parseConflictResolution(resolutionStr)
+		if err != nil {
+			return err
+		}
+
+		others := make([]versource.ComponentConflictChoice, 0, len(otherMap))
+		for otherIDStr, otherResolutionStr := range otherMap {
+			otherID, err := strconv.ParseUint(otherIDStr, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid component ID: %w", err)
+			}
+			otherResolution, err := parseConflictResolution(otherResolutionStr)
+			if err != nil {
+				return err
+			}
+			if otherResolution == versource.ConflictResolutionManual {
+				return fmt.Errorf("other components can only be resolved with ours or theirs")
+			}
+			others = append(others, versource.ComponentConflictChoice{
+				ComponentID: uint(otherID),
+				Resolution:  otherResolution,
+			})
+		}
+		slices.SortFunc(others, func(a, b versource.ComponentConflictChoice) int {
+			return cmp.Compare(a.ComponentID, b.ComponentID)
+		})
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.ResolveComponentConflictRequest{
+			ComponentID:   uint(componentID),
+			ChangesetName: changeset,
+			Resolution:    resolution,
+			Others:        others,
+		}
+
+		if resolution == versource.ConflictResolutionManual {
+			variables, err := parseVariables(variableMap)
+			if err != nil {
+				return err
+			}
+			req.Variables = &variables
+		}
+
+		component, err := client.ResolveComponentConflict(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(component, "Component conflict resolved successfully for ID: %d\n", component.Component.ID)
+	},
+}
+
+func parseConflictResolution(resolution string) (versource.ConflictResolution, error) {
+	switch resolution {
+	case "ours":
+		return versource.ConflictResolutionOurs, nil
+	case "theirs":
+		return versource.ConflictResolutionTheirs, nil
+	case "manual":
+		return versource.ConflictResolutionManual, nil
+	default:
+		return "", fmt.Errorf("resolution must be one of ours, theirs or manual")
+	}
+}
+
+var componentHistoryCmd = &cobra.Command{
+	Use:   "history [component-id]",
+	Short: "Show the history of a component",
+	Long:  `Show every revision of a component on main together with the changeset, plan and apply that rolled it out`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := component.NewHistoryTableData(httpClient, args[0], page)
+		return renderTableData(tableData)
+	},
+}
+
+var componentRevertCmd = &cobra.Command{
+	Use:   "revert [component-id]",
+	Short: "Revert a component to a previous revision",
+	Long:  `Stage the variables and module version of a previous revision of a component in a changeset`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		componentIDStr := args[0]
+		componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid component ID: %w", err)
+		}
+
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		commit, err := cmd.Flags().GetString("commit")
+		if err != nil {
+			return fmt.Errorf("failed to get commit flag: %w", err)
+		}
+
+		if changeset == "" {
+			return fmt.Errorf("changeset is required")
+		}
+		if commit == "" {
+			return fmt.Errorf("commit is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.RevertComponentToRevisionRequest{
+			ComponentID:   uint(componentID),
+			ChangesetName: changeset,
+			Commit:        commit,
+		}
+
+		component, err := client.RevertComponentToRevision(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(component, "Component %d reverted to revision %s\n", component.Component.ID, commit)
+	},
+}
+
+var componentPromotionCmd = &cobra.Command{
+	Use:   "promotion [template]",
+	Short: "Show the promotion path of a template",
+	Long:  `Show the component of a template in every environment and whether it has been promoted there`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := environment.NewPromotionTableData(httpClient, args[0])
+		return renderTableData(tableData)
+	},
+}
+
+var componentPromoteCmd = &cobra.Command{
+	Use:   "promote [template]",
+	Short: "Promote a template to the next environment",
+	Long:  `Open a changeset that applies the definition of a template in one environment to the next environment`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return fmt.Errorf("failed to get from flag: %w", err)
+		}
+
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		if from == "" {
+			return fmt.Errorf("from is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.PromoteComponentRequest{
+			Template:        args[0],
+			FromEnvironment: from,
+			ChangesetName:   changeset,
+		}
+
+		resp, err := client.PromoteComponent(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Template %s promoted to environment %s in changeset %s\n", args[0], resp.Component.Environment, resp.Changeset.Name)
+	},
+}
+
 func parseVariables(variableMap map[string]string) (map[string]any, error) {
 	variables := make(map[string]any)
 
@@ -367,15 +868,29 @@ func parseVariables(variableMap map[string]string) (map[string]any, error) {
 
 func init() {
 	componentGetCmd.Flags().String("changeset", "", "Filter component by changeset name")
+	componentGetCmd.Flags().String("as-of", "", "Get component as of a tag or commit")
 
 	componentListCmd.Flags().String("module-id", "", "Filter components by module ID")
 	componentListCmd.Flags().String("module-version-id", "", "Filter components by module version ID")
 	componentListCmd.Flags().String("changeset", "", "Filter components by changeset name")
+	componentListCmd.Flags().String("as-of", "", "List components as of a tag or commit")
+	componentListCmd.Flags().String("selector", "", "Filter components by label selector, e.g. env=prod,team!=data")
+	componentListCmd.Flags().String("owner", "", "Filter components by owning team")
+	componentListCmd.Flags().String("template", "", "Filter components by template")
+	componentListCmd.Flags().String("environment", "", "Filter components by environment")
+	addPageFlags(componentListCmd, "id, name, owner, environment")
 
 	componentCreateCmd.Flags().String("name", "", "Component name")
 	componentCreateCmd.Flags().String("module-id", "", "Module ID (will use latest version)")
 	componentCreateCmd.Flags().String("changeset", "", "Component changeset")
 	componentCreateCmd.Flags().StringToString("variable", nil, "Component variable in key=value format (can be used multiple times)")
+	componentCreateCmd.Flags().StringToString("label", nil, "Component label in key=value format (can be used multiple times)")
+	componentCreateCmd.Flags().String("owner", "", "Owning team (defaults to the module owner)")
+	componentCreateCmd.Flags().StringSlice("variable-set", nil, "Variable set to attach, in increasing order of precedence (can be used multiple times)")
+	componentCreateCmd.Flags().String("template", "", "Template the component belongs to")
+	componentCreateCmd.Flags().String("environment", "", "Environment of the component within its template")
+	componentCreateCmd.Flags().StringToString("override", nil, "Environment override in key=value format (can be used multiple times)")
+	componentCreateCmd.Flags().StringToString("secret", nil, "Secret variable in key=value format, stored encrypted (can be used multiple times)")
 	_ = componentCreateCmd.MarkFlagRequired("name")
 	_ = componentCreateCmd.MarkFlagRequired("module-id")
 	_ = componentCreateCmd.MarkFlagRequired("changeset")
@@ -383,8 +898,26 @@ func init() {
 	componentUpdateCmd.Flags().String("changeset", "", "Changeset name")
 	componentUpdateCmd.Flags().String("module-id", "", "Module ID (will use latest version)")
 	componentUpdateCmd.Flags().StringToString("variable", nil, "Component variable in key=value format (can be used multiple times)")
+	componentUpdateCmd.Flags().StringToString("label", nil, "Component label in key=value format, replacing all existing labels (can be used multiple times)")
+	componentUpdateCmd.Flags().String("owner", "", "Owning team")
+	componentUpdateCmd.Flags().StringSlice("variable-set", nil, "Variable set to attach, in increasing order of precedence, replacing all existing attachments (can be used multiple times)")
+	componentUpdateCmd.Flags().StringToString("override", nil, "Environment override in key=value format, replacing all existing overrides (can be used multiple times)")
+	componentUpdateCmd.Flags().StringToString("secret", nil, "Secret variable in key=value format to set or rotate, stored encrypted (can be used multiple times)")
+	componentUpdateCmd.Flags().StringSlice("remove-secret", nil, "Secret variable to remove (can be used multiple times)")
+	componentUpdateCmd.Flags().String("expected-revision", "", "Reject the update if the component has been modified since this revision")
 	_ = componentUpdateCmd.MarkFlagRequired("changeset")
 
+	componentRenameCmd.Flags().String("changeset", "", "Changeset name")
+	componentRenameCmd.Flags().String("name", "", "New component name")
+	_ = componentRenameCmd.MarkFlagRequired("changeset")
+	_ = componentRenameCmd.MarkFlagRequired("name")
+
+	componentMoveCmd.Flags().String("changeset", "", "Changeset name")
+	componentMoveCmd.Flags().Uint("module-id", 0, "Module ID to move the component to (will use latest version)")
+	componentMoveCmd.Flags().StringArray("move", nil, "Resource address mapping in from=to format (can be used multiple times)")
+	_ = componentMoveCmd.MarkFlagRequired("changeset")
+	_ = componentMoveCmd.MarkFlagRequired("module-id")
+
 	componentDeleteCmd.Flags().String("changeset", "", "Changeset name")
 	_ = componentDeleteCmd.MarkFlagRequired("changeset")
 
@@ -394,11 +927,36 @@ func init() {
 	componentRestoreCmd.Flags().String("changeset", "", "Changeset name")
 	_ = componentRestoreCmd.MarkFlagRequired("changeset")
 
+	addPageFlags(componentHistoryCmd, "order")
+
+	componentRevertCmd.Flags().String("changeset", "", "Changeset name")
+	componentRevertCmd.Flags().String("commit", "", "Commit of the revision to revert to")
+	_ = componentRevertCmd.MarkFlagRequired("changeset")
+	_ = componentRevertCmd.MarkFlagRequired("commit")
+
+	componentPromoteCmd.Flags().String("from", "", "Environment to promote from")
+	componentPromoteCmd.Flags().String("changeset", "", "Changeset name (defaults to promote-<template>-<environment>)")
+	_ = componentPromoteCmd.MarkFlagRequired("from")
+
+	componentResolveCmd.Flags().String("changeset", "", "Changeset name")
+	componentResolveCmd.Flags().String("resolution", "", "Resolution strategy: ours, theirs or manual")
+	componentResolveCmd.Flags().StringToString("variable", nil, "Component variable in key=value format for manual resolutions (can be used multiple times)")
+	componentResolveCmd.Flags().StringToString("other", nil, "Another conflicting component to resolve in the same step in component-id=ours|theirs format (can be used multiple times)")
+	_ = componentResolveCmd.MarkFlagRequired("changeset")
+	_ = componentResolveCmd.MarkFlagRequired("resolution")
+
 	componentCmd.AddCommand(componentGetCmd)
 	componentCmd.AddCommand(componentListCmd)
 	componentCmd.AddCommand(componentCreateCmd)
+	componentCmd.AddCommand(componentRenameCmd)
+	componentCmd.AddCommand(componentMoveCmd)
 	componentCmd.AddCommand(componentUpdateCmd)
 	componentCmd.AddCommand(componentDeleteCmd)
 	componentCmd.AddCommand(componentPlanCmd)
 	componentCmd.AddCommand(componentRestoreCmd)
+	componentCmd.AddCommand(componentResolveCmd)
+	componentCmd.AddCommand(componentHistoryCmd)
+	componentCmd.AddCommand(componentRevertCmd)
+	componentCmd.AddCommand(componentPromotionCmd)
+	componentCmd.AddCommand(componentPromoteCmd)
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -50,11 +51,30 @@ func LoadConfig(cmd *cobra.Command) (*versource.Config, error) {
 		return nil, err
 	}
 	httpConfig := LoadHttpConfig(v)
+	secretsConfig, err := LoadSecretsConfig(v)
+	if err != nil {
+		return nil, err
+	}
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return nil, err
+	}
+	if user != "" {
+		httpConfig.User = user
+	}
+	token, err := cmd.Flags().GetString("token")
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		httpConfig.Token = token
+	}
 
 	return &versource.Config{
 		Database:  dbConfig,
 		Terraform: tfConfig,
 		HTTP:      httpConfig,
+		Secrets:   secretsConfig,
 	}, nil
 }
 
@@ -96,8 +116,26 @@ func LoadHttpConfig(v *viper.Viper) *versource.HttpConfig {
 	v.SetDefault("http.port", "8080")
 
 	return &versource.HttpConfig{
-		Scheme:   v.GetString("http.scheme"),
-		Hostname: v.GetString("http.hostname"),
-		Port:     v.GetString("http.port"),
+		Scheme:          v.GetString("http.scheme"),
+		Hostname:        v.GetString("http.hostname"),
+		Port:            v.GetString("http.port"),
+		User:            v.GetString("http.user"),
+		Token:           v.GetString("http.token"),
+		TrustUserHeader: v.GetBool("http.trustuserheader"),
+	}
+}
+
+func LoadSecretsConfig(v *viper.Viper) (*versource.SecretsConfig, error) {
+	key := v.GetString("secrets.key")
+	if keyFile := v.GetString("secrets.keyfile"); key == "" && keyFile != "" {
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secrets key file: %w", err)
+		}
+		key = strings.TrimSpace(string(content))
 	}
+
+	return &versource.SecretsConfig{
+		Key: key,
+	}, nil
 }
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/internal/http/client"
+	"github.com/marcbran/versource/internal/tui/environment"
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/spf13/cobra"
+)
+
+var environmentCmd = &cobra.Command{
+	Use:   "environment",
+	Short: "Manage environments",
+	Long:  `Manage the ordered environments components are promoted through`,
+}
+
+var environmentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all environments",
+	Long:  `List all environments in promotion order`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := environment.NewTableData(httpClient)
+		return renderTableData(tableData)
+	},
+}
+
+var environmentCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new environment",
+	Long:  `Create a new environment at the end of the promotion path`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return fmt.Errorf("failed to get name flag: %w", err)
+		}
+
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.CreateEnvironmentRequest{
+			Name: name,
+		}
+
+		resp, err := client.CreateEnvironment(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Environment created successfully with ID: %d\n", resp.Environment.ID)
+	},
+}
+
+func init() {
+	environmentCreateCmd.Flags().String("name", "", "Environment name")
+
+	environmentCmd.AddCommand(environmentListCmd)
+	environmentCmd.AddCommand(environmentCreateCmd)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/internal/http/client"
+	"github.com/marcbran/versource/internal/store/file"
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [directory]",
+	Short: "Export the inventory",
+	Long:  `Export modules, module versions, components, states, resources, view resources and teams together with their terraform states into an archive directory`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("failed to get format flag: %w", err)
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		resp, err := client.ExportInventory(cmd.Context(), versource.ExportInventoryRequest{})
+		if err != nil {
+			return err
+		}
+
+		err = file.NewInventoryArchive(args[0]).Write(resp.Inventory, format)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp.Inventory, "Inventory exported to %s\n", args[0])
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import [directory]",
+	Short: "Import an inventory",
+	Long:  `Import an inventory archive directory created by export into an empty instance, keeping all IDs stable`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inventory, err := file.NewInventoryArchive(args[0]).Read()
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.ImportInventoryRequest{
+			Inventory: *inventory,
+		}
+
+		resp, err := client.ImportInventory(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Imported %d modules, %d module versions, %d components, %d states, %d view resources and %d teams\n",
+			resp.Modules, resp.ModuleVersions, resp.Components, resp.States, resp.ViewResources, resp.Teams)
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("format", "yaml", "Archive file format (yaml or json)")
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/marcbran/versource/internal/http/client"
 	"github.com/marcbran/versource/internal/tui/merge"
 	"github.com/marcbran/versource/pkg/versource"
@@ -38,6 +40,7 @@ var mergeGetCmd = &cobra.Command{
 
 		return waitForTaskCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			detailData,
 			func(resp versource.GetMergeResponse) bool {
@@ -57,13 +60,17 @@ var mergeListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
 
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
 		}
 		httpClient := client.New(config)
-		tableData := merge.NewTableData(httpClient, changeset)
+		tableData := merge.NewTableData(httpClient, changeset, page)
 
 		waitForCompletion, err := cmd.Flags().GetBool("wait-for-completion")
 		if err != nil {
@@ -72,6 +79,7 @@ var mergeListCmd = &cobra.Command{
 
 		return waitForTableCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			tableData,
 			func(merges []versource.Merge) bool {
@@ -86,12 +94,65 @@ var mergeListCmd = &cobra.Command{
 	},
 }
 
+var mergeValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a changeset merge",
+	Long:  `Run the merge validation checks for a changeset without attempting the merge`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+
+		resp, err := httpClient.ValidateMerge(cmd.Context(), versource.ValidateMergeRequest{
+			ChangesetName: changeset,
+		})
+		if err != nil {
+			return err
+		}
+
+		return renderValue(resp, func() string {
+			if resp.Valid {
+				return fmt.Sprintf("Changeset %s can be merged\n", changeset)
+			}
+			columns, rows, _ := merge.NewValidationTableData(httpClient, changeset).ResolveData(resp.Findings)
+			return renderTable(columns, rows)
+		})
+	},
+}
+
+var mergeQueueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "List the merge queue",
+	Long:  `List queued and running merges in the order they will be processed`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := merge.NewQueueTableData(httpClient)
+		return renderTableData(tableData)
+	},
+}
+
 func init() {
 	mergeGetCmd.Flags().Bool("wait-for-completion", false, "Wait for the merge to reach a terminal state before returning")
 	mergeGetCmd.Flags().String("changeset", "", "Changeset name (required)")
 	_ = mergeGetCmd.MarkFlagRequired("changeset")
 	mergeListCmd.Flags().String("changeset", "", "Changeset name (optional)")
 	mergeListCmd.Flags().Bool("wait-for-completion", false, "Wait for all merges to reach terminal states before returning")
+	addPageFlags(mergeListCmd, "id, state")
 	mergeCmd.AddCommand(mergeGetCmd)
 	mergeCmd.AddCommand(mergeListCmd)
+	mergeValidateCmd.Flags().String("changeset", "", "Changeset name (required)")
+	_ = mergeValidateCmd.MarkFlagRequired("changeset")
+	mergeCmd.AddCommand(mergeValidateCmd)
+	mergeCmd.AddCommand(mergeQueueCmd)
 }
@@ -37,12 +37,22 @@ var moduleListCmd = &cobra.Command{
 	Short: "List all modules",
 	Long:  `List all modules in the system`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		asOf, err := cmd.Flags().GetString("as-of")
+		if err != nil {
+			return fmt.Errorf("failed to get as-of flag: %w", err)
+		}
+
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
 		}
 		httpClient := client.New(config)
-		tableData := module.NewTableData(httpClient)
+		tableData := module.NewTableData(httpClient, asOf, page)
 		return renderTableData(tableData)
 	},
 }
@@ -72,6 +82,11 @@ var moduleCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to get executor flag: %w", err)
 		}
 
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			return fmt.Errorf("failed to get owner flag: %w", err)
+		}
+
 		if name == "" {
 			return fmt.Errorf("name is required")
 		}
@@ -92,6 +107,7 @@ var moduleCreateCmd = &cobra.Command{
 			Source:       source,
 			Version:      version,
 			ExecutorType: executorType,
+			Owner:        owner,
 		}
 
 		module, err := client.CreateModule(cmd.Context(), req)
@@ -214,17 +230,26 @@ var moduleVersionListCmd = &cobra.Command{
 			moduleID = &moduleIDStr
 		}
 
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+
 		httpClient := client.New(config)
-		tableData := module.NewVersionsTableData(httpClient, moduleID)
+		tableData := module.NewVersionsTableData(httpClient, moduleID, page)
 		return renderTableData(tableData)
 	},
 }
 
 func init() {
+	moduleListCmd.Flags().String("as-of", "", "List modules as of a tag or commit")
+	addPageFlags(moduleListCmd, "id, name, owner")
+
 	moduleCreateCmd.Flags().String("name", "", "Module name")
 	moduleCreateCmd.Flags().String("source", "", "Module source")
 	moduleCreateCmd.Flags().String("version", "", "Module version (optional for some source types)")
 	moduleCreateCmd.Flags().String("executor", "terraform-jsonnet", "Executor type (terraform-module, terraform-jsonnet)")
+	moduleCreateCmd.Flags().String("owner", "", "Owning team")
 	_ = moduleCreateCmd.MarkFlagRequired("name")
 	_ = moduleCreateCmd.MarkFlagRequired("source")
 
@@ -232,6 +257,7 @@ func init() {
 	_ = moduleUpdateCmd.MarkFlagRequired("version")
 
 	moduleVersionListCmd.Flags().String("module-id", "", "Filter versions by module ID")
+	addPageFlags(moduleVersionListCmd, "id, version")
 
 	moduleVersionCmd.AddCommand(moduleVersionGetCmd)
 	moduleVersionCmd.AddCommand(moduleVersionListCmd)
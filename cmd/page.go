@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/spf13/cobra"
+)
+
+func addPageFlags(cmd *cobra.Command, sortFields string) {
+	cmd.Flags().Int("limit", 0, "Maximum number of results to return (defaults to 100)")
+	cmd.Flags().String("cursor", "", "Cursor returned by a previous list to continue from")
+	cmd.Flags().String("sort", "", fmt.Sprintf("Field to sort by (%s), prefixed with - for descending order", sortFields))
+}
+
+func getPageRequest(cmd *cobra.Command) (versource.PageRequest, error) {
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return versource.PageRequest{}, fmt.Errorf("failed to get limit flag: %w", err)
+	}
+
+	cursor, err := cmd.Flags().GetString("cursor")
+	if err != nil {
+		return versource.PageRequest{}, fmt.Errorf("failed to get cursor flag: %w", err)
+	}
+
+	sort, err := cmd.Flags().GetString("sort")
+	if err != nil {
+		return versource.PageRequest{}, fmt.Errorf("failed to get sort flag: %w", err)
+	}
+
+	return versource.PageRequest{
+		Cursor: cursor,
+		Limit:  limit,
+		Sort:   sort,
+	}, nil
+}
@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+	"strconv"
+
 	"github.com/marcbran/versource/internal/http/client"
 	"github.com/marcbran/versource/internal/tui/plan"
 	"github.com/marcbran/versource/pkg/versource"
@@ -38,6 +42,7 @@ var planGetCmd = &cobra.Command{
 
 		return waitForTaskCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			detailData,
 			func(resp versource.GetPlanResponse) bool {
@@ -61,8 +66,28 @@ var planListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		selector, err := cmd.Flags().GetString("selector")
+		if err != nil {
+			return err
+		}
+		state, err := cmd.Flags().GetString("state")
+		if err != nil {
+			return err
+		}
+		createdAfter, err := cmd.Flags().GetString("created-after")
+		if err != nil {
+			return err
+		}
+		createdBefore, err := cmd.Flags().GetString("created-before")
+		if err != nil {
+			return err
+		}
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
 		httpClient := client.New(config)
-		tableData := plan.NewTableData(httpClient, changeset)
+		tableData := plan.NewTableData(httpClient, changeset, selector, state, createdAfter, createdBefore, page)
 
 		waitForCompletion, err := cmd.Flags().GetBool("wait-for-completion")
 		if err != nil {
@@ -71,6 +96,7 @@ var planListCmd = &cobra.Command{
 
 		return waitForTableCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			tableData,
 			func(plans []versource.Plan) bool {
@@ -85,11 +111,66 @@ var planListCmd = &cobra.Command{
 	},
 }
 
+var planLogsCmd = &cobra.Command{
+	Use:   "logs [plan-id]",
+	Short: "Show the logs of a plan",
+	Long:  `Show the logs of a plan, optionally following them until the plan has finished`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		planID, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid plan ID: %w", err)
+		}
+
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return err
+		}
+
+		follow, err := cmd.Flags().GetBool("follow")
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.GetPlanLogRequest{
+			PlanID: uint(planID),
+			Follow: follow,
+		}
+		if changeset != "" {
+			req.ChangesetName = &changeset
+		}
+
+		resp, err := client.GetPlanLog(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+		defer resp.Content.Close()
+
+		_, err = io.Copy(cmd.OutOrStdout(), resp.Content)
+		return err
+	},
+}
+
 func init() {
 	planGetCmd.Flags().Bool("wait-for-completion", false, "Wait for the plan to reach a terminal state before returning")
 	planGetCmd.Flags().String("changeset", "", "Changeset name to get the plan from")
 	planListCmd.Flags().String("changeset", "", "Changeset name (optional)")
+	planListCmd.Flags().String("selector", "", "Filter plans by label selector of their components, e.g. env=prod,team!=data")
 	planListCmd.Flags().Bool("wait-for-completion", false, "Wait for all plans to reach terminal states before returning")
+	planListCmd.Flags().String("state", "", "Filter plans by state")
+	planListCmd.Flags().String("created-after", "", "Only list plans created at or after this RFC3339 time")
+	planListCmd.Flags().String("created-before", "", "Only list plans created before this RFC3339 time")
+	addPageFlags(planListCmd, "id, state, created")
 	planCmd.AddCommand(planGetCmd)
+	planLogsCmd.Flags().String("changeset", "", "Changeset name to get the plan logs from")
+	planLogsCmd.Flags().BoolP("follow", "f", false, "Stream new log lines until the plan has finished")
 	planCmd.AddCommand(planListCmd)
+	planCmd.AddCommand(planLogsCmd)
 }
@@ -38,6 +38,7 @@ var rebaseGetCmd = &cobra.Command{
 
 		return waitForTaskCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			detailData,
 			func(resp versource.GetRebaseResponse) bool {
@@ -57,13 +58,17 @@ var rebaseListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
 
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
 		}
 		httpClient := client.New(config)
-		tableData := rebase.NewTableData(httpClient, changeset)
+		tableData := rebase.NewTableData(httpClient, changeset, page)
 
 		waitForCompletion, err := cmd.Flags().GetBool("wait-for-completion")
 		if err != nil {
@@ -72,6 +77,7 @@ var rebaseListCmd = &cobra.Command{
 
 		return waitForTableCompletion(
 			ctx,
+			httpClient,
 			waitForCompletion,
 			tableData,
 			func(rebases []versource.Rebase) bool {
@@ -92,6 +98,7 @@ func init() {
 	_ = rebaseGetCmd.MarkFlagRequired("changeset")
 	rebaseListCmd.Flags().String("changeset", "", "Changeset name (optional)")
 	rebaseListCmd.Flags().Bool("wait-for-completion", false, "Wait for all rebases to reach terminal states before returning")
+	addPageFlags(rebaseListCmd, "id, state")
 	rebaseCmd.AddCommand(rebaseGetCmd)
 	rebaseCmd.AddCommand(rebaseListCmd)
 }
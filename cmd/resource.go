@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/marcbran/versource/internal/http/client"
 	"github.com/marcbran/versource/internal/tui/resource"
 	"github.com/spf13/cobra"
@@ -17,16 +19,41 @@ var resourceListCmd = &cobra.Command{
 	Short: "List all resources",
 	Long:  `List all resources in the system`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		asOf, err := cmd.Flags().GetString("as-of")
+		if err != nil {
+			return fmt.Errorf("failed to get as-of flag: %w", err)
+		}
+
+		provider, err := cmd.Flags().GetString("provider")
+		if err != nil {
+			return fmt.Errorf("failed to get provider flag: %w", err)
+		}
+
+		resourceType, err := cmd.Flags().GetString("resource-type")
+		if err != nil {
+			return fmt.Errorf("failed to get resource-type flag: %w", err)
+		}
+
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
 		}
 		httpClient := client.New(config)
-		tableData := resource.NewTableData(httpClient)
+		tableData := resource.NewTableData(httpClient, asOf, provider, resourceType, page)
 		return renderTableData(tableData)
 	},
 }
 
 func init() {
+	resourceListCmd.Flags().String("as-of", "", "List resources as of a tag or commit")
+	resourceListCmd.Flags().String("provider", "", "Filter resources by provider")
+	resourceListCmd.Flags().String("resource-type", "", "Filter resources by resource type")
+	addPageFlags(resourceListCmd, "uuid, provider, type, name")
+
 	resourceCmd.AddCommand(resourceListCmd)
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -25,6 +26,8 @@ var rootCmd = &cobra.Command{
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format (text or json)")
 	rootCmd.PersistentFlags().String("config", "default", "Configuration key to use (defaults to 'default')")
+	rootCmd.PersistentFlags().String("user", "", "User to act as when talking to the server (overrides http.user)")
+	rootCmd.PersistentFlags().String("token", "", "API token to authenticate with when talking to the server (overrides http.token)")
 	rootCmd.AddCommand(applyCmd)
 	rootCmd.AddCommand(changesetCmd)
 	rootCmd.AddCommand(componentCmd)
@@ -34,6 +37,15 @@ func init() {
 	rootCmd.AddCommand(planCmd)
 	rootCmd.AddCommand(resourceCmd)
 	rootCmd.AddCommand(viewResourceCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(teamCmd)
+	rootCmd.AddCommand(variableSetCmd)
+	rootCmd.AddCommand(environmentCmd)
+	rootCmd.AddCommand(tokenCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(webhookCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(uiCmd)
@@ -66,7 +78,7 @@ func renderViewportViewData[T any, V any](detailData platform.ViewportViewData[T
 }
 
 func renderTableData[T any](tableData platform.TableData[T]) error {
-	resp, err := tableData.LoadData()
+	resp, err := loadTableData(tableData)
 	if err != nil {
 		return err
 	}
@@ -76,6 +88,21 @@ func renderTableData[T any](tableData platform.TableData[T]) error {
 	})
 }
 
+func loadTableData[T any](tableData platform.TableData[T]) ([]T, error) {
+	paged, ok := tableData.(platform.PagedTableData[T])
+	if !ok {
+		return tableData.LoadData()
+	}
+	data, nextCursor, err := paged.LoadPage("")
+	if err != nil {
+		return nil, err
+	}
+	if nextCursor != "" {
+		fmt.Fprintf(os.Stderr, "More results available, continue with --cursor %s\n", nextCursor)
+	}
+	return data, nil
+}
+
 func renderViewModel[T any, V any](data T, viewModelFunc func() V) error {
 	return renderValue(data, func() string {
 		viewModel := viewModelFunc()
@@ -155,10 +182,19 @@ func renderTable(columns []table.Column, rows []table.Row) string {
 
 func waitForTaskCompletion[T any, V any](
 	ctx context.Context,
+	facade versource.Facade,
 	waitForCompletion bool,
 	detailData platform.ViewportViewData[T, V],
 	isCompleted func(T) bool,
 ) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var events <-chan versource.Event
+	if waitForCompletion {
+		events = subscribeTaskEvents(ctx, facade)
+	}
+
 	data, err := detailData.LoadData()
 	if err != nil {
 		return err
@@ -170,36 +206,38 @@ func waitForTaskCompletion[T any, V any](
 		})
 	}
 
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
-			data, err = detailData.LoadData()
-			if err != nil {
-				return err
-			}
-
-			if !isCompleted(*data) {
-				continue
-			}
-
-			return renderViewModel(*data, func() V {
-				return detailData.ResolveData(*data)
-			})
+	err = waitForChange(ctx, events, func() (bool, error) {
+		data, err = detailData.LoadData()
+		if err != nil {
+			return false, err
 		}
+		return isCompleted(*data), nil
+	})
+	if err != nil {
+		return err
 	}
+
+	return renderViewModel(*data, func() V {
+		return detailData.ResolveData(*data)
+	})
 }
 
 func waitForTableCompletion[T any](
 	ctx context.Context,
+	facade versource.Facade,
 	waitForCompletion bool,
 	tableData platform.TableData[T],
 	isCompleted func([]T) bool,
 ) error {
-	data, err := tableData.LoadData()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var events <-chan versource.Event
+	if waitForCompletion {
+		events = subscribeTaskEvents(ctx, facade)
+	}
+
+	data, err := loadTableData(tableData)
 	if err != nil {
 		return err
 	}
@@ -211,26 +249,66 @@ func waitForTableCompletion[T any](
 		})
 	}
 
-	ticker := time.NewTicker(2 * time.Second)
+	err = waitForChange(ctx, events, func() (bool, error) {
+		data, err = tableData.LoadData()
+		if err != nil {
+			return false, err
+		}
+		return isCompleted(data), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return renderValue(data, func() string {
+		columns, rows, _ := tableData.ResolveData(data)
+		return renderTable(columns, rows)
+	})
+}
+
+var taskEventTypes = []versource.EventType{
+	versource.EventTypePlanStateChanged,
+	versource.EventTypeApplyStateChanged,
+	versource.EventTypeMergeStateChanged,
+	versource.EventTypeRebaseStateChanged,
+	versource.EventTypeChangesetMerged,
+}
+
+func subscribeTaskEvents(ctx context.Context, facade versource.Facade) <-chan versource.Event {
+	resp, err := facade.SubscribeEvents(ctx, versource.SubscribeEventsRequest{Types: taskEventTypes})
+	if err != nil {
+		return nil
+	}
+	return resp.Events
+}
+
+func waitForChange(ctx context.Context, events <-chan versource.Event, isCompleted func() (bool, error)) error {
+	pollInterval := 2 * time.Second
+	if events != nil {
+		pollInterval = 30 * time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-ticker.C:
-			data, err = tableData.LoadData()
-			if err != nil {
-				return err
-			}
-
-			if !isCompleted(data) {
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				ticker.Reset(2 * time.Second)
 				continue
 			}
+		case <-ticker.C:
+		}
 
-			return renderValue(data, func() string {
-				columns, rows, _ := tableData.ResolveData(data)
-				return renderTable(columns, rows)
-			})
+		completed, err := isCompleted()
+		if err != nil {
+			return err
+		}
+		if completed {
+			return nil
 		}
 	}
 }
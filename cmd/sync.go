@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/http/client"
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync [directory]",
+	Short: "Sync component manifests into a changeset",
+	Long:  `Read a directory of YAML or JSON component manifests and create, update or delete components in a changeset so that it matches the manifests exactly`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to get dry-run flag: %w", err)
+		}
+
+		selector, err := cmd.Flags().GetString("selector")
+		if err != nil {
+			return fmt.Errorf("failed to get selector flag: %w", err)
+		}
+
+		manifests, err := loadComponentManifests(args[0])
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.SyncComponentsRequest{
+			ChangesetName: changeset,
+			Manifests:     manifests,
+			DryRun:        dryRun,
+			Selector:      selector,
+		}
+
+		resp, err := client.SyncComponents(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return renderValue(resp, func() string {
+			columns, rows := syncChangesTable(resp.Changes)
+			text := renderTable(columns, rows)
+			if dryRun {
+				return text
+			}
+			return text + fmt.Sprintf("Changeset %s synced with %d plans\n", changeset, len(resp.Plans))
+		})
+	},
+}
+
+func loadComponentManifests(dir string) ([]versource.ComponentManifest, error) {
+	var manifests []versource.ComponentManifest
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open manifest %s: %w", path, err)
+		}
+		defer file.Close()
+
+		decoder := yaml.NewDecoder(file)
+		for {
+			var manifest versource.ComponentManifest
+			err := decoder.Decode(&manifest)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to parse manifest %s: %w", path, err)
+			}
+			manifests = append(manifests, manifest)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
+
+func syncChangesTable(changes []versource.ComponentChange) ([]table.Column, []table.Row) {
+	columns := []table.Column{
+		{Title: "Change", Width: 2},
+		{Title: "ID", Width: 1},
+		{Title: "Name", Width: 3},
+		{Title: "Module", Width: 3},
+		{Title: "Version", Width: 3},
+	}
+
+	var rows []table.Row
+	for _, change := range changes {
+		component := change.ToComponent
+		if component == nil {
+			component = change.FromComponent
+		}
+		if component == nil {
+			continue
+		}
+
+		id := ""
+		if component.ID != 0 {
+			id = strconv.FormatUint(uint64(component.ID), 10)
+		}
+		rows = append(rows, table.Row{
+			string(change.ChangeType),
+			id,
+			component.Name,
+			component.ModuleVersion.Module.Name,
+			component.ModuleVersion.Version,
+		})
+	}
+
+	return columns, rows
+}
+
+func init() {
+	syncCmd.Flags().String("changeset", "", "Changeset name")
+	_ = syncCmd.MarkFlagRequired("changeset")
+	syncCmd.Flags().Bool("dry-run", false, "Print the planned changes without modifying the changeset")
+	syncCmd.Flags().String("selector", "", "Only sync components matching the label selector, e.g. env=prod,team!=data")
+}
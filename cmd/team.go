@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/internal/http/client"
+	"github.com/marcbran/versource/internal/tui/team"
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/spf13/cobra"
+)
+
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Manage teams",
+	Long:  `Manage teams`,
+}
+
+var teamListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all teams",
+	Long:  `List all teams and their members`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := team.NewTableData(httpClient)
+		return renderTableData(tableData)
+	},
+}
+
+var teamCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new team",
+	Long:  `Create a new team with a name`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return fmt.Errorf("failed to get name flag: %w", err)
+		}
+
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.CreateTeamRequest{
+			Name: name,
+		}
+
+		resp, err := client.CreateTeam(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Team created successfully with ID: %d\n", resp.Team.ID)
+	},
+}
+
+var teamMemberCmd = &cobra.Command{
+	Use:   "member",
+	Short: "Manage team members",
+	Long:  `Manage team members`,
+}
+
+var teamMemberAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a member to a team",
+	Long:  `Add a user to a team`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		teamName, user, err := getTeamMemberFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.AddTeamMemberRequest{
+			TeamName: teamName,
+			User:     user,
+		}
+
+		resp, err := client.AddTeamMember(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "User %s added to team %s\n", user, teamName)
+	},
+}
+
+var teamMemberRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a member from a team",
+	Long:  `Remove a user from a team`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		teamName, user, err := getTeamMemberFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.RemoveTeamMemberRequest{
+			TeamName: teamName,
+			User:     user,
+		}
+
+		resp, err := client.RemoveTeamMember(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "User %s removed from team %s\n", user, teamName)
+	},
+}
+
+func getTeamMemberFlags(cmd *cobra.Command) (string, string, error) {
+	teamName, err := cmd.Flags().GetString("team")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get team flag: %w", err)
+	}
+	if teamName == "" {
+		return "", "", fmt.Errorf("team is required")
+	}
+
+	user, err := cmd.Flags().GetString("member")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get member flag: %w", err)
+	}
+	if user == "" {
+		return "", "", fmt.Errorf("member is required")
+	}
+
+	return teamName, user, nil
+}
+
+func init() {
+	teamCreateCmd.Flags().String("name", "", "Team name")
+
+	teamMemberAddCmd.Flags().String("team", "", "Team name")
+	teamMemberAddCmd.Flags().String("member", "", "User to add to the team")
+
+	teamMemberRemoveCmd.Flags().String("team", "", "Team name")
+	teamMemberRemoveCmd.Flags().String("member", "", "User to remove from the team")
+
+	teamMemberCmd.AddCommand(teamMemberAddCmd)
+	teamMemberCmd.AddCommand(teamMemberRemoveCmd)
+
+	teamCmd.AddCommand(teamListCmd)
+	teamCmd.AddCommand(teamCreateCmd)
+	teamCmd.AddCommand(teamMemberCmd)
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/internal/http/client"
+	"github.com/marcbran/versource/internal/tui/variableset"
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/spf13/cobra"
+)
+
+var variableSetCmd = &cobra.Command{
+	Use:   "variable-set",
+	Short: "Manage variable sets",
+	Long:  `Manage shared variable sets that components can attach`,
+}
+
+var variableSetGetCmd = &cobra.Command{
+	Use:   "get [variable-set-name]",
+	Short: "Get a specific variable set",
+	Long:  `Get details for a specific variable set by name`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.GetVariableSetRequest{
+			Name: args[0],
+		}
+		if changeset != "" {
+			req.ChangesetName = &changeset
+		}
+
+		resp, err := client.GetVariableSet(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Variable set %s (version %d): %s\n", resp.VariableSet.Name, resp.VariableSet.Version, string(resp.VariableSet.Variables))
+	},
+}
+
+var variableSetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all variable sets",
+	Long:  `List all variable sets on main or in a changeset`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := variableset.NewTableData(httpClient, changeset)
+		return renderTableData(tableData)
+	},
+}
+
+var variableSetCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new variable set",
+	Long:  `Create a new variable set with a name and variables in a changeset`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return fmt.Errorf("failed to get name flag: %w", err)
+		}
+
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		variableMap, err := cmd.Flags().GetStringToString("variable")
+		if err != nil {
+			return fmt.Errorf("failed to get variable flags: %w", err)
+		}
+
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+		if changeset == "" {
+			return fmt.Errorf("changeset is required")
+		}
+
+		variables, err := parseVariables(variableMap)
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.CreateVariableSetRequest{
+			ChangesetName: changeset,
+			Name:          name,
+			Variables:     variables,
+		}
+
+		resp, err := client.CreateVariableSet(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Variable set created successfully with ID: %d\n", resp.VariableSet.ID)
+	},
+}
+
+var variableSetUpdateCmd = &cobra.Command{
+	Use:   "update [variable-set-name]",
+	Short: "Update a variable set",
+	Long:  `Replace the variables of a variable set in a changeset and plan every component that attaches it`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changeset, err := cmd.Flags().GetString("changeset")
+		if err != nil {
+			return fmt.Errorf("failed to get changeset flag: %w", err)
+		}
+
+		variableMap, err := cmd.Flags().GetStringToString("variable")
+		if err != nil {
+			return fmt.Errorf("failed to get variable flags: %w", err)
+		}
+
+		if changeset == "" {
+			return fmt.Errorf("changeset is required")
+		}
+
+		variables, err := parseVariables(variableMap)
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.UpdateVariableSetRequest{
+			ChangesetName: changeset,
+			Name:          args[0],
+			Variables:     variables,
+		}
+
+		resp, err := client.UpdateVariableSet(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Variable set %s updated to version %d, %d plans queued\n", resp.VariableSet.Name, resp.VariableSet.Version, len(resp.Plans))
+	},
+}
+
+func init() {
+	variableSetGetCmd.Flags().String("changeset", "", "Get variable set from a changeset")
+
+	variableSetListCmd.Flags().String("changeset", "", "List variable sets of a changeset")
+
+	variableSetCreateCmd.Flags().String("name", "", "Variable set name")
+	variableSetCreateCmd.Flags().String("changeset", "", "Changeset name")
+	variableSetCreateCmd.Flags().StringToString("variable", nil, "Variable in key=value format (can be used multiple times)")
+
+	variableSetUpdateCmd.Flags().String("changeset", "", "Changeset name")
+	variableSetUpdateCmd.Flags().StringToString("variable", nil, "Variable in key=value format, replacing all existing variables (can be used multiple times)")
+
+	variableSetCmd.AddCommand(variableSetGetCmd)
+	variableSetCmd.AddCommand(variableSetListCmd)
+	variableSetCmd.AddCommand(variableSetCreateCmd)
+	variableSetCmd.AddCommand(variableSetUpdateCmd)
+}
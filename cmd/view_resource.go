@@ -52,6 +52,11 @@ var viewResourceListCmd = &cobra.Command{
 	Short: "List all view resources",
 	Long:  `List all view resources in the system`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+
 		config, err := LoadConfig(cmd)
 		if err != nil {
 			return err
@@ -59,7 +64,9 @@ var viewResourceListCmd = &cobra.Command{
 
 		client := client.New(config)
 
-		req := versource.ListViewResourcesRequest{}
+		req := versource.ListViewResourcesRequest{
+			PageRequest: page,
+		}
 
 		viewResources, err := client.ListViewResources(cmd.Context(), req)
 		if err != nil {
@@ -140,6 +147,8 @@ func init() {
 	viewResourceSaveCmd.Flags().String("query", "", "View resource query")
 	_ = viewResourceSaveCmd.MarkFlagRequired("query")
 
+	addPageFlags(viewResourceListCmd, "id, name")
+
 	viewResourceCmd.AddCommand(viewResourceGetCmd)
 	viewResourceCmd.AddCommand(viewResourceListCmd)
 	viewResourceCmd.AddCommand(viewResourceSaveCmd)
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marcbran/versource/internal/http/client"
+	"github.com/marcbran/versource/internal/tui/webhook"
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/spf13/cobra"
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage webhooks",
+	Long:  `Manage the webhooks that are notified about selected events`,
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all webhooks",
+	Long:  `List all webhooks together with their URL and triggers`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := webhook.NewTableData(httpClient)
+		return renderTableData(tableData)
+	},
+}
+
+var webhookCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new webhook",
+	Long:  `Create a new webhook that receives signed JSON payloads for the given triggers. The secret is only shown once.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return fmt.Errorf("failed to get name flag: %w", err)
+		}
+
+		url, err := cmd.Flags().GetString("url")
+		if err != nil {
+			return fmt.Errorf("failed to get url flag: %w", err)
+		}
+
+		triggerFlags, err := cmd.Flags().GetStringSlice("trigger")
+		if err != nil {
+			return fmt.Errorf("failed to get trigger flag: %w", err)
+		}
+
+		secret, err := cmd.Flags().GetString("secret")
+		if err != nil {
+			return fmt.Errorf("failed to get secret flag: %w", err)
+		}
+
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+
+		if url == "" {
+			return fmt.Errorf("url is required")
+		}
+
+		if len(triggerFlags) == 0 {
+			return fmt.Errorf("at least one trigger is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		triggers := make([]versource.WebhookTrigger, len(triggerFlags))
+		for i, trigger := range triggerFlags {
+			triggers[i] = versource.WebhookTrigger(trigger)
+		}
+
+		req := versource.CreateWebhookRequest{
+			Name:     name,
+			URL:      url,
+			Triggers: triggers,
+			Secret:   secret,
+		}
+
+		resp, err := client.CreateWebhook(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Webhook %s created successfully with secret: %s\n", resp.Webhook.Name, resp.Secret)
+	},
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a webhook",
+	Long:  `Delete a webhook together with its delivery log`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.DeleteWebhookRequest{
+			Name: args[0],
+		}
+
+		resp, err := client.DeleteWebhook(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Webhook %s deleted successfully\n", resp.Webhook.Name)
+	},
+}
+
+var webhookDeliveryCmd = &cobra.Command{
+	Use:   "delivery",
+	Short: "Manage webhook deliveries",
+	Long:  `Inspect and redeliver the deliveries of a webhook`,
+}
+
+var webhookDeliveryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deliveries of a webhook",
+	Long:  `List the delivery log of a webhook, newest first`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhookName, err := cmd.Flags().GetString("webhook")
+		if err != nil {
+			return fmt.Errorf("failed to get webhook flag: %w", err)
+		}
+		if webhookName == "" {
+			return fmt.Errorf("webhook is required")
+		}
+
+		page, err := getPageRequest(cmd)
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		tableData := webhook.NewDeliveriesTableData(httpClient, webhookName, page)
+		return renderTableData(tableData)
+	},
+}
+
+var webhookDeliveryGetCmd = &cobra.Command{
+	Use:   "get [delivery-id]",
+	Short: "Get a specific webhook delivery",
+	Long:  `Get the payload and attempt details of a webhook delivery`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhookName, err := cmd.Flags().GetString("webhook")
+		if err != nil {
+			return fmt.Errorf("failed to get webhook flag: %w", err)
+		}
+		if webhookName == "" {
+			return fmt.Errorf("webhook is required")
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		httpClient := client.New(config)
+		detailData := webhook.NewDeliveryDetailData(httpClient, webhookName, args[0])
+		return renderViewportViewData(detailData)
+	},
+}
+
+var webhookDeliveryRedeliverCmd = &cobra.Command{
+	Use:   "redeliver [delivery-id]",
+	Short: "Redeliver a webhook delivery",
+	Long:  `Queue a new delivery with the same payload as an earlier delivery`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhookName, err := cmd.Flags().GetString("webhook")
+		if err != nil {
+			return fmt.Errorf("failed to get webhook flag: %w", err)
+		}
+		if webhookName == "" {
+			return fmt.Errorf("webhook is required")
+		}
+
+		deliveryID, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid delivery ID: %w", err)
+		}
+
+		config, err := LoadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(config)
+
+		req := versource.RedeliverWebhookDeliveryRequest{
+			WebhookName: webhookName,
+			DeliveryID:  uint(deliveryID),
+		}
+
+		resp, err := client.RedeliverWebhookDelivery(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return formatOutput(resp, "Delivery %d queued successfully\n", resp.Delivery.ID)
+	},
+}
+
+func init() {
+	triggers := make([]string, len(versource.WebhookTriggers))
+	for i, trigger := range versource.WebhookTriggers {
+		triggers[i] = string(trigger)
+	}
+
+	webhookCreateCmd.Flags().String("name", "", "Webhook name")
+	webhookCreateCmd.Flags().String("url", "", "URL the payloads are posted to")
+	webhookCreateCmd.Flags().StringSlice("trigger", nil, fmt.Sprintf("Trigger to deliver (%s) (can be used multiple times)", strings.Join(triggers, ", ")))
+	webhookCreateCmd.Flags().String("secret", "", "Secret used to sign payloads (generated if omitted)")
+
+	webhookDeliveryListCmd.Flags().String("webhook", "", "Webhook name")
+	addPageFlags(webhookDeliveryListCmd, "id, state, created")
+	webhookDeliveryGetCmd.Flags().String("webhook", "", "Webhook name")
+	webhookDeliveryRedeliverCmd.Flags().String("webhook", "", "Webhook name")
+
+	webhookDeliveryCmd.AddCommand(webhookDeliveryListCmd)
+	webhookDeliveryCmd.AddCommand(webhookDeliveryGetCmd)
+	webhookDeliveryCmd.AddCommand(webhookDeliveryRedeliverCmd)
+
+	webhookCmd.AddCommand(webhookListCmd)
+	webhookCmd.AddCommand(webhookCreateCmd)
+	webhookCmd.AddCommand(webhookDeleteCmd)
+	webhookCmd.AddCommand(webhookDeliveryCmd)
+}
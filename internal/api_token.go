@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+const apiTokenPrefix = "vs_"
+
+var ErrUnauthenticated = errors.New("missing or invalid api token")
+
+type ApiTokenRepo interface {
+	ListApiTokens(ctx context.Context) ([]versource.ApiToken, error)
+	HasIssuedApiTokens(ctx context.Context) (bool, error)
+	GetApiTokenByName(ctx context.Context, name string) (*versource.ApiToken, error)
+	GetApiTokenByHash(ctx context.Context, tokenHash string) (*versource.ApiToken, error)
+	CreateApiToken(ctx context.Context, apiToken *versource.ApiToken) error
+	RevokeApiToken(ctx context.Context, apiTokenID uint) error
+}
+
+type ListApiTokens struct {
+	apiTokenRepo ApiTokenRepo
+	tx           TransactionManager
+}
+
+func NewListApiTokens(apiTokenRepo ApiTokenRepo, tx TransactionManager) *ListApiTokens {
+	return &ListApiTokens{
+		apiTokenRepo: apiTokenRepo,
+		tx:           tx,
+	}
+}
+
+func (l *ListApiTokens) Exec(ctx context.Context, req versource.ListApiTokensRequest) (*versource.ListApiTokensResponse, error) {
+	var apiTokens []versource.ApiToken
+	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		apiTokens, err = l.apiTokenRepo.ListApiTokens(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list api tokens", err)
+	}
+
+	return &versource.ListApiTokensResponse{
+		ApiTokens: apiTokens,
+	}, nil
+}
+
+type CreateApiToken struct {
+	apiTokenRepo ApiTokenRepo
+	tx           TransactionManager
+}
+
+func NewCreateApiToken(apiTokenRepo ApiTokenRepo, tx TransactionManager) *CreateApiToken {
+	return &CreateApiToken{
+		apiTokenRepo: apiTokenRepo,
+		tx:           tx,
+	}
+}
+
+func (c *CreateApiToken) Exec(ctx context.Context, req versource.CreateApiTokenRequest) (*versource.CreateApiTokenResponse, error) {
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+	if req.User == "" {
+		return nil, versource.UserErr("user is required")
+	}
+	if !versource.IsValidRole(req.Role) {
+		return nil, versource.UserErrf("invalid role %s, must be one of viewer, editor, merger or admin", req.Role)
+	}
+
+	var response *versource.CreateApiTokenResponse
+	err := c.tx.Do(ctx, AdminBranch, fmt.Sprintf("create api token %s", req.Name), func(ctx context.Context) error {
+		var err error
+		response, err = issueApiToken(ctx, c.apiTokenRepo, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// BootstrapApiToken issues the first admin token. It talks to the database
+// directly rather than through the http api, which never lets an
+// unauthenticated caller create tokens.
+type BootstrapApiToken struct {
+	apiTokenRepo ApiTokenRepo
+	tx           TransactionManager
+}
+
+func NewBootstrapApiToken(apiTokenRepo ApiTokenRepo, tx TransactionManager) *BootstrapApiToken {
+	return &BootstrapApiToken{
+		apiTokenRepo: apiTokenRepo,
+		tx:           tx,
+	}
+}
+
+func (b *BootstrapApiToken) Exec(ctx context.Context, req versource.BootstrapApiTokenRequest) (*versource.CreateApiTokenResponse, error) {
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+	if req.User == "" {
+		return nil, versource.UserErr("user is required")
+	}
+
+	var response *versource.CreateApiTokenResponse
+	err := b.tx.Do(ctx, AdminBranch, fmt.Sprintf("bootstrap api token %s", req.Name), func(ctx context.Context) error {
+		issued, err := b.apiTokenRepo.HasIssuedApiTokens(ctx)
+		if err != nil {
+			return versource.InternalErrE("failed to check for api tokens", err)
+		}
+		if issued {
+			return versource.PreconditionFailedErr("api tokens have already been issued, create further tokens with an admin token")
+		}
+
+		response, err = issueApiToken(ctx, b.apiTokenRepo, versource.CreateApiTokenRequest{
+			Name: req.Name,
+			User: req.User,
+			Role: versource.RoleAdmin,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func issueApiToken(ctx context.Context, apiTokenRepo ApiTokenRepo, req versource.CreateApiTokenRequest) (*versource.CreateApiTokenResponse, error) {
+	if !versource.IsValidUser(req.User) {
+		return nil, versource.UserErr("user must not contain angle brackets or control characters")
+	}
+
+	existing, err := apiTokenRepo.GetApiTokenByName(ctx, req.Name)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to check api token name", err)
+	}
+	if existing != nil {
+		return nil, versource.ConflictErrf("api token with name %s already exists", req.Name)
+	}
+
+	token, err := generateApiToken()
+	if err != nil {
+		return nil, versource.InternalErrE("failed to generate api token", err)
+	}
+
+	apiToken := &versource.ApiToken{
+		Name:      req.Name,
+		User:      req.User,
+		Role:      req.Role,
+		TokenHash: hashApiToken(token),
+	}
+	err = apiTokenRepo.CreateApiToken(ctx, apiToken)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to create api token", err)
+	}
+
+	return &versource.CreateApiTokenResponse{
+		ApiToken: *apiToken,
+		Token:    token,
+	}, nil
+}
+
+type RevokeApiToken struct {
+	apiTokenRepo ApiTokenRepo
+	tx           TransactionManager
+}
+
+func NewRevokeApiToken(apiTokenRepo ApiTokenRepo, tx TransactionManager) *RevokeApiToken {
+	return &RevokeApiToken{
+		apiTokenRepo: apiTokenRepo,
+		tx:           tx,
+	}
+}
+
+func (r *RevokeApiToken) Exec(ctx context.Context, req versource.RevokeApiTokenRequest) (*versource.RevokeApiTokenResponse, error) {
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+
+	var response *versource.RevokeApiTokenResponse
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("revoke api token %s", req.Name), func(ctx context.Context) error {
+		apiToken, err := r.apiTokenRepo.GetApiTokenByName(ctx, req.Name)
+		if err != nil {
+			return versource.InternalErrE("failed to get api token", err)
+		}
+		if apiToken == nil || apiToken.RevokedAt != nil {
+			return versource.NotFoundErrf("api token %s not found", req.Name)
+		}
+
+		err = r.apiTokenRepo.RevokeApiToken(ctx, apiToken.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to revoke api token", err)
+		}
+
+		response = &versource.RevokeApiTokenResponse{
+			ApiToken: *apiToken,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type AuthenticateApiToken struct {
+	apiTokenRepo ApiTokenRepo
+	tx           TransactionManager
+}
+
+func NewAuthenticateApiToken(apiTokenRepo ApiTokenRepo, tx TransactionManager) *AuthenticateApiToken {
+	return &AuthenticateApiToken{
+		apiTokenRepo: apiTokenRepo,
+		tx:           tx,
+	}
+}
+
+// Exec returns nil without a token until the first api token has been issued.
+// Revoked tokens still count as issued, so revoking the last one does not
+// turn authentication off again.
+func (a *AuthenticateApiToken) Exec(ctx context.Context, token string) (*versource.ApiToken, error) {
+	var apiToken *versource.ApiToken
+	err := a.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		issued, err := a.apiTokenRepo.HasIssuedApiTokens(ctx)
+		if err != nil {
+			return err
+		}
+		if !issued {
+			return nil
+		}
+		if token == "" {
+			return ErrUnauthenticated
+		}
+		apiToken, err = a.apiTokenRepo.GetApiTokenByHash(ctx, hashApiToken(token))
+		if err != nil {
+			return err
+		}
+		if apiToken == nil {
+			return ErrUnauthenticated
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiToken, nil
+}
+
+func generateApiToken() (string, error) {
+	bytes := make([]byte, 32)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return apiTokenPrefix + base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func hashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
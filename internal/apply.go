@@ -3,7 +3,9 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/marcbran/versource/pkg/versource"
@@ -15,12 +17,41 @@ type ApplyRepo interface {
 	GetApply(ctx context.Context, applyID uint) (*versource.Apply, error)
 	GetQueuedApplies(ctx context.Context) ([]uint, error)
 	GetQueuedAppliesByChangeset(ctx context.Context, changesetID uint) ([]uint, error)
-	ListApplies(ctx context.Context) ([]versource.Apply, error)
+	ListApplies(ctx context.Context, filter ApplyFilter, page PageQuery) ([]versource.Apply, error)
 	ListAppliesByChangeset(ctx context.Context, changesetID uint) ([]versource.Apply, error)
 	CreateApply(ctx context.Context, apply *versource.Apply) error
 	UpdateApplyState(ctx context.Context, applyID uint, state versource.TaskState) error
 }
 
+type ApplyFilter struct {
+	ChangesetName *string
+	State         *versource.TaskState
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+var applyPageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":      "id",
+		"state":   "state",
+		"created": "created_at",
+	},
+	DefaultSort: "id",
+}
+
+func applyPageKey(apply versource.Apply, field string) (string, string) {
+	id := pageID(apply.ID)
+	switch field {
+	case "state":
+		return string(apply.State), id
+	case "created":
+		return pageTime(apply.CreatedAt), id
+	default:
+		return id, id
+	}
+}
+
 type GetApply struct {
 	applyRepo     ApplyRepo
 	componentRepo ComponentRepo
@@ -46,6 +77,9 @@ func (g *GetApply) Exec(ctx context.Context, req versource.GetApplyRequest) (*ve
 		apply, err = g.applyRepo.GetApply(ctx, req.ApplyID)
 		return err
 	})
+	if errors.Is(err, ErrNotFound) {
+		return nil, versource.NotFoundErrf("apply %d not found", req.ApplyID)
+	}
 	if err != nil {
 		return nil, versource.InternalErrE("failed to get apply", err)
 	}
@@ -74,12 +108,16 @@ func (g *GetApply) Exec(ctx context.Context, req versource.GetApplyRequest) (*ve
 }
 
 type GetApplyLog struct {
-	logStore LogStore
+	applyRepo ApplyRepo
+	logStore  LogStore
+	tx        TransactionManager
 }
 
-func NewGetApplyLog(logStore LogStore) *GetApplyLog {
+func NewGetApplyLog(applyRepo ApplyRepo, logStore LogStore, tx TransactionManager) *GetApplyLog {
 	return &GetApplyLog{
-		logStore: logStore,
+		applyRepo: applyRepo,
+		logStore:  logStore,
+		tx:        tx,
 	}
 }
 
@@ -88,6 +126,10 @@ func (g *GetApplyLog) Exec(ctx context.Context, req versource.GetApplyLogRequest
 		return nil, versource.UserErr("apply ID is required")
 	}
 
+	if req.Follow {
+		return g.follow(ctx, req.ApplyID)
+	}
+
 	reader, err := g.logStore.LoadLog(ctx, "apply", req.ApplyID)
 	if err != nil {
 		return nil, versource.InternalErrE("failed to load apply log", err)
@@ -98,6 +140,40 @@ func (g *GetApplyLog) Exec(ctx context.Context, req versource.GetApplyLogRequest
 	}, nil
 }
 
+func (g *GetApplyLog) follow(ctx context.Context, applyID uint) (*versource.GetApplyLogResponse, error) {
+	completed := func(ctx context.Context) (bool, error) {
+		var apply *versource.Apply
+		err := g.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+			var err error
+			apply, err = g.applyRepo.GetApply(ctx, applyID)
+			return err
+		})
+		if errors.Is(err, ErrNotFound) {
+			return false, versource.NotFoundErrf("apply %d not found", applyID)
+		}
+		if err != nil {
+			return false, err
+		}
+		return isTaskFinished(apply.State), nil
+	}
+	open := func(ctx context.Context) (io.ReadCloser, error) {
+		return g.logStore.LoadLog(ctx, "apply", applyID)
+	}
+
+	done, err := completed(ctx)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to get apply", err)
+	}
+	reader, err := open(ctx)
+	if err != nil && done {
+		return nil, versource.InternalErrE("failed to load apply log", err)
+	}
+
+	return &versource.GetApplyLogResponse{
+		Content: newFollowLogReader(ctx, reader, open, completed),
+	}, nil
+}
+
 type ListApplies struct {
 	applyRepo ApplyRepo
 	tx        TransactionManager
@@ -111,18 +187,33 @@ func NewListApplies(applyRepo ApplyRepo, tx TransactionManager) *ListApplies {
 }
 
 func (l *ListApplies) Exec(ctx context.Context, req versource.ListAppliesRequest) (*versource.ListAppliesResponse, error) {
+	page, err := NewPageQuery(req.PageRequest, applyPageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ApplyFilter{
+		ChangesetName: req.ChangesetName,
+		State:         req.State,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+	}
+
 	var applies []versource.Apply
-	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+	err = l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
 		var err error
-		applies, err = l.applyRepo.ListApplies(ctx)
+		applies, err = l.applyRepo.ListApplies(ctx, filter, page)
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list applies", err)
 	}
 
+	applies, nextCursor := nextPage(applies, page, applyPageKey)
+
 	return &versource.ListAppliesResponse{
-		Applies: applies,
+		Applies:    applies,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -178,7 +269,7 @@ func (aw *ApplyWorker) runApplyInBackground(ctx context.Context, applyID uint) {
 
 		err := aw.runApply.Exec(workerCtx, applyID)
 		if err != nil {
-			stateErr := aw.tx.Do(ctx, AdminBranch, "fail apply", func(ctx context.Context) error {
+			stateErr := aw.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail apply %d", applyID), func(ctx context.Context) error {
 				return aw.applyRepo.UpdateApplyState(ctx, applyID, versource.TaskStateFailed)
 			})
 			if stateErr != nil {
@@ -224,9 +315,11 @@ type RunApply struct {
 	tx                TransactionManager
 	newExecutor       NewExecutor
 	componentRepo     ComponentRepo
+	variableSetRepo   VariableSetRepo
+	secretCipher      *SecretCipher
 }
 
-func NewRunApply(config *versource.Config, applyRepo ApplyRepo, stateRepo StateRepo, stateResourceRepo StateResourceRepo, resourceRepo ResourceRepo, planStore PlanStore, logStore LogStore, tx TransactionManager, newExecutor NewExecutor, componentRepo ComponentRepo) *RunApply {
+func NewRunApply(config *versource.Config, applyRepo ApplyRepo, stateRepo StateRepo, stateResourceRepo StateResourceRepo, resourceRepo ResourceRepo, planStore PlanStore, logStore LogStore, tx TransactionManager, newExecutor NewExecutor, componentRepo ComponentRepo, variableSetRepo VariableSetRepo, secretCipher *SecretCipher) *RunApply {
 	return &RunApply{
 		config:            config,
 		applyRepo:         applyRepo,
@@ -238,13 +331,15 @@ func NewRunApply(config *versource.Config, applyRepo ApplyRepo, stateRepo StateR
 		tx:                tx,
 		newExecutor:       newExecutor,
 		componentRepo:     componentRepo,
+		variableSetRepo:   variableSetRepo,
+		secretCipher:      secretCipher,
 	}
 }
 
 func (a *RunApply) Exec(ctx context.Context, applyID uint) error {
 	var apply *versource.Apply
 
-	err := a.tx.Do(ctx, AdminBranch, "start apply", func(ctx context.Context) error {
+	err := a.tx.Do(ctx, AdminBranch, fmt.Sprintf("start apply %d", applyID), func(ctx context.Context) error {
 		var err error
 		apply, err = a.applyRepo.GetApply(ctx, applyID)
 		if err != nil {
@@ -266,6 +361,8 @@ func (a *RunApply) Exec(ctx context.Context, applyID uint) error {
 		return err
 	}
 
+	ctx = versource.WithUser(ctx, apply.RequestedBy)
+
 	logWriter, err := a.logStore.NewLogWriter("apply", applyID)
 	if err != nil {
 		return fmt.Errorf("failed to create log writer: %w", err)
@@ -276,6 +373,14 @@ func (a *RunApply) Exec(ctx context.Context, applyID uint) error {
 	err = a.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
 		var err error
 		component, err = a.componentRepo.GetComponentAtCommit(ctx, apply.Plan.ComponentID, apply.Plan.To)
+		if err != nil {
+			return err
+		}
+		component.Variables, err = effectiveVariables(ctx, a.variableSetRepo, component, apply.Plan.To)
+		if err != nil {
+			return err
+		}
+		component.Variables, err = withSecretVariables(a.secretCipher, component.Variables, component.Secrets)
 		return err
 	})
 	if err != nil {
@@ -310,7 +415,7 @@ func (a *RunApply) Exec(ctx context.Context, applyID uint) error {
 
 	log.Info("Terraform apply completed successfully")
 
-	err = a.tx.Do(ctx, MainBranch, "update state resources", func(ctx context.Context) error {
+	err = a.tx.Do(ctx, MainBranch, fmt.Sprintf("update state resources for apply %d", applyID), func(ctx context.Context) error {
 		state.ComponentID = component.ID
 
 		resourceMapping, err := extractResourceMapping(state.Output)
@@ -404,7 +509,7 @@ func (a *RunApply) Exec(ctx context.Context, applyID uint) error {
 		return err
 	}
 
-	err = a.tx.Do(ctx, AdminBranch, "succeed apply", func(ctx context.Context) error {
+	err = a.tx.Do(ctx, AdminBranch, fmt.Sprintf("succeed apply %d", applyID), func(ctx context.Context) error {
 		err = a.applyRepo.UpdateApplyState(ctx, applyID, versource.TaskStateSucceeded)
 		if err != nil {
 			return fmt.Errorf("failed to update apply state: %w", err)
@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type AuditEventRepo interface {
+	ListAuditBranches(ctx context.Context) ([]string, error)
+	ListAuditEvents(ctx context.Context, branch string, filter AuditEventFilter, page PageQuery) ([]versource.AuditEvent, error)
+}
+
+type AuditEventFilter struct {
+	Author *string
+}
+
+var auditEventPageSpec = PageSpec{
+	IDColumn: "commit_hash",
+	Columns: map[string]string{
+		"date": "date",
+	},
+	DefaultSort: "-date",
+}
+
+func auditEventPageKey(event versource.AuditEvent, field string) (string, string) {
+	return pageTime(event.Date), event.Commit
+}
+
+type ListAuditEvents struct {
+	auditEventRepo AuditEventRepo
+	tx             TransactionManager
+}
+
+func NewListAuditEvents(auditEventRepo AuditEventRepo, tx TransactionManager) *ListAuditEvents {
+	return &ListAuditEvents{
+		auditEventRepo: auditEventRepo,
+		tx:             tx,
+	}
+}
+
+func (l *ListAuditEvents) Exec(ctx context.Context, req versource.ListAuditEventsRequest) (*versource.ListAuditEventsResponse, error) {
+	if req.Branch != nil && !IsValidBranch(*req.Branch) {
+		return nil, versource.UserErrf("invalid branch: %s", *req.Branch)
+	}
+
+	page, err := NewPageQuery(req.PageRequest, auditEventPageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := AuditEventFilter{
+		Author: req.Author,
+	}
+
+	var events []versource.AuditEvent
+	err = l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var branches []string
+		if req.Branch != nil {
+			branches = []string{*req.Branch}
+		} else {
+			var err error
+			branches, err = l.auditEventRepo.ListAuditBranches(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		eventsByBranch := make(map[string][]versource.AuditEvent, len(branches))
+		for _, branch := range branches {
+			branchEvents, err := l.auditEventRepo.ListAuditEvents(ctx, branch, filter, page)
+			if err != nil {
+				return err
+			}
+			eventsByBranch[branch] = branchEvents
+		}
+		events = collectAuditEvents(branches, eventsByBranch, page)
+		return nil
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list audit events", err)
+	}
+
+	events, nextCursor := nextPage(events, page, auditEventPageKey)
+
+	return &versource.ListAuditEventsResponse{
+		AuditEvents: events,
+		NextCursor:  nextCursor,
+	}, nil
+}
+
+// collectAuditEvents merges the pages read from each branch into a single
+// page. Every branch contributes at most one page worth of events past the
+// cursor, which is enough to fill the merged page even after commits shared
+// between branches have been dropped.
+func collectAuditEvents(branches []string, eventsByBranch map[string][]versource.AuditEvent, page PageQuery) []versource.AuditEvent {
+	ordered := slices.Clone(branches)
+	slices.SortStableFunc(ordered, func(a, b string) int {
+		return auditBranchRank(a) - auditBranchRank(b)
+	})
+
+	seen := make(map[string]bool)
+	events := make([]versource.AuditEvent, 0)
+	for _, branch := range ordered {
+		for _, event := range eventsByBranch[branch] {
+			if seen[event.Commit] {
+				continue
+			}
+			seen[event.Commit] = true
+			event.Branch = branch
+			events = append(events, event)
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b versource.AuditEvent) int {
+		c := a.Date.Compare(b.Date)
+		if c == 0 {
+			c = strings.Compare(a.Commit, b.Commit)
+		}
+		if page.Descending {
+			return -c
+		}
+		return c
+	})
+	return events
+}
+
+func auditBranchRank(branch string) int {
+	switch branch {
+	case MainBranch:
+		return 0
+	case AdminBranch:
+		return 1
+	default:
+		return 2
+	}
+}
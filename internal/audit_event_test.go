@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func TestCollectAuditEvents(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	page, err := NewPageQuery(versource.PageRequest{}, auditEventPageSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		branches       []string
+		eventsByBranch map[string][]versource.AuditEvent
+		expected       []string
+	}{
+		{
+			name:     "newest first across branches",
+			branches: []string{AdminBranch, "changeset1", MainBranch},
+			eventsByBranch: map[string][]versource.AuditEvent{
+				MainBranch:   {{Commit: "m1", Date: base}},
+				AdminBranch:  {{Commit: "a1", Date: base.Add(2 * time.Minute)}},
+				"changeset1": {{Commit: "c1", Date: base.Add(time.Minute)}},
+			},
+			expected: []string{"a1@admin", "c1@changeset1", "m1@main"},
+		},
+		{
+			name:     "shared commits are attributed to main",
+			branches: []string{"changeset1", MainBranch},
+			eventsByBranch: map[string][]versource.AuditEvent{
+				MainBranch:   {{Commit: "m1", Date: base}},
+				"changeset1": {{Commit: "c1", Date: base.Add(time.Minute)}, {Commit: "m1", Date: base}},
+			},
+			expected: []string{"c1@changeset1", "m1@main"},
+		},
+		{
+			name:     "same date ordered by commit",
+			branches: []string{"changeset1", MainBranch},
+			eventsByBranch: map[string][]versource.AuditEvent{
+				MainBranch:   {{Commit: "b", Date: base}},
+				"changeset1": {{Commit: "c", Date: base}, {Commit: "a", Date: base}},
+			},
+			expected: []string{"c@changeset1", "b@main", "a@changeset1"},
+		},
+		{
+			name:           "no events",
+			branches:       []string{MainBranch},
+			eventsByBranch: map[string][]versource.AuditEvent{},
+			expected:       []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := collectAuditEvents(tt.branches, tt.eventsByBranch, page)
+			actual := make([]string, 0, len(events))
+			for _, event := range events {
+				actual = append(actual, event.Commit+"@"+event.Branch)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -3,23 +3,63 @@ package internal
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/marcbran/versource/pkg/versource"
+	log "github.com/sirupsen/logrus"
 )
 
 type ChangesetRepo interface {
 	GetChangeset(ctx context.Context, changesetID uint) (*versource.Changeset, error)
 	GetChangesetByName(ctx context.Context, name string) (*versource.Changeset, error)
 	GetOpenChangesetByName(ctx context.Context, name string) (*versource.Changeset, error)
-	ListChangesets(ctx context.Context) ([]versource.Changeset, error)
+	ListChangesets(ctx context.Context, filter ChangesetFilter, page PageQuery) ([]versource.Changeset, error)
+	ListChangesetsByState(ctx context.Context, state versource.ChangesetState) ([]versource.Changeset, error)
+	ListChildChangesets(ctx context.Context, parentID uint) ([]versource.Changeset, error)
 	HasOpenChangesetWithName(ctx context.Context, name string) (bool, error)
 	HasChangesetWithName(ctx context.Context, name string) (bool, error)
 	CreateChangeset(ctx context.Context, changeset *versource.Changeset) error
 	UpdateChangesetState(ctx context.Context, changesetID uint, state versource.ChangesetState) error
 	UpdateChangesetReviewState(ctx context.Context, changesetID uint, reviewState versource.ChangesetReviewState) error
+	UpdateChangesetStaleness(ctx context.Context, changesetID uint, stale bool, staleComponentIDs []uint) error
+	UpdateChangesetAutoRebase(ctx context.Context, changesetID uint, autoRebase bool) error
+	UpdateChangesetParent(ctx context.Context, changesetID uint, parentID *uint) error
 	DeleteChangeset(ctx context.Context, changesetID uint) error
 }
 
+type ChangesetFilter struct {
+	State         *versource.ChangesetState
+	ExcludeClosed bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+var changesetPageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":      "id",
+		"name":    "name",
+		"state":   "state",
+		"created": "created_at",
+	},
+	DefaultSort: "id",
+}
+
+func changesetPageKey(changeset versource.Changeset, field string) (string, string) {
+	id := pageID(changeset.ID)
+	switch field {
+	case "name":
+		return changeset.Name, id
+	case "state":
+		return string(changeset.State), id
+	case "created":
+		return pageTime(changeset.CreatedAt), id
+	default:
+		return id, id
+	}
+}
+
 type ListChangesets struct {
 	changesetRepo ChangesetRepo
 	tx            TransactionManager
@@ -33,18 +73,33 @@ func NewListChangesets(changesetRepo ChangesetRepo, tx TransactionManager) *List
 }
 
 func (l *ListChangesets) Exec(ctx context.Context, req versource.ListChangesetsRequest) (*versource.ListChangesetsResponse, error) {
+	page, err := NewPageQuery(req.PageRequest, changesetPageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ChangesetFilter{
+		State:         req.State,
+		ExcludeClosed: !req.IncludeClosed && req.State == nil,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+	}
+
 	var changesets []versource.Changeset
-	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+	err = l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
 		var err error
-		changesets, err = l.changesetRepo.ListChangesets(ctx)
+		changesets, err = l.changesetRepo.ListChangesets(ctx, filter, page)
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list changesets", err)
 	}
 
+	changesets, nextCursor := nextPage(changesets, page, changesetPageKey)
+
 	return &versource.ListChangesetsResponse{
 		Changesets: changesets,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -66,13 +121,13 @@ func (c *CreateChangeset) Exec(ctx context.Context, req versource.CreateChangese
 	}
 
 	var response *versource.CreateChangesetResponse
-	err := c.tx.Do(ctx, AdminBranch, "create changeset", func(ctx context.Context) error {
+	err := c.tx.Do(ctx, AdminBranch, fmt.Sprintf("create changeset %s", req.Name), func(ctx context.Context) error {
 		hasChangesets, err := c.changesetRepo.HasChangesetWithName(ctx, req.Name)
 		if err != nil {
 			return versource.InternalErrE("failed to check for changesets", err)
 		}
 		if hasChangesets {
-			return versource.UserErr("cannot create changeset: changeset with this name already exists")
+			return versource.ConflictErr("cannot create changeset: changeset with this name already exists")
 		}
 
 		changeset := &versource.Changeset{
@@ -80,6 +135,17 @@ func (c *CreateChangeset) Exec(ctx context.Context, req versource.CreateChangese
 			State: versource.ChangesetStateOpen,
 		}
 
+		if req.Parent != "" {
+			parent, err := c.changesetRepo.GetOpenChangesetByName(ctx, req.Parent)
+			if err != nil {
+				return versource.InternalErrE("failed to get parent changeset", err)
+			}
+			if parent == nil {
+				return versource.PreconditionFailedErrf("cannot create changeset: parent changeset %s is not open", req.Parent)
+			}
+			changeset.ParentID = &parent.ID
+		}
+
 		err = c.changesetRepo.CreateChangeset(ctx, changeset)
 		if err != nil {
 			return versource.InternalErrE("failed to create changeset", err)
@@ -95,7 +161,12 @@ func (c *CreateChangeset) Exec(ctx context.Context, req versource.CreateChangese
 		return nil, fmt.Errorf("failed to create changeset: %w", err)
 	}
 
-	err = c.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+	baseBranch := MainBranch
+	if req.Parent != "" {
+		baseBranch = req.Parent
+	}
+
+	err = c.tx.Checkout(ctx, baseBranch, func(ctx context.Context) error {
 		err = c.tx.CreateBranch(ctx, req.Name)
 		if err != nil {
 			return versource.InternalErrE("failed to create changeset branch", err)
@@ -141,6 +212,9 @@ func (e *EnsureChangeset) Exec(ctx context.Context, req versource.EnsureChangese
 		return nil, fmt.Errorf("failed to get changeset: %w", err)
 	}
 	if existingChangeset != nil {
+		if existingChangeset.State == versource.ChangesetStateClosed {
+			return nil, versource.PreconditionFailedErr("changeset is closed")
+		}
 		return &versource.EnsureChangesetResponse{
 			Changeset: *existingChangeset,
 		}, nil
@@ -162,16 +236,18 @@ type DeleteChangeset struct {
 	applyRepo     ApplyRepo
 	planStore     PlanStore
 	logStore      LogStore
+	createRebase  *CreateRebase
 	tx            TransactionManager
 }
 
-func NewDeleteChangeset(changesetRepo ChangesetRepo, planRepo PlanRepo, applyRepo ApplyRepo, planStore PlanStore, logStore LogStore, tx TransactionManager) *DeleteChangeset {
+func NewDeleteChangeset(changesetRepo ChangesetRepo, planRepo PlanRepo, applyRepo ApplyRepo, planStore PlanStore, logStore LogStore, createRebase *CreateRebase, tx TransactionManager) *DeleteChangeset {
 	return &DeleteChangeset{
 		changesetRepo: changesetRepo,
 		planRepo:      planRepo,
 		applyRepo:     applyRepo,
 		planStore:     planStore,
 		logStore:      logStore,
+		createRebase:  createRebase,
 		tx:            tx,
 	}
 }
@@ -189,7 +265,7 @@ func (d *DeleteChangeset) Exec(ctx context.Context, req versource.DeleteChangese
 			return versource.InternalErrE("failed to get changeset", err)
 		}
 		if changeset == nil {
-			return versource.UserErr("changeset not found")
+			return versource.NotFoundErr("changeset not found")
 		}
 		return nil
 	})
@@ -237,7 +313,13 @@ func (d *DeleteChangeset) Exec(ctx context.Context, req versource.DeleteChangese
 		return nil, err
 	}
 
-	err = d.tx.Do(ctx, AdminBranch, "delete changeset", func(ctx context.Context) error {
+	var children []versource.Changeset
+	err = d.tx.Do(ctx, AdminBranch, fmt.Sprintf("delete changeset %s", req.ChangesetName), func(ctx context.Context) error {
+		children, err = unstackChildChangesets(ctx, d.changesetRepo, changeset.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to unstack child changesets", err)
+		}
+
 		err = d.changesetRepo.DeleteChangeset(ctx, changeset.ID)
 		if err != nil {
 			return versource.InternalErrE("failed to delete changeset", err)
@@ -248,7 +330,587 @@ func (d *DeleteChangeset) Exec(ctx context.Context, req versource.DeleteChangese
 		return nil, err
 	}
 
+	rebaseChildrenOntoMain(ctx, d.createRebase, children)
+
 	return &versource.DeleteChangesetResponse{
 		ID: changeset.ID,
 	}, nil
 }
+
+type UpdateChangeset struct {
+	changesetRepo ChangesetRepo
+	tx            TransactionManager
+}
+
+func NewUpdateChangeset(changesetRepo ChangesetRepo, tx TransactionManager) *UpdateChangeset {
+	return &UpdateChangeset{
+		changesetRepo: changesetRepo,
+		tx:            tx,
+	}
+}
+
+func (u *UpdateChangeset) Exec(ctx context.Context, req versource.UpdateChangesetRequest) (*versource.UpdateChangesetResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset name is required")
+	}
+	if req.AutoRebase == nil {
+		return nil, versource.UserErr("at least one field must be provided for update")
+	}
+
+	var response *versource.UpdateChangesetResponse
+	err := u.tx.Do(ctx, AdminBranch, fmt.Sprintf("update changeset %s", req.ChangesetName), func(ctx context.Context) error {
+		changeset, err := u.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
+		}
+
+		err = u.changesetRepo.UpdateChangesetAutoRebase(ctx, changeset.ID, *req.AutoRebase)
+		if err != nil {
+			return versource.InternalErrE("failed to update changeset", err)
+		}
+		changeset.AutoRebase = *req.AutoRebase
+
+		response = &versource.UpdateChangesetResponse{
+			Changeset: *changeset,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type CloseChangeset struct {
+	changesetRepo ChangesetRepo
+	createRebase  *CreateRebase
+	tx            TransactionManager
+}
+
+func NewCloseChangeset(changesetRepo ChangesetRepo, createRebase *CreateRebase, tx TransactionManager) *CloseChangeset {
+	return &CloseChangeset{
+		changesetRepo: changesetRepo,
+		createRebase:  createRebase,
+		tx:            tx,
+	}
+}
+
+func (c *CloseChangeset) Exec(ctx context.Context, req versource.CloseChangesetRequest) (*versource.CloseChangesetResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset name is required")
+	}
+
+	var response *versource.CloseChangesetResponse
+	var children []versource.Changeset
+	err := c.tx.Do(ctx, AdminBranch, fmt.Sprintf("close changeset %s", req.ChangesetName), func(ctx context.Context) error {
+		changeset, err := c.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
+		}
+		if changeset.State != versource.ChangesetStateOpen {
+			return versource.PreconditionFailedErrf("cannot close changeset in state %s", changeset.State)
+		}
+
+		err = c.changesetRepo.UpdateChangesetState(ctx, changeset.ID, versource.ChangesetStateClosed)
+		if err != nil {
+			return versource.InternalErrE("failed to close changeset", err)
+		}
+		changeset.State = versource.ChangesetStateClosed
+
+		children, err = unstackChildChangesets(ctx, c.changesetRepo, changeset.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to unstack child changesets", err)
+		}
+
+		response = &versource.CloseChangesetResponse{
+			Changeset: *changeset,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rebaseChildrenOntoMain(ctx, c.createRebase, children)
+
+	return response, nil
+}
+
+type ReopenChangeset struct {
+	changesetRepo ChangesetRepo
+	tx            TransactionManager
+}
+
+func NewReopenChangeset(changesetRepo ChangesetRepo, tx TransactionManager) *ReopenChangeset {
+	return &ReopenChangeset{
+		changesetRepo: changesetRepo,
+		tx:            tx,
+	}
+}
+
+func (r *ReopenChangeset) Exec(ctx context.Context, req versource.ReopenChangesetRequest) (*versource.ReopenChangesetResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset name is required")
+	}
+
+	var response *versource.ReopenChangesetResponse
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("reopen changeset %s", req.ChangesetName), func(ctx context.Context) error {
+		changeset, err := r.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
+		}
+		if changeset.State != versource.ChangesetStateClosed {
+			return versource.PreconditionFailedErrf("cannot reopen changeset in state %s", changeset.State)
+		}
+
+		err = r.changesetRepo.UpdateChangesetState(ctx, changeset.ID, versource.ChangesetStateOpen)
+		if err != nil {
+			return versource.InternalErrE("failed to reopen changeset", err)
+		}
+		changeset.State = versource.ChangesetStateOpen
+
+		response = &versource.ReopenChangesetResponse{
+			Changeset: *changeset,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
+		mergeBase, err := r.tx.GetMergeBase(ctx, MainBranch, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get merge base", err)
+		}
+
+		mainHead, err := r.tx.GetBranchHead(ctx, MainBranch)
+		if err != nil {
+			return versource.InternalErrE("failed to get head of main", err)
+		}
+
+		response.RebaseRequired = mergeBase != mainHead
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type RevertChangeset struct {
+	changesetRepo        ChangesetRepo
+	mergeRepo            MergeRepo
+	componentRepo        ComponentRepo
+	componentChangeRepo  ComponentChangeRepo
+	createChangeset      *CreateChangeset
+	listComponentChanges *ListComponentChanges
+	createPlan           *CreatePlan
+	tx                   TransactionManager
+}
+
+func NewRevertChangeset(changesetRepo ChangesetRepo, mergeRepo MergeRepo, componentRepo ComponentRepo, componentChangeRepo ComponentChangeRepo, createChangeset *CreateChangeset, listComponentChanges *ListComponentChanges, createPlan *CreatePlan, tx TransactionManager) *RevertChangeset {
+	return &RevertChangeset{
+		changesetRepo:        changesetRepo,
+		mergeRepo:            mergeRepo,
+		componentRepo:        componentRepo,
+		componentChangeRepo:  componentChangeRepo,
+		createChangeset:      createChangeset,
+		listComponentChanges: listComponentChanges,
+		createPlan:           createPlan,
+		tx:                   tx,
+	}
+}
+
+func (r *RevertChangeset) Exec(ctx context.Context, req versource.RevertChangesetRequest) (*versource.RevertChangesetResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset name is required")
+	}
+	selector, err := ParseLabelSelector(req.Selector)
+	if err != nil {
+		return nil, versource.UserErrE("invalid label selector", err)
+	}
+
+	name := req.Name
+	if name == "" {
+		name = fmt.Sprintf("revert-%s", req.ChangesetName)
+	}
+
+	var merge *versource.Merge
+	err = r.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		changeset, err := r.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
+		}
+		if changeset.State != versource.ChangesetStateMerged {
+			return versource.PreconditionFailedErr("cannot revert changeset: changeset is not merged")
+		}
+
+		merges, err := r.mergeRepo.ListMergesByChangesetName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to list merges", err)
+		}
+		for i := range merges {
+			if merges[i].State != versource.TaskStateSucceeded {
+				continue
+			}
+			if merge == nil || merges[i].ID > merge.ID {
+				merge = &merges[i]
+			}
+		}
+		if merge == nil {
+			return versource.PreconditionFailedErr("cannot revert changeset: no successful merge found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []versource.ComponentChange
+	err = r.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var err error
+		changes, err = r.componentChangeRepo.ListComponentChangesBetween(ctx, merge.MergeBase, merge.Head)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list merged component changes", err)
+	}
+	if len(changes) == 0 {
+		return nil, versource.UserErr("cannot revert changeset: changeset has no component changes")
+	}
+	if len(selector) > 0 {
+		changes = filterChangesBySelector(changes, selector)
+		if len(changes) == 0 {
+			return nil, versource.UserErrf("cannot revert changeset: no component changes match selector %s", req.Selector)
+		}
+	}
+
+	createResp, err := r.createChangeset.Exec(ctx, versource.CreateChangesetRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.tx.Do(ctx, name, fmt.Sprintf("revert changeset %s", req.ChangesetName), func(ctx context.Context) error {
+		for _, change := range changes {
+			err := r.revertChange(ctx, change)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revert changeset: %w", err)
+	}
+
+	changesResp, err := r.listComponentChanges.listAll(ctx, versource.ListComponentChangesRequest{
+		ChangesetName: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component changes: %w", err)
+	}
+
+	plans := make([]versource.Plan, 0, len(changesResp.Changes))
+	for _, change := range changesResp.Changes {
+		if change.ToComponent == nil {
+			continue
+		}
+
+		planResp, err := r.createPlan.Exec(ctx, versource.CreatePlanRequest{
+			ComponentID:   change.ToComponent.ID,
+			ChangesetName: name,
+		})
+		if err != nil {
+			return nil, versource.InternalErrE("failed to create plan after revert", err)
+		}
+		plans = append(plans, planResp.Plan)
+	}
+
+	return &versource.RevertChangesetResponse{
+		Changeset: createResp.Changeset,
+		Plans:     plans,
+	}, nil
+}
+
+func (r *RevertChangeset) revertChange(ctx context.Context, change versource.ComponentChange) error {
+	if change.FromComponent == nil {
+		exists, err := r.componentRepo.HasComponent(ctx, change.ToComponent.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to check component existence", err)
+		}
+		if !exists {
+			return nil
+		}
+
+		component, err := r.componentRepo.GetComponent(ctx, change.ToComponent.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to get component", err)
+		}
+		component.Status = versource.ComponentStatusDeleted
+
+		err = r.componentRepo.UpdateComponent(ctx, component)
+		if err != nil {
+			return versource.InternalErrE("failed to delete component", err)
+		}
+		return nil
+	}
+
+	previous := change.FromComponent
+	exists, err := r.componentRepo.HasComponent(ctx, previous.ID)
+	if err != nil {
+		return versource.InternalErrE("failed to check component existence", err)
+	}
+	if !exists {
+		component := &versource.Component{
+			ID:              previous.ID,
+			Name:            previous.Name,
+			ModuleVersionID: previous.ModuleVersionID,
+			Variables:       previous.Variables,
+			Labels:          previous.Labels,
+			VariableSets:    previous.VariableSets,
+			Template:        previous.Template,
+			Environment:     previous.Environment,
+			Overrides:       previous.Overrides,
+			Secrets:         previous.Secrets,
+			Owner:           previous.Owner,
+			Status:          previous.Status,
+		}
+		err = r.componentRepo.CreateComponent(ctx, component)
+		if err != nil {
+			return versource.InternalErrE("failed to recreate component", err)
+		}
+		return nil
+	}
+
+	component, err := r.componentRepo.GetComponent(ctx, previous.ID)
+	if err != nil {
+		return versource.InternalErrE("failed to get component", err)
+	}
+	component.Name = previous.Name
+	component.ModuleVersionID = previous.ModuleVersionID
+	component.ModuleVersion = versource.ModuleVersion{}
+	component.Variables = previous.Variables
+	component.Labels = previous.Labels
+	component.VariableSets = previous.VariableSets
+	component.Template = previous.Template
+	component.Environment = previous.Environment
+	component.Overrides = previous.Overrides
+	component.Secrets = previous.Secrets
+	component.Owner = previous.Owner
+	component.Status = previous.Status
+
+	err = r.componentRepo.UpdateComponent(ctx, component)
+	if err != nil {
+		return versource.InternalErrE("failed to restore component", err)
+	}
+	return nil
+}
+
+type DetectStaleChangesets struct {
+	changesetRepo       ChangesetRepo
+	componentChangeRepo ComponentChangeRepo
+	rebaseRepo          RebaseRepo
+	tx                  TransactionManager
+	createRebase        *CreateRebase
+}
+
+func NewDetectStaleChangesets(changesetRepo ChangesetRepo, componentChangeRepo ComponentChangeRepo, rebaseRepo RebaseRepo, tx TransactionManager, createRebase *CreateRebase) *DetectStaleChangesets {
+	return &DetectStaleChangesets{
+		changesetRepo:       changesetRepo,
+		componentChangeRepo: componentChangeRepo,
+		rebaseRepo:          rebaseRepo,
+		tx:                  tx,
+		createRebase:        createRebase,
+	}
+}
+
+func (d *DetectStaleChangesets) Exec(ctx context.Context) error {
+	var changesets []versource.Changeset
+	err := d.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		changesets, err = d.changesetRepo.ListChangesetsByState(ctx, versource.ChangesetStateOpen)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list open changesets: %w", err)
+	}
+
+	for _, changeset := range changesets {
+		err = d.detect(ctx, changeset)
+		if err != nil {
+			log.WithError(err).WithField("changeset", changeset.Name).Warn("Failed to detect stale changeset")
+		}
+	}
+
+	return nil
+}
+
+func (d *DetectStaleChangesets) detect(ctx context.Context, changeset versource.Changeset) error {
+	var stale bool
+	var staleComponentIDs []uint
+	var hasConflicts bool
+	err := d.tx.Checkout(ctx, changeset.Name, func(ctx context.Context) error {
+		mergeBase, err := d.tx.GetMergeBase(ctx, MainBranch, changeset.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get merge base: %w", err)
+		}
+
+		mainHead, err := d.tx.GetBranchHead(ctx, MainBranch)
+		if err != nil {
+			return fmt.Errorf("failed to get main head: %w", err)
+		}
+
+		stale = mergeBase != mainHead
+		if !stale {
+			return nil
+		}
+
+		staleComponentIDs, err = d.componentChangeRepo.ListComponentIDsChangedOnMain(ctx, changeset.Name)
+		if err != nil {
+			return fmt.Errorf("failed to list components changed on main: %w", err)
+		}
+
+		hasConflicts, err = d.componentChangeRepo.HasComponentConflicts(ctx, changeset.Name)
+		if err != nil {
+			return fmt.Errorf("failed to check component conflicts: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if stale != changeset.Stale || !slices.Equal(staleComponentIDs, changeset.StaleComponentIDs) {
+		err = d.tx.Do(ctx, AdminBranch, fmt.Sprintf("update changeset %s staleness", changeset.Name), func(ctx context.Context) error {
+			return d.changesetRepo.UpdateChangesetStaleness(ctx, changeset.ID, stale, staleComponentIDs)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update changeset staleness: %w", err)
+		}
+	}
+
+	if !stale || !changeset.AutoRebase || hasConflicts {
+		return nil
+	}
+
+	var rebases []versource.Rebase
+	err = d.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		rebases, err = d.rebaseRepo.ListRebasesByChangesetName(ctx, changeset.Name)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list rebases: %w", err)
+	}
+	for _, rebase := range rebases {
+		if !versource.IsTaskCompleted(rebase.State) {
+			return nil
+		}
+	}
+
+	_, err = d.createRebase.Exec(ctx, versource.CreateRebaseRequest{
+		ChangesetName: changeset.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rebase: %w", err)
+	}
+
+	log.WithField("changeset", changeset.Name).Info("Queued automatic rebase for stale changeset")
+
+	return nil
+}
+
+type StaleChangesetWorker struct {
+	detectStaleChangesets *DetectStaleChangesets
+	detectChan            chan struct{}
+}
+
+func NewStaleChangesetWorker(detectStaleChangesets *DetectStaleChangesets) *StaleChangesetWorker {
+	return &StaleChangesetWorker{
+		detectStaleChangesets: detectStaleChangesets,
+		detectChan:            make(chan struct{}, 1),
+	}
+}
+
+func (sw *StaleChangesetWorker) Start(ctx context.Context) {
+	go sw.processDetections(ctx)
+}
+
+func (sw *StaleChangesetWorker) QueueDetection() {
+	select {
+	case sw.detectChan <- struct{}{}:
+		log.Debug("Queued stale changeset detection")
+	default:
+	}
+}
+
+func (sw *StaleChangesetWorker) processDetections(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sw.detectChan:
+			sw.runDetection(ctx)
+		case <-ticker.C:
+			sw.runDetection(ctx)
+		}
+	}
+}
+
+func (sw *StaleChangesetWorker) runDetection(ctx context.Context) {
+	workerCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+
+	err := sw.detectStaleChangesets.Exec(workerCtx)
+	if err != nil {
+		log.WithError(err).Error("Failed to detect stale changesets")
+	}
+}
+
+func unstackChildChangesets(ctx context.Context, changesetRepo ChangesetRepo, parentID uint) ([]versource.Changeset, error) {
+	children, err := changesetRepo.ListChildChangesets(ctx, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child changesets: %w", err)
+	}
+
+	for i, child := range children {
+		err = changesetRepo.UpdateChangesetParent(ctx, child.ID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update parent of changeset %s: %w", child.Name, err)
+		}
+		children[i].ParentID = nil
+	}
+
+	return children, nil
+}
+
+func rebaseChildrenOntoMain(ctx context.Context, createRebase *CreateRebase, children []versource.Changeset) {
+	for _, child := range children {
+		_, err := createRebase.Exec(ctx, versource.CreateRebaseRequest{ChangesetName: child.Name})
+		if err != nil {
+			log.WithError(err).WithField("changeset", child.Name).Warn("Failed to rebase child changeset onto main")
+		}
+	}
+}
@@ -3,7 +3,13 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/datatypes"
@@ -12,19 +18,103 @@ import (
 type ComponentRepo interface {
 	GetComponent(ctx context.Context, componentID uint) (*versource.Component, error)
 	GetComponentAtCommit(ctx context.Context, componentID uint, commit string) (*versource.Component, error)
+	ListComponentsAtCommit(ctx context.Context, commit string) ([]versource.Component, error)
 	GetLastCommitOfComponent(ctx context.Context, componentID uint) (string, error)
+	ListComponentHistory(ctx context.Context, componentID uint, page PageQuery) ([]versource.ComponentRevision, error)
 	HasComponent(ctx context.Context, componentID uint) (bool, error)
+	HasComponentWithName(ctx context.Context, name string) (bool, error)
 	ListComponents(ctx context.Context) ([]versource.Component, error)
 	ListComponentsByModule(ctx context.Context, moduleID uint) ([]versource.Component, error)
-	ListComponentsByModuleVersion(ctx context.Context, moduleVersionID uint) ([]versource.Component, error)
+	ListComponentsPage(ctx context.Context, filter ComponentFilter, page PageQuery) ([]versource.Component, error)
+	ListComponentsPageAtCommit(ctx context.Context, commit string, filter ComponentFilter, page PageQuery) ([]versource.Component, error)
 	CreateComponent(ctx context.Context, component *versource.Component) error
 	UpdateComponent(ctx context.Context, component *versource.Component) error
 }
 
+type ComponentFilter struct {
+	ModuleID        *uint
+	ModuleVersionID *uint
+	Owner           *string
+	Template        *string
+	Environment     *string
+}
+
+var componentPageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":          "id",
+		"name":        "name",
+		"owner":       "owner",
+		"environment": "environment",
+	},
+	DefaultSort: "id",
+}
+
+func componentPageKey(component versource.Component, field string) (string, string) {
+	id := pageID(component.ID)
+	switch field {
+	case "name":
+		return component.Name, id
+	case "owner":
+		return component.Owner, id
+	case "environment":
+		return component.Environment, id
+	default:
+		return id, id
+	}
+}
+
 type ComponentChangeRepo interface {
-	ListComponentChanges(ctx context.Context) ([]versource.ComponentChange, error)
-	GetComponentChange(ctx context.Context, componentID uint) (*versource.ComponentChange, error)
+	ListComponentChanges(ctx context.Context, baseBranch string) ([]versource.ComponentChange, error)
+	GetComponentChange(ctx context.Context, baseBranch string, componentID uint) (*versource.ComponentChange, error)
+	GetChangesetBaseBranch(ctx context.Context, changesetName string) (string, error)
 	HasComponentConflicts(ctx context.Context, changesetName string) (bool, error)
+	ListComponentIDsChangedOnMain(ctx context.Context, changesetName string) ([]uint, error)
+	ListComponentChangesBetween(ctx context.Context, fromCommit, toCommit string) ([]versource.ComponentChange, error)
+	ListComponentConflicts(ctx context.Context, changesetName string) ([]versource.ComponentConflict, error)
+	MarkComponentConflictResolved(ctx context.Context, componentID uint) error
+	ListUnresolvedComponentConflicts(ctx context.Context) ([]uint, error)
+}
+
+var componentRevisionPageSpec = PageSpec{
+	IDColumn: "commit_hash",
+	Columns: map[string]string{
+		"order": "commit_order",
+	},
+	DefaultSort: "-order",
+}
+
+func componentRevisionPageKey(revision versource.ComponentRevision, field string) (string, string) {
+	return revision.Commit, revision.Commit
+}
+
+var componentChangePageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":   "id",
+		"name": "name",
+		"type": "change_type",
+	},
+	DefaultSort: "id",
+}
+
+func componentChangePageKey(change versource.ComponentChange, field string) (string, string) {
+	component := change.ToComponent
+	if component == nil {
+		component = change.FromComponent
+	}
+	if component == nil {
+		return "", ""
+	}
+	id := pageID(component.ID)
+	switch field {
+	case "name":
+		return component.Name, id
+	case "type":
+		return string(change.ChangeType), id
+	default:
+		return id, id
+	}
 }
 
 type GetComponent struct {
@@ -40,21 +130,62 @@ func NewGetComponent(componentRepo ComponentRepo, tx TransactionManager) *GetCom
 }
 
 func (g *GetComponent) Exec(ctx context.Context, req versource.GetComponentRequest) (*versource.GetComponentResponse, error) {
+	if req.AsOf != nil {
+		return g.getComponentAsOf(ctx, req)
+	}
+
 	var component *versource.Component
+	var revision string
 	var err error
 
-	if req.ChangesetName != nil {
-		err = g.tx.Checkout(ctx, *req.ChangesetName, func(ctx context.Context) error {
-			component, err = g.componentRepo.GetComponent(ctx, req.ComponentID)
+	getComponent := func(ctx context.Context) error {
+		component, err = g.componentRepo.GetComponent(ctx, req.ComponentID)
+		if err != nil {
 			return err
-		})
+		}
+		revision, err = g.componentRepo.GetLastCommitOfComponent(ctx, req.ComponentID)
+		return err
+	}
+
+	if req.ChangesetName != nil {
+		err = g.tx.Checkout(ctx, *req.ChangesetName, getComponent)
 	} else {
-		component, err = g.componentRepo.GetComponent(ctx, req.ComponentID)
+		err = getComponent(ctx)
+	}
+
+	if errors.Is(err, ErrNotFound) {
+		return nil, versource.NotFoundErrf("component %d not found", req.ComponentID)
 	}
+	if err != nil {
+		return nil, versource.InternalErrE("failed to get component", err)
+	}
+
+	return &versource.GetComponentResponse{
+		Component: *component,
+		Revision:  revision,
+	}, nil
+}
 
+func (g *GetComponent) getComponentAsOf(ctx context.Context, req versource.GetComponentRequest) (*versource.GetComponentResponse, error) {
+	if req.ChangesetName != nil {
+		return nil, versource.UserErr("as-of cannot be combined with changeset")
+	}
+	if !IsValidRevision(*req.AsOf) {
+		return nil, versource.UserErr("invalid as-of revision")
+	}
+
+	var component *versource.Component
+	err := g.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var err error
+		component, err = g.componentRepo.GetComponentAtCommit(ctx, req.ComponentID, *req.AsOf)
+		return err
+	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to get component", err)
 	}
+	if component.ID == 0 {
+		return nil, versource.NotFoundErrf("component %d not found as of %s", req.ComponentID, *req.AsOf)
+	}
 
 	return &versource.GetComponentResponse{
 		Component: *component,
@@ -74,33 +205,110 @@ func NewListComponents(componentRepo ComponentRepo, tx TransactionManager) *List
 }
 
 func (l *ListComponents) Exec(ctx context.Context, req versource.ListComponentsRequest) (*versource.ListComponentsResponse, error) {
-	var components []versource.Component
+	selector, err := ParseLabelSelector(req.Selector)
+	if err != nil {
+		return nil, versource.UserErrE("invalid label selector", err)
+	}
+
+	page, err := NewPageQuery(req.PageRequest, componentPageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ComponentFilter{
+		ModuleID:        req.ModuleID,
+		ModuleVersionID: req.ModuleVersionID,
+		Owner:           req.Owner,
+		Template:        req.Template,
+		Environment:     req.Environment,
+	}
+
+	fetch := func(page PageQuery) ([]versource.Component, error) {
+		if req.AsOf != nil {
+			return l.listComponentsAsOf(ctx, req, filter, page)
+		}
+		return l.listComponents(ctx, req, filter, page)
+	}
+	keep := func(components []versource.Component) ([]versource.Component, error) {
+		if len(selector) == 0 {
+			return components, nil
+		}
+		return filterComponentsBySelector(components, selector), nil
+	}
+
+	components, nextCursor, err := collectPage(page, fetch, keep, componentPageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &versource.ListComponentsResponse{
+		Components: components,
+		NextCursor: nextCursor,
+	}, nil
+}
 
+func (l *ListComponents) listComponents(ctx context.Context, req versource.ListComponentsRequest, filter ComponentFilter, page PageQuery) ([]versource.Component, error) {
 	branch := MainBranch
 	if req.ChangesetName != nil {
 		branch = *req.ChangesetName
 	}
 
+	var components []versource.Component
 	err := l.tx.Checkout(ctx, branch, func(ctx context.Context) error {
 		var err error
+		components, err = l.componentRepo.ListComponentsPage(ctx, filter, page)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list components", err)
+	}
 
-		if req.ModuleVersionID != nil {
-			components, err = l.componentRepo.ListComponentsByModuleVersion(ctx, *req.ModuleVersionID)
-		} else if req.ModuleID != nil {
-			components, err = l.componentRepo.ListComponentsByModule(ctx, *req.ModuleID)
-		} else {
-			components, err = l.componentRepo.ListComponents(ctx)
-		}
+	return components, nil
+}
+
+func (l *ListComponents) listComponentsAsOf(ctx context.Context, req versource.ListComponentsRequest, filter ComponentFilter, page PageQuery) ([]versource.Component, error) {
+	if req.ChangesetName != nil {
+		return nil, versource.UserErr("as-of cannot be combined with changeset")
+	}
+	if !IsValidRevision(*req.AsOf) {
+		return nil, versource.UserErr("invalid as-of revision")
+	}
 
+	var components []versource.Component
+	err := l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var err error
+		components, err = l.componentRepo.ListComponentsPageAtCommit(ctx, *req.AsOf, filter, page)
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list components", err)
 	}
 
-	return &versource.ListComponentsResponse{
-		Components: components,
-	}, nil
+	return components, nil
+}
+
+func filterComponentsBySelector(components []versource.Component, selector LabelSelector) []versource.Component {
+	filtered := make([]versource.Component, 0, len(components))
+	for _, component := range components {
+		if selector.Matches(component.Labels) {
+			filtered = append(filtered, component)
+		}
+	}
+	return filtered
+}
+
+func filterChangesBySelector(changes []versource.ComponentChange, selector LabelSelector) []versource.ComponentChange {
+	filtered := make([]versource.ComponentChange, 0, len(changes))
+	for _, change := range changes {
+		component := change.ToComponent
+		if component == nil {
+			component = change.FromComponent
+		}
+		if component != nil && selector.Matches(component.Labels) {
+			filtered = append(filtered, change)
+		}
+	}
+	return filtered
 }
 
 type GetComponentChange struct {
@@ -122,8 +330,11 @@ func (g *GetComponentChange) Exec(ctx context.Context, req versource.GetComponen
 
 	var change *versource.ComponentChange
 	err := g.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
-		var err error
-		change, err = g.componentChangeRepo.GetComponentChange(ctx, req.ComponentID)
+		baseBranch, err := g.componentChangeRepo.GetChangesetBaseBranch(ctx, req.ChangesetName)
+		if err != nil {
+			return err
+		}
+		change, err = g.componentChangeRepo.GetComponentChange(ctx, baseBranch, req.ComponentID)
 		return err
 	})
 	if err != nil {
@@ -148,20 +359,51 @@ func NewListComponentChanges(componentChangeRepo ComponentChangeRepo, tx Transac
 }
 
 func (l *ListComponentChanges) Exec(ctx context.Context, req versource.ListComponentChangesRequest) (*versource.ListComponentChangesResponse, error) {
+	page, err := NewPageQuery(req.PageRequest, componentChangePageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.listAll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, nextCursor := pageSlice(resp.Changes, page, componentChangePageKey)
+
+	return &versource.ListComponentChangesResponse{
+		Changes:    changes,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (l *ListComponentChanges) listAll(ctx context.Context, req versource.ListComponentChangesRequest) (*versource.ListComponentChangesResponse, error) {
 	if req.ChangesetName == "" {
 		return nil, versource.UserErr("changeset is required")
 	}
 
+	selector, err := ParseLabelSelector(req.Selector)
+	if err != nil {
+		return nil, versource.UserErrE("invalid label selector", err)
+	}
+
 	var changes []versource.ComponentChange
-	err := l.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
-		var err error
-		changes, err = l.componentChangeRepo.ListComponentChanges(ctx)
+	err = l.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
+		baseBranch, err := l.componentChangeRepo.GetChangesetBaseBranch(ctx, req.ChangesetName)
+		if err != nil {
+			return err
+		}
+		changes, err = l.componentChangeRepo.ListComponentChanges(ctx, baseBranch)
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list component changes", err)
 	}
 
+	if len(selector) > 0 {
+		changes = filterChangesBySelector(changes, selector)
+	}
+
 	return &versource.ListComponentChangesResponse{
 		Changes: changes,
 	}, nil
@@ -171,16 +413,24 @@ type CreateComponent struct {
 	componentRepo     ComponentRepo
 	moduleRepo        ModuleRepo
 	moduleVersionRepo ModuleVersionRepo
+	teamRepo          TeamRepo
+	variableSetRepo   VariableSetRepo
+	environmentRepo   EnvironmentRepo
+	secretCipher      *SecretCipher
 	ensureChangeset   *EnsureChangeset
 	createPlan        *CreatePlan
 	tx                TransactionManager
 }
 
-func NewCreateComponent(componentRepo ComponentRepo, moduleRepo ModuleRepo, moduleVersionRepo ModuleVersionRepo, ensureChangeset *EnsureChangeset, createPlan *CreatePlan, tx TransactionManager) *CreateComponent {
+func NewCreateComponent(componentRepo ComponentRepo, moduleRepo ModuleRepo, moduleVersionRepo ModuleVersionRepo, teamRepo TeamRepo, variableSetRepo VariableSetRepo, environmentRepo EnvironmentRepo, secretCipher *SecretCipher, ensureChangeset *EnsureChangeset, createPlan *CreatePlan, tx TransactionManager) *CreateComponent {
 	return &CreateComponent{
 		componentRepo:     componentRepo,
 		moduleRepo:        moduleRepo,
 		moduleVersionRepo: moduleVersionRepo,
+		teamRepo:          teamRepo,
+		variableSetRepo:   variableSetRepo,
+		environmentRepo:   environmentRepo,
+		secretCipher:      secretCipher,
 		ensureChangeset:   ensureChangeset,
 		createPlan:        createPlan,
 		tx:                tx,
@@ -191,18 +441,36 @@ func (c *CreateComponent) Exec(ctx context.Context, req versource.CreateComponen
 	if req.ChangesetName == "" {
 		return nil, versource.UserErr("changeset is required")
 	}
+	err := ValidateLabels(req.Labels)
+	if err != nil {
+		return nil, versource.UserErrE("invalid labels", err)
+	}
+	err = ensureTeamExists(ctx, c.tx, c.teamRepo, req.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if (req.Template == "") != (req.Environment == "") {
+		return nil, versource.UserErr("template and environment must be given together")
+	}
+	if req.Overrides != nil && req.Environment == "" {
+		return nil, versource.UserErr("overrides require an environment")
+	}
+	err = ensureEnvironmentExists(ctx, c.tx, c.environmentRepo, req.Environment)
+	if err != nil {
+		return nil, err
+	}
 
 	ensureChangesetReq := versource.EnsureChangesetRequest{
 		Name: req.ChangesetName,
 	}
 
-	_, err := c.ensureChangeset.Exec(ctx, ensureChangesetReq)
+	_, err = c.ensureChangeset.Exec(ctx, ensureChangesetReq)
 	if err != nil {
 		return nil, versource.InternalErrE("failed to ensure changeset", err)
 	}
 
 	var response *versource.CreateComponentResponse
-	err = c.tx.Do(ctx, req.ChangesetName, "create component", func(ctx context.Context) error {
+	err = c.tx.Do(ctx, req.ChangesetName, fmt.Sprintf("create component %s", req.Name), func(ctx context.Context) error {
 		latestVersion, err := c.moduleVersionRepo.GetLatestModuleVersion(ctx, req.ModuleID)
 		if err != nil {
 			return versource.InternalErrE("failed to get latest module version", err)
@@ -211,15 +479,60 @@ func (c *CreateComponent) Exec(ctx context.Context, req versource.CreateComponen
 			return versource.UserErr("module has no versions")
 		}
 
+		owner := req.Owner
+		if owner == "" {
+			module, err := c.moduleRepo.GetModule(ctx, req.ModuleID)
+			if err != nil {
+				return versource.InternalErrE("failed to get module", err)
+			}
+			owner = module.Owner
+		}
+
 		variablesJSON, err := json.Marshal(req.Variables)
 		if err != nil {
 			return versource.UserErrE("invalid variables format", err)
 		}
 
+		variableSets, err := attachVariableSets(ctx, c.variableSetRepo, req.VariableSets)
+		if err != nil {
+			return err
+		}
+
+		var overrides datatypes.JSON
+		if req.Overrides != nil {
+			overridesJSON, err := json.Marshal(req.Overrides)
+			if err != nil {
+				return versource.UserErrE("invalid overrides format", err)
+			}
+			overrides = datatypes.JSON(overridesJSON)
+		}
+
+		secrets, err := encryptSecrets(c.secretCipher, nil, req.Secrets, nil)
+		if err != nil {
+			return err
+		}
+
+		if req.Template != "" {
+			components, err := c.componentRepo.ListComponents(ctx)
+			if err != nil {
+				return versource.InternalErrE("failed to list components", err)
+			}
+			if findTemplateComponent(components, req.Template, req.Environment) != nil {
+				return versource.ConflictErrf("template %s already has a component in environment %s", req.Template, req.Environment)
+			}
+		}
+
 		component := &versource.Component{
 			Name:            req.Name,
 			ModuleVersionID: latestVersion.ID,
 			Variables:       datatypes.JSON(variablesJSON),
+			Labels:          req.Labels,
+			Owner:           owner,
+			VariableSets:    variableSets,
+			Template:        req.Template,
+			Environment:     req.Environment,
+			Overrides:       overrides,
+			Secrets:         secrets,
 			Status:          versource.ComponentStatusReady,
 		}
 
@@ -262,16 +575,22 @@ type UpdateComponent struct {
 	componentRepo     ComponentRepo
 	moduleVersionRepo ModuleVersionRepo
 	changesetRepo     ChangesetRepo
+	teamRepo          TeamRepo
+	variableSetRepo   VariableSetRepo
+	secretCipher      *SecretCipher
 	ensureChangeset   *EnsureChangeset
 	createPlan        *CreatePlan
 	tx                TransactionManager
 }
 
-func NewUpdateComponent(componentRepo ComponentRepo, moduleVersionRepo ModuleVersionRepo, changesetRepo ChangesetRepo, ensureChangeset *EnsureChangeset, createPlan *CreatePlan, tx TransactionManager) *UpdateComponent {
+func NewUpdateComponent(componentRepo ComponentRepo, moduleVersionRepo ModuleVersionRepo, changesetRepo ChangesetRepo, teamRepo TeamRepo, variableSetRepo VariableSetRepo, secretCipher *SecretCipher, ensureChangeset *EnsureChangeset, createPlan *CreatePlan, tx TransactionManager) *UpdateComponent {
 	return &UpdateComponent{
 		componentRepo:     componentRepo,
 		moduleVersionRepo: moduleVersionRepo,
 		changesetRepo:     changesetRepo,
+		teamRepo:          teamRepo,
+		variableSetRepo:   variableSetRepo,
+		secretCipher:      secretCipher,
 		ensureChangeset:   ensureChangeset,
 		createPlan:        createPlan,
 		tx:                tx,
@@ -282,6 +601,29 @@ func (u *UpdateComponent) Exec(ctx context.Context, req versource.UpdateComponen
 	if req.ChangesetName == "" {
 		return nil, versource.UserErr("changeset is required")
 	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, versource.UserErr("name cannot be empty")
+	}
+	if len(req.Moves) > 0 && req.ModuleID == nil {
+		return nil, versource.UserErr("moves require a module to move the component to")
+	}
+	for _, move := range req.Moves {
+		if move.From == "" || move.To == "" {
+			return nil, versource.UserErr("moves require both a from and a to address")
+		}
+	}
+	if req.Labels != nil {
+		err := ValidateLabels(*req.Labels)
+		if err != nil {
+			return nil, versource.UserErrE("invalid labels", err)
+		}
+	}
+	if req.Owner != nil {
+		err := ensureTeamExists(ctx, u.tx, u.teamRepo, *req.Owner)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	var hasChangeset bool
 	err := u.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
@@ -309,7 +651,7 @@ func (u *UpdateComponent) Exec(ctx context.Context, req versource.UpdateComponen
 			return versource.InternalErrE("failed to check component existence", err)
 		}
 		if !exists {
-			return versource.UserErr("component not found")
+			return versource.NotFoundErr("component not found")
 		}
 		return nil
 	})
@@ -327,16 +669,39 @@ func (u *UpdateComponent) Exec(ctx context.Context, req versource.UpdateComponen
 	}
 
 	var response *versource.UpdateComponentResponse
-	err = u.tx.Do(ctx, req.ChangesetName, "update component", func(ctx context.Context) error {
+	err = u.tx.Do(ctx, req.ChangesetName, fmt.Sprintf("update component %d", req.ComponentID), func(ctx context.Context) error {
 		component, err := u.componentRepo.GetComponent(ctx, req.ComponentID)
+		if errors.Is(err, ErrNotFound) {
+			return versource.NotFoundErrf("component %d not found", req.ComponentID)
+		}
 		if err != nil {
-			return versource.UserErrE("component not found", err)
+			return versource.InternalErrE("failed to get component", err)
 		}
 
 		if component.Status == versource.ComponentStatusDeleted {
-			return versource.UserErr("component is deleted")
+			return versource.PreconditionFailedErr("component is deleted")
 		}
 
+		if req.ExpectedRevision != nil {
+			revision, err := u.componentRepo.GetLastCommitOfComponent(ctx, req.ComponentID)
+			if err != nil {
+				return versource.InternalErrE("failed to get component revision", err)
+			}
+			if revision != *req.ExpectedRevision {
+				return versource.PreconditionFailedErrf("component %d has been modified since revision %s", req.ComponentID, *req.ExpectedRevision)
+			}
+		}
+
+		if req.Name != nil && *req.Name != component.Name {
+			nameTaken, err := u.componentRepo.HasComponentWithName(ctx, *req.Name)
+			if err != nil {
+				return versource.InternalErrE("failed to check component name", err)
+			}
+			if nameTaken {
+				return versource.ConflictErrf("component with name %s already exists", *req.Name)
+			}
+			component.Name = *req.Name
+		}
 		if req.ModuleID != nil {
 			latestVersion, err := u.moduleVersionRepo.GetLatestModuleVersion(ctx, *req.ModuleID)
 			if err != nil {
@@ -346,6 +711,7 @@ func (u *UpdateComponent) Exec(ctx context.Context, req versource.UpdateComponen
 				return versource.UserErr("module has no versions")
 			}
 			component.ModuleVersionID = latestVersion.ID
+			component.Moves = req.Moves
 		}
 		if req.Variables != nil {
 			variablesJSON, err := json.Marshal(*req.Variables)
@@ -354,7 +720,35 @@ func (u *UpdateComponent) Exec(ctx context.Context, req versource.UpdateComponen
 			}
 			component.Variables = datatypes.JSON(variablesJSON)
 		}
+		if req.Labels != nil {
+			component.Labels = *req.Labels
+		}
+		if req.Owner != nil {
+			component.Owner = *req.Owner
+		}
+		if req.VariableSets != nil {
+			variableSets, err := attachVariableSets(ctx, u.variableSetRepo, *req.VariableSets)
+			if err != nil {
+				return err
+			}
+			component.VariableSets = variableSets
+		}
+		if req.Overrides != nil {
+			if component.Environment == "" {
+				return versource.UserErr("overrides require a component with an environment")
+			}
+			overridesJSON, err := json.Marshal(*req.Overrides)
+			if err != nil {
+				return versource.UserErrE("invalid overrides format", err)
+			}
+			component.Overrides = datatypes.JSON(overridesJSON)
+		}
+		component.Secrets, err = encryptSecrets(u.secretCipher, component.Secrets, req.Secrets, req.RemoveSecrets)
+		if err != nil {
+			return err
+		}
 
+		component.ModuleVersion = versource.ModuleVersion{}
 		err = u.componentRepo.UpdateComponent(ctx, component)
 		if err != nil {
 			return versource.InternalErrE("failed to update component", err)
@@ -376,6 +770,15 @@ func (u *UpdateComponent) Exec(ctx context.Context, req versource.UpdateComponen
 		return nil, fmt.Errorf("failed to update component: %w", err)
 	}
 
+	err = u.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
+		var err error
+		response.Revision, err = u.componentRepo.GetLastCommitOfComponent(ctx, req.ComponentID)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to get component revision", err)
+	}
+
 	planReq := versource.CreatePlanRequest{
 		ComponentID:   response.Component.ID,
 		ChangesetName: req.ChangesetName,
@@ -442,7 +845,7 @@ func (d *DeleteComponent) Exec(ctx context.Context, req versource.DeleteComponen
 			return versource.InternalErrE("failed to check component existence", err)
 		}
 		if !exists {
-			return versource.UserErr("component not found")
+			return versource.NotFoundErr("component not found")
 		}
 		return nil
 	})
@@ -460,17 +863,25 @@ func (d *DeleteComponent) Exec(ctx context.Context, req versource.DeleteComponen
 	}
 
 	var response *versource.DeleteComponentResponse
-	err = d.tx.Do(ctx, req.ChangesetName, "delete component", func(ctx context.Context) error {
+	err = d.tx.Do(ctx, req.ChangesetName, fmt.Sprintf("delete component %d", req.ComponentID), func(ctx context.Context) error {
 		component, err := d.componentRepo.GetComponent(ctx, req.ComponentID)
+		if errors.Is(err, ErrNotFound) {
+			return versource.NotFoundErrf("component %d not found", req.ComponentID)
+		}
 		if err != nil {
-			return versource.UserErrE("component not found", err)
+			return versource.InternalErrE("failed to get component", err)
 		}
 
 		if component.Status == versource.ComponentStatusDeleted {
-			return versource.UserErr("component is already deleted")
+			return versource.ConflictErr("component is already deleted")
 		}
 
-		componentChange, err := d.componentChangeRepo.GetComponentChange(ctx, req.ComponentID)
+		baseBranch, err := d.componentChangeRepo.GetChangesetBaseBranch(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset base branch", err)
+		}
+
+		componentChange, err := d.componentChangeRepo.GetComponentChange(ctx, baseBranch, req.ComponentID)
 		if err != nil {
 			return versource.InternalErrE("failed to get component change", err)
 		}
@@ -482,6 +893,13 @@ func (d *DeleteComponent) Exec(ctx context.Context, req versource.DeleteComponen
 			} else {
 				component.Variables = componentChange.FromComponent.Variables
 			}
+			component.Labels = componentChange.FromComponent.Labels
+			component.VariableSets = componentChange.FromComponent.VariableSets
+			component.Template = componentChange.FromComponent.Template
+			component.Environment = componentChange.FromComponent.Environment
+			component.Overrides = componentChange.FromComponent.Overrides
+			component.Secrets = componentChange.FromComponent.Secrets
+			component.Owner = componentChange.FromComponent.Owner
 		}
 
 		component.Status = versource.ComponentStatusDeleted
@@ -573,7 +991,7 @@ func (r *RestoreComponent) Exec(ctx context.Context, req versource.RestoreCompon
 			return versource.InternalErrE("failed to check component existence", err)
 		}
 		if !exists {
-			return versource.UserErr("component not found")
+			return versource.NotFoundErr("component not found")
 		}
 		return nil
 	})
@@ -591,17 +1009,25 @@ func (r *RestoreComponent) Exec(ctx context.Context, req versource.RestoreCompon
 	}
 
 	var response *versource.RestoreComponentResponse
-	err = r.tx.Do(ctx, req.ChangesetName, "restore component", func(ctx context.Context) error {
+	err = r.tx.Do(ctx, req.ChangesetName, fmt.Sprintf("restore component %d", req.ComponentID), func(ctx context.Context) error {
 		component, err := r.componentRepo.GetComponent(ctx, req.ComponentID)
+		if errors.Is(err, ErrNotFound) {
+			return versource.NotFoundErrf("component %d not found", req.ComponentID)
+		}
 		if err != nil {
-			return versource.UserErrE("component not found", err)
+			return versource.InternalErrE("failed to get component", err)
 		}
 
 		if component.Status != versource.ComponentStatusDeleted {
-			return versource.UserErr("component is not deleted")
+			return versource.ConflictErr("component is not deleted")
 		}
 
-		componentChange, err := r.componentChangeRepo.GetComponentChange(ctx, req.ComponentID)
+		baseBranch, err := r.componentChangeRepo.GetChangesetBaseBranch(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset base branch", err)
+		}
+
+		componentChange, err := r.componentChangeRepo.GetComponentChange(ctx, baseBranch, req.ComponentID)
 		if err != nil {
 			return versource.InternalErrE("failed to get component change", err)
 		}
@@ -613,6 +1039,13 @@ func (r *RestoreComponent) Exec(ctx context.Context, req versource.RestoreCompon
 			} else {
 				component.Variables = componentChange.FromComponent.Variables
 			}
+			component.Labels = componentChange.FromComponent.Labels
+			component.VariableSets = componentChange.FromComponent.VariableSets
+			component.Template = componentChange.FromComponent.Template
+			component.Environment = componentChange.FromComponent.Environment
+			component.Overrides = componentChange.FromComponent.Overrides
+			component.Secrets = componentChange.FromComponent.Secrets
+			component.Owner = componentChange.FromComponent.Owner
 		}
 
 		component.Status = versource.ComponentStatusReady
@@ -652,3 +1085,743 @@ func (r *RestoreComponent) Exec(ctx context.Context, req versource.RestoreCompon
 
 	return response, nil
 }
+
+type ListComponentConflicts struct {
+	componentChangeRepo ComponentChangeRepo
+	tx                  TransactionManager
+}
+
+func NewListComponentConflicts(componentChangeRepo ComponentChangeRepo, tx TransactionManager) *ListComponentConflicts {
+	return &ListComponentConflicts{
+		componentChangeRepo: componentChangeRepo,
+		tx:                  tx,
+	}
+}
+
+func (l *ListComponentConflicts) Exec(ctx context.Context, req versource.ListComponentConflictsRequest) (*versource.ListComponentConflictsResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset is required")
+	}
+
+	var conflicts []versource.ComponentConflict
+	err := l.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
+		var err error
+		conflicts, err = l.componentChangeRepo.ListComponentConflicts(ctx, req.ChangesetName)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list component conflicts", err)
+	}
+
+	return &versource.ListComponentConflictsResponse{
+		Conflicts: conflicts,
+	}, nil
+}
+
+type ResolveComponentConflict struct {
+	componentRepo       ComponentRepo
+	componentChangeRepo ComponentChangeRepo
+	changesetRepo       ChangesetRepo
+	createPlan          *CreatePlan
+	tx                  TransactionManager
+}
+
+func NewResolveComponentConflict(componentRepo ComponentRepo, componentChangeRepo ComponentChangeRepo, changesetRepo ChangesetRepo, createPlan *CreatePlan, tx TransactionManager) *ResolveComponentConflict {
+	return &ResolveComponentConflict{
+		componentRepo:       componentRepo,
+		componentChangeRepo: componentChangeRepo,
+		changesetRepo:       changesetRepo,
+		createPlan:          createPlan,
+		tx:                  tx,
+	}
+}
+
+func (r *ResolveComponentConflict) Exec(ctx context.Context, req versource.ResolveComponentConflictRequest) (*versource.ResolveComponentConflictResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset is required")
+	}
+
+	choices := append([]versource.ComponentConflictChoice{{
+		ComponentID: req.ComponentID,
+		Resolution:  req.Resolution,
+		Variables:   req.Variables,
+	}}, req.Others...)
+	for i, choice := range choices {
+		if slices.ContainsFunc(choices[:i], func(c versource.ComponentConflictChoice) bool { return c.ComponentID == choice.ComponentID }) {
+			return nil, versource.UserErrf("component %d is resolved more than once", choice.ComponentID)
+		}
+		switch choice.Resolution {
+		case versource.ConflictResolutionOurs, versource.ConflictResolutionTheirs:
+		case versource.ConflictResolutionManual:
+			if choice.Variables == nil {
+				return nil, versource.UserErr("variables are required for a manual resolution")
+			}
+		default:
+			return nil, versource.UserErrf("invalid resolution: %s", choice.Resolution)
+		}
+	}
+
+	err := r.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		changeset, err := r.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
+		}
+		if changeset.State != versource.ChangesetStateOpen {
+			return versource.PreconditionFailedErrf("cannot resolve conflicts: changeset is %s", changeset.State)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []versource.ComponentConflict
+	err = r.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
+		conflicts, err = r.componentChangeRepo.ListComponentConflicts(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to list component conflicts", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]versource.Component, len(choices))
+	for i, choice := range choices {
+		resolved[i], err = resolveConflictChoice(conflicts, choice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var response *versource.ResolveComponentConflictResponse
+	err = r.tx.Do(ctx, req.ChangesetName, fmt.Sprintf("resolve component %d conflict", req.ComponentID), func(ctx context.Context) error {
+		err := r.tx.MergeBranch(ctx, MainBranch)
+		if err != nil {
+			return versource.InternalErrE("failed to merge main into changeset", err)
+		}
+
+		for i, choice := range choices {
+			component, err := r.componentRepo.GetComponent(ctx, choice.ComponentID)
+			if err != nil {
+				return versource.InternalErrE("failed to get component", err)
+			}
+
+			component.Name = resolved[i].Name
+			component.ModuleVersionID = resolved[i].ModuleVersionID
+			component.ModuleVersion = versource.ModuleVersion{}
+			component.Variables = resolved[i].Variables
+			component.Labels = resolved[i].Labels
+			component.VariableSets = resolved[i].VariableSets
+			component.Template = resolved[i].Template
+			component.Environment = resolved[i].Environment
+			component.Overrides = resolved[i].Overrides
+			component.Secrets = resolved[i].Secrets
+			component.Owner = resolved[i].Owner
+			component.Status = resolved[i].Status
+
+			err = r.componentRepo.UpdateComponent(ctx, component)
+			if err != nil {
+				return versource.InternalErrE("failed to update component", err)
+			}
+
+			err = r.componentChangeRepo.MarkComponentConflictResolved(ctx, choice.ComponentID)
+			if err != nil {
+				return versource.InternalErrE("failed to mark component conflict as resolved", err)
+			}
+		}
+
+		unresolvedIDs, err := r.componentChangeRepo.ListUnresolvedComponentConflicts(ctx)
+		if err != nil {
+			return versource.InternalErrE("failed to list unresolved component conflicts", err)
+		}
+		if len(unresolvedIDs) > 0 {
+			ids := make([]string, len(unresolvedIDs))
+			for i, id := range unresolvedIDs {
+				ids[i] = strconv.FormatUint(uint64(id), 10)
+			}
+			return versource.ConflictErrf("components %s also conflict with main and must be resolved together", strings.Join(ids, ", "))
+		}
+
+		tables, err := r.tx.ListConflictedTables(ctx)
+		if err != nil {
+			return versource.InternalErrE("failed to list conflicted tables", err)
+		}
+		if len(tables) > 0 {
+			return versource.ConflictErrf("tables %s conflict with main and cannot be resolved per component", strings.Join(tables, ", "))
+		}
+
+		component, err := r.componentRepo.GetComponent(ctx, req.ComponentID)
+		if err != nil {
+			return versource.InternalErrE("failed to get component", err)
+		}
+
+		response = &versource.ResolveComponentConflictResponse{
+			Component: *component,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve component conflict: %w", err)
+	}
+
+	for i, choice := range choices {
+		planReq := versource.CreatePlanRequest{
+			ComponentID:   choice.ComponentID,
+			ChangesetName: req.ChangesetName,
+		}
+
+		planResp, err := r.createPlan.Exec(ctx, planReq)
+		if err != nil {
+			return nil, versource.InternalErrE("failed to create plan after conflict resolution", err)
+		}
+
+		if i == 0 {
+			response.Plan = planResp.Plan
+		} else {
+			response.OtherPlans = append(response.OtherPlans, planResp.Plan)
+		}
+	}
+
+	return response, nil
+}
+
+func resolveConflictChoice(conflicts []versource.ComponentConflict, choice versource.ComponentConflictChoice) (versource.Component, error) {
+	var conflict *versource.ComponentConflict
+	for _, c := range conflicts {
+		if c.ComponentID == choice.ComponentID {
+			conflict = &c
+			break
+		}
+	}
+
+	if conflict == nil {
+		return versource.Component{}, versource.UserErrf("component %d has no conflict", choice.ComponentID)
+	}
+	if conflict.Changeset == nil || conflict.Main == nil || conflict.Changeset.ID != conflict.Main.ID {
+		return versource.Component{}, versource.ConflictErrf("component %d conflicts by name with another component on main and cannot be resolved", choice.ComponentID)
+	}
+
+	resolved := *conflict.Changeset
+	switch choice.Resolution {
+	case versource.ConflictResolutionTheirs:
+		resolved = *conflict.Main
+	case versource.ConflictResolutionManual:
+		variablesJSON, err := json.Marshal(*choice.Variables)
+		if err != nil {
+			return versource.Component{}, versource.UserErrE("invalid variables format", err)
+		}
+		resolved.Variables = datatypes.JSON(variablesJSON)
+	}
+	return resolved, nil
+}
+
+type ListComponentHistory struct {
+	componentRepo ComponentRepo
+	tx            TransactionManager
+}
+
+func NewListComponentHistory(componentRepo ComponentRepo, tx TransactionManager) *ListComponentHistory {
+	return &ListComponentHistory{
+		componentRepo: componentRepo,
+		tx:            tx,
+	}
+}
+
+func (l *ListComponentHistory) Exec(ctx context.Context, req versource.ListComponentHistoryRequest) (*versource.ListComponentHistoryResponse, error) {
+	page, err := NewPageQuery(req.PageRequest, componentRevisionPageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []versource.ComponentRevision
+	err = l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var err error
+		revisions, err = l.componentRepo.ListComponentHistory(ctx, req.ComponentID, page)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list component history", err)
+	}
+
+	revisions, nextCursor := nextPage(revisions, page, componentRevisionPageKey)
+
+	return &versource.ListComponentHistoryResponse{
+		Revisions:  revisions,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+type RevertComponentToRevision struct {
+	componentRepo   ComponentRepo
+	ensureChangeset *EnsureChangeset
+	createPlan      *CreatePlan
+	tx              TransactionManager
+}
+
+func NewRevertComponentToRevision(componentRepo ComponentRepo, ensureChangeset *EnsureChangeset, createPlan *CreatePlan, tx TransactionManager) *RevertComponentToRevision {
+	return &RevertComponentToRevision{
+		componentRepo:   componentRepo,
+		ensureChangeset: ensureChangeset,
+		createPlan:      createPlan,
+		tx:              tx,
+	}
+}
+
+func (r *RevertComponentToRevision) Exec(ctx context.Context, req versource.RevertComponentToRevisionRequest) (*versource.RevertComponentToRevisionResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset is required")
+	}
+	if !IsValidCommitHash(req.Commit) {
+		return nil, versource.UserErr("invalid commit")
+	}
+
+	var revision *versource.Component
+	err := r.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var err error
+		revision, err = r.componentRepo.GetComponentAtCommit(ctx, req.ComponentID, req.Commit)
+		if err != nil {
+			return versource.InternalErrE("failed to get component at revision", err)
+		}
+		if revision.ID == 0 {
+			return versource.UserErr("component does not exist at revision")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.ensureChangeset.Exec(ctx, versource.EnsureChangesetRequest{Name: req.ChangesetName})
+	if err != nil {
+		return nil, err
+	}
+
+	var response *versource.RevertComponentToRevisionResponse
+	err = r.tx.Do(ctx, req.ChangesetName, fmt.Sprintf("revert component %d to revision %s", req.ComponentID, req.Commit), func(ctx context.Context) error {
+		exists, err := r.componentRepo.HasComponent(ctx, req.ComponentID)
+		if err != nil {
+			return versource.InternalErrE("failed to check component existence", err)
+		}
+		if !exists {
+			return versource.NotFoundErr("component not found")
+		}
+
+		component, err := r.componentRepo.GetComponent(ctx, req.ComponentID)
+		if err != nil {
+			return versource.InternalErrE("failed to get component", err)
+		}
+		if component.Status == versource.ComponentStatusDeleted {
+			return versource.PreconditionFailedErr("component is deleted")
+		}
+
+		component.ModuleVersionID = revision.ModuleVersionID
+		component.ModuleVersion = versource.ModuleVersion{}
+		component.Variables = revision.Variables
+		component.Labels = revision.Labels
+		component.VariableSets = revision.VariableSets
+		component.Template = revision.Template
+		component.Environment = revision.Environment
+		component.Overrides = revision.Overrides
+		component.Secrets = revision.Secrets
+		component.Owner = revision.Owner
+
+		err = r.componentRepo.UpdateComponent(ctx, component)
+		if err != nil {
+			return versource.InternalErrE("failed to update component", err)
+		}
+
+		component, err = r.componentRepo.GetComponent(ctx, req.ComponentID)
+		if err != nil {
+			return versource.InternalErrE("failed to get component", err)
+		}
+
+		response = &versource.RevertComponentToRevisionResponse{
+			Component: *component,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revert component to revision: %w", err)
+	}
+
+	planResp, err := r.createPlan.Exec(ctx, versource.CreatePlanRequest{
+		ComponentID:   req.ComponentID,
+		ChangesetName: req.ChangesetName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.Plan = planResp.Plan
+
+	return response, nil
+}
+
+type SyncComponents struct {
+	componentRepo     ComponentRepo
+	moduleRepo        ModuleRepo
+	moduleVersionRepo ModuleVersionRepo
+	teamRepo          TeamRepo
+	ensureChangeset   *EnsureChangeset
+	createPlan        *CreatePlan
+	tx                TransactionManager
+}
+
+func NewSyncComponents(componentRepo ComponentRepo, moduleRepo ModuleRepo, moduleVersionRepo ModuleVersionRepo, teamRepo TeamRepo, ensureChangeset *EnsureChangeset, createPlan *CreatePlan, tx TransactionManager) *SyncComponents {
+	return &SyncComponents{
+		componentRepo:     componentRepo,
+		moduleRepo:        moduleRepo,
+		moduleVersionRepo: moduleVersionRepo,
+		teamRepo:          teamRepo,
+		ensureChangeset:   ensureChangeset,
+		createPlan:        createPlan,
+		tx:                tx,
+	}
+}
+
+type desiredComponent struct {
+	name          string
+	moduleVersion versource.ModuleVersion
+	variables     datatypes.JSON
+	labels        map[string]string
+	owner         string
+}
+
+func (s *SyncComponents) Exec(ctx context.Context, req versource.SyncComponentsRequest) (*versource.SyncComponentsResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset is required")
+	}
+	if !IsValidBranch(req.ChangesetName) {
+		return nil, versource.UserErr("invalid changeset name")
+	}
+	selector, err := ParseLabelSelector(req.Selector)
+	if err != nil {
+		return nil, versource.UserErrE("invalid label selector", err)
+	}
+	for _, manifest := range req.Manifests {
+		if !selector.Matches(manifest.Labels) {
+			return nil, versource.UserErrf("component %s does not match selector %s", manifest.Name, req.Selector)
+		}
+		err := ensureTeamExists(ctx, s.tx, s.teamRepo, manifest.Owner)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var desired []desiredComponent
+	var mainComponents []versource.Component
+	err = s.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var err error
+		desired, err = s.resolveManifests(ctx, req.Manifests)
+		if err != nil {
+			return err
+		}
+
+		mainComponents, err = s.componentRepo.ListComponents(ctx)
+		if err != nil {
+			return versource.InternalErrE("failed to list components", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := diffDesiredComponents(mainComponents, desired, selector)
+
+	if req.DryRun {
+		return &versource.SyncComponentsResponse{
+			Changes: changes,
+			Plans:   []versource.Plan{},
+		}, nil
+	}
+
+	_, err = s.ensureChangeset.Exec(ctx, versource.EnsureChangesetRequest{Name: req.ChangesetName})
+	if err != nil {
+		return nil, err
+	}
+
+	var changedComponentIDs []uint
+	err = s.tx.Do(ctx, req.ChangesetName, "sync components", func(ctx context.Context) error {
+		var err error
+		changedComponentIDs, err = s.reconcile(ctx, mainComponents, desired, selector)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync components: %w", err)
+	}
+
+	plans := make([]versource.Plan, 0, len(changedComponentIDs))
+	for _, componentID := range changedComponentIDs {
+		planResp, err := s.createPlan.Exec(ctx, versource.CreatePlanRequest{
+			ComponentID:   componentID,
+			ChangesetName: req.ChangesetName,
+		})
+		if err != nil {
+			return nil, versource.InternalErrE("failed to create plan after component sync", err)
+		}
+		plans = append(plans, planResp.Plan)
+	}
+
+	return &versource.SyncComponentsResponse{
+		Changes: changes,
+		Plans:   plans,
+	}, nil
+}
+
+func (s *SyncComponents) resolveManifests(ctx context.Context, manifests []versource.ComponentManifest) ([]desiredComponent, error) {
+	modules, err := s.moduleRepo.ListModules(ctx)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list modules", err)
+	}
+	modulesByName := make(map[string]versource.Module, len(modules))
+	for _, module := range modules {
+		modulesByName[module.Name] = module
+	}
+
+	moduleVersions, err := s.moduleVersionRepo.ListModuleVersions(ctx)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list module versions", err)
+	}
+
+	names := make(map[string]bool, len(manifests))
+	desired := make([]desiredComponent, 0, len(manifests))
+	for _, manifest := range manifests {
+		if manifest.Name == "" {
+			return nil, versource.UserErr("component name is required")
+		}
+		if names[manifest.Name] {
+			return nil, versource.UserErrf("duplicate component %s", manifest.Name)
+		}
+		names[manifest.Name] = true
+
+		module, ok := modulesByName[manifest.Module]
+		if !ok {
+			return nil, versource.UserErrf("module %s not found for component %s", manifest.Module, manifest.Name)
+		}
+
+		var moduleVersion *versource.ModuleVersion
+		for _, candidate := range moduleVersions {
+			if candidate.ModuleID != module.ID {
+				continue
+			}
+			if manifest.Version != "" && candidate.Version != manifest.Version {
+				continue
+			}
+			if moduleVersion == nil || candidate.ID > moduleVersion.ID {
+				moduleVersion = &candidate
+			}
+		}
+		if moduleVersion == nil {
+			if manifest.Version == "" {
+				return nil, versource.UserErrf("module %s has no versions", manifest.Module)
+			}
+			return nil, versource.UserErrf("module %s has no version %s", manifest.Module, manifest.Version)
+		}
+		moduleVersion.Module = module
+
+		variables := manifest.Variables
+		if variables == nil {
+			variables = map[string]any{}
+		}
+		variablesJSON, err := json.Marshal(variables)
+		if err != nil {
+			return nil, versource.UserErrE("invalid variables format", err)
+		}
+
+		err = ValidateLabels(manifest.Labels)
+		if err != nil {
+			return nil, versource.UserErrE(fmt.Sprintf("invalid labels for component %s", manifest.Name), err)
+		}
+
+		owner := manifest.Owner
+		if owner == "" {
+			owner = module.Owner
+		}
+
+		desired = append(desired, desiredComponent{
+			name:          manifest.Name,
+			moduleVersion: *moduleVersion,
+			variables:     datatypes.JSON(variablesJSON),
+			labels:        manifest.Labels,
+			owner:         owner,
+		})
+	}
+
+	return desired, nil
+}
+
+func (s *SyncComponents) reconcile(ctx context.Context, mainComponents []versource.Component, desired []desiredComponent, selector LabelSelector) ([]uint, error) {
+	components, err := s.componentRepo.ListComponents(ctx)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list components", err)
+	}
+
+	componentsByName := make(map[string]versource.Component, len(components))
+	for _, component := range components {
+		componentsByName[component.Name] = component
+	}
+
+	mainComponentsByID := make(map[uint]versource.Component, len(mainComponents))
+	for _, component := range mainComponents {
+		mainComponentsByID[component.ID] = component
+	}
+
+	var changedComponentIDs []uint
+	desiredNames := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		desiredNames[d.name] = true
+
+		component, ok := componentsByName[d.name]
+		if !ok {
+			component = versource.Component{
+				Name:            d.name,
+				ModuleVersionID: d.moduleVersion.ID,
+				Variables:       d.variables,
+				Labels:          d.labels,
+				Owner:           d.owner,
+				Status:          versource.ComponentStatusReady,
+			}
+			err = s.componentRepo.CreateComponent(ctx, &component)
+			if err != nil {
+				return nil, versource.InternalErrE("failed to create component", err)
+			}
+			changedComponentIDs = append(changedComponentIDs, component.ID)
+			continue
+		}
+
+		if component.Status != versource.ComponentStatusDeleted &&
+			component.ModuleVersionID == d.moduleVersion.ID &&
+			variablesEqual(component.Variables, d.variables) &&
+			labelsEqual(component.Labels, d.labels) &&
+			component.Owner == d.owner {
+			continue
+		}
+
+		component.ModuleVersionID = d.moduleVersion.ID
+		component.ModuleVersion = versource.ModuleVersion{}
+		component.Variables = d.variables
+		component.Labels = d.labels
+		component.Owner = d.owner
+		component.Status = versource.ComponentStatusReady
+		err = s.componentRepo.UpdateComponent(ctx, &component)
+		if err != nil {
+			return nil, versource.InternalErrE("failed to update component", err)
+		}
+		changedComponentIDs = append(changedComponentIDs, component.ID)
+	}
+
+	for _, component := range components {
+		if desiredNames[component.Name] || component.Status == versource.ComponentStatusDeleted {
+			continue
+		}
+
+		if mainComponent, ok := mainComponentsByID[component.ID]; ok {
+			component.ModuleVersionID = mainComponent.ModuleVersionID
+			component.Variables = mainComponent.Variables
+			component.Labels = mainComponent.Labels
+			component.Owner = mainComponent.Owner
+		}
+		if !selector.Matches(component.Labels) {
+			continue
+		}
+		component.ModuleVersion = versource.ModuleVersion{}
+		component.Status = versource.ComponentStatusDeleted
+		err = s.componentRepo.UpdateComponent(ctx, &component)
+		if err != nil {
+			return nil, versource.InternalErrE("failed to delete component", err)
+		}
+		changedComponentIDs = append(changedComponentIDs, component.ID)
+	}
+
+	return changedComponentIDs, nil
+}
+
+func diffDesiredComponents(mainComponents []versource.Component, desired []desiredComponent, selector LabelSelector) []versource.ComponentChange {
+	mainComponentsByName := make(map[string]versource.Component, len(mainComponents))
+	for _, component := range mainComponents {
+		if component.Status == versource.ComponentStatusDeleted {
+			continue
+		}
+		mainComponentsByName[component.Name] = component
+	}
+
+	changes := make([]versource.ComponentChange, 0)
+	desiredNames := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		desiredNames[d.name] = true
+
+		toComponent := &versource.Component{
+			Name:            d.name,
+			ModuleVersion:   d.moduleVersion,
+			ModuleVersionID: d.moduleVersion.ID,
+			Variables:       d.variables,
+			Labels:          d.labels,
+			Owner:           d.owner,
+			Status:          versource.ComponentStatusReady,
+		}
+
+		mainComponent, ok := mainComponentsByName[d.name]
+		if !ok {
+			changes = append(changes, versource.ComponentChange{
+				ToComponent: toComponent,
+				ChangeType:  versource.ChangeTypeCreated,
+			})
+			continue
+		}
+
+		if mainComponent.ModuleVersionID == d.moduleVersion.ID &&
+			variablesEqual(mainComponent.Variables, d.variables) &&
+			labelsEqual(mainComponent.Labels, d.labels) &&
+			mainComponent.Owner == d.owner {
+			continue
+		}
+
+		toComponent.ID = mainComponent.ID
+		changes = append(changes, versource.ComponentChange{
+			FromComponent: &mainComponent,
+			ToComponent:   toComponent,
+			ChangeType:    versource.ChangeTypeModified,
+		})
+	}
+
+	for _, component := range mainComponents {
+		if component.Status == versource.ComponentStatusDeleted || desiredNames[component.Name] || !selector.Matches(component.Labels) {
+			continue
+		}
+		changes = append(changes, versource.ComponentChange{
+			FromComponent: &component,
+			ChangeType:    versource.ChangeTypeDeleted,
+		})
+	}
+
+	return changes
+}
+
+func variablesEqual(a, b datatypes.JSON) bool {
+	var left, right map[string]any
+	if len(a) > 0 && json.Unmarshal(a, &left) != nil {
+		return false
+	}
+	if len(b) > 0 && json.Unmarshal(b, &right) != nil {
+		return false
+	}
+	if len(left) == 0 && len(right) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return maps.Equal(a, b)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/gorm"
+)
+
+type GormApiTokenRepo struct {
+	db *gorm.DB
+}
+
+func NewGormApiTokenRepo(db *gorm.DB) *GormApiTokenRepo {
+	return &GormApiTokenRepo{db: db}
+}
+
+func (r *GormApiTokenRepo) ListApiTokens(ctx context.Context) ([]versource.ApiToken, error) {
+	db := getTxOrDb(ctx, r.db)
+	var apiTokens []versource.ApiToken
+	err := db.WithContext(ctx).Where("revoked_at IS NULL").Order("name").Find(&apiTokens).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	return apiTokens, nil
+}
+
+func (r *GormApiTokenRepo) HasIssuedApiTokens(ctx context.Context) (bool, error) {
+	db := getTxOrDb(ctx, r.db)
+	var count int64
+	err := db.WithContext(ctx).Model(&versource.ApiToken{}).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check for api tokens: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *GormApiTokenRepo) GetApiTokenByName(ctx context.Context, name string) (*versource.ApiToken, error) {
+	return r.getApiToken(ctx, "name = ?", name)
+}
+
+func (r *GormApiTokenRepo) GetApiTokenByHash(ctx context.Context, tokenHash string) (*versource.ApiToken, error) {
+	return r.getApiToken(ctx, "token_hash = ? AND revoked_at IS NULL", tokenHash)
+}
+
+func (r *GormApiTokenRepo) getApiToken(ctx context.Context, query string, arg any) (*versource.ApiToken, error) {
+	db := getTxOrDb(ctx, r.db)
+	var apiToken versource.ApiToken
+	err := db.WithContext(ctx).Where(query, arg).First(&apiToken).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+	return &apiToken, nil
+}
+
+func (r *GormApiTokenRepo) CreateApiToken(ctx context.Context, apiToken *versource.ApiToken) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Create(apiToken).Error
+	if err != nil {
+		return fmt.Errorf("failed to create api token: %w", err)
+	}
+	return nil
+}
+
+func (r *GormApiTokenRepo) RevokeApiToken(ctx context.Context, apiTokenID uint) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Model(&versource.ApiToken{}).Where("id = ?", apiTokenID).Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke api token: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/marcbran/versource/internal"
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/gorm"
 )
@@ -24,7 +25,7 @@ func (r *GormApplyRepo) GetApply(ctx context.Context, applyID uint) (*versource.
 		Preload("Changeset").
 		Where("id = ?", applyID).First(&apply).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get apply: %w", err)
+		return nil, fmt.Errorf("failed to get apply: %w", notFound(err))
 	}
 	return &apply, nil
 }
@@ -59,13 +60,23 @@ func (r *GormApplyRepo) GetQueuedAppliesByChangeset(ctx context.Context, changes
 	return applyIDs, nil
 }
 
-func (r *GormApplyRepo) ListApplies(ctx context.Context) ([]versource.Apply, error) {
+func (r *GormApplyRepo) ListApplies(ctx context.Context, filter internal.ApplyFilter, page internal.PageQuery) ([]versource.Apply, error) {
 	db := getTxOrDb(ctx, r.db)
-	var applies []versource.Apply
-	err := db.WithContext(ctx).
+	query := db.WithContext(ctx).
 		Preload("Plan.Changeset").
-		Preload("Changeset").
-		Find(&applies).Error
+		Preload("Changeset")
+	if filter.ChangesetName != nil {
+		query = query.
+			Joins("JOIN changesets ON applies.changeset_id = changesets.id").
+			Where("changesets.name = ?", *filter.ChangesetName)
+	}
+	if filter.State != nil {
+		query = query.Where("applies.state = ?", *filter.State)
+	}
+	query = filterCreated(query, "applies", filter.CreatedAfter, filter.CreatedBefore)
+
+	var applies []versource.Apply
+	err := paginate(query, "applies", page).Find(&applies).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to list applies: %w", err)
 	}
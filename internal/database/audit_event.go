@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcbran/versource/internal"
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/gorm"
+)
+
+type GormAuditEventRepo struct {
+	db *gorm.DB
+}
+
+func NewGormAuditEventRepo(db *gorm.DB) *GormAuditEventRepo {
+	return &GormAuditEventRepo{db: db}
+}
+
+func (r *GormAuditEventRepo) ListAuditBranches(ctx context.Context) ([]string, error) {
+	db := getTxOrDb(ctx, r.db)
+	var branches []string
+	err := db.WithContext(ctx).Raw("SELECT name FROM dolt_branches ORDER BY name").Scan(&branches).Error
+	if err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+type auditLogEntry struct {
+	CommitHash string
+	Committer  string
+	Email      string
+	Date       time.Time
+	Message    string
+}
+
+func (r *GormAuditEventRepo) ListAuditEvents(ctx context.Context, branch string, filter internal.AuditEventFilter, page internal.PageQuery) ([]versource.AuditEvent, error) {
+	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx)
+	if branch == internal.MainBranch {
+		query = query.Table("dolt_log(?) AS l", branch)
+	} else {
+		query = query.Table("dolt_log(?, '--not', ?) AS l", branch, internal.MainBranch)
+	}
+	query = query.Select("l.commit_hash, l.committer, l.email, l.date, l.message")
+	if filter.Author != nil {
+		query = query.Where("l.committer = ?", *filter.Author)
+	}
+
+	var entries []auditLogEntry
+	err := paginate(query, "l", page).Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]versource.AuditEvent, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, versource.AuditEvent{
+			Commit:  entry.CommitHash,
+			Branch:  branch,
+			Author:  entry.Committer,
+			Email:   entry.Email,
+			Date:    entry.Date,
+			Message: entry.Message,
+		})
+	}
+	return events, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/marcbran/versource/internal"
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/gorm"
 )
@@ -21,7 +22,7 @@ func (r *GormChangesetRepo) GetChangeset(ctx context.Context, changesetID uint)
 	var changeset versource.Changeset
 	err := db.WithContext(ctx).Where("id = ?", changesetID).First(&changeset).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get changeset: %w", err)
+		return nil, fmt.Errorf("failed to get changeset: %w", notFound(err))
 	}
 	return &changeset, nil
 }
@@ -52,16 +53,45 @@ func (r *GormChangesetRepo) GetOpenChangesetByName(ctx context.Context, name str
 	return &changeset, nil
 }
 
-func (r *GormChangesetRepo) ListChangesets(ctx context.Context) ([]versource.Changeset, error) {
+func (r *GormChangesetRepo) ListChangesets(ctx context.Context, filter internal.ChangesetFilter, page internal.PageQuery) ([]versource.Changeset, error) {
 	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx)
+	if filter.State != nil {
+		query = query.Where("changesets.state = ?", *filter.State)
+	}
+	if filter.ExcludeClosed {
+		query = query.Where("changesets.state <> ?", versource.ChangesetStateClosed)
+	}
+	query = filterCreated(query, "changesets", filter.CreatedAfter, filter.CreatedBefore)
+
 	var changesets []versource.Changeset
-	err := db.WithContext(ctx).Find(&changesets).Error
+	err := paginate(query, "changesets", page).Find(&changesets).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to list changesets: %w", err)
 	}
 	return changesets, nil
 }
 
+func (r *GormChangesetRepo) ListChangesetsByState(ctx context.Context, state versource.ChangesetState) ([]versource.Changeset, error) {
+	db := getTxOrDb(ctx, r.db)
+	var changesets []versource.Changeset
+	err := db.WithContext(ctx).Where("state = ?", state).Find(&changesets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changesets by state: %w", err)
+	}
+	return changesets, nil
+}
+
+func (r *GormChangesetRepo) ListChildChangesets(ctx context.Context, parentID uint) ([]versource.Changeset, error) {
+	db := getTxOrDb(ctx, r.db)
+	var changesets []versource.Changeset
+	err := db.WithContext(ctx).Where("parent_id = ? AND state = ?", parentID, versource.ChangesetStateOpen).Find(&changesets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child changesets: %w", err)
+	}
+	return changesets, nil
+}
+
 func (r *GormChangesetRepo) HasOpenChangesetWithName(ctx context.Context, name string) (bool, error) {
 	db := getTxOrDb(ctx, r.db)
 	var count int64
@@ -109,6 +139,36 @@ func (r *GormChangesetRepo) UpdateChangesetReviewState(ctx context.Context, chan
 	return nil
 }
 
+func (r *GormChangesetRepo) UpdateChangesetStaleness(ctx context.Context, changesetID uint, stale bool, staleComponentIDs []uint) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Model(&versource.Changeset{}).
+		Where("id = ?", changesetID).
+		Select("stale", "stale_component_ids").
+		Updates(&versource.Changeset{Stale: stale, StaleComponentIDs: staleComponentIDs}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update changeset staleness: %w", err)
+	}
+	return nil
+}
+
+func (r *GormChangesetRepo) UpdateChangesetAutoRebase(ctx context.Context, changesetID uint, autoRebase bool) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Model(&versource.Changeset{}).Where("id = ?", changesetID).Update("auto_rebase", autoRebase).Error
+	if err != nil {
+		return fmt.Errorf("failed to update changeset auto rebase: %w", err)
+	}
+	return nil
+}
+
+func (r *GormChangesetRepo) UpdateChangesetParent(ctx context.Context, changesetID uint, parentID *uint) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Model(&versource.Changeset{}).Where("id = ?", changesetID).Update("parent_id", parentID).Error
+	if err != nil {
+		return fmt.Errorf("failed to update changeset parent: %w", err)
+	}
+	return nil
+}
+
 func (r *GormChangesetRepo) DeleteChangeset(ctx context.Context, changesetID uint) error {
 	db := getTxOrDb(ctx, r.db)
 	err := db.WithContext(ctx).Delete(&versource.Changeset{}, changesetID).Error
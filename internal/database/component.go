@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/marcbran/versource/pkg/versource"
@@ -24,7 +25,7 @@ func (r *GormComponentRepo) GetComponent(ctx context.Context, componentID uint)
 	var component versource.Component
 	err := db.WithContext(ctx).Preload("ModuleVersion.Module").Where("id = ?", componentID).First(&component).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get component: %w", err)
+		return nil, fmt.Errorf("failed to get component: %w", notFound(err))
 	}
 	return &component, nil
 }
@@ -58,6 +59,77 @@ func (r *GormComponentRepo) GetComponentAtCommit(ctx context.Context, componentI
 	return &component, nil
 }
 
+func (r *GormComponentRepo) ListComponentsAtCommit(ctx context.Context, commit string) ([]versource.Component, error) {
+	db := getTxOrDb(ctx, r.db)
+
+	var components []versource.Component
+	query := fmt.Sprintf("SELECT * FROM components AS OF '%s'", commit)
+	err := db.WithContext(ctx).Raw(query).Scan(&components).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list components at commit: %w", err)
+	}
+
+	err = loadModuleVersionsAtCommit(ctx, db, commit, components)
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+func (r *GormComponentRepo) ListComponentsPageAtCommit(ctx context.Context, commit string, filter internal.ComponentFilter, page internal.PageQuery) ([]versource.Component, error) {
+	db := getTxOrDb(ctx, r.db)
+
+	query := db.WithContext(ctx).Table(fmt.Sprintf("components AS OF '%s'", commit))
+	query = filterComponents(query, fmt.Sprintf("module_versions AS OF '%s'", commit), filter)
+
+	var components []versource.Component
+	err := paginate(query, "components", page).Find(&components).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list components at commit: %w", err)
+	}
+
+	err = loadModuleVersionsAtCommit(ctx, db, commit, components)
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+func loadModuleVersionsAtCommit(ctx context.Context, db *gorm.DB, commit string, components []versource.Component) error {
+	var moduleVersions []versource.ModuleVersion
+	moduleVersionQuery := fmt.Sprintf("SELECT * FROM module_versions AS OF '%s'", commit)
+	err := db.WithContext(ctx).Raw(moduleVersionQuery).Scan(&moduleVersions).Error
+	if err != nil {
+		return fmt.Errorf("failed to list module versions at commit: %w", err)
+	}
+
+	var modules []versource.Module
+	moduleQuery := fmt.Sprintf("SELECT * FROM modules AS OF '%s'", commit)
+	err = db.WithContext(ctx).Raw(moduleQuery).Scan(&modules).Error
+	if err != nil {
+		return fmt.Errorf("failed to list modules at commit: %w", err)
+	}
+
+	modulesByID := make(map[uint]versource.Module, len(modules))
+	for _, module := range modules {
+		modulesByID[module.ID] = module
+	}
+
+	moduleVersionsByID := make(map[uint]versource.ModuleVersion, len(moduleVersions))
+	for _, moduleVersion := range moduleVersions {
+		moduleVersion.Module = modulesByID[moduleVersion.ModuleID]
+		moduleVersionsByID[moduleVersion.ID] = moduleVersion
+	}
+
+	for i := range components {
+		components[i].ModuleVersion = moduleVersionsByID[components[i].ModuleVersionID]
+	}
+
+	return nil
+}
+
 func (r *GormComponentRepo) GetLastCommitOfComponent(ctx context.Context, componentID uint) (string, error) {
 	db := getTxOrDb(ctx, r.db)
 
@@ -78,6 +150,95 @@ func (r *GormComponentRepo) GetLastCommitOfComponent(ctx context.Context, compon
 	return commit, nil
 }
 
+func (r *GormComponentRepo) ListComponentHistory(ctx context.Context, componentID uint, page internal.PageQuery) ([]versource.ComponentRevision, error) {
+	db := getTxOrDb(ctx, r.db)
+
+	op, direction := ">", "ASC"
+	if page.Descending {
+		op, direction = "<", "DESC"
+	}
+
+	args := []any{componentID, componentID, componentID}
+	after := ""
+	if page.After != nil {
+		after = fmt.Sprintf(`
+			AND (commit_order %[1]s (SELECT commit_order FROM dolt_log WHERE commit_hash = ?)
+				OR (commit_order = (SELECT commit_order FROM dolt_log WHERE commit_hash = ?) AND to_commit %[1]s ?))`, op)
+		args = append(args, page.After.ID, page.After.ID, page.After.ID)
+	}
+	args = append(args, page.Limit+1)
+
+	query := fmt.Sprintf(`
+		WITH ranked AS (
+			SELECT
+				d.to_id,
+				d.to_module_version_id,
+				d.to_name,
+				d.to_variables,
+				d.to_labels,
+				d.to_owner,
+				d.to_variable_sets,
+				d.to_template,
+				d.to_environment,
+				d.to_overrides,
+				d.to_secrets,
+				d.to_status,
+				d.to_commit,
+				d.to_commit_date,
+				l.message as commit_message,
+				l.commit_order,
+				c.name as changeset_name,
+				p.id as plan_id,
+				p.changeset_id as plan_changeset_id,
+				p.from as plan_from,
+				p.to as plan_to,
+				p.state as plan_state,
+				p.add as plan_add,
+				p.change as plan_change,
+				p.destroy as plan_destroy,
+				a.id as apply_id,
+				a.state as apply_state,
+				ROW_NUMBER() OVER (
+					PARTITION BY d.to_commit
+					ORDER BY a.id IS NULL, p.id DESC
+				) AS rn
+			FROM dolt_diff_components d
+			JOIN dolt_log l
+				ON d.to_commit = l.commit_hash
+			LEFT JOIN plans AS OF admin p
+				ON p.component_id = ? AND p.to = d.to_commit
+			LEFT JOIN applies AS OF admin a
+				ON a.plan_id = p.id
+			LEFT JOIN changesets AS OF admin c
+				ON c.id = p.changeset_id
+			WHERE (d.to_id = ? OR d.from_id = ?)
+				AND NOT EXISTS (
+					SELECT 1
+					FROM dolt_commit_ancestors ca
+					WHERE ca.commit_hash = d.to_commit AND ca.parent_index > 0
+				)
+		)
+		SELECT *
+		FROM ranked
+		WHERE rn = 1%s
+		ORDER BY commit_order %s, to_commit %s
+		LIMIT ?;
+	`, after, direction, direction)
+
+	var rawRevisions []rawRevision
+	err := db.WithContext(ctx).Raw(query, args...).Scan(&rawRevisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component history: %w", err)
+	}
+
+	revisions := make([]versource.ComponentRevision, len(rawRevisions))
+	for i, raw := range rawRevisions {
+		revisions[i] = convertRawRevisionToComponentRevision(raw, componentID)
+	}
+
+	return revisions, nil
+}
+
 func (r *GormComponentRepo) HasComponent(ctx context.Context, componentID uint) (bool, error) {
 	db := getTxOrDb(ctx, r.db)
 	var count int64
@@ -88,6 +249,16 @@ func (r *GormComponentRepo) HasComponent(ctx context.Context, componentID uint)
 	return count > 0, nil
 }
 
+func (r *GormComponentRepo) HasComponentWithName(ctx context.Context, name string) (bool, error) {
+	db := getTxOrDb(ctx, r.db)
+	var count int64
+	err := db.WithContext(ctx).Model(&versource.Component{}).Where("name = ?", name).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check component name: %w", err)
+	}
+	return count > 0, nil
+}
+
 func (r *GormComponentRepo) ListComponents(ctx context.Context) ([]versource.Component, error) {
 	db := getTxOrDb(ctx, r.db)
 	var components []versource.Component
@@ -112,19 +283,38 @@ func (r *GormComponentRepo) ListComponentsByModule(ctx context.Context, moduleID
 	return components, nil
 }
 
-func (r *GormComponentRepo) ListComponentsByModuleVersion(ctx context.Context, moduleVersionID uint) ([]versource.Component, error) {
+func (r *GormComponentRepo) ListComponentsPage(ctx context.Context, filter internal.ComponentFilter, page internal.PageQuery) ([]versource.Component, error) {
 	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx).Preload("ModuleVersion.Module")
+	query = filterComponents(query, "module_versions", filter)
+
 	var components []versource.Component
-	err := db.WithContext(ctx).
-		Preload("ModuleVersion.Module").
-		Where("module_version_id = ?", moduleVersionID).
-		Find(&components).Error
+	err := paginate(query, "components", page).Find(&components).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to list components by module version: %w", err)
+		return nil, fmt.Errorf("failed to list components: %w", err)
 	}
 	return components, nil
 }
 
+func filterComponents(db *gorm.DB, moduleVersionsTable string, filter internal.ComponentFilter) *gorm.DB {
+	if filter.ModuleVersionID != nil {
+		db = db.Where("components.module_version_id = ?", *filter.ModuleVersionID)
+	}
+	if filter.ModuleID != nil {
+		db = db.Where(fmt.Sprintf("components.module_version_id IN (SELECT id FROM %s WHERE module_id = ?)", moduleVersionsTable), *filter.ModuleID)
+	}
+	if filter.Owner != nil {
+		db = db.Where("components.owner = ?", *filter.Owner)
+	}
+	if filter.Template != nil {
+		db = db.Where("components.template = ?", *filter.Template)
+	}
+	if filter.Environment != nil {
+		db = db.Where("components.environment = ?", *filter.Environment)
+	}
+	return db
+}
+
 func (r *GormComponentRepo) CreateComponent(ctx context.Context, component *versource.Component) error {
 	db := getTxOrDb(ctx, r.db)
 	err := db.WithContext(ctx).Create(component).Error
@@ -151,16 +341,27 @@ func NewGormComponentChangeRepo(db *gorm.DB) *GormComponentChangeRepo {
 	return &GormComponentChangeRepo{db: db}
 }
 
-func (r *GormComponentChangeRepo) ListComponentChanges(ctx context.Context) ([]versource.ComponentChange, error) {
+func (r *GormComponentChangeRepo) ListComponentChanges(ctx context.Context, baseBranch string) ([]versource.ComponentChange, error) {
+	if !internal.IsValidBranch(baseBranch) {
+		return nil, fmt.Errorf("invalid branch name: %s", baseBranch)
+	}
+
 	db := getTxOrDb(ctx, r.db)
 
-	query := `
+	query := fmt.Sprintf(`
 		WITH ranked AS (
 			SELECT
 				d.to_id,
 				d.to_module_version_id,
 				d.to_name,
 				d.to_variables,
+				d.to_labels,
+				d.to_owner,
+				d.to_variable_sets,
+				d.to_template,
+				d.to_environment,
+				d.to_overrides,
+				d.to_secrets,
 				d.to_status,
 				d.to_commit,
 				d.to_commit_date,
@@ -168,6 +369,13 @@ func (r *GormComponentChangeRepo) ListComponentChanges(ctx context.Context) ([]v
 				m.to_module_version_id as from_module_version_id,
 				m.to_name as from_name,
 				m.to_variables as from_variables,
+				m.to_labels as from_labels,
+				m.to_owner as from_owner,
+				m.to_variable_sets as from_variable_sets,
+				m.to_template as from_template,
+				m.to_environment as from_environment,
+				m.to_overrides as from_overrides,
+				m.to_secrets as from_secrets,
 				m.to_status as from_status,
 				m.to_commit as from_commit,
 				m.to_commit_date as from_commit_date,
@@ -187,9 +395,9 @@ func (r *GormComponentChangeRepo) ListComponentChanges(ctx context.Context) ([]v
 			FROM dolt_diff_components d
 			LEFT JOIN dolt_log dl
 				ON d.to_commit = dl.commit_hash
-			LEFT JOIN dolt_diff_components AS OF main AS m
+			LEFT JOIN dolt_diff_components AS OF '%s' AS m
 				ON d.to_id = m.to_id
-			LEFT JOIN dolt_log AS OF main AS ml
+			LEFT JOIN dolt_log AS OF '%s' AS ml
 				ON m.to_commit = ml.commit_hash
 			LEFT JOIN plans AS OF admin p
 				ON d.to_id = p.component_id AND d.to_commit = p.to
@@ -201,7 +409,7 @@ func (r *GormComponentChangeRepo) ListComponentChanges(ctx context.Context) ([]v
 		SELECT *
 		FROM ranked
 		WHERE rn = 1;
-	`
+	`, baseBranch, baseBranch)
 
 	var rawDiffs []rawDiff
 	err := db.WithContext(ctx).Raw(query).Scan(&rawDiffs).Error
@@ -217,15 +425,26 @@ func (r *GormComponentChangeRepo) ListComponentChanges(ctx context.Context) ([]v
 	return changes, nil
 }
 
-func (r *GormComponentChangeRepo) GetComponentChange(ctx context.Context, componentID uint) (*versource.ComponentChange, error) {
+func (r *GormComponentChangeRepo) GetComponentChange(ctx context.Context, baseBranch string, componentID uint) (*versource.ComponentChange, error) {
+	if !internal.IsValidBranch(baseBranch) {
+		return nil, fmt.Errorf("invalid branch name: %s", baseBranch)
+	}
+
 	db := getTxOrDb(ctx, r.db)
 
-	query := `
+	query := fmt.Sprintf(`
 		SELECT
 			d.to_id,
 			d.to_module_version_id,
 			d.to_name,
 			d.to_variables,
+			d.to_labels,
+			d.to_owner,
+			d.to_variable_sets,
+			d.to_template,
+			d.to_environment,
+			d.to_overrides,
+			d.to_secrets,
 			d.to_status,
 			d.to_commit,
 			d.to_commit_date,
@@ -233,6 +452,13 @@ func (r *GormComponentChangeRepo) GetComponentChange(ctx context.Context, compon
 			m.to_module_version_id as from_module_version_id,
 			m.to_name as from_name,
 			m.to_variables as from_variables,
+			m.to_labels as from_labels,
+			m.to_owner as from_owner,
+			m.to_variable_sets as from_variable_sets,
+			m.to_template as from_template,
+			m.to_environment as from_environment,
+			m.to_overrides as from_overrides,
+			m.to_secrets as from_secrets,
 			m.to_status as from_status,
 			m.to_commit as from_commit,
 			m.to_commit_date as from_commit_date,
@@ -248,9 +474,9 @@ func (r *GormComponentChangeRepo) GetComponentChange(ctx context.Context, compon
 		FROM dolt_diff_components d
 		LEFT JOIN dolt_log dl
 			ON d.to_commit = dl.commit_hash
-		LEFT JOIN dolt_diff_components AS OF main AS m
+		LEFT JOIN dolt_diff_components AS OF '%s' AS m
 			ON d.to_id = m.to_id
-		LEFT JOIN dolt_log AS OF main AS ml
+		LEFT JOIN dolt_log AS OF '%s' AS ml
 			ON m.to_commit = ml.commit_hash
 		LEFT JOIN plans AS OF admin AS p
 			ON d.to_id = p.component_id AND d.to_commit = p.to
@@ -263,7 +489,7 @@ func (r *GormComponentChangeRepo) GetComponentChange(ctx context.Context, compon
 			)
 		ORDER BY dl.commit_order DESC, ml.commit_order DESC, p.id DESC
 		LIMIT 1;
-	`
+	`, baseBranch, baseBranch)
 
 	var singleRawDiff rawDiff
 	err := db.WithContext(ctx).Raw(query, componentID).Scan(&singleRawDiff).Error
@@ -275,6 +501,29 @@ func (r *GormComponentChangeRepo) GetComponentChange(ctx context.Context, compon
 	return &change, nil
 }
 
+func (r *GormComponentChangeRepo) GetChangesetBaseBranch(ctx context.Context, changesetName string) (string, error) {
+	db := getTxOrDb(ctx, r.db)
+
+	query := `
+		SELECT p.name
+		FROM changesets AS OF admin AS c
+		JOIN changesets AS OF admin AS p
+			ON c.parent_id = p.id
+		WHERE c.name = ? AND p.state = ?
+	`
+
+	var parentNames []string
+	err := db.WithContext(ctx).Raw(query, changesetName, versource.ChangesetStateOpen).Scan(&parentNames).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to get changeset base branch: %w", err)
+	}
+
+	if len(parentNames) == 0 {
+		return internal.MainBranch, nil
+	}
+	return parentNames[0], nil
+}
+
 func (r *GormComponentChangeRepo) HasComponentConflicts(ctx context.Context, changesetName string) (bool, error) {
 	if !internal.IsValidBranch(changesetName) {
 		return false, fmt.Errorf("invalid branch name: %s", changesetName)
@@ -298,30 +547,228 @@ func (r *GormComponentChangeRepo) HasComponentConflicts(ctx context.Context, cha
 	return count > 0, nil
 }
 
+func (r *GormComponentChangeRepo) ListComponentIDsChangedOnMain(ctx context.Context, changesetName string) ([]uint, error) {
+	if !internal.IsValidBranch(changesetName) {
+		return nil, fmt.Errorf("invalid branch name: %s", changesetName)
+	}
+
+	db := getTxOrDb(ctx, r.db)
+
+	query := fmt.Sprintf(`
+		SELECT DISTINCT COALESCE(m.to_id, m.from_id)
+		FROM dolt_diff("%s...main", "components") m
+		ORDER BY 1`, changesetName)
+
+	var componentIDs []uint
+	err := db.WithContext(ctx).Raw(query).Scan(&componentIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list components changed on main: %w", err)
+	}
+
+	return componentIDs, nil
+}
+
+func (r *GormComponentChangeRepo) ListComponentChangesBetween(ctx context.Context, fromCommit, toCommit string) ([]versource.ComponentChange, error) {
+	if !internal.IsValidCommitHash(fromCommit) {
+		return nil, fmt.Errorf("invalid commit hash: %s", fromCommit)
+	}
+	if !internal.IsValidCommitHash(toCommit) {
+		return nil, fmt.Errorf("invalid commit hash: %s", toCommit)
+	}
+
+	db := getTxOrDb(ctx, r.db)
+
+	query := fmt.Sprintf(`
+		SELECT
+			d.to_id,
+			d.to_module_version_id,
+			d.to_name,
+			d.to_variables,
+			d.to_labels,
+			d.to_owner,
+			d.to_variable_sets,
+			d.to_template,
+			d.to_environment,
+			d.to_overrides,
+			d.to_secrets,
+			d.to_status,
+			d.to_commit,
+			d.from_id,
+			d.from_module_version_id,
+			d.from_name,
+			d.from_variables,
+			d.from_labels,
+			d.from_owner,
+			d.from_variable_sets,
+			d.from_template,
+			d.from_environment,
+			d.from_overrides,
+			d.from_secrets,
+			d.from_status,
+			d.from_commit
+		FROM dolt_diff("%s", "%s", "components") d
+		ORDER BY COALESCE(d.to_id, d.from_id)`, fromCommit, toCommit)
+
+	var rawDiffs []rawDiff
+	err := db.WithContext(ctx).Raw(query).Scan(&rawDiffs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component changes between commits: %w", err)
+	}
+
+	changes := make([]versource.ComponentChange, len(rawDiffs))
+	for i, raw := range rawDiffs {
+		changes[i] = convertRawDiffToComponentChange(raw)
+	}
+
+	return changes, nil
+}
+
+func (r *GormComponentChangeRepo) ListComponentConflicts(ctx context.Context, changesetName string) ([]versource.ComponentConflict, error) {
+	if !internal.IsValidBranch(changesetName) {
+		return nil, fmt.Errorf("invalid branch name: %s", changesetName)
+	}
+
+	db := getTxOrDb(ctx, r.db)
+
+	query := fmt.Sprintf(`
+		SELECT
+			b.from_id as base_id,
+			b.from_module_version_id as base_module_version_id,
+			b.from_name as base_name,
+			b.from_variables as base_variables,
+			b.from_labels as base_labels,
+			b.from_owner as base_owner,
+			b.from_variable_sets as base_variable_sets,
+			b.from_template as base_template,
+			b.from_environment as base_environment,
+			b.from_overrides as base_overrides,
+			b.from_secrets as base_secrets,
+			b.from_status as base_status,
+			b.to_id as changeset_id,
+			b.to_module_version_id as changeset_module_version_id,
+			b.to_name as changeset_name,
+			b.to_variables as changeset_variables,
+			b.to_labels as changeset_labels,
+			b.to_owner as changeset_owner,
+			b.to_variable_sets as changeset_variable_sets,
+			b.to_template as changeset_template,
+			b.to_environment as changeset_environment,
+			b.to_overrides as changeset_overrides,
+			b.to_secrets as changeset_secrets,
+			b.to_status as changeset_status,
+			m.to_id as main_id,
+			m.to_module_version_id as main_module_version_id,
+			m.to_name as main_name,
+			m.to_variables as main_variables,
+			m.to_labels as main_labels,
+			m.to_owner as main_owner,
+			m.to_variable_sets as main_variable_sets,
+			m.to_template as main_template,
+			m.to_environment as main_environment,
+			m.to_overrides as main_overrides,
+			m.to_secrets as main_secrets,
+			m.to_status as main_status
+		FROM dolt_diff("main...%s", "components") b
+		JOIN dolt_diff("%s...main", "components") m
+		ON b.to_id = m.to_id
+		OR b.to_name = m.to_name
+		ORDER BY COALESCE(b.to_id, b.from_id)`, changesetName, changesetName)
+
+	var rawConflicts []rawConflict
+	err := db.WithContext(ctx).Raw(query).Scan(&rawConflicts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component conflicts: %w", err)
+	}
+
+	conflicts := make([]versource.ComponentConflict, len(rawConflicts))
+	for i, raw := range rawConflicts {
+		conflicts[i] = convertRawConflictToComponentConflict(raw)
+	}
+
+	return conflicts, nil
+}
+
+func (r *GormComponentChangeRepo) MarkComponentConflictResolved(ctx context.Context, componentID uint) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Exec("DELETE FROM dolt_conflicts_components WHERE our_id = ? OR their_id = ?", componentID, componentID).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark component conflict as resolved: %w", err)
+	}
+	return nil
+}
+
+func (r *GormComponentChangeRepo) ListUnresolvedComponentConflicts(ctx context.Context) ([]uint, error) {
+	db := getTxOrDb(ctx, r.db)
+	var componentIDs []uint
+	err := db.WithContext(ctx).Raw("SELECT DISTINCT COALESCE(our_id, their_id) FROM dolt_conflicts_components ORDER BY 1").Scan(&componentIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unresolved component conflicts: %w", err)
+	}
+	return componentIDs, nil
+}
+
 type rawDiff struct {
-	ToID                *uint          `json:"toId"`
-	ToModuleVersionID   *uint          `json:"toModuleVersionId"`
-	ToName              *string        `json:"toName"`
-	ToVariables         datatypes.JSON `json:"toVariables"`
-	ToStatus            *string        `json:"toStatus"`
-	ToCommit            string         `json:"toCommit"`
-	ToCommitDate        string         `json:"toCommitDate"`
-	FromID              *uint          `json:"fromId"`
-	FromModuleVersionID *uint          `json:"fromModuleVersionId"`
-	FromName            *string        `json:"fromName"`
-	FromVariables       datatypes.JSON `json:"fromVariables"`
-	FromStatus          *string        `json:"fromStatus"`
-	FromCommit          string         `json:"fromCommit"`
-	FromCommitDate      string         `json:"fromCommitDate"`
-	PlanID              *uint          `json:"planId"`
-	PlanComponentID     *uint          `json:"planComponentId"`
-	PlanChangesetID     *uint          `json:"planChangesetId"`
-	PlanFrom            *string        `json:"planFrom"`
-	PlanTo              *string        `json:"planTo"`
-	PlanState           *string        `json:"planState"`
-	PlanAdd             *int           `json:"planAdd"`
-	PlanChange          *int           `json:"planChange"`
-	PlanDestroy         *int           `json:"planDestroy"`
+	ToID                *uint                     `json:"toId"`
+	ToModuleVersionID   *uint                     `json:"toModuleVersionId"`
+	ToName              *string                   `json:"toName"`
+	ToVariables         datatypes.JSON            `json:"toVariables"`
+	ToLabels            datatypes.JSON            `json:"toLabels"`
+	ToOwner             *string                   `json:"toOwner"`
+	ToVariableSets      datatypes.JSON            `json:"toVariableSets"`
+	ToTemplate          *string                   `json:"toTemplate"`
+	ToEnvironment       *string                   `json:"toEnvironment"`
+	ToOverrides         datatypes.JSON            `json:"toOverrides"`
+	ToSecrets           versource.SecretVariables `json:"toSecrets"`
+	ToStatus            *string                   `json:"toStatus"`
+	ToCommit            string                    `json:"toCommit"`
+	ToCommitDate        string                    `json:"toCommitDate"`
+	FromID              *uint                     `json:"fromId"`
+	FromModuleVersionID *uint                     `json:"fromModuleVersionId"`
+	FromName            *string                   `json:"fromName"`
+	FromVariables       datatypes.JSON            `json:"fromVariables"`
+	FromLabels          datatypes.JSON            `json:"fromLabels"`
+	FromOwner           *string                   `json:"fromOwner"`
+	FromVariableSets    datatypes.JSON            `json:"fromVariableSets"`
+	FromTemplate        *string                   `json:"fromTemplate"`
+	FromEnvironment     *string                   `json:"fromEnvironment"`
+	FromOverrides       datatypes.JSON            `json:"fromOverrides"`
+	FromSecrets         versource.SecretVariables `json:"fromSecrets"`
+	FromStatus          *string                   `json:"fromStatus"`
+	FromCommit          string                    `json:"fromCommit"`
+	FromCommitDate      string                    `json:"fromCommitDate"`
+	PlanID              *uint                     `json:"planId"`
+	PlanComponentID     *uint                     `json:"planComponentId"`
+	PlanChangesetID     *uint                     `json:"planChangesetId"`
+	PlanFrom            *string                   `json:"planFrom"`
+	PlanTo              *string                   `json:"planTo"`
+	PlanState           *string                   `json:"planState"`
+	PlanAdd             *int                      `json:"planAdd"`
+	PlanChange          *int                      `json:"planChange"`
+	PlanDestroy         *int                      `json:"planDestroy"`
+}
+
+func unmarshalLabels(data datatypes.JSON) map[string]string {
+	if len(data) == 0 {
+		return nil
+	}
+	var labels map[string]string
+	err := json.Unmarshal(data, &labels)
+	if err != nil {
+		return nil
+	}
+	return labels
+}
+
+func unmarshalVariableSets(data datatypes.JSON) []versource.VariableSetAttachment {
+	if len(data) == 0 {
+		return nil
+	}
+	var variableSets []versource.VariableSetAttachment
+	err := json.Unmarshal(data, &variableSets)
+	if err != nil {
+		return nil
+	}
+	return variableSets
 }
 
 func convertRawDiffToComponentChange(raw rawDiff) versource.ComponentChange {
@@ -337,6 +784,19 @@ func convertRawDiffToComponentChange(raw rawDiff) versource.ComponentChange {
 			fromComponent.Name = *raw.FromName
 		}
 		fromComponent.Variables = raw.FromVariables
+		fromComponent.Labels = unmarshalLabels(raw.FromLabels)
+		fromComponent.VariableSets = unmarshalVariableSets(raw.FromVariableSets)
+		if raw.FromTemplate != nil {
+			fromComponent.Template = *raw.FromTemplate
+		}
+		if raw.FromEnvironment != nil {
+			fromComponent.Environment = *raw.FromEnvironment
+		}
+		fromComponent.Overrides = raw.FromOverrides
+		fromComponent.Secrets = raw.FromSecrets
+		if raw.FromOwner != nil {
+			fromComponent.Owner = *raw.FromOwner
+		}
 		if raw.FromStatus != nil {
 			fromComponent.Status = versource.ComponentStatus(*raw.FromStatus)
 		}
@@ -352,6 +812,19 @@ func convertRawDiffToComponentChange(raw rawDiff) versource.ComponentChange {
 			toComponent.Name = *raw.ToName
 		}
 		toComponent.Variables = raw.ToVariables
+		toComponent.Labels = unmarshalLabels(raw.ToLabels)
+		toComponent.VariableSets = unmarshalVariableSets(raw.ToVariableSets)
+		if raw.ToTemplate != nil {
+			toComponent.Template = *raw.ToTemplate
+		}
+		if raw.ToEnvironment != nil {
+			toComponent.Environment = *raw.ToEnvironment
+		}
+		toComponent.Overrides = raw.ToOverrides
+		toComponent.Secrets = raw.ToSecrets
+		if raw.ToOwner != nil {
+			toComponent.Owner = *raw.ToOwner
+		}
 		if raw.ToStatus != nil {
 			toComponent.Status = versource.ComponentStatus(*raw.ToStatus)
 		}
@@ -388,3 +861,229 @@ func convertRawDiffToComponentChange(raw rawDiff) versource.ComponentChange {
 		ToCommit:      raw.ToCommit,
 	}
 }
+
+type rawConflict struct {
+	BaseID                   *uint                     `json:"baseId"`
+	BaseModuleVersionID      *uint                     `json:"baseModuleVersionId"`
+	BaseName                 *string                   `json:"baseName"`
+	BaseVariables            datatypes.JSON            `json:"baseVariables"`
+	BaseLabels               datatypes.JSON            `json:"baseLabels"`
+	BaseOwner                *string                   `json:"baseOwner"`
+	BaseVariableSets         datatypes.JSON            `json:"baseVariableSets"`
+	BaseTemplate             *string                   `json:"baseTemplate"`
+	BaseEnvironment          *string                   `json:"baseEnvironment"`
+	BaseOverrides            datatypes.JSON            `json:"baseOverrides"`
+	BaseSecrets              versource.SecretVariables `json:"baseSecrets"`
+	BaseStatus               *string                   `json:"baseStatus"`
+	ChangesetID              *uint                     `json:"changesetId"`
+	ChangesetModuleVersionID *uint                     `json:"changesetModuleVersionId"`
+	ChangesetName            *string                   `json:"changesetName"`
+	ChangesetVariables       datatypes.JSON            `json:"changesetVariables"`
+	ChangesetLabels          datatypes.JSON            `json:"changesetLabels"`
+	ChangesetOwner           *string                   `json:"changesetOwner"`
+	ChangesetVariableSets    datatypes.JSON            `json:"changesetVariableSets"`
+	ChangesetTemplate        *string                   `json:"changesetTemplate"`
+	ChangesetEnvironment     *string                   `json:"changesetEnvironment"`
+	ChangesetOverrides       datatypes.JSON            `json:"changesetOverrides"`
+	ChangesetSecrets         versource.SecretVariables `json:"changesetSecrets"`
+	ChangesetStatus          *string                   `json:"changesetStatus"`
+	MainID                   *uint                     `json:"mainId"`
+	MainModuleVersionID      *uint                     `json:"mainModuleVersionId"`
+	MainName                 *string                   `json:"mainName"`
+	MainVariables            datatypes.JSON            `json:"mainVariables"`
+	MainLabels               datatypes.JSON            `json:"mainLabels"`
+	MainOwner                *string                   `json:"mainOwner"`
+	MainVariableSets         datatypes.JSON            `json:"mainVariableSets"`
+	MainTemplate             *string                   `json:"mainTemplate"`
+	MainEnvironment          *string                   `json:"mainEnvironment"`
+	MainOverrides            datatypes.JSON            `json:"mainOverrides"`
+	MainSecrets              versource.SecretVariables `json:"mainSecrets"`
+	MainStatus               *string                   `json:"mainStatus"`
+}
+
+func convertRawConflictToComponentConflict(raw rawConflict) versource.ComponentConflict {
+	base := convertRawComponent(rawComponent{
+		ID:              raw.BaseID,
+		ModuleVersionID: raw.BaseModuleVersionID,
+		Name:            raw.BaseName,
+		Variables:       raw.BaseVariables,
+		Labels:          raw.BaseLabels,
+		Owner:           raw.BaseOwner,
+		VariableSets:    raw.BaseVariableSets,
+		Template:        raw.BaseTemplate,
+		Environment:     raw.BaseEnvironment,
+		Overrides:       raw.BaseOverrides,
+		Secrets:         raw.BaseSecrets,
+		Status:          raw.BaseStatus,
+	})
+	changeset := convertRawComponent(rawComponent{
+		ID:              raw.ChangesetID,
+		ModuleVersionID: raw.ChangesetModuleVersionID,
+		Name:            raw.ChangesetName,
+		Variables:       raw.ChangesetVariables,
+		Labels:          raw.ChangesetLabels,
+		Owner:           raw.ChangesetOwner,
+		VariableSets:    raw.ChangesetVariableSets,
+		Template:        raw.ChangesetTemplate,
+		Environment:     raw.ChangesetEnvironment,
+		Overrides:       raw.ChangesetOverrides,
+		Secrets:         raw.ChangesetSecrets,
+		Status:          raw.ChangesetStatus,
+	})
+	main := convertRawComponent(rawComponent{
+		ID:              raw.MainID,
+		ModuleVersionID: raw.MainModuleVersionID,
+		Name:            raw.MainName,
+		Variables:       raw.MainVariables,
+		Labels:          raw.MainLabels,
+		Owner:           raw.MainOwner,
+		VariableSets:    raw.MainVariableSets,
+		Template:        raw.MainTemplate,
+		Environment:     raw.MainEnvironment,
+		Overrides:       raw.MainOverrides,
+		Secrets:         raw.MainSecrets,
+		Status:          raw.MainStatus,
+	})
+
+	var componentID uint
+	if changeset != nil {
+		componentID = changeset.ID
+	} else if base != nil {
+		componentID = base.ID
+	}
+
+	return versource.ComponentConflict{
+		ComponentID: componentID,
+		Base:        base,
+		Changeset:   changeset,
+		Main:        main,
+	}
+}
+
+type rawComponent struct {
+	ID              *uint
+	ModuleVersionID *uint
+	Name            *string
+	Variables       datatypes.JSON
+	Labels          datatypes.JSON
+	Owner           *string
+	VariableSets    datatypes.JSON
+	Template        *string
+	Environment     *string
+	Overrides       datatypes.JSON
+	Secrets         versource.SecretVariables
+	Status          *string
+}
+
+func convertRawComponent(raw rawComponent) *versource.Component {
+	if raw.ID == nil {
+		return nil
+	}
+
+	component := &versource.Component{}
+	component.ID = *raw.ID
+	if raw.ModuleVersionID != nil {
+		component.ModuleVersionID = *raw.ModuleVersionID
+	}
+	if raw.Name != nil {
+		component.Name = *raw.Name
+	}
+	component.Variables = raw.Variables
+	component.Labels = unmarshalLabels(raw.Labels)
+	if raw.Owner != nil {
+		component.Owner = *raw.Owner
+	}
+	component.VariableSets = unmarshalVariableSets(raw.VariableSets)
+	if raw.Template != nil {
+		component.Template = *raw.Template
+	}
+	if raw.Environment != nil {
+		component.Environment = *raw.Environment
+	}
+	component.Overrides = raw.Overrides
+	component.Secrets = raw.Secrets
+	if raw.Status != nil {
+		component.Status = versource.ComponentStatus(*raw.Status)
+	}
+	return component
+}
+
+type rawRevision struct {
+	ToID              *uint                     `json:"toId"`
+	ToModuleVersionID *uint                     `json:"toModuleVersionId"`
+	ToName            *string                   `json:"toName"`
+	ToVariables       datatypes.JSON            `json:"toVariables"`
+	ToLabels          datatypes.JSON            `json:"toLabels"`
+	ToOwner           *string                   `json:"toOwner"`
+	ToVariableSets    datatypes.JSON            `json:"toVariableSets"`
+	ToTemplate        *string                   `json:"toTemplate"`
+	ToEnvironment     *string                   `json:"toEnvironment"`
+	ToOverrides       datatypes.JSON            `json:"toOverrides"`
+	ToSecrets         versource.SecretVariables `json:"toSecrets"`
+	ToStatus          *string                   `json:"toStatus"`
+	ToCommit          string                    `json:"toCommit"`
+	ToCommitDate      string                    `json:"toCommitDate"`
+	CommitMessage     string                    `json:"commitMessage"`
+	ChangesetName     *string                   `json:"changesetName"`
+	PlanID            *uint                     `json:"planId"`
+	PlanChangesetID   *uint                     `json:"planChangesetId"`
+	PlanFrom          *string                   `json:"planFrom"`
+	PlanTo            *string                   `json:"planTo"`
+	PlanState         *string                   `json:"planState"`
+	PlanAdd           *int                      `json:"planAdd"`
+	PlanChange        *int                      `json:"planChange"`
+	PlanDestroy       *int                      `json:"planDestroy"`
+	ApplyID           *uint                     `json:"applyId"`
+	ApplyState        *string                   `json:"applyState"`
+}
+
+func convertRawRevisionToComponentRevision(raw rawRevision, componentID uint) versource.ComponentRevision {
+	revision := versource.ComponentRevision{
+		Commit:     raw.ToCommit,
+		CommitDate: raw.ToCommitDate,
+		Message:    raw.CommitMessage,
+		Component: convertRawComponent(rawComponent{
+			ID:              raw.ToID,
+			ModuleVersionID: raw.ToModuleVersionID,
+			Name:            raw.ToName,
+			Variables:       raw.ToVariables,
+			Labels:          raw.ToLabels,
+			Owner:           raw.ToOwner,
+			VariableSets:    raw.ToVariableSets,
+			Template:        raw.ToTemplate,
+			Environment:     raw.ToEnvironment,
+			Overrides:       raw.ToOverrides,
+			Secrets:         raw.ToSecrets,
+			Status:          raw.ToStatus,
+		}),
+	}
+
+	if raw.ChangesetName != nil {
+		revision.ChangesetName = *raw.ChangesetName
+	}
+
+	if raw.PlanID != nil {
+		revision.Plan = &versource.Plan{
+			ID:          *raw.PlanID,
+			ComponentID: componentID,
+			ChangesetID: *raw.PlanChangesetID,
+			From:        *raw.PlanFrom,
+			To:          *raw.PlanTo,
+			State:       versource.TaskState(*raw.PlanState),
+			Add:         raw.PlanAdd,
+			Change:      raw.PlanChange,
+			Destroy:     raw.PlanDestroy,
+		}
+	}
+
+	if raw.ApplyID != nil {
+		revision.Apply = &versource.Apply{
+			ID:          *raw.ApplyID,
+			PlanID:      *raw.PlanID,
+			ChangesetID: *raw.PlanChangesetID,
+			State:       versource.TaskState(*raw.ApplyState),
+		}
+	}
+
+	return revision
+}
@@ -2,8 +2,10 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/marcbran/versource/internal"
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -30,3 +32,10 @@ func NewGormDb(config *versource.DatabaseConfig) (*gorm.DB, error) {
 	}
 	return db, nil
 }
+
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return internal.ErrNotFound
+	}
+	return err
+}
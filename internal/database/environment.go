@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/gorm"
+)
+
+type GormEnvironmentRepo struct {
+	db *gorm.DB
+}
+
+func NewGormEnvironmentRepo(db *gorm.DB) *GormEnvironmentRepo {
+	return &GormEnvironmentRepo{db: db}
+}
+
+func (r *GormEnvironmentRepo) ListEnvironments(ctx context.Context) ([]versource.Environment, error) {
+	db := getTxOrDb(ctx, r.db)
+	var environments []versource.Environment
+	err := db.WithContext(ctx).Order("position").Find(&environments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list environments: %w", err)
+	}
+	return environments, nil
+}
+
+func (r *GormEnvironmentRepo) HasEnvironmentWithName(ctx context.Context, name string) (bool, error) {
+	db := getTxOrDb(ctx, r.db)
+	var count int64
+	err := db.WithContext(ctx).Model(&versource.Environment{}).Where("name = ?", name).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check for environments: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *GormEnvironmentRepo) CreateEnvironment(ctx context.Context, environment *versource.Environment) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Create(environment).Error
+	if err != nil {
+		return fmt.Errorf("failed to create environment: %w", err)
+	}
+	return nil
+}
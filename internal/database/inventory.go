@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormInventoryRepo struct {
+	db *gorm.DB
+}
+
+func NewGormInventoryRepo(db *gorm.DB) *GormInventoryRepo {
+	return &GormInventoryRepo{db: db}
+}
+
+func (r *GormInventoryRepo) GetInventory(ctx context.Context) (*versource.Inventory, error) {
+	db := getTxOrDb(ctx, r.db).WithContext(ctx)
+	var inventory versource.Inventory
+
+	err := db.Order("id").Find(&inventory.Modules).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	err = db.Order("id").Find(&inventory.ModuleVersions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list module versions: %w", err)
+	}
+
+	err = db.Order("id").Find(&inventory.VariableSets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variable sets: %w", err)
+	}
+
+	err = db.Order("id").Find(&inventory.Components).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list components: %w", err)
+	}
+
+	err = db.Order("uuid").Find(&inventory.Resources).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	err = db.Order("id").Find(&inventory.States).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list states: %w", err)
+	}
+
+	err = db.Order("id").Find(&inventory.StateResources).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list state resources: %w", err)
+	}
+
+	err = db.Order("id").Find(&inventory.ViewResources).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list view resources: %w", err)
+	}
+
+	return &inventory, nil
+}
+
+func (r *GormInventoryRepo) IsInventoryEmpty(ctx context.Context) (bool, error) {
+	db := getTxOrDb(ctx, r.db).WithContext(ctx)
+
+	for _, model := range []any{
+		&versource.Module{},
+		&versource.ModuleVersion{},
+		&versource.VariableSet{},
+		&versource.Component{},
+		&versource.Resource{},
+		&versource.State{},
+		&versource.StateResource{},
+		&versource.ViewResource{},
+	} {
+		var count int64
+		err := db.Model(model).Count(&count).Error
+		if err != nil {
+			return false, fmt.Errorf("failed to count inventory: %w", err)
+		}
+		if count > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (r *GormInventoryRepo) InsertInventory(ctx context.Context, inventory *versource.Inventory) error {
+	db := getTxOrDb(ctx, r.db).WithContext(ctx).Omit(clause.Associations).Session(&gorm.Session{})
+
+	if len(inventory.Modules) > 0 {
+		err := db.Create(&inventory.Modules).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert modules: %w", err)
+		}
+	}
+
+	if len(inventory.ModuleVersions) > 0 {
+		err := db.Create(&inventory.ModuleVersions).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert module versions: %w", err)
+		}
+	}
+
+	if len(inventory.VariableSets) > 0 {
+		err := db.Create(&inventory.VariableSets).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert variable sets: %w", err)
+		}
+	}
+
+	if len(inventory.Components) > 0 {
+		err := db.Create(&inventory.Components).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert components: %w", err)
+		}
+	}
+
+	if len(inventory.Resources) > 0 {
+		err := db.Create(&inventory.Resources).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert resources: %w", err)
+		}
+	}
+
+	if len(inventory.States) > 0 {
+		err := db.Create(&inventory.States).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert states: %w", err)
+		}
+	}
+
+	if len(inventory.StateResources) > 0 {
+		err := db.Create(&inventory.StateResources).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert state resources: %w", err)
+		}
+	}
+
+	if len(inventory.ViewResources) > 0 {
+		err := db.Create(&inventory.ViewResources).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert view resources: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/marcbran/versource/internal"
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/gorm"
 )
@@ -31,7 +32,10 @@ func (r *GormMergeRepo) GetMerge(ctx context.Context, mergeID uint) (*versource.
 func (r *GormMergeRepo) GetQueuedMerges(ctx context.Context) ([]uint, error) {
 	db := getTxOrDb(ctx, r.db)
 	var merges []versource.Merge
-	err := db.WithContext(ctx).Where("state = ?", versource.TaskStateQueued).Find(&merges).Error
+	err := db.WithContext(ctx).
+		Where("state = ?", versource.TaskStateQueued).
+		Order("id ASC").
+		Find(&merges).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get queued merges: %w", err)
 	}
@@ -58,13 +62,17 @@ func (r *GormMergeRepo) GetQueuedMergesByChangeset(ctx context.Context, changese
 	return mergeIDs, nil
 }
 
-func (r *GormMergeRepo) ListMerges(ctx context.Context) ([]versource.Merge, error) {
+func (r *GormMergeRepo) ListMerges(ctx context.Context, filter internal.MergeFilter, page internal.PageQuery) ([]versource.Merge, error) {
 	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx).Preload("Changeset")
+	if filter.ChangesetName != nil {
+		query = query.
+			Joins("JOIN changesets ON merges.changeset_id = changesets.id").
+			Where("changesets.name = ?", *filter.ChangesetName)
+	}
+
 	var merges []versource.Merge
-	err := db.WithContext(ctx).
-		Preload("Changeset").
-		Order("id DESC").
-		Find(&merges).Error
+	err := paginate(query, "merges", page).Find(&merges).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to list merges: %w", err)
 	}
@@ -86,6 +94,20 @@ func (r *GormMergeRepo) ListMergesByChangesetName(ctx context.Context, changeset
 	return merges, nil
 }
 
+func (r *GormMergeRepo) ListMergeQueue(ctx context.Context) ([]versource.Merge, error) {
+	db := getTxOrDb(ctx, r.db)
+	var merges []versource.Merge
+	err := db.WithContext(ctx).
+		Preload("Changeset").
+		Where("state IN ?", []versource.TaskState{versource.TaskStateQueued, versource.TaskStateStarted}).
+		Order("id ASC").
+		Find(&merges).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue: %w", err)
+	}
+	return merges, nil
+}
+
 func (r *GormMergeRepo) CreateMerge(ctx context.Context, merge *versource.Merge) error {
 	db := getTxOrDb(ctx, r.db)
 	err := db.WithContext(ctx).Create(merge).Error
@@ -103,3 +125,36 @@ func (r *GormMergeRepo) UpdateMergeState(ctx context.Context, mergeID uint, stat
 	}
 	return nil
 }
+
+func (r *GormMergeRepo) UpdateMergePhase(ctx context.Context, mergeID uint, phase versource.MergePhase) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Model(&versource.Merge{}).Where("id = ?", mergeID).Update("phase", phase).Error
+	if err != nil {
+		return fmt.Errorf("failed to update merge phase: %w", err)
+	}
+	return nil
+}
+
+func (r *GormMergeRepo) UpdateMergeFindings(ctx context.Context, mergeID uint, findings []versource.MergeFinding) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Model(&versource.Merge{}).
+		Where("id = ?", mergeID).
+		Select("findings").
+		Updates(&versource.Merge{Findings: findings}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update merge findings: %w", err)
+	}
+	return nil
+}
+
+func (r *GormMergeRepo) UpdateMergeRevision(ctx context.Context, mergeID uint, mergeBase, head string) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Model(&versource.Merge{}).Where("id = ?", mergeID).Updates(map[string]any{
+		"merge_base": mergeBase,
+		"head":       head,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update merge revision: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE changesets ADD COLUMN created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE plans ADD COLUMN created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE applies ADD COLUMN created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX changesets_created_at ON changesets (created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX plans_created_at ON plans (created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX applies_created_at ON applies (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX applies_created_at ON applies;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX plans_created_at ON plans;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX changesets_created_at ON changesets;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE applies DROP COLUMN created_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE plans DROP COLUMN created_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE changesets DROP COLUMN created_at;
+-- +goose StatementEnd
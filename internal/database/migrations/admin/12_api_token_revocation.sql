@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE api_tokens ADD COLUMN revoked_at DATETIME NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE api_tokens DROP COLUMN revoked_at;
+-- +goose StatementEnd
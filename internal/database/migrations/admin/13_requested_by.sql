@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE plans ADD COLUMN requested_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE applies ADD COLUMN requested_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE merges ADD COLUMN requested_by VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE merges DROP COLUMN requested_by;
+ALTER TABLE applies DROP COLUMN requested_by;
+ALTER TABLE plans DROP COLUMN requested_by;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE merges ADD COLUMN phase VARCHAR(50) NOT NULL DEFAULT ('Waiting');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE merges DROP COLUMN phase;
+-- +goose StatementEnd
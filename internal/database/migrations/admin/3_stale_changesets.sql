@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE changesets ADD COLUMN stale BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE changesets ADD COLUMN stale_component_ids JSON NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE changesets ADD COLUMN auto_rebase BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE changesets DROP COLUMN auto_rebase;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE changesets DROP COLUMN stale_component_ids;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE changesets DROP COLUMN stale;
+-- +goose StatementEnd
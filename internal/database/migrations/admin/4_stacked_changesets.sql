@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE changesets ADD COLUMN parent_id INT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE changesets DROP COLUMN parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE modules ADD COLUMN owner VARCHAR(255) NOT NULL DEFAULT ('');
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE components ADD COLUMN owner VARCHAR(255) NOT NULL DEFAULT ('');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE components DROP COLUMN owner;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE modules DROP COLUMN owner;
+-- +goose StatementEnd
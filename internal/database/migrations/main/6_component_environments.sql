@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE components ADD COLUMN template VARCHAR(255) NOT NULL DEFAULT ('');
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE components ADD COLUMN environment VARCHAR(255) NOT NULL DEFAULT ('');
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE components ADD COLUMN overrides JSON NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE components DROP COLUMN overrides;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE components DROP COLUMN environment;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE components DROP COLUMN template;
+-- +goose StatementEnd
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/marcbran/versource/internal"
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/gorm"
 )
@@ -56,6 +57,28 @@ func (r *GormModuleRepo) ListModules(ctx context.Context) ([]versource.Module, e
 	return modules, nil
 }
 
+func (r *GormModuleRepo) ListModulesPage(ctx context.Context, page internal.PageQuery) ([]versource.Module, error) {
+	db := getTxOrDb(ctx, r.db)
+	var modules []versource.Module
+	err := paginate(db.WithContext(ctx), "modules", page).Find(&modules).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+	return modules, nil
+}
+
+func (r *GormModuleRepo) ListModulesPageAtCommit(ctx context.Context, commit string, page internal.PageQuery) ([]versource.Module, error) {
+	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx).Table(fmt.Sprintf("modules AS OF '%s'", commit))
+
+	var modules []versource.Module
+	err := paginate(query, "modules", page).Find(&modules).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules at commit: %w", err)
+	}
+	return modules, nil
+}
+
 func (r *GormModuleRepo) CreateModule(ctx context.Context, module *versource.Module) error {
 	db := getTxOrDb(ctx, r.db)
 	err := db.WithContext(ctx).Create(module).Error
@@ -115,12 +138,17 @@ func (r *GormModuleVersionRepo) ListModuleVersions(ctx context.Context) ([]verso
 	return moduleVersions, nil
 }
 
-func (r *GormModuleVersionRepo) ListModuleVersionsForModule(ctx context.Context, moduleID uint) ([]versource.ModuleVersion, error) {
+func (r *GormModuleVersionRepo) ListModuleVersionsPage(ctx context.Context, filter internal.ModuleVersionFilter, page internal.PageQuery) ([]versource.ModuleVersion, error) {
 	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx).Preload("Module")
+	if filter.ModuleID != nil {
+		query = query.Where("module_versions.module_id = ?", *filter.ModuleID)
+	}
+
 	var moduleVersions []versource.ModuleVersion
-	err := db.WithContext(ctx).Preload("Module").Where("module_id = ?", moduleID).Find(&moduleVersions).Error
+	err := paginate(query, "module_versions", page).Find(&moduleVersions).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to list module versions for module: %w", err)
+		return nil, fmt.Errorf("failed to list module versions: %w", err)
 	}
 	return moduleVersions, nil
 }
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marcbran/versource/internal"
+	"gorm.io/gorm"
+)
+
+func paginate(db *gorm.DB, table string, page internal.PageQuery) *gorm.DB {
+	column := fmt.Sprintf("%s.%s", table, page.Column)
+	idColumn := fmt.Sprintf("%s.%s", table, page.IDColumn)
+
+	op, direction := ">", "ASC"
+	if page.Descending {
+		op, direction = "<", "DESC"
+	}
+
+	if page.After != nil {
+		if column == idColumn {
+			db = db.Where(fmt.Sprintf("%s %s ?", idColumn, op), page.After.ID)
+		} else {
+			db = db.Where(
+				fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", column, op, column, idColumn, op),
+				page.After.Value, page.After.Value, page.After.ID,
+			)
+		}
+	}
+
+	if column != idColumn {
+		db = db.Order(fmt.Sprintf("%s %s", column, direction))
+	}
+	return db.Order(fmt.Sprintf("%s %s", idColumn, direction)).Limit(page.Limit + 1)
+}
+
+func filterCreated(db *gorm.DB, table string, after, before *time.Time) *gorm.DB {
+	if after != nil {
+		db = db.Where(fmt.Sprintf("%s.created_at >= ?", table), *after)
+	}
+	if before != nil {
+		db = db.Where(fmt.Sprintf("%s.created_at < ?", table), *before)
+	}
+	return db
+}
@@ -25,7 +25,7 @@ func (r *GormPlanRepo) GetPlan(ctx context.Context, planID uint) (*versource.Pla
 		Preload("Changeset").
 		Where("id = ?", planID).First(&plan).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get plan: %w", err)
+		return nil, fmt.Errorf("failed to get plan: %w", notFound(err))
 	}
 	return &plan, nil
 }
@@ -45,12 +45,21 @@ func (r *GormPlanRepo) GetQueuedPlans(ctx context.Context) ([]uint, error) {
 	return planIDs, nil
 }
 
-func (r *GormPlanRepo) ListPlans(ctx context.Context) ([]versource.Plan, error) {
+func (r *GormPlanRepo) ListPlans(ctx context.Context, filter internal.PlanFilter, page internal.PageQuery) ([]versource.Plan, error) {
 	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx).Preload("Changeset")
+	if filter.ChangesetName != nil {
+		query = query.
+			Joins("JOIN changesets ON plans.changeset_id = changesets.id").
+			Where("changesets.name = ?", *filter.ChangesetName)
+	}
+	if filter.State != nil {
+		query = query.Where("plans.state = ?", *filter.State)
+	}
+	query = filterCreated(query, "plans", filter.CreatedAfter, filter.CreatedBefore)
+
 	var plans []versource.Plan
-	err := db.WithContext(ctx).
-		Preload("Changeset").
-		Find(&plans).Error
+	err := paginate(query, "plans", page).Find(&plans).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to list plans: %w", err)
 	}
@@ -70,20 +79,6 @@ func (r *GormPlanRepo) ListPlansByChangeset(ctx context.Context, changesetID uin
 	return plans, nil
 }
 
-func (r *GormPlanRepo) ListPlansByChangesetName(ctx context.Context, changesetName string) ([]versource.Plan, error) {
-	db := getTxOrDb(ctx, r.db)
-	var plans []versource.Plan
-	err := db.WithContext(ctx).
-		Preload("Changeset").
-		Joins("JOIN changesets ON plans.changeset_id = changesets.id").
-		Where("changesets.name = ?", changesetName).
-		Find(&plans).Error
-	if err != nil {
-		return nil, fmt.Errorf("failed to list plans by changeset name: %w", err)
-	}
-	return plans, nil
-}
-
 func (r *GormPlanRepo) CreatePlan(ctx context.Context, plan *versource.Plan) error {
 	db := getTxOrDb(ctx, r.db)
 	err := db.WithContext(ctx).Create(plan).Error
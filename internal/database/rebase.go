@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/marcbran/versource/internal"
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/gorm"
 )
@@ -58,13 +59,17 @@ func (r *GormRebaseRepo) GetQueuedRebasesByChangeset(ctx context.Context, change
 	return rebaseIDs, nil
 }
 
-func (r *GormRebaseRepo) ListRebases(ctx context.Context) ([]versource.Rebase, error) {
+func (r *GormRebaseRepo) ListRebases(ctx context.Context, filter internal.RebaseFilter, page internal.PageQuery) ([]versource.Rebase, error) {
 	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx).Preload("Changeset")
+	if filter.ChangesetName != nil {
+		query = query.
+			Joins("JOIN changesets ON rebases.changeset_id = changesets.id").
+			Where("changesets.name = ?", *filter.ChangesetName)
+	}
+
 	var rebases []versource.Rebase
-	err := db.WithContext(ctx).
-		Preload("Changeset").
-		Order("id DESC").
-		Find(&rebases).Error
+	err := paginate(query, "rebases", page).Find(&rebases).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to list rebases: %w", err)
 	}
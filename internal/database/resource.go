@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/marcbran/versource/internal"
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/gorm"
 )
@@ -57,12 +58,35 @@ func (r *GormResourceRepo) DeleteResources(ctx context.Context, resourceUUIDs []
 	return nil
 }
 
-func (r *GormResourceRepo) ListResources(ctx context.Context) ([]versource.Resource, error) {
+func (r *GormResourceRepo) ListResources(ctx context.Context, filter internal.ResourceFilter, page internal.PageQuery) ([]versource.Resource, error) {
 	db := getTxOrDb(ctx, r.db)
+	query := filterResources(db.WithContext(ctx), filter)
 	var resources []versource.Resource
-	err := db.WithContext(ctx).Find(&resources).Error
+	err := paginate(query, "resources", page).Find(&resources).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to list resources: %w", err)
 	}
 	return resources, nil
 }
+
+func (r *GormResourceRepo) ListResourcesAtCommit(ctx context.Context, commit string, filter internal.ResourceFilter, page internal.PageQuery) ([]versource.Resource, error) {
+	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx).Table(fmt.Sprintf("resources AS OF '%s'", commit))
+	query = filterResources(query, filter)
+	var resources []versource.Resource
+	err := paginate(query, "resources", page).Find(&resources).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources at commit: %w", err)
+	}
+	return resources, nil
+}
+
+func filterResources(db *gorm.DB, filter internal.ResourceFilter) *gorm.DB {
+	if filter.Provider != nil {
+		db = db.Where("resources.provider = ?", *filter.Provider)
+	}
+	if filter.ResourceType != nil {
+		db = db.Where("resources.resource_type = ?", *filter.ResourceType)
+	}
+	return db
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/gorm"
+)
+
+type GormTeamRepo struct {
+	db *gorm.DB
+}
+
+func NewGormTeamRepo(db *gorm.DB) *GormTeamRepo {
+	return &GormTeamRepo{db: db}
+}
+
+func (r *GormTeamRepo) GetTeamByName(ctx context.Context, name string) (*versource.Team, error) {
+	db := getTxOrDb(ctx, r.db)
+	var team versource.Team
+	err := db.WithContext(ctx).Preload("Members").Where("name = ?", name).First(&team).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get team by name: %w", err)
+	}
+	return &team, nil
+}
+
+func (r *GormTeamRepo) ListTeams(ctx context.Context) ([]versource.Team, error) {
+	db := getTxOrDb(ctx, r.db)
+	var teams []versource.Team
+	err := db.WithContext(ctx).Preload("Members").Order("name").Find(&teams).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	return teams, nil
+}
+
+func (r *GormTeamRepo) HasTeamWithName(ctx context.Context, name string) (bool, error) {
+	db := getTxOrDb(ctx, r.db)
+	var count int64
+	err := db.WithContext(ctx).Model(&versource.Team{}).Where("name = ?", name).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check for teams: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *GormTeamRepo) CreateTeam(ctx context.Context, team *versource.Team) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Create(team).Error
+	if err != nil {
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	return nil
+}
+
+func (r *GormTeamRepo) AddTeamMember(ctx context.Context, member *versource.TeamMember) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Create(member).Error
+	if err != nil {
+		return fmt.Errorf("failed to add team member: %w", err)
+	}
+	return nil
+}
+
+func (r *GormTeamRepo) RemoveTeamMember(ctx context.Context, teamID uint, user string) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Where("team_id = ? AND username = ?", teamID, user).Delete(&versource.TeamMember{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+	return nil
+}
+
+type GormChangesetApprovalRepo struct {
+	db *gorm.DB
+}
+
+func NewGormChangesetApprovalRepo(db *gorm.DB) *GormChangesetApprovalRepo {
+	return &GormChangesetApprovalRepo{db: db}
+}
+
+func (r *GormChangesetApprovalRepo) ListChangesetApprovals(ctx context.Context, changesetID uint) ([]versource.ChangesetApproval, error) {
+	db := getTxOrDb(ctx, r.db)
+	var approvals []versource.ChangesetApproval
+	err := db.WithContext(ctx).Where("changeset_id = ?", changesetID).Order("id").Find(&approvals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changeset approvals: %w", err)
+	}
+	return approvals, nil
+}
+
+func (r *GormChangesetApprovalRepo) UpdateChangesetApproval(ctx context.Context, approval *versource.ChangesetApproval) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Save(approval).Error
+	if err != nil {
+		return fmt.Errorf("failed to update changeset approval: %w", err)
+	}
+	return nil
+}
+
+func (r *GormChangesetApprovalRepo) CreateChangesetApproval(ctx context.Context, approval *versource.ChangesetApproval) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Create(approval).Error
+	if err != nil {
+		return fmt.Errorf("failed to create changeset approval: %w", err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/marcbran/versource/internal"
+	"github.com/marcbran/versource/pkg/versource"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -57,7 +58,7 @@ func (tm *GormTransactionManager) Do(ctx context.Context, branch, message string
 			return err
 		}
 
-		err = commitChanges(tx, message)
+		err = commitChanges(tx, message, commitAuthor(ctx))
 		if err != nil {
 			return fmt.Errorf("failed to commit changes: %w", err)
 		}
@@ -113,7 +114,15 @@ func ensureBranch(tx *gorm.DB, branch string) error {
 	return nil
 }
 
-func commitChanges(tx *gorm.DB, message string) error {
+func commitAuthor(ctx context.Context) string {
+	user := versource.UserFromContext(ctx)
+	if !versource.IsValidUser(user) {
+		return ""
+	}
+	return fmt.Sprintf("%s <%s@versource>", user, user)
+}
+
+func commitChanges(tx *gorm.DB, message, author string) error {
 	var count int64
 	err := tx.Raw("SELECT COUNT(*) FROM dolt_diff WHERE commit_hash = 'WORKING'").Scan(&count).Error
 	if err != nil {
@@ -129,7 +138,11 @@ func commitChanges(tx *gorm.DB, message string) error {
 		return fmt.Errorf("failed to add changes: %w", err)
 	}
 
-	err = tx.Exec("CALL DOLT_COMMIT('-m', ?)", message).Error
+	if author != "" {
+		err = tx.Exec("CALL DOLT_COMMIT('-m', ?, '--author', ?)", message, author).Error
+	} else {
+		err = tx.Exec("CALL DOLT_COMMIT('-m', ?)", message).Error
+	}
 	if err != nil {
 		return fmt.Errorf("failed to commit with message '%s': %w", message, err)
 	}
@@ -171,7 +184,12 @@ func (tm *GormTransactionManager) MergeBranch(ctx context.Context, branch string
 		return fmt.Errorf("cannot merge currently checked out branch %s into itself", branch)
 	}
 
-	err := tx.Exec("CALL DOLT_MERGE(?, '--no-ff')", branch).Error
+	var err error
+	if author := commitAuthor(ctx); author != "" {
+		err = tx.Exec("CALL DOLT_MERGE(?, '--no-ff', '--author', ?)", branch, author).Error
+	} else {
+		err = tx.Exec("CALL DOLT_MERGE(?, '--no-ff')", branch).Error
+	}
 	if err != nil {
 		return fmt.Errorf("failed to merge branch %s: %w", branch, err)
 	}
@@ -208,7 +226,7 @@ func (tm *GormTransactionManager) RebaseBranch(ctx context.Context, onto string)
 
 	err = tx.WithContext(ctx).Exec("CALL DOLT_REBASE('--continue')").Error
 	if err != nil {
-		err = resolveAllConflicts(ctx, tx)
+		err = resolveAllConflicts(ctx, tx, "--theirs")
 		if err != nil {
 			return fmt.Errorf("failed to resolve conflicts during rebase: %w", err)
 		}
@@ -221,6 +239,17 @@ func (tm *GormTransactionManager) RebaseBranch(ctx context.Context, onto string)
 	return nil
 }
 
+func (tm *GormTransactionManager) CreateTag(ctx context.Context, tag, ref string) error {
+	tx := getTxOrDb(ctx, tm.db)
+
+	err := tx.Exec("CALL DOLT_TAG(?, ?)", tag, ref).Error
+	if err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", tag, err)
+	}
+
+	return nil
+}
+
 func (tm *GormTransactionManager) DeleteBranch(ctx context.Context, branch string) error {
 	tx := getTxOrDb(ctx, tm.db)
 	parent := getBranch(ctx)
@@ -284,7 +313,19 @@ func (tm *GormTransactionManager) HasCommitsAfter(ctx context.Context, branch, c
 	return count > 0, nil
 }
 
-func resolveAllConflicts(ctx context.Context, tx *gorm.DB) error {
+func (tm *GormTransactionManager) ListConflictedTables(ctx context.Context) ([]string, error) {
+	tx := getTxOrDb(ctx, tm.db)
+
+	var tables []string
+	err := tx.WithContext(ctx).Raw("SELECT `table` FROM dolt_conflicts").Scan(&tables).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dolt_conflicts: %w", err)
+	}
+
+	return tables, nil
+}
+
+func resolveAllConflicts(ctx context.Context, tx *gorm.DB, strategy string) error {
 	var tables []string
 	err := tx.WithContext(ctx).Raw("SELECT `table` FROM dolt_conflicts").Scan(&tables).Error
 	if err != nil {
@@ -292,7 +333,7 @@ func resolveAllConflicts(ctx context.Context, tx *gorm.DB) error {
 	}
 
 	for _, table := range tables {
-		err := tx.WithContext(ctx).Exec("CALL DOLT_CONFLICTS_RESOLVE('--theirs', ?)", table).Error
+		err := tx.WithContext(ctx).Exec("CALL DOLT_CONFLICTS_RESOLVE(?, ?)", strategy, table).Error
 		if err != nil {
 			return fmt.Errorf("failed to resolve conflicts for table %s: %w", table, err)
 		}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/gorm"
+)
+
+type GormVariableSetRepo struct {
+	db *gorm.DB
+}
+
+func NewGormVariableSetRepo(db *gorm.DB) *GormVariableSetRepo {
+	return &GormVariableSetRepo{db: db}
+}
+
+func (r *GormVariableSetRepo) GetVariableSetByName(ctx context.Context, name string) (*versource.VariableSet, error) {
+	db := getTxOrDb(ctx, r.db)
+	var variableSet versource.VariableSet
+	err := db.WithContext(ctx).Where("name = ?", name).First(&variableSet).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get variable set by name: %w", err)
+	}
+	return &variableSet, nil
+}
+
+func (r *GormVariableSetRepo) ListVariableSets(ctx context.Context) ([]versource.VariableSet, error) {
+	db := getTxOrDb(ctx, r.db)
+	var variableSets []versource.VariableSet
+	err := db.WithContext(ctx).Order("name").Find(&variableSets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variable sets: %w", err)
+	}
+	return variableSets, nil
+}
+
+func (r *GormVariableSetRepo) ListVariableSetsAtCommit(ctx context.Context, commit string) ([]versource.VariableSet, error) {
+	db := getTxOrDb(ctx, r.db)
+	var variableSets []versource.VariableSet
+	query := fmt.Sprintf("SELECT * FROM variable_sets AS OF '%s' ORDER BY name", commit)
+	err := db.WithContext(ctx).Raw(query).Scan(&variableSets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variable sets at commit: %w", err)
+	}
+	return variableSets, nil
+}
+
+func (r *GormVariableSetRepo) CreateVariableSet(ctx context.Context, variableSet *versource.VariableSet) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Create(variableSet).Error
+	if err != nil {
+		return fmt.Errorf("failed to create variable set: %w", err)
+	}
+	return nil
+}
+
+func (r *GormVariableSetRepo) UpdateVariableSet(ctx context.Context, variableSet *versource.VariableSet) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Save(variableSet).Error
+	if err != nil {
+		return fmt.Errorf("failed to update variable set: %w", err)
+	}
+	return nil
+}
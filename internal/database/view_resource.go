@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/marcbran/versource/internal"
 	"github.com/marcbran/versource/pkg/versource"
 	"gorm.io/gorm"
 )
@@ -40,10 +41,10 @@ func (r *GormViewResourceRepo) GetViewResourceByName(ctx context.Context, name s
 	return &viewResource, nil
 }
 
-func (r *GormViewResourceRepo) ListViewResources(ctx context.Context) ([]versource.ViewResource, error) {
+func (r *GormViewResourceRepo) ListViewResources(ctx context.Context, page internal.PageQuery) ([]versource.ViewResource, error) {
 	db := getTxOrDb(ctx, r.db)
 	var viewResources []versource.ViewResource
-	err := db.WithContext(ctx).Find(&viewResources).Error
+	err := paginate(db.WithContext(ctx), "view_resources", page).Find(&viewResources).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to list view resources: %w", err)
 	}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcbran/versource/internal"
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/gorm"
+)
+
+type GormWebhookRepo struct {
+	db *gorm.DB
+}
+
+func NewGormWebhookRepo(db *gorm.DB) *GormWebhookRepo {
+	return &GormWebhookRepo{db: db}
+}
+
+func (r *GormWebhookRepo) ListWebhooks(ctx context.Context) ([]versource.Webhook, error) {
+	db := getTxOrDb(ctx, r.db)
+	var webhooks []versource.Webhook
+	err := db.WithContext(ctx).Order("name").Find(&webhooks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (r *GormWebhookRepo) GetWebhookByName(ctx context.Context, name string) (*versource.Webhook, error) {
+	db := getTxOrDb(ctx, r.db)
+	var webhook versource.Webhook
+	err := db.WithContext(ctx).Where("name = ?", name).First(&webhook).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+func (r *GormWebhookRepo) CreateWebhook(ctx context.Context, webhook *versource.Webhook) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Create(webhook).Error
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *GormWebhookRepo) DeleteWebhook(ctx context.Context, webhookID uint) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Delete(&versource.Webhook{}, webhookID).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+type GormWebhookDeliveryRepo struct {
+	db *gorm.DB
+}
+
+func NewGormWebhookDeliveryRepo(db *gorm.DB) *GormWebhookDeliveryRepo {
+	return &GormWebhookDeliveryRepo{db: db}
+}
+
+func (r *GormWebhookDeliveryRepo) ListWebhookDeliveries(ctx context.Context, webhookID uint, page internal.PageQuery) ([]versource.WebhookDelivery, error) {
+	db := getTxOrDb(ctx, r.db)
+	query := db.WithContext(ctx).
+		Preload("Webhook").
+		Where("webhook_deliveries.webhook_id = ?", webhookID)
+
+	var deliveries []versource.WebhookDelivery
+	err := paginate(query, "webhook_deliveries", page).Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *GormWebhookDeliveryRepo) GetWebhookDelivery(ctx context.Context, deliveryID uint) (*versource.WebhookDelivery, error) {
+	db := getTxOrDb(ctx, r.db)
+	var delivery versource.WebhookDelivery
+	err := db.WithContext(ctx).
+		Preload("Webhook").
+		Where("id = ?", deliveryID).
+		First(&delivery).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+func (r *GormWebhookDeliveryRepo) GetDueWebhookDeliveries(ctx context.Context, now time.Time) ([]uint, error) {
+	db := getTxOrDb(ctx, r.db)
+	var deliveries []versource.WebhookDelivery
+	err := db.WithContext(ctx).
+		Where("state = ? AND next_attempt_at <= ?", versource.WebhookDeliveryStatePending, now).
+		Order("next_attempt_at").
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due webhook deliveries: %w", err)
+	}
+
+	deliveryIDs := make([]uint, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryIDs[i] = delivery.ID
+	}
+	return deliveryIDs, nil
+}
+
+func (r *GormWebhookDeliveryRepo) CreateWebhookDelivery(ctx context.Context, delivery *versource.WebhookDelivery) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).Create(delivery).Error
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *GormWebhookDeliveryRepo) UpdateWebhookDelivery(ctx context.Context, delivery *versource.WebhookDelivery) error {
+	db := getTxOrDb(ctx, r.db)
+	err := db.WithContext(ctx).
+		Model(&versource.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]any{
+			"state":           delivery.State,
+			"attempts":        delivery.Attempts,
+			"status_code":     delivery.StatusCode,
+			"error":           delivery.Error,
+			"next_attempt_at": delivery.NextAttemptAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type EnvironmentRepo interface {
+	ListEnvironments(ctx context.Context) ([]versource.Environment, error)
+	HasEnvironmentWithName(ctx context.Context, name string) (bool, error)
+	CreateEnvironment(ctx context.Context, environment *versource.Environment) error
+}
+
+type ListEnvironments struct {
+	environmentRepo EnvironmentRepo
+	tx              TransactionManager
+}
+
+func NewListEnvironments(environmentRepo EnvironmentRepo, tx TransactionManager) *ListEnvironments {
+	return &ListEnvironments{
+		environmentRepo: environmentRepo,
+		tx:              tx,
+	}
+}
+
+func (l *ListEnvironments) Exec(ctx context.Context, req versource.ListEnvironmentsRequest) (*versource.ListEnvironmentsResponse, error) {
+	var environments []versource.Environment
+	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		environments, err = l.environmentRepo.ListEnvironments(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list environments", err)
+	}
+
+	return &versource.ListEnvironmentsResponse{
+		Environments: environments,
+	}, nil
+}
+
+type CreateEnvironment struct {
+	environmentRepo EnvironmentRepo
+	tx              TransactionManager
+}
+
+func NewCreateEnvironment(environmentRepo EnvironmentRepo, tx TransactionManager) *CreateEnvironment {
+	return &CreateEnvironment{
+		environmentRepo: environmentRepo,
+		tx:              tx,
+	}
+}
+
+func (c *CreateEnvironment) Exec(ctx context.Context, req versource.CreateEnvironmentRequest) (*versource.CreateEnvironmentResponse, error) {
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+
+	var response *versource.CreateEnvironmentResponse
+	err := c.tx.Do(ctx, AdminBranch, fmt.Sprintf("create environment %s", req.Name), func(ctx context.Context) error {
+		environments, err := c.environmentRepo.ListEnvironments(ctx)
+		if err != nil {
+			return versource.InternalErrE("failed to list environments", err)
+		}
+
+		position := 0
+		for _, environment := range environments {
+			if environment.Name == req.Name {
+				return versource.ConflictErrf("environment with name %s already exists", req.Name)
+			}
+			position = max(position, environment.Position+1)
+		}
+
+		environment := &versource.Environment{
+			Name:     req.Name,
+			Position: position,
+		}
+		err = c.environmentRepo.CreateEnvironment(ctx, environment)
+		if err != nil {
+			return versource.InternalErrE("failed to create environment", err)
+		}
+
+		response = &versource.CreateEnvironmentResponse{
+			Environment: *environment,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type GetPromotionPath struct {
+	environmentRepo EnvironmentRepo
+	componentRepo   ComponentRepo
+	tx              TransactionManager
+}
+
+func NewGetPromotionPath(environmentRepo EnvironmentRepo, componentRepo ComponentRepo, tx TransactionManager) *GetPromotionPath {
+	return &GetPromotionPath{
+		environmentRepo: environmentRepo,
+		componentRepo:   componentRepo,
+		tx:              tx,
+	}
+}
+
+func (g *GetPromotionPath) Exec(ctx context.Context, req versource.GetPromotionPathRequest) (*versource.GetPromotionPathResponse, error) {
+	if req.Template == "" {
+		return nil, versource.UserErr("template is required")
+	}
+
+	var environments []versource.Environment
+	err := g.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		environments, err = g.environmentRepo.ListEnvironments(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list environments", err)
+	}
+
+	var components []versource.Component
+	err = g.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var err error
+		components, err = g.componentRepo.ListComponents(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list components", err)
+	}
+
+	stages := make([]versource.PromotionStage, 0, len(environments))
+	var previous *versource.Component
+	for i, environment := range environments {
+		component := findTemplateComponent(components, req.Template, environment.Name)
+		promoted := component != nil
+		if i > 0 {
+			promoted = promoted && previous != nil && sameTemplateDefinition(previous, component)
+		}
+		stages = append(stages, versource.PromotionStage{
+			Environment: environment.Name,
+			Component:   component,
+			Promoted:    promoted,
+		})
+		previous = component
+	}
+
+	return &versource.GetPromotionPathResponse{
+		Stages: stages,
+	}, nil
+}
+
+type PromoteComponent struct {
+	environmentRepo EnvironmentRepo
+	componentRepo   ComponentRepo
+	createChangeset *CreateChangeset
+	createPlan      *CreatePlan
+	tx              TransactionManager
+}
+
+func NewPromoteComponent(environmentRepo EnvironmentRepo, componentRepo ComponentRepo, createChangeset *CreateChangeset, createPlan *CreatePlan, tx TransactionManager) *PromoteComponent {
+	return &PromoteComponent{
+		environmentRepo: environmentRepo,
+		componentRepo:   componentRepo,
+		createChangeset: createChangeset,
+		createPlan:      createPlan,
+		tx:              tx,
+	}
+}
+
+func (p *PromoteComponent) Exec(ctx context.Context, req versource.PromoteComponentRequest) (*versource.PromoteComponentResponse, error) {
+	if req.Template == "" {
+		return nil, versource.UserErr("template is required")
+	}
+	if req.FromEnvironment == "" {
+		return nil, versource.UserErr("environment to promote from is required")
+	}
+
+	var environments []versource.Environment
+	err := p.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		environments, err = p.environmentRepo.ListEnvironments(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list environments", err)
+	}
+
+	index := slices.IndexFunc(environments, func(environment versource.Environment) bool {
+		return environment.Name == req.FromEnvironment
+	})
+	if index < 0 {
+		return nil, versource.NotFoundErrf("environment %s not found", req.FromEnvironment)
+	}
+	if index == len(environments)-1 {
+		return nil, versource.PreconditionFailedErrf("environment %s is the last environment of the promotion path", req.FromEnvironment)
+	}
+	toEnvironment := environments[index+1].Name
+
+	componentName := fmt.Sprintf("%s-%s", req.Template, toEnvironment)
+
+	var source, target *versource.Component
+	var nameTaken bool
+	err = p.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		components, err := p.componentRepo.ListComponents(ctx)
+		if err != nil {
+			return err
+		}
+		source = findTemplateComponent(components, req.Template, req.FromEnvironment)
+		target = findTemplateComponent(components, req.Template, toEnvironment)
+		if target == nil {
+			nameTaken, err = p.componentRepo.HasComponentWithName(ctx, componentName)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list components", err)
+	}
+	if nameTaken {
+		return nil, versource.ConflictErrf("component with name %s already exists", componentName)
+	}
+	if source == nil {
+		return nil, versource.UserErrf("template %s has no component in environment %s on main", req.Template, req.FromEnvironment)
+	}
+	if target != nil && sameTemplateDefinition(source, target) {
+		return nil, versource.ConflictErrf("template %s is already promoted to environment %s", req.Template, toEnvironment)
+	}
+
+	name := req.ChangesetName
+	if name == "" {
+		name = fmt.Sprintf("promote-%s-%s", req.Template, toEnvironment)
+	}
+
+	createResp, err := p.createChangeset.Exec(ctx, versource.CreateChangesetRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var component *versource.Component
+	err = p.tx.Do(ctx, name, fmt.Sprintf("promote %s from %s to %s", req.Template, req.FromEnvironment, toEnvironment), func(ctx context.Context) error {
+		if target == nil {
+			nameTaken, err := p.componentRepo.HasComponentWithName(ctx, componentName)
+			if err != nil {
+				return versource.InternalErrE("failed to check component name", err)
+			}
+			if nameTaken {
+				return versource.ConflictErrf("component with name %s already exists", componentName)
+			}
+
+			component = &versource.Component{
+				Name:            componentName,
+				ModuleVersionID: source.ModuleVersionID,
+				Variables:       source.Variables,
+				VariableSets:    source.VariableSets,
+				Labels:          source.Labels,
+				Owner:           source.Owner,
+				Template:        req.Template,
+				Environment:     toEnvironment,
+				Status:          versource.ComponentStatusReady,
+			}
+			err = p.componentRepo.CreateComponent(ctx, component)
+			if err != nil {
+				return versource.InternalErrE("failed to create component", err)
+			}
+			return nil
+		}
+
+		var err error
+		component, err = p.componentRepo.GetComponent(ctx, target.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to get component", err)
+		}
+		component.ModuleVersionID = source.ModuleVersionID
+		component.ModuleVersion = versource.ModuleVersion{}
+		component.Variables = source.Variables
+		component.VariableSets = source.VariableSets
+		err = p.componentRepo.UpdateComponent(ctx, component)
+		if err != nil {
+			return versource.InternalErrE("failed to update component", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote component: %w", err)
+	}
+
+	planResp, err := p.createPlan.Exec(ctx, versource.CreatePlanRequest{
+		ComponentID:   component.ID,
+		ChangesetName: name,
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to create plan after promotion", err)
+	}
+
+	return &versource.PromoteComponentResponse{
+		Changeset: createResp.Changeset,
+		Component: *component,
+		Plan:      planResp.Plan,
+	}, nil
+}
+
+func ensureEnvironmentExists(ctx context.Context, tx TransactionManager, environmentRepo EnvironmentRepo, name string) error {
+	if name == "" {
+		return nil
+	}
+	var exists bool
+	err := tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		exists, err = environmentRepo.HasEnvironmentWithName(ctx, name)
+		return err
+	})
+	if err != nil {
+		return versource.InternalErrE("failed to check environment existence", err)
+	}
+	if !exists {
+		return versource.NotFoundErrf("environment %s not found", name)
+	}
+	return nil
+}
+
+func findTemplateComponent(components []versource.Component, template, environment string) *versource.Component {
+	for i := range components {
+		component := &components[i]
+		if component.Status == versource.ComponentStatusDeleted {
+			continue
+		}
+		if component.Template == template && component.Environment == environment {
+			return component
+		}
+	}
+	return nil
+}
+
+func sameTemplateDefinition(a, b *versource.Component) bool {
+	return a.ModuleVersionID == b.ModuleVersionID &&
+		variablesEqual(a.Variables, b.Variables) &&
+		slices.Equal(a.VariableSets, b.VariableSets)
+}
@@ -0,0 +1,382 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	eventHistorySize      = 1000
+	eventSubscriberBuffer = 64
+)
+
+type EventBus struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []versource.Event
+	subscribers map[*eventSubscriber]struct{}
+}
+
+type eventSubscriber struct {
+	req    versource.SubscribeEventsRequest
+	events chan versource.Event
+	onDrop func(versource.Event)
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+func (b *EventBus) Publish(event versource.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.history = append(b.history, event)
+	if len(b.history) > eventHistorySize {
+		b.history = b.history[len(b.history)-eventHistorySize:]
+	}
+
+	for subscriber := range b.subscribers {
+		if !matchesEvent(subscriber.req, event) {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+			log.WithField("event_id", event.ID).Warn("Event subscriber is too slow, dropping event")
+			if subscriber.onDrop != nil {
+				go subscriber.onDrop(event)
+			}
+		}
+	}
+}
+
+func (b *EventBus) Subscribe(ctx context.Context, req versource.SubscribeEventsRequest) <-chan versource.Event {
+	return b.SubscribeWithDropHandler(ctx, req, nil)
+}
+
+func (b *EventBus) SubscribeWithDropHandler(ctx context.Context, req versource.SubscribeEventsRequest, onDrop func(versource.Event)) <-chan versource.Event {
+	b.mu.Lock()
+	var replay []versource.Event
+	if req.LastEventID > 0 {
+		for _, event := range b.history {
+			if event.ID > req.LastEventID && matchesEvent(req, event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+	subscriber := &eventSubscriber{
+		req:    req,
+		events: make(chan versource.Event, len(replay)+eventSubscriberBuffer),
+		onDrop: onDrop,
+	}
+	for _, event := range replay {
+		subscriber.events <- event
+	}
+	b.subscribers[subscriber] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, subscriber)
+		close(subscriber.events)
+		b.mu.Unlock()
+	}()
+
+	return subscriber.events
+}
+
+func matchesEvent(req versource.SubscribeEventsRequest, event versource.Event) bool {
+	if req.ChangesetName != nil && event.ChangesetName != *req.ChangesetName {
+		return false
+	}
+	if len(req.Types) > 0 && !slices.Contains(req.Types, event.Type) {
+		return false
+	}
+	return true
+}
+
+type SubscribeEvents struct {
+	eventBus *EventBus
+}
+
+func NewSubscribeEvents(eventBus *EventBus) *SubscribeEvents {
+	return &SubscribeEvents{
+		eventBus: eventBus,
+	}
+}
+
+func (s *SubscribeEvents) Exec(ctx context.Context, req versource.SubscribeEventsRequest) (*versource.SubscribeEventsResponse, error) {
+	return &versource.SubscribeEventsResponse{
+		Events: s.eventBus.Subscribe(ctx, req),
+	}, nil
+}
+
+type pendingEventsKey struct{}
+
+type pendingEvents struct {
+	branch string
+	events []versource.Event
+}
+
+func recordEvent(ctx context.Context, eventBus *EventBus, event versource.Event) {
+	pending, ok := ctx.Value(pendingEventsKey{}).(*pendingEvents)
+	if !ok {
+		eventBus.Publish(event)
+		return
+	}
+	pending.events = append(pending.events, event)
+}
+
+func eventBranch(ctx context.Context) string {
+	pending, ok := ctx.Value(pendingEventsKey{}).(*pendingEvents)
+	if !ok || pending.branch == MainBranch || pending.branch == AdminBranch {
+		return ""
+	}
+	return pending.branch
+}
+
+type eventTransactionManager struct {
+	TransactionManager
+	eventBus *EventBus
+}
+
+func (t *eventTransactionManager) Do(ctx context.Context, branch, message string, fn func(ctx context.Context) error) error {
+	pending := &pendingEvents{branch: branch}
+	err := t.TransactionManager.Do(ctx, branch, message, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, pendingEventsKey{}, pending))
+	})
+	if err != nil {
+		return err
+	}
+	for _, event := range pending.events {
+		t.eventBus.Publish(event)
+	}
+	return nil
+}
+
+type eventPlanRepo struct {
+	PlanRepo
+	eventBus *EventBus
+}
+
+func (r *eventPlanRepo) CreatePlan(ctx context.Context, plan *versource.Plan) error {
+	err := r.PlanRepo.CreatePlan(ctx, plan)
+	if err != nil {
+		return err
+	}
+	return r.recordStateChange(ctx, plan.ID)
+}
+
+func (r *eventPlanRepo) UpdatePlanState(ctx context.Context, planID uint, state versource.TaskState) error {
+	err := r.PlanRepo.UpdatePlanState(ctx, planID, state)
+	if err != nil {
+		return err
+	}
+	return r.recordStateChange(ctx, planID)
+}
+
+func (r *eventPlanRepo) recordStateChange(ctx context.Context, planID uint) error {
+	plan, err := r.PlanRepo.GetPlan(ctx, planID)
+	if err != nil || plan == nil {
+		return err
+	}
+	recordEvent(ctx, r.eventBus, versource.Event{
+		Type:          versource.EventTypePlanStateChanged,
+		ChangesetName: plan.Changeset.Name,
+		EntityID:      plan.ID,
+		State:         string(plan.State),
+	})
+	return nil
+}
+
+type eventApplyRepo struct {
+	ApplyRepo
+	eventBus *EventBus
+}
+
+func (r *eventApplyRepo) CreateApply(ctx context.Context, apply *versource.Apply) error {
+	err := r.ApplyRepo.CreateApply(ctx, apply)
+	if err != nil {
+		return err
+	}
+	return r.recordStateChange(ctx, apply.ID)
+}
+
+func (r *eventApplyRepo) UpdateApplyState(ctx context.Context, applyID uint, state versource.TaskState) error {
+	err := r.ApplyRepo.UpdateApplyState(ctx, applyID, state)
+	if err != nil {
+		return err
+	}
+	return r.recordStateChange(ctx, applyID)
+}
+
+func (r *eventApplyRepo) recordStateChange(ctx context.Context, applyID uint) error {
+	apply, err := r.ApplyRepo.GetApply(ctx, applyID)
+	if err != nil || apply == nil {
+		return err
+	}
+	recordEvent(ctx, r.eventBus, versource.Event{
+		Type:          versource.EventTypeApplyStateChanged,
+		ChangesetName: apply.Changeset.Name,
+		EntityID:      apply.ID,
+		State:         string(apply.State),
+	})
+	return nil
+}
+
+type eventMergeRepo struct {
+	MergeRepo
+	eventBus *EventBus
+}
+
+func (r *eventMergeRepo) CreateMerge(ctx context.Context, merge *versource.Merge) error {
+	err := r.MergeRepo.CreateMerge(ctx, merge)
+	if err != nil {
+		return err
+	}
+	return r.recordStateChange(ctx, merge.ID)
+}
+
+func (r *eventMergeRepo) UpdateMergeState(ctx context.Context, mergeID uint, state versource.TaskState) error {
+	err := r.MergeRepo.UpdateMergeState(ctx, mergeID, state)
+	if err != nil {
+		return err
+	}
+	return r.recordStateChange(ctx, mergeID)
+}
+
+func (r *eventMergeRepo) recordStateChange(ctx context.Context, mergeID uint) error {
+	merge, err := r.MergeRepo.GetMerge(ctx, mergeID)
+	if err != nil || merge == nil {
+		return err
+	}
+	recordEvent(ctx, r.eventBus, versource.Event{
+		Type:          versource.EventTypeMergeStateChanged,
+		ChangesetName: merge.Changeset.Name,
+		EntityID:      merge.ID,
+		State:         string(merge.State),
+	})
+	return nil
+}
+
+type eventRebaseRepo struct {
+	RebaseRepo
+	eventBus *EventBus
+}
+
+func (r *eventRebaseRepo) CreateRebase(ctx context.Context, rebase *versource.Rebase) error {
+	err := r.RebaseRepo.CreateRebase(ctx, rebase)
+	if err != nil {
+		return err
+	}
+	return r.recordStateChange(ctx, rebase.ID)
+}
+
+func (r *eventRebaseRepo) UpdateRebaseState(ctx context.Context, rebaseID uint, state versource.TaskState) error {
+	err := r.RebaseRepo.UpdateRebaseState(ctx, rebaseID, state)
+	if err != nil {
+		return err
+	}
+	return r.recordStateChange(ctx, rebaseID)
+}
+
+func (r *eventRebaseRepo) recordStateChange(ctx context.Context, rebaseID uint) error {
+	rebase, err := r.RebaseRepo.GetRebase(ctx, rebaseID)
+	if err != nil || rebase == nil {
+		return err
+	}
+	recordEvent(ctx, r.eventBus, versource.Event{
+		Type:          versource.EventTypeRebaseStateChanged,
+		ChangesetName: rebase.Changeset.Name,
+		EntityID:      rebase.ID,
+		State:         string(rebase.State),
+	})
+	return nil
+}
+
+type eventChangesetRepo struct {
+	ChangesetRepo
+	eventBus *EventBus
+}
+
+func (r *eventChangesetRepo) CreateChangeset(ctx context.Context, changeset *versource.Changeset) error {
+	err := r.ChangesetRepo.CreateChangeset(ctx, changeset)
+	if err != nil {
+		return err
+	}
+	recordEvent(ctx, r.eventBus, versource.Event{
+		Type:          versource.EventTypeChangesetCreated,
+		ChangesetName: changeset.Name,
+		EntityID:      changeset.ID,
+		State:         string(changeset.State),
+	})
+	return nil
+}
+
+func (r *eventChangesetRepo) UpdateChangesetState(ctx context.Context, changesetID uint, state versource.ChangesetState) error {
+	err := r.ChangesetRepo.UpdateChangesetState(ctx, changesetID, state)
+	if err != nil {
+		return err
+	}
+	if state != versource.ChangesetStateMerged {
+		return nil
+	}
+	changeset, err := r.ChangesetRepo.GetChangeset(ctx, changesetID)
+	if err != nil || changeset == nil {
+		return err
+	}
+	recordEvent(ctx, r.eventBus, versource.Event{
+		Type:          versource.EventTypeChangesetMerged,
+		ChangesetName: changeset.Name,
+		EntityID:      changeset.ID,
+		State:         string(state),
+	})
+	return nil
+}
+
+type eventComponentRepo struct {
+	ComponentRepo
+	eventBus *EventBus
+}
+
+func (r *eventComponentRepo) CreateComponent(ctx context.Context, component *versource.Component) error {
+	err := r.ComponentRepo.CreateComponent(ctx, component)
+	if err != nil {
+		return err
+	}
+	r.recordChange(ctx, component)
+	return nil
+}
+
+func (r *eventComponentRepo) UpdateComponent(ctx context.Context, component *versource.Component) error {
+	err := r.ComponentRepo.UpdateComponent(ctx, component)
+	if err != nil {
+		return err
+	}
+	r.recordChange(ctx, component)
+	return nil
+}
+
+func (r *eventComponentRepo) recordChange(ctx context.Context, component *versource.Component) {
+	recordEvent(ctx, r.eventBus, versource.Event{
+		Type:          versource.EventTypeComponentChanged,
+		ChangesetName: eventBranch(ctx),
+		EntityID:      component.ID,
+		State:         string(component.Status),
+	})
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func TestEventBusSubscribe(t *testing.T) {
+	changeset1 := "changeset1"
+
+	published := []versource.Event{
+		{Type: versource.EventTypeChangesetCreated, ChangesetName: "changeset1"},
+		{Type: versource.EventTypePlanStateChanged, ChangesetName: "changeset1", State: "Started"},
+		{Type: versource.EventTypePlanStateChanged, ChangesetName: "changeset2", State: "Started"},
+		{Type: versource.EventTypePlanStateChanged, ChangesetName: "changeset1", State: "Succeeded"},
+	}
+
+	tests := []struct {
+		name     string
+		req      versource.SubscribeEventsRequest
+		expected []uint64
+	}{
+		{
+			name:     "no replay without last event id",
+			req:      versource.SubscribeEventsRequest{},
+			expected: nil,
+		},
+		{
+			name:     "replay after last event id",
+			req:      versource.SubscribeEventsRequest{LastEventID: 1},
+			expected: []uint64{2, 3, 4},
+		},
+		{
+			name:     "replay filtered by changeset",
+			req:      versource.SubscribeEventsRequest{ChangesetName: &changeset1, LastEventID: 1},
+			expected: []uint64{2, 4},
+		},
+		{
+			name: "replay filtered by type",
+			req: versource.SubscribeEventsRequest{
+				Types:       []versource.EventType{versource.EventTypePlanStateChanged},
+				LastEventID: 2,
+			},
+			expected: []uint64{3, 4},
+		},
+		{
+			name:     "replay after latest event",
+			req:      versource.SubscribeEventsRequest{LastEventID: 4},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewEventBus()
+			for _, event := range published {
+				bus.Publish(event)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			events := bus.Subscribe(ctx, tt.req)
+			cancel()
+
+			var ids []uint64
+			for event := range events {
+				ids = append(ids, event.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestEventBusPublish(t *testing.T) {
+	changeset1 := "changeset1"
+
+	bus := NewEventBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	events := bus.Subscribe(ctx, versource.SubscribeEventsRequest{
+		ChangesetName: &changeset1,
+		Types:         []versource.EventType{versource.EventTypeApplyStateChanged},
+	})
+
+	bus.Publish(versource.Event{Type: versource.EventTypeApplyStateChanged, ChangesetName: "changeset1", State: "Queued"})
+	bus.Publish(versource.Event{Type: versource.EventTypePlanStateChanged, ChangesetName: "changeset1", State: "Queued"})
+	bus.Publish(versource.Event{Type: versource.EventTypeApplyStateChanged, ChangesetName: "changeset2", State: "Queued"})
+	bus.Publish(versource.Event{Type: versource.EventTypeApplyStateChanged, ChangesetName: "changeset1", State: "Succeeded"})
+	cancel()
+
+	var states []string
+	for event := range events {
+		if event.Time.IsZero() {
+			t.Errorf("expected event %d to have a time", event.ID)
+		}
+		states = append(states, event.State)
+	}
+	expected := []string{"Queued", "Succeeded"}
+	if !reflect.DeepEqual(states, expected) {
+		t.Errorf("expected %v, got %v", expected, states)
+	}
+}
+
+func TestEventBusDropHandler(t *testing.T) {
+	bus := NewEventBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dropped := make(chan versource.Event, 1)
+	bus.SubscribeWithDropHandler(ctx, versource.SubscribeEventsRequest{}, func(event versource.Event) {
+		dropped <- event
+	})
+
+	for range eventSubscriberBuffer + 1 {
+		bus.Publish(versource.Event{Type: versource.EventTypePlanStateChanged, State: "Queued"})
+	}
+
+	select {
+	case event := <-dropped:
+		if event.ID != eventSubscriberBuffer+1 {
+			t.Errorf("expected event %d to be dropped, got %d", eventSubscriberBuffer+1, event.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected drop handler to be called")
+	}
+}
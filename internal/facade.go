@@ -19,23 +19,67 @@ type facade struct {
 	createChangeset *CreateChangeset
 	deleteChangeset *DeleteChangeset
 	ensureChangeset *EnsureChangeset
+	updateChangeset *UpdateChangeset
+	closeChangeset  *CloseChangeset
+	reopenChangeset *ReopenChangeset
+	revertChangeset *RevertChangeset
 
-	getMerge    *GetMerge
-	listMerges  *ListMerges
-	createMerge *CreateMerge
+	approveChangeset       *ApproveChangeset
+	listChangesetApprovals *ListChangesetApprovals
+
+	listTeams        *ListTeams
+	createTeam       *CreateTeam
+	addTeamMember    *AddTeamMember
+	removeTeamMember *RemoveTeamMember
+
+	getMerge       *GetMerge
+	listMerges     *ListMerges
+	listMergeQueue *ListMergeQueue
+	createMerge    *CreateMerge
+	validateMerge  *ValidateMerge
 
 	getRebase    *GetRebase
 	listRebases  *ListRebases
 	createRebase *CreateRebase
 
-	getComponent         *GetComponent
-	listComponents       *ListComponents
-	getComponentChange   *GetComponentChange
-	listComponentChanges *ListComponentChanges
-	createComponent      *CreateComponent
-	updateComponent      *UpdateComponent
-	deleteComponent      *DeleteComponent
-	restoreComponent     *RestoreComponent
+	getComponent              *GetComponent
+	listComponents            *ListComponents
+	getComponentChange        *GetComponentChange
+	listComponentChanges      *ListComponentChanges
+	createComponent           *CreateComponent
+	updateComponent           *UpdateComponent
+	deleteComponent           *DeleteComponent
+	restoreComponent          *RestoreComponent
+	listComponentConflicts    *ListComponentConflicts
+	resolveComponentConflict  *ResolveComponentConflict
+	listComponentHistory      *ListComponentHistory
+	revertComponentToRevision *RevertComponentToRevision
+	syncComponents            *SyncComponents
+
+	getVariableSet    *GetVariableSet
+	listVariableSets  *ListVariableSets
+	createVariableSet *CreateVariableSet
+	updateVariableSet *UpdateVariableSet
+
+	listEnvironments  *ListEnvironments
+	createEnvironment *CreateEnvironment
+	getPromotionPath  *GetPromotionPath
+	promoteComponent  *PromoteComponent
+
+	listApiTokens  *ListApiTokens
+	createApiToken *CreateApiToken
+	revokeApiToken *RevokeApiToken
+
+	listAuditEvents *ListAuditEvents
+
+	subscribeEvents *SubscribeEvents
+
+	listWebhooks             *ListWebhooks
+	createWebhook            *CreateWebhook
+	deleteWebhook            *DeleteWebhook
+	listWebhookDeliveries    *ListWebhookDeliveries
+	getWebhookDelivery       *GetWebhookDelivery
+	redeliverWebhookDelivery *RedeliverWebhookDelivery
 
 	getPlan    *GetPlan
 	getPlanLog *GetPlanLog
@@ -55,10 +99,15 @@ type facade struct {
 	saveViewResource   *SaveViewResource
 	deleteViewResource *DeleteViewResource
 
-	planWorker   *PlanWorker
-	applyWorker  *ApplyWorker
-	mergeWorker  *MergeWorker
-	rebaseWorker *RebaseWorker
+	exportInventory *ExportInventory
+	importInventory *ImportInventory
+
+	planWorker           *PlanWorker
+	applyWorker          *ApplyWorker
+	mergeWorker          *MergeWorker
+	rebaseWorker         *RebaseWorker
+	staleChangesetWorker *StaleChangesetWorker
+	webhookWorker        *WebhookWorker
 }
 
 func NewFacade(
@@ -71,6 +120,7 @@ func NewFacade(
 	planRepo PlanRepo,
 	planStore PlanStore,
 	logStore LogStore,
+	stateStore StateStore,
 	applyRepo ApplyRepo,
 	mergeRepo MergeRepo,
 	rebaseRepo RebaseRepo,
@@ -78,76 +128,139 @@ func NewFacade(
 	moduleRepo ModuleRepo,
 	moduleVersionRepo ModuleVersionRepo,
 	viewResourceRepo ViewResourceRepo,
+	inventoryRepo InventoryRepo,
+	teamRepo TeamRepo,
+	changesetApprovalRepo ChangesetApprovalRepo,
+	variableSetRepo VariableSetRepo,
+	environmentRepo EnvironmentRepo,
+	apiTokenRepo ApiTokenRepo,
+	auditEventRepo AuditEventRepo,
+	webhookRepo WebhookRepo,
+	webhookDeliveryRepo WebhookDeliveryRepo,
 	queryParser ViewQueryParser,
 	transactionManager TransactionManager,
 	newExecutor NewExecutor,
 ) versource.Facade {
-	runApply := NewRunApply(config, applyRepo, stateRepo, stateResourceRepo, resourceRepo, planStore, logStore, transactionManager, newExecutor, componentRepo)
-	runPlan := NewRunPlan(config, planRepo, planStore, logStore, transactionManager, newExecutor, componentRepo)
+	eventBus := NewEventBus()
+	transactionManager = &eventTransactionManager{TransactionManager: transactionManager, eventBus: eventBus}
+	planRepo = &eventPlanRepo{PlanRepo: planRepo, eventBus: eventBus}
+	applyRepo = &eventApplyRepo{ApplyRepo: applyRepo, eventBus: eventBus}
+	mergeRepo = &eventMergeRepo{MergeRepo: mergeRepo, eventBus: eventBus}
+	rebaseRepo = &eventRebaseRepo{RebaseRepo: rebaseRepo, eventBus: eventBus}
+	changesetRepo = &eventChangesetRepo{ChangesetRepo: changesetRepo, eventBus: eventBus}
+	componentRepo = &eventComponentRepo{ComponentRepo: componentRepo, eventBus: eventBus}
+
+	secretCipher := NewSecretCipher(config.Secrets)
+	runApply := NewRunApply(config, applyRepo, stateRepo, stateResourceRepo, resourceRepo, planStore, logStore, transactionManager, newExecutor, componentRepo, variableSetRepo, secretCipher)
+	runPlan := NewRunPlan(config, planRepo, planStore, logStore, transactionManager, newExecutor, componentRepo, variableSetRepo, secretCipher)
 	listComponentChanges := NewListComponentChanges(componentChangeRepo, transactionManager)
 	applyWorker := NewApplyWorker(runApply, applyRepo, transactionManager)
-	runMerge := NewRunMerge(config, mergeRepo, changesetRepo, planRepo, planStore, logStore, transactionManager, listComponentChanges, componentChangeRepo, applyRepo, applyWorker)
 	planWorker := NewPlanWorker(runPlan, planRepo, transactionManager)
 	createPlan := NewCreatePlan(componentRepo, componentChangeRepo, planRepo, changesetRepo, transactionManager, planWorker)
 	runRebase := NewRunRebase(config, rebaseRepo, changesetRepo, transactionManager, listComponentChanges, createPlan)
-	mergeWorker := NewMergeWorker(runMerge, mergeRepo, transactionManager)
+	runMerge := NewRunMerge(config, mergeRepo, changesetRepo, changesetApprovalRepo, teamRepo, rebaseRepo, planRepo, planStore, logStore, transactionManager, listComponentChanges, componentChangeRepo, applyRepo, applyWorker, runRebase)
 	rebaseWorker := NewRebaseWorker(runRebase, rebaseRepo, transactionManager)
+	createRebase := NewCreateRebase(changesetRepo, rebaseRepo, transactionManager, rebaseWorker)
+	detectStaleChangesets := NewDetectStaleChangesets(changesetRepo, componentChangeRepo, rebaseRepo, transactionManager, createRebase)
+	staleChangesetWorker := NewStaleChangesetWorker(detectStaleChangesets)
+	mergeWorker := NewMergeWorker(runMerge, mergeRepo, transactionManager, staleChangesetWorker)
 	getMerge := NewGetMerge(mergeRepo, transactionManager)
 	listMerges := NewListMerges(mergeRepo, transactionManager)
-	createMerge := NewCreateMerge(changesetRepo, mergeRepo, transactionManager, mergeWorker)
+	createMerge := NewCreateMerge(changesetRepo, mergeRepo, teamRepo, changesetApprovalRepo, listComponentChanges, transactionManager, mergeWorker)
 	getRebase := NewGetRebase(rebaseRepo, transactionManager)
 	listRebases := NewListRebases(rebaseRepo, transactionManager)
-	createRebase := NewCreateRebase(changesetRepo, rebaseRepo, transactionManager, rebaseWorker)
 	getPlan := NewGetPlan(planRepo, componentRepo, transactionManager)
-	getPlanLog := NewGetPlanLog(logStore, transactionManager)
+	getPlanLog := NewGetPlanLog(planRepo, logStore, transactionManager)
 	getApply := NewGetApply(applyRepo, componentRepo, transactionManager)
-	getApplyLog := NewGetApplyLog(logStore)
+	getApplyLog := NewGetApplyLog(applyRepo, logStore, transactionManager)
 	ensureChangeset := NewEnsureChangeset(changesetRepo, transactionManager)
+	createChangeset := NewCreateChangeset(changesetRepo, transactionManager)
+	webhookWorker := NewWebhookWorker(eventBus, webhookRepo, webhookDeliveryRepo, planRepo, applyRepo, mergeRepo, rebaseRepo, secretCipher, transactionManager)
 
 	return &facade{
-		getModule:            NewGetModule(moduleRepo, moduleVersionRepo, transactionManager),
-		listModules:          NewListModules(moduleRepo, transactionManager),
-		createModule:         NewCreateModule(moduleRepo, moduleVersionRepo, transactionManager),
-		updateModule:         NewUpdateModule(moduleRepo, moduleVersionRepo, transactionManager),
-		deleteModule:         NewDeleteModule(moduleRepo, componentRepo, transactionManager),
-		getModuleVersion:     NewGetModuleVersion(moduleVersionRepo, transactionManager),
-		listModuleVersions:   NewListModuleVersions(moduleVersionRepo, transactionManager),
-		listChangesets:       NewListChangesets(changesetRepo, transactionManager),
-		createChangeset:      NewCreateChangeset(changesetRepo, transactionManager),
-		deleteChangeset:      NewDeleteChangeset(changesetRepo, planRepo, applyRepo, planStore, logStore, transactionManager),
-		ensureChangeset:      ensureChangeset,
-		getMerge:             getMerge,
-		listMerges:           listMerges,
-		createMerge:          createMerge,
-		getRebase:            getRebase,
-		listRebases:          listRebases,
-		createRebase:         createRebase,
-		getComponent:         NewGetComponent(componentRepo, transactionManager),
-		listComponents:       NewListComponents(componentRepo, transactionManager),
-		getComponentChange:   NewGetComponentChange(componentChangeRepo, transactionManager),
-		listComponentChanges: listComponentChanges,
-		createComponent:      NewCreateComponent(componentRepo, moduleRepo, moduleVersionRepo, ensureChangeset, createPlan, transactionManager),
-		updateComponent:      NewUpdateComponent(componentRepo, moduleVersionRepo, changesetRepo, ensureChangeset, createPlan, transactionManager),
-		deleteComponent:      NewDeleteComponent(componentRepo, componentChangeRepo, changesetRepo, ensureChangeset, createPlan, transactionManager),
-		restoreComponent:     NewRestoreComponent(componentRepo, componentChangeRepo, changesetRepo, ensureChangeset, createPlan, transactionManager),
-		getPlan:              getPlan,
-		getPlanLog:           getPlanLog,
-		listPlans:            NewListPlans(planRepo, transactionManager),
-		createPlan:           createPlan,
-		runPlan:              runPlan,
-		getApply:             getApply,
-		getApplyLog:          getApplyLog,
-		listApplies:          NewListApplies(applyRepo, transactionManager),
-		runApply:             runApply,
-		listResources:        NewListResources(resourceRepo, transactionManager),
-		getViewResource:      NewGetViewResource(viewResourceRepo, transactionManager),
-		listViewResources:    NewListViewResources(viewResourceRepo, transactionManager),
-		saveViewResource:     NewSaveViewResource(viewResourceRepo, queryParser, transactionManager),
-		deleteViewResource:   NewDeleteViewResource(viewResourceRepo, transactionManager),
-		planWorker:           planWorker,
-		applyWorker:          applyWorker,
-		mergeWorker:          mergeWorker,
-		rebaseWorker:         rebaseWorker,
+		getModule:                 NewGetModule(moduleRepo, moduleVersionRepo, transactionManager),
+		listModules:               NewListModules(moduleRepo, transactionManager),
+		createModule:              NewCreateModule(moduleRepo, moduleVersionRepo, teamRepo, transactionManager),
+		updateModule:              NewUpdateModule(moduleRepo, moduleVersionRepo, transactionManager),
+		deleteModule:              NewDeleteModule(moduleRepo, componentRepo, transactionManager),
+		getModuleVersion:          NewGetModuleVersion(moduleVersionRepo, transactionManager),
+		listModuleVersions:        NewListModuleVersions(moduleVersionRepo, transactionManager),
+		listChangesets:            NewListChangesets(changesetRepo, transactionManager),
+		createChangeset:           createChangeset,
+		deleteChangeset:           NewDeleteChangeset(changesetRepo, planRepo, applyRepo, planStore, logStore, createRebase, transactionManager),
+		ensureChangeset:           ensureChangeset,
+		updateChangeset:           NewUpdateChangeset(changesetRepo, transactionManager),
+		closeChangeset:            NewCloseChangeset(changesetRepo, createRebase, transactionManager),
+		reopenChangeset:           NewReopenChangeset(changesetRepo, transactionManager),
+		revertChangeset:           NewRevertChangeset(changesetRepo, mergeRepo, componentRepo, componentChangeRepo, createChangeset, listComponentChanges, createPlan, transactionManager),
+		approveChangeset:          NewApproveChangeset(changesetRepo, changesetApprovalRepo, teamRepo, listComponentChanges, transactionManager),
+		listChangesetApprovals:    NewListChangesetApprovals(changesetRepo, changesetApprovalRepo, transactionManager),
+		listTeams:                 NewListTeams(teamRepo, transactionManager),
+		createTeam:                NewCreateTeam(teamRepo, transactionManager),
+		addTeamMember:             NewAddTeamMember(teamRepo, transactionManager),
+		removeTeamMember:          NewRemoveTeamMember(teamRepo, transactionManager),
+		getMerge:                  getMerge,
+		listMerges:                listMerges,
+		listMergeQueue:            NewListMergeQueue(mergeRepo, transactionManager),
+		createMerge:               createMerge,
+		validateMerge:             NewValidateMerge(changesetRepo, changesetApprovalRepo, teamRepo, componentChangeRepo, listComponentChanges, transactionManager),
+		getRebase:                 getRebase,
+		listRebases:               listRebases,
+		createRebase:              createRebase,
+		getComponent:              NewGetComponent(componentRepo, transactionManager),
+		listComponents:            NewListComponents(componentRepo, transactionManager),
+		getComponentChange:        NewGetComponentChange(componentChangeRepo, transactionManager),
+		listComponentChanges:      listComponentChanges,
+		createComponent:           NewCreateComponent(componentRepo, moduleRepo, moduleVersionRepo, teamRepo, variableSetRepo, environmentRepo, secretCipher, ensureChangeset, createPlan, transactionManager),
+		updateComponent:           NewUpdateComponent(componentRepo, moduleVersionRepo, changesetRepo, teamRepo, variableSetRepo, secretCipher, ensureChangeset, createPlan, transactionManager),
+		deleteComponent:           NewDeleteComponent(componentRepo, componentChangeRepo, changesetRepo, ensureChangeset, createPlan, transactionManager),
+		restoreComponent:          NewRestoreComponent(componentRepo, componentChangeRepo, changesetRepo, ensureChangeset, createPlan, transactionManager),
+		listComponentConflicts:    NewListComponentConflicts(componentChangeRepo, transactionManager),
+		resolveComponentConflict:  NewResolveComponentConflict(componentRepo, componentChangeRepo, changesetRepo, createPlan, transactionManager),
+		listComponentHistory:      NewListComponentHistory(componentRepo, transactionManager),
+		revertComponentToRevision: NewRevertComponentToRevision(componentRepo, ensureChangeset, createPlan, transactionManager),
+		syncComponents:            NewSyncComponents(componentRepo, moduleRepo, moduleVersionRepo, teamRepo, ensureChangeset, createPlan, transactionManager),
+		getVariableSet:            NewGetVariableSet(variableSetRepo, transactionManager),
+		listVariableSets:          NewListVariableSets(variableSetRepo, transactionManager),
+		createVariableSet:         NewCreateVariableSet(variableSetRepo, ensureChangeset, transactionManager),
+		updateVariableSet:         NewUpdateVariableSet(variableSetRepo, componentRepo, ensureChangeset, createPlan, transactionManager),
+		listEnvironments:          NewListEnvironments(environmentRepo, transactionManager),
+		createEnvironment:         NewCreateEnvironment(environmentRepo, transactionManager),
+		getPromotionPath:          NewGetPromotionPath(environmentRepo, componentRepo, transactionManager),
+		promoteComponent:          NewPromoteComponent(environmentRepo, componentRepo, createChangeset, createPlan, transactionManager),
+		listApiTokens:             NewListApiTokens(apiTokenRepo, transactionManager),
+		createApiToken:            NewCreateApiToken(apiTokenRepo, transactionManager),
+		revokeApiToken:            NewRevokeApiToken(apiTokenRepo, transactionManager),
+		listAuditEvents:           NewListAuditEvents(auditEventRepo, transactionManager),
+		subscribeEvents:           NewSubscribeEvents(eventBus),
+		listWebhooks:              NewListWebhooks(webhookRepo, transactionManager),
+		createWebhook:             NewCreateWebhook(webhookRepo, secretCipher, transactionManager),
+		deleteWebhook:             NewDeleteWebhook(webhookRepo, transactionManager),
+		listWebhookDeliveries:     NewListWebhookDeliveries(webhookRepo, webhookDeliveryRepo, transactionManager),
+		getWebhookDelivery:        NewGetWebhookDelivery(webhookRepo, webhookDeliveryRepo, transactionManager),
+		redeliverWebhookDelivery:  NewRedeliverWebhookDelivery(webhookRepo, webhookDeliveryRepo, transactionManager, webhookWorker),
+		getPlan:                   getPlan,
+		getPlanLog:                getPlanLog,
+		listPlans:                 NewListPlans(planRepo, componentRepo, transactionManager),
+		createPlan:                createPlan,
+		runPlan:                   runPlan,
+		getApply:                  getApply,
+		getApplyLog:               getApplyLog,
+		listApplies:               NewListApplies(applyRepo, transactionManager),
+		runApply:                  runApply,
+		listResources:             NewListResources(resourceRepo, transactionManager),
+		getViewResource:           NewGetViewResource(viewResourceRepo, transactionManager),
+		listViewResources:         NewListViewResources(viewResourceRepo, transactionManager),
+		saveViewResource:          NewSaveViewResource(viewResourceRepo, queryParser, transactionManager),
+		deleteViewResource:        NewDeleteViewResource(viewResourceRepo, transactionManager),
+		exportInventory:           NewExportInventory(inventoryRepo, teamRepo, stateStore, transactionManager),
+		importInventory:           NewImportInventory(inventoryRepo, teamRepo, viewResourceRepo, queryParser, stateStore, secretCipher, transactionManager),
+		planWorker:                planWorker,
+		applyWorker:               applyWorker,
+		mergeWorker:               mergeWorker,
+		rebaseWorker:              rebaseWorker,
+		staleChangesetWorker:      staleChangesetWorker,
+		webhookWorker:             webhookWorker,
 	}
 }
 
@@ -195,6 +308,46 @@ func (f *facade) EnsureChangeset(ctx context.Context, req versource.EnsureChange
 	return f.ensureChangeset.Exec(ctx, req)
 }
 
+func (f *facade) UpdateChangeset(ctx context.Context, req versource.UpdateChangesetRequest) (*versource.UpdateChangesetResponse, error) {
+	return f.updateChangeset.Exec(ctx, req)
+}
+
+func (f *facade) CloseChangeset(ctx context.Context, req versource.CloseChangesetRequest) (*versource.CloseChangesetResponse, error) {
+	return f.closeChangeset.Exec(ctx, req)
+}
+
+func (f *facade) ReopenChangeset(ctx context.Context, req versource.ReopenChangesetRequest) (*versource.ReopenChangesetResponse, error) {
+	return f.reopenChangeset.Exec(ctx, req)
+}
+
+func (f *facade) RevertChangeset(ctx context.Context, req versource.RevertChangesetRequest) (*versource.RevertChangesetResponse, error) {
+	return f.revertChangeset.Exec(ctx, req)
+}
+
+func (f *facade) ApproveChangeset(ctx context.Context, req versource.ApproveChangesetRequest) (*versource.ApproveChangesetResponse, error) {
+	return f.approveChangeset.Exec(ctx, req)
+}
+
+func (f *facade) ListChangesetApprovals(ctx context.Context, req versource.ListChangesetApprovalsRequest) (*versource.ListChangesetApprovalsResponse, error) {
+	return f.listChangesetApprovals.Exec(ctx, req)
+}
+
+func (f *facade) ListTeams(ctx context.Context, req versource.ListTeamsRequest) (*versource.ListTeamsResponse, error) {
+	return f.listTeams.Exec(ctx, req)
+}
+
+func (f *facade) CreateTeam(ctx context.Context, req versource.CreateTeamRequest) (*versource.CreateTeamResponse, error) {
+	return f.createTeam.Exec(ctx, req)
+}
+
+func (f *facade) AddTeamMember(ctx context.Context, req versource.AddTeamMemberRequest) (*versource.AddTeamMemberResponse, error) {
+	return f.addTeamMember.Exec(ctx, req)
+}
+
+func (f *facade) RemoveTeamMember(ctx context.Context, req versource.RemoveTeamMemberRequest) (*versource.RemoveTeamMemberResponse, error) {
+	return f.removeTeamMember.Exec(ctx, req)
+}
+
 func (f *facade) GetMerge(ctx context.Context, req versource.GetMergeRequest) (*versource.GetMergeResponse, error) {
 	return f.getMerge.Exec(ctx, req)
 }
@@ -203,10 +356,18 @@ func (f *facade) ListMerges(ctx context.Context, req versource.ListMergesRequest
 	return f.listMerges.Exec(ctx, req)
 }
 
+func (f *facade) ListMergeQueue(ctx context.Context, req versource.ListMergeQueueRequest) (*versource.ListMergeQueueResponse, error) {
+	return f.listMergeQueue.Exec(ctx, req)
+}
+
 func (f *facade) CreateMerge(ctx context.Context, req versource.CreateMergeRequest) (*versource.CreateMergeResponse, error) {
 	return f.createMerge.Exec(ctx, req)
 }
 
+func (f *facade) ValidateMerge(ctx context.Context, req versource.ValidateMergeRequest) (*versource.ValidateMergeResponse, error) {
+	return f.validateMerge.Exec(ctx, req)
+}
+
 func (f *facade) GetRebase(ctx context.Context, req versource.GetRebaseRequest) (*versource.GetRebaseResponse, error) {
 	return f.getRebase.Exec(ctx, req)
 }
@@ -251,6 +412,102 @@ func (f *facade) RestoreComponent(ctx context.Context, req versource.RestoreComp
 	return f.restoreComponent.Exec(ctx, req)
 }
 
+func (f *facade) ListComponentConflicts(ctx context.Context, req versource.ListComponentConflictsRequest) (*versource.ListComponentConflictsResponse, error) {
+	return f.listComponentConflicts.Exec(ctx, req)
+}
+
+func (f *facade) ListComponentHistory(ctx context.Context, req versource.ListComponentHistoryRequest) (*versource.ListComponentHistoryResponse, error) {
+	return f.listComponentHistory.Exec(ctx, req)
+}
+
+func (f *facade) RevertComponentToRevision(ctx context.Context, req versource.RevertComponentToRevisionRequest) (*versource.RevertComponentToRevisionResponse, error) {
+	return f.revertComponentToRevision.Exec(ctx, req)
+}
+
+func (f *facade) SyncComponents(ctx context.Context, req versource.SyncComponentsRequest) (*versource.SyncComponentsResponse, error) {
+	return f.syncComponents.Exec(ctx, req)
+}
+
+func (f *facade) GetVariableSet(ctx context.Context, req versource.GetVariableSetRequest) (*versource.GetVariableSetResponse, error) {
+	return f.getVariableSet.Exec(ctx, req)
+}
+
+func (f *facade) ListVariableSets(ctx context.Context, req versource.ListVariableSetsRequest) (*versource.ListVariableSetsResponse, error) {
+	return f.listVariableSets.Exec(ctx, req)
+}
+
+func (f *facade) CreateVariableSet(ctx context.Context, req versource.CreateVariableSetRequest) (*versource.CreateVariableSetResponse, error) {
+	return f.createVariableSet.Exec(ctx, req)
+}
+
+func (f *facade) UpdateVariableSet(ctx context.Context, req versource.UpdateVariableSetRequest) (*versource.UpdateVariableSetResponse, error) {
+	return f.updateVariableSet.Exec(ctx, req)
+}
+
+func (f *facade) ListEnvironments(ctx context.Context, req versource.ListEnvironmentsRequest) (*versource.ListEnvironmentsResponse, error) {
+	return f.listEnvironments.Exec(ctx, req)
+}
+
+func (f *facade) CreateEnvironment(ctx context.Context, req versource.CreateEnvironmentRequest) (*versource.CreateEnvironmentResponse, error) {
+	return f.createEnvironment.Exec(ctx, req)
+}
+
+func (f *facade) GetPromotionPath(ctx context.Context, req versource.GetPromotionPathRequest) (*versource.GetPromotionPathResponse, error) {
+	return f.getPromotionPath.Exec(ctx, req)
+}
+
+func (f *facade) PromoteComponent(ctx context.Context, req versource.PromoteComponentRequest) (*versource.PromoteComponentResponse, error) {
+	return f.promoteComponent.Exec(ctx, req)
+}
+
+func (f *facade) ListApiTokens(ctx context.Context, req versource.ListApiTokensRequest) (*versource.ListApiTokensResponse, error) {
+	return f.listApiTokens.Exec(ctx, req)
+}
+
+func (f *facade) CreateApiToken(ctx context.Context, req versource.CreateApiTokenRequest) (*versource.CreateApiTokenResponse, error) {
+	return f.createApiToken.Exec(ctx, req)
+}
+
+func (f *facade) RevokeApiToken(ctx context.Context, req versource.RevokeApiTokenRequest) (*versource.RevokeApiTokenResponse, error) {
+	return f.revokeApiToken.Exec(ctx, req)
+}
+
+func (f *facade) ListAuditEvents(ctx context.Context, req versource.ListAuditEventsRequest) (*versource.ListAuditEventsResponse, error) {
+	return f.listAuditEvents.Exec(ctx, req)
+}
+
+func (f *facade) SubscribeEvents(ctx context.Context, req versource.SubscribeEventsRequest) (*versource.SubscribeEventsResponse, error) {
+	return f.subscribeEvents.Exec(ctx, req)
+}
+
+func (f *facade) ListWebhooks(ctx context.Context, req versource.ListWebhooksRequest) (*versource.ListWebhooksResponse, error) {
+	return f.listWebhooks.Exec(ctx, req)
+}
+
+func (f *facade) CreateWebhook(ctx context.Context, req versource.CreateWebhookRequest) (*versource.CreateWebhookResponse, error) {
+	return f.createWebhook.Exec(ctx, req)
+}
+
+func (f *facade) DeleteWebhook(ctx context.Context, req versource.DeleteWebhookRequest) (*versource.DeleteWebhookResponse, error) {
+	return f.deleteWebhook.Exec(ctx, req)
+}
+
+func (f *facade) ListWebhookDeliveries(ctx context.Context, req versource.ListWebhookDeliveriesRequest) (*versource.ListWebhookDeliveriesResponse, error) {
+	return f.listWebhookDeliveries.Exec(ctx, req)
+}
+
+func (f *facade) GetWebhookDelivery(ctx context.Context, req versource.GetWebhookDeliveryRequest) (*versource.GetWebhookDeliveryResponse, error) {
+	return f.getWebhookDelivery.Exec(ctx, req)
+}
+
+func (f *facade) RedeliverWebhookDelivery(ctx context.Context, req versource.RedeliverWebhookDeliveryRequest) (*versource.RedeliverWebhookDeliveryResponse, error) {
+	return f.redeliverWebhookDelivery.Exec(ctx, req)
+}
+
+func (f *facade) ResolveComponentConflict(ctx context.Context, req versource.ResolveComponentConflictRequest) (*versource.ResolveComponentConflictResponse, error) {
+	return f.resolveComponentConflict.Exec(ctx, req)
+}
+
 func (f *facade) GetPlan(ctx context.Context, req versource.GetPlanRequest) (*versource.GetPlanResponse, error) {
 	return f.getPlan.Exec(ctx, req)
 }
@@ -307,9 +564,19 @@ func (f *facade) DeleteViewResource(ctx context.Context, req versource.DeleteVie
 	return f.deleteViewResource.Exec(ctx, req)
 }
 
+func (f *facade) ExportInventory(ctx context.Context, req versource.ExportInventoryRequest) (*versource.ExportInventoryResponse, error) {
+	return f.exportInventory.Exec(ctx, req)
+}
+
+func (f *facade) ImportInventory(ctx context.Context, req versource.ImportInventoryRequest) (*versource.ImportInventoryResponse, error) {
+	return f.importInventory.Exec(ctx, req)
+}
+
 func (f *facade) Start(ctx context.Context) {
 	f.planWorker.Start(ctx)
 	f.applyWorker.Start(ctx)
 	f.mergeWorker.Start(ctx)
 	f.rebaseWorker.Start(ctx)
+	f.staleChangesetWorker.Start(ctx)
+	f.webhookWorker.Start(ctx)
 }
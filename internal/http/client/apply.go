@@ -5,8 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strings"
 
-	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
@@ -24,12 +25,7 @@ func (c *Client) GetApply(ctx context.Context, req versource.GetApplyRequest) (*
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var applyResp versource.GetApplyResponse
@@ -43,6 +39,9 @@ func (c *Client) GetApply(ctx context.Context, req versource.GetApplyRequest) (*
 
 func (c *Client) GetApplyLog(ctx context.Context, req versource.GetApplyLogRequest) (*versource.GetApplyLogResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/applies/%d/logs", c.baseURL, req.ApplyID)
+	if req.Follow {
+		url += "?follow=true"
+	}
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -55,12 +54,7 @@ func (c *Client) GetApplyLog(ctx context.Context, req versource.GetApplyLogReque
 
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	return &versource.GetApplyLogResponse{
@@ -70,6 +64,21 @@ func (c *Client) GetApplyLog(ctx context.Context, req versource.GetApplyLogReque
 
 func (c *Client) ListApplies(ctx context.Context, req versource.ListAppliesRequest) (*versource.ListAppliesResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/applies", c.baseURL)
+
+	params := pageParams(req.PageRequest)
+	if req.ChangesetName != nil {
+		params = append(params, fmt.Sprintf("changeset=%s", neturl.QueryEscape(*req.ChangesetName)))
+	}
+	if req.State != nil {
+		params = append(params, fmt.Sprintf("state=%s", neturl.QueryEscape(string(*req.State))))
+	}
+	params = append(params, timeParam("created-after", req.CreatedAfter)...)
+	params = append(params, timeParam("created-before", req.CreatedBefore)...)
+
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -82,12 +91,7 @@ func (c *Client) ListApplies(ctx context.Context, req versource.ListAppliesReque
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var appliesResp versource.ListAppliesResponse
@@ -113,12 +117,7 @@ func (c *Client) RunApply(ctx context.Context, applyID uint) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return fmt.Errorf("server error: %s", errorResp.Message)
+		return decodeError(resp)
 	}
 
 	return nil
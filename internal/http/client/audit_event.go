@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (c *Client) ListAuditEvents(ctx context.Context, req versource.ListAuditEventsRequest) (*versource.ListAuditEventsResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/audit", c.baseURL)
+
+	params := pageParams(req.PageRequest)
+	if req.Branch != nil {
+		params = append(params, fmt.Sprintf("branch=%s", neturl.QueryEscape(*req.Branch)))
+	}
+	if req.Author != nil {
+		params = append(params, fmt.Sprintf("author=%s", neturl.QueryEscape(*req.Author)))
+	}
+
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var auditResp versource.ListAuditEventsResponse
+	err = json.NewDecoder(resp.Body).Decode(&auditResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &auditResp, nil
+}
@@ -6,13 +6,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strings"
 
-	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
 func (c *Client) ListChangesets(ctx context.Context, req versource.ListChangesetsRequest) (*versource.ListChangesetsResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/changesets", c.baseURL)
+
+	params := pageParams(req.PageRequest)
+	if req.IncludeClosed {
+		params = append(params, "include-closed=true")
+	}
+	if req.State != nil {
+		params = append(params, fmt.Sprintf("state=%s", neturl.QueryEscape(string(*req.State))))
+	}
+	params = append(params, timeParam("created-after", req.CreatedAfter)...)
+	params = append(params, timeParam("created-before", req.CreatedBefore)...)
+
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -25,12 +41,7 @@ func (c *Client) ListChangesets(ctx context.Context, req versource.ListChangeset
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var changesetsResp versource.ListChangesetsResponse
@@ -63,12 +74,7 @@ func (c *Client) CreateChangeset(ctx context.Context, req versource.CreateChange
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var changesetResp versource.CreateChangesetResponse
@@ -96,12 +102,7 @@ func (c *Client) CreateMerge(ctx context.Context, req versource.CreateMergeReque
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var mergeResp versource.CreateMergeResponse
@@ -134,12 +135,7 @@ func (c *Client) EnsureChangeset(ctx context.Context, req versource.EnsureChange
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var changesetResp versource.EnsureChangesetResponse
@@ -172,12 +168,7 @@ func (c *Client) DeleteChangeset(ctx context.Context, req versource.DeleteChange
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var changesetResp versource.DeleteChangesetResponse
@@ -188,3 +179,173 @@ func (c *Client) DeleteChangeset(ctx context.Context, req versource.DeleteChange
 
 	return &changesetResp, nil
 }
+
+func (c *Client) UpdateChangeset(ctx context.Context, req versource.UpdateChangesetRequest) (*versource.UpdateChangesetResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/changesets/%s", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var changesetResp versource.UpdateChangesetResponse
+	err = json.NewDecoder(resp.Body).Decode(&changesetResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &changesetResp, nil
+}
+
+func (c *Client) CloseChangeset(ctx context.Context, req versource.CloseChangesetRequest) (*versource.CloseChangesetResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/close", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var changesetResp versource.CloseChangesetResponse
+	err = json.NewDecoder(resp.Body).Decode(&changesetResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &changesetResp, nil
+}
+
+func (c *Client) ReopenChangeset(ctx context.Context, req versource.ReopenChangesetRequest) (*versource.ReopenChangesetResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/reopen", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var changesetResp versource.ReopenChangesetResponse
+	err = json.NewDecoder(resp.Body).Decode(&changesetResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &changesetResp, nil
+}
+
+func (c *Client) RevertChangeset(ctx context.Context, req versource.RevertChangesetRequest) (*versource.RevertChangesetResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/revert", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var changesetResp versource.RevertChangesetResponse
+	err = json.NewDecoder(resp.Body).Decode(&changesetResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &changesetResp, nil
+}
+
+func (c *Client) ApproveChangeset(ctx context.Context, req versource.ApproveChangesetRequest) (*versource.ApproveChangesetResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/approvals", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, decodeError(resp)
+	}
+
+	var approveResp versource.ApproveChangesetResponse
+	err = json.NewDecoder(resp.Body).Decode(&approveResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &approveResp, nil
+}
+
+func (c *Client) ListChangesetApprovals(ctx context.Context, req versource.ListChangesetApprovalsRequest) (*versource.ListChangesetApprovalsResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/approvals", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var approvalsResp versource.ListChangesetApprovalsResponse
+	err = json.NewDecoder(resp.Body).Decode(&approvalsResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &approvalsResp, nil
+}
@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
+	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
@@ -19,11 +21,67 @@ func New(config *versource.Config) versource.Facade {
 		baseURL = fmt.Sprintf("%s://localhost:%s", config.HTTP.Scheme, config.HTTP.Port)
 	}
 
+	client := &http.Client{}
+	if config.HTTP.User != "" || config.HTTP.Token != "" {
+		client.Transport = &userTransport{
+			user:  config.HTTP.User,
+			token: config.HTTP.Token,
+			base:  http.DefaultTransport,
+		}
+	}
+
 	return &Client{
 		baseURL: baseURL,
-		client:  &http.Client{},
+		client:  client,
+	}
+}
+
+type userTransport struct {
+	user  string
+	token string
+	base  http.RoundTripper
+}
+
+func (t *userTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.user != "" {
+		req.Header.Set(http2.UserHeader, t.user)
+	}
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
 	}
+	return t.base.RoundTrip(req)
 }
 
 func (c *Client) Start(ctx context.Context) {
 }
+
+func decodeError(resp *http.Response) error {
+	var errorResp http2.ErrorResponse
+	err := json.NewDecoder(resp.Body).Decode(&errorResp)
+	if err != nil {
+		return fmt.Errorf("failed to decode error response: %w", err)
+	}
+	code := errorResp.Code
+	if code == "" {
+		code = errorCode(resp.StatusCode)
+	}
+	return versource.NewError(code, errorResp.Message)
+}
+
+func errorCode(status int) versource.ErrorCode {
+	switch status {
+	case http.StatusNotFound:
+		return versource.ErrorCodeNotFound
+	case http.StatusConflict:
+		return versource.ErrorCodeConflict
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return versource.ErrorCodeValidation
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return versource.ErrorCodeForbidden
+	case http.StatusPreconditionFailed:
+		return versource.ErrorCodePreconditionFailed
+	default:
+		return versource.ErrorCodeInternal
+	}
+}
@@ -6,9 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
 	"strings"
 
-	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
@@ -20,6 +20,10 @@ func (c *Client) GetComponent(ctx context.Context, req versource.GetComponentReq
 		url = fmt.Sprintf("%s/api/v1/components/%d", c.baseURL, req.ComponentID)
 	}
 
+	if req.AsOf != nil {
+		url += fmt.Sprintf("?as-of=%s", *req.AsOf)
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -32,12 +36,7 @@ func (c *Client) GetComponent(ctx context.Context, req versource.GetComponentReq
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var componentResp versource.GetComponentResponse
@@ -57,13 +56,28 @@ func (c *Client) ListComponents(ctx context.Context, req versource.ListComponent
 		url = fmt.Sprintf("%s/api/v1/components", c.baseURL)
 	}
 
-	params := make([]string, 0)
+	params := pageParams(req.PageRequest)
 	if req.ModuleID != nil {
 		params = append(params, fmt.Sprintf("module-id=%d", *req.ModuleID))
 	}
 	if req.ModuleVersionID != nil {
 		params = append(params, fmt.Sprintf("module-version-id=%d", *req.ModuleVersionID))
 	}
+	if req.AsOf != nil {
+		params = append(params, fmt.Sprintf("as-of=%s", *req.AsOf))
+	}
+	if req.Selector != "" {
+		params = append(params, fmt.Sprintf("selector=%s", neturl.QueryEscape(req.Selector)))
+	}
+	if req.Owner != nil {
+		params = append(params, fmt.Sprintf("owner=%s", neturl.QueryEscape(*req.Owner)))
+	}
+	if req.Template != nil {
+		params = append(params, fmt.Sprintf("template=%s", neturl.QueryEscape(*req.Template)))
+	}
+	if req.Environment != nil {
+		params = append(params, fmt.Sprintf("environment=%s", neturl.QueryEscape(*req.Environment)))
+	}
 
 	if len(params) > 0 {
 		url += "?" + strings.Join(params, "&")
@@ -81,12 +95,7 @@ func (c *Client) ListComponents(ctx context.Context, req versource.ListComponent
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var componentsResp versource.ListComponentsResponse
@@ -113,12 +122,7 @@ func (c *Client) GetComponentChange(ctx context.Context, req versource.GetCompon
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var changeResp versource.GetComponentChangeResponse
@@ -133,6 +137,15 @@ func (c *Client) GetComponentChange(ctx context.Context, req versource.GetCompon
 func (c *Client) ListComponentChanges(ctx context.Context, req versource.ListComponentChangesRequest) (*versource.ListComponentChangesResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/changesets/%s/components/changes", c.baseURL, req.ChangesetName)
 
+	params := pageParams(req.PageRequest)
+	if req.Selector != "" {
+		params = append(params, fmt.Sprintf("selector=%s", neturl.QueryEscape(req.Selector)))
+	}
+
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -145,12 +158,7 @@ func (c *Client) ListComponentChanges(ctx context.Context, req versource.ListCom
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var changesResp versource.ListComponentChangesResponse
@@ -183,12 +191,7 @@ func (c *Client) CreateComponent(ctx context.Context, req versource.CreateCompon
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var componentResp versource.CreateComponentResponse
@@ -221,12 +224,7 @@ func (c *Client) UpdateComponent(ctx context.Context, req versource.UpdateCompon
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var componentResp versource.UpdateComponentResponse
@@ -252,12 +250,7 @@ func (c *Client) DeleteComponent(ctx context.Context, req versource.DeleteCompon
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var componentResp versource.DeleteComponentResponse
@@ -283,12 +276,7 @@ func (c *Client) RestoreComponent(ctx context.Context, req versource.RestoreComp
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var componentResp versource.RestoreComponentResponse
@@ -299,3 +287,161 @@ func (c *Client) RestoreComponent(ctx context.Context, req versource.RestoreComp
 
 	return &componentResp, nil
 }
+
+func (c *Client) ListComponentConflicts(ctx context.Context, req versource.ListComponentConflictsRequest) (*versource.ListComponentConflictsResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/components/conflicts", c.baseURL, req.ChangesetName)
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var conflictsResp versource.ListComponentConflictsResponse
+	err = json.NewDecoder(resp.Body).Decode(&conflictsResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &conflictsResp, nil
+}
+
+func (c *Client) ResolveComponentConflict(ctx context.Context, req versource.ResolveComponentConflictRequest) (*versource.ResolveComponentConflictResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/components/%d/resolve", c.baseURL, req.ChangesetName, req.ComponentID)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var componentResp versource.ResolveComponentConflictResponse
+	err = json.NewDecoder(resp.Body).Decode(&componentResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &componentResp, nil
+}
+
+func (c *Client) ListComponentHistory(ctx context.Context, req versource.ListComponentHistoryRequest) (*versource.ListComponentHistoryResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/components/%d/history", c.baseURL, req.ComponentID)
+
+	params := pageParams(req.PageRequest)
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var historyResp versource.ListComponentHistoryResponse
+	err = json.NewDecoder(resp.Body).Decode(&historyResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &historyResp, nil
+}
+
+func (c *Client) RevertComponentToRevision(ctx context.Context, req versource.RevertComponentToRevisionRequest) (*versource.RevertComponentToRevisionResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/components/%d/revert", c.baseURL, req.ChangesetName, req.ComponentID)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var componentResp versource.RevertComponentToRevisionResponse
+	err = json.NewDecoder(resp.Body).Decode(&componentResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &componentResp, nil
+}
+
+func (c *Client) SyncComponents(ctx context.Context, req versource.SyncComponentsRequest) (*versource.SyncComponentsResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/components/sync", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var syncResp versource.SyncComponentsResponse
+	err = json.NewDecoder(resp.Body).Decode(&syncResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &syncResp, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (c *Client) ListEnvironments(ctx context.Context, req versource.ListEnvironmentsRequest) (*versource.ListEnvironmentsResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/environments", c.baseURL)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var environmentsResp versource.ListEnvironmentsResponse
+	err = json.NewDecoder(resp.Body).Decode(&environmentsResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &environmentsResp, nil
+}
+
+func (c *Client) CreateEnvironment(ctx context.Context, req versource.CreateEnvironmentRequest) (*versource.CreateEnvironmentResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/environments", c.baseURL)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, decodeError(resp)
+	}
+
+	var environmentResp versource.CreateEnvironmentResponse
+	err = json.NewDecoder(resp.Body).Decode(&environmentResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &environmentResp, nil
+}
+
+func (c *Client) GetPromotionPath(ctx context.Context, req versource.GetPromotionPathRequest) (*versource.GetPromotionPathResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/templates/%s/promotion", c.baseURL, neturl.PathEscape(req.Template))
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var promotionResp versource.GetPromotionPathResponse
+	err = json.NewDecoder(resp.Body).Decode(&promotionResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &promotionResp, nil
+}
+
+func (c *Client) PromoteComponent(ctx context.Context, req versource.PromoteComponentRequest) (*versource.PromoteComponentResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/templates/%s/promote", c.baseURL, neturl.PathEscape(req.Template))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, decodeError(resp)
+	}
+
+	var promoteResp versource.PromoteComponentResponse
+	err = json.NewDecoder(resp.Body).Decode(&promoteResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &promoteResp, nil
+}
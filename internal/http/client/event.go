@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+
+	"github.com/marcbran/versource/pkg/versource"
+	log "github.com/sirupsen/logrus"
+)
+
+func (c *Client) SubscribeEvents(ctx context.Context, req versource.SubscribeEventsRequest) (*versource.SubscribeEventsResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/events", c.baseURL)
+
+	params := make([]string, 0)
+	if req.ChangesetName != nil {
+		params = append(params, fmt.Sprintf("changeset=%s", neturl.QueryEscape(*req.ChangesetName)))
+	}
+	for _, eventType := range req.Types {
+		params = append(params, fmt.Sprintf("type=%s", neturl.QueryEscape(string(eventType))))
+	}
+
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Accept", "text/event-stream")
+	if req.LastEventID > 0 {
+		httpReq.Header.Set("Last-Event-ID", fmt.Sprintf("%d", req.LastEventID))
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
+	}
+
+	events := make(chan versource.Event)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		var data strings.Builder
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line != "" {
+				if value, ok := strings.CutPrefix(line, "data:"); ok {
+					data.WriteString(strings.TrimPrefix(value, " "))
+				}
+				continue
+			}
+			if data.Len() == 0 {
+				continue
+			}
+
+			var event versource.Event
+			err := json.Unmarshal([]byte(data.String()), &event)
+			data.Reset()
+			if err != nil {
+				log.WithError(err).Warn("Failed to decode event")
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return &versource.SubscribeEventsResponse{
+		Events: events,
+	}, nil
+}
@@ -5,8 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
-	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
@@ -24,12 +24,7 @@ func (c *Client) GetMerge(ctx context.Context, req versource.GetMergeRequest) (*
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var mergeResp versource.GetMergeResponse
@@ -43,6 +38,12 @@ func (c *Client) GetMerge(ctx context.Context, req versource.GetMergeRequest) (*
 
 func (c *Client) ListMerges(ctx context.Context, req versource.ListMergesRequest) (*versource.ListMergesResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/changesets/%s/merges", c.baseURL, req.ChangesetName)
+
+	params := pageParams(req.PageRequest)
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -55,12 +56,7 @@ func (c *Client) ListMerges(ctx context.Context, req versource.ListMergesRequest
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var mergesResp versource.ListMergesResponse
@@ -71,3 +67,57 @@ func (c *Client) ListMerges(ctx context.Context, req versource.ListMergesRequest
 
 	return &mergesResp, nil
 }
+
+func (c *Client) ListMergeQueue(ctx context.Context, req versource.ListMergeQueueRequest) (*versource.ListMergeQueueResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/merges/queue", c.baseURL)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var queueResp versource.ListMergeQueueResponse
+	err = json.NewDecoder(resp.Body).Decode(&queueResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &queueResp, nil
+}
+
+func (c *Client) ValidateMerge(ctx context.Context, req versource.ValidateMergeRequest) (*versource.ValidateMergeResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/merges/validate", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var validateResp versource.ValidateMergeResponse
+	err = json.NewDecoder(resp.Body).Decode(&validateResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &validateResp, nil
+}
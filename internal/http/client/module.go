@@ -6,8 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strings"
 
-	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
@@ -25,12 +26,7 @@ func (c *Client) GetModule(ctx context.Context, req versource.GetModuleRequest)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var moduleResp versource.GetModuleResponse
@@ -44,6 +40,16 @@ func (c *Client) GetModule(ctx context.Context, req versource.GetModuleRequest)
 
 func (c *Client) ListModules(ctx context.Context, req versource.ListModulesRequest) (*versource.ListModulesResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/modules", c.baseURL)
+
+	params := pageParams(req.PageRequest)
+	if req.AsOf != nil {
+		params = append(params, fmt.Sprintf("as-of=%s", neturl.QueryEscape(*req.AsOf)))
+	}
+
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -56,12 +62,7 @@ func (c *Client) ListModules(ctx context.Context, req versource.ListModulesReque
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var modulesResp versource.ListModulesResponse
@@ -94,12 +95,7 @@ func (c *Client) CreateModule(ctx context.Context, req versource.CreateModuleReq
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var moduleResp versource.CreateModuleResponse
@@ -132,12 +128,7 @@ func (c *Client) UpdateModule(ctx context.Context, req versource.UpdateModuleReq
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var moduleResp versource.UpdateModuleResponse
@@ -163,12 +154,7 @@ func (c *Client) DeleteModule(ctx context.Context, req versource.DeleteModuleReq
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var moduleResp versource.DeleteModuleResponse
@@ -194,12 +180,7 @@ func (c *Client) GetModuleVersion(ctx context.Context, req versource.GetModuleVe
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var moduleVersionResp versource.GetModuleVersionResponse
@@ -218,6 +199,12 @@ func (c *Client) ListModuleVersions(ctx context.Context, req versource.ListModul
 	} else {
 		url = fmt.Sprintf("%s/api/v1/module-versions", c.baseURL)
 	}
+
+	params := pageParams(req.PageRequest)
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -230,12 +217,7 @@ func (c *Client) ListModuleVersions(ctx context.Context, req versource.ListModul
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var moduleVersionsResp versource.ListModuleVersionsResponse
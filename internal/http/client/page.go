@@ -0,0 +1,30 @@
+package client
+
+import (
+	"fmt"
+	neturl "net/url"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func pageParams(page versource.PageRequest) []string {
+	params := make([]string, 0)
+	if page.Cursor != "" {
+		params = append(params, fmt.Sprintf("cursor=%s", neturl.QueryEscape(page.Cursor)))
+	}
+	if page.Limit != 0 {
+		params = append(params, fmt.Sprintf("limit=%d", page.Limit))
+	}
+	if page.Sort != "" {
+		params = append(params, fmt.Sprintf("sort=%s", neturl.QueryEscape(page.Sort)))
+	}
+	return params
+}
+
+func timeParam(name string, t *time.Time) []string {
+	if t == nil {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s=%s", name, neturl.QueryEscape(t.Format(time.RFC3339)))}
+}
@@ -6,8 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strings"
 
-	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
@@ -28,12 +29,7 @@ func (c *Client) GetPlan(ctx context.Context, req versource.GetPlanRequest) (*ve
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var planResp versource.GetPlanResponse
@@ -50,6 +46,9 @@ func (c *Client) GetPlanLog(ctx context.Context, req versource.GetPlanLogRequest
 	if req.ChangesetName != nil {
 		url = fmt.Sprintf("%s/api/v1/changesets/%s/plans/%d/logs", c.baseURL, *req.ChangesetName, req.PlanID)
 	}
+	if req.Follow {
+		url += "?follow=true"
+	}
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -62,12 +61,7 @@ func (c *Client) GetPlanLog(ctx context.Context, req versource.GetPlanLogRequest
 
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	return &versource.GetPlanLogResponse{
@@ -80,6 +74,21 @@ func (c *Client) ListPlans(ctx context.Context, req versource.ListPlansRequest)
 	if req.ChangesetName != "" {
 		url = fmt.Sprintf("%s/api/v1/changesets/%s/plans", c.baseURL, req.ChangesetName)
 	}
+
+	params := pageParams(req.PageRequest)
+	if req.Selector != "" {
+		params = append(params, fmt.Sprintf("selector=%s", neturl.QueryEscape(req.Selector)))
+	}
+	if req.State != nil {
+		params = append(params, fmt.Sprintf("state=%s", neturl.QueryEscape(string(*req.State))))
+	}
+	params = append(params, timeParam("created-after", req.CreatedAfter)...)
+	params = append(params, timeParam("created-before", req.CreatedBefore)...)
+
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -92,12 +101,7 @@ func (c *Client) ListPlans(ctx context.Context, req versource.ListPlansRequest)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var plansResp versource.ListPlansResponse
@@ -125,12 +129,7 @@ func (c *Client) CreatePlan(ctx context.Context, req versource.CreatePlanRequest
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var planResp versource.CreatePlanResponse
@@ -164,12 +163,7 @@ func (c *Client) RunPlan(ctx context.Context, planID uint) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return fmt.Errorf("server error: %s", errorResp.Message)
+		return decodeError(resp)
 	}
 
 	return nil
@@ -5,8 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
-	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
@@ -24,12 +24,7 @@ func (c *Client) GetRebase(ctx context.Context, req versource.GetRebaseRequest)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var rebaseResp versource.GetRebaseResponse
@@ -43,6 +38,12 @@ func (c *Client) GetRebase(ctx context.Context, req versource.GetRebaseRequest)
 
 func (c *Client) ListRebases(ctx context.Context, req versource.ListRebasesRequest) (*versource.ListRebasesResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/changesets/%s/rebases", c.baseURL, req.ChangesetName)
+
+	params := pageParams(req.PageRequest)
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -55,12 +56,7 @@ func (c *Client) ListRebases(ctx context.Context, req versource.ListRebasesReque
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var rebasesResp versource.ListRebasesResponse
@@ -86,12 +82,7 @@ func (c *Client) CreateRebase(ctx context.Context, req versource.CreateRebaseReq
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var rebaseResp versource.CreateRebaseResponse
@@ -5,13 +5,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"strings"
 
-	http2 "github.com/marcbran/versource/internal/http/server"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
 func (c *Client) ListResources(ctx context.Context, req versource.ListResourcesRequest) (*versource.ListResourcesResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/resources", c.baseURL)
+
+	params := pageParams(req.PageRequest)
+	if req.AsOf != nil {
+		params = append(params, fmt.Sprintf("as-of=%s", *req.AsOf))
+	}
+	if req.Provider != nil {
+		params = append(params, fmt.Sprintf("provider=%s", neturl.QueryEscape(*req.Provider)))
+	}
+	if req.ResourceType != nil {
+		params = append(params, fmt.Sprintf("resource-type=%s", neturl.QueryEscape(*req.ResourceType)))
+	}
+
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -24,12 +41,7 @@ func (c *Client) ListResources(ctx context.Context, req versource.ListResourcesR
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResp http2.ErrorResponse
-		err := json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode error response: %w", err)
-		}
-		return nil, fmt.Errorf("server error: %s", errorResp.Message)
+		return nil, decodeError(resp)
 	}
 
 	var resourcesResp versource.ListResourcesResponse
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (c *Client) ListTeams(ctx context.Context, req versource.ListTeamsRequest) (*versource.ListTeamsResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/teams", c.baseURL)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var teamsResp versource.ListTeamsResponse
+	err = json.NewDecoder(resp.Body).Decode(&teamsResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &teamsResp, nil
+}
+
+func (c *Client) CreateTeam(ctx context.Context, req versource.CreateTeamRequest) (*versource.CreateTeamResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/teams", c.baseURL)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, decodeError(resp)
+	}
+
+	var teamResp versource.CreateTeamResponse
+	err = json.NewDecoder(resp.Body).Decode(&teamResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &teamResp, nil
+}
+
+func (c *Client) AddTeamMember(ctx context.Context, req versource.AddTeamMemberRequest) (*versource.AddTeamMemberResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/teams/%s/members/%s", c.baseURL, neturl.PathEscape(req.TeamName), neturl.PathEscape(req.User))
+	httpReq, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var memberResp versource.AddTeamMemberResponse
+	err = json.NewDecoder(resp.Body).Decode(&memberResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &memberResp, nil
+}
+
+func (c *Client) RemoveTeamMember(ctx context.Context, req versource.RemoveTeamMemberRequest) (*versource.RemoveTeamMemberResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/teams/%s/members/%s", c.baseURL, neturl.PathEscape(req.TeamName), neturl.PathEscape(req.User))
+	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var memberResp versource.RemoveTeamMemberResponse
+	err = json.NewDecoder(resp.Body).Decode(&memberResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &memberResp, nil
+}
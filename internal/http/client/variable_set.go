@@ -0,0 +1,138 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (c *Client) ListVariableSets(ctx context.Context, req versource.ListVariableSetsRequest) (*versource.ListVariableSetsResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/variable-sets", c.baseURL)
+	if req.ChangesetName != nil {
+		url = fmt.Sprintf("%s/api/v1/changesets/%s/variable-sets", c.baseURL, *req.ChangesetName)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var variableSetsResp versource.ListVariableSetsResponse
+	err = json.NewDecoder(resp.Body).Decode(&variableSetsResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &variableSetsResp, nil
+}
+
+func (c *Client) GetVariableSet(ctx context.Context, req versource.GetVariableSetRequest) (*versource.GetVariableSetResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/variable-sets/%s", c.baseURL, neturl.PathEscape(req.Name))
+	if req.ChangesetName != nil {
+		url = fmt.Sprintf("%s/api/v1/changesets/%s/variable-sets/%s", c.baseURL, *req.ChangesetName, neturl.PathEscape(req.Name))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var variableSetResp versource.GetVariableSetResponse
+	err = json.NewDecoder(resp.Body).Decode(&variableSetResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &variableSetResp, nil
+}
+
+func (c *Client) CreateVariableSet(ctx context.Context, req versource.CreateVariableSetRequest) (*versource.CreateVariableSetResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/variable-sets", c.baseURL, req.ChangesetName)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, decodeError(resp)
+	}
+
+	var variableSetResp versource.CreateVariableSetResponse
+	err = json.NewDecoder(resp.Body).Decode(&variableSetResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &variableSetResp, nil
+}
+
+func (c *Client) UpdateVariableSet(ctx context.Context, req versource.UpdateVariableSetRequest) (*versource.UpdateVariableSetResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/changesets/%s/variable-sets/%s", c.baseURL, req.ChangesetName, neturl.PathEscape(req.Name))
+	httpReq, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var variableSetResp versource.UpdateVariableSetResponse
+	err = json.NewDecoder(resp.Body).Decode(&variableSetResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &variableSetResp, nil
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (c *Client) ListWebhooks(ctx context.Context, req versource.ListWebhooksRequest) (*versource.ListWebhooksResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/webhooks", c.baseURL)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var webhooksResp versource.ListWebhooksResponse
+	err = json.NewDecoder(resp.Body).Decode(&webhooksResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &webhooksResp, nil
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, req versource.CreateWebhookRequest) (*versource.CreateWebhookResponse, error) {
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/webhooks", c.baseURL)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, decodeError(resp)
+	}
+
+	var webhookResp versource.CreateWebhookResponse
+	err = json.NewDecoder(resp.Body).Decode(&webhookResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &webhookResp, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, req versource.DeleteWebhookRequest) (*versource.DeleteWebhookResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/webhooks/%s", c.baseURL, neturl.PathEscape(req.Name))
+	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var webhookResp versource.DeleteWebhookResponse
+	err = json.NewDecoder(resp.Body).Decode(&webhookResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &webhookResp, nil
+}
+
+func (c *Client) ListWebhookDeliveries(ctx context.Context, req versource.ListWebhookDeliveriesRequest) (*versource.ListWebhookDeliveriesResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/webhooks/%s/deliveries", c.baseURL, neturl.PathEscape(req.WebhookName))
+
+	params := pageParams(req.PageRequest)
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var deliveriesResp versource.ListWebhookDeliveriesResponse
+	err = json.NewDecoder(resp.Body).Decode(&deliveriesResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &deliveriesResp, nil
+}
+
+func (c *Client) GetWebhookDelivery(ctx context.Context, req versource.GetWebhookDeliveryRequest) (*versource.GetWebhookDeliveryResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/webhooks/%s/deliveries/%d", c.baseURL, neturl.PathEscape(req.WebhookName), req.DeliveryID)
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, decodeError(resp)
+	}
+
+	var deliveryResp versource.GetWebhookDeliveryResponse
+	err = json.NewDecoder(resp.Body).Decode(&deliveryResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &deliveryResp, nil
+}
+
+func (c *Client) RedeliverWebhookDelivery(ctx context.Context, req versource.RedeliverWebhookDeliveryRequest) (*versource.RedeliverWebhookDeliveryResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/webhooks/%s/deliveries/%d/redeliver", c.baseURL, neturl.PathEscape(req.WebhookName), req.DeliveryID)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, decodeError(resp)
+	}
+
+	var deliveryResp versource.RedeliverWebhookDeliveryResponse
+	err = json.NewDecoder(resp.Body).Decode(&deliveryResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &deliveryResp, nil
+}
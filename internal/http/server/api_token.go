@@ -0,0 +1,53 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (s *Server) handleListApiTokens(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.facade.ListApiTokens(r.Context(), versource.ListApiTokensRequest{})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleCreateApiToken(w http.ResponseWriter, r *http.Request) {
+	var req versource.CreateApiTokenRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	resp, err := s.facade.CreateApiToken(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnCreated(w, resp)
+}
+
+func (s *Server) handleRevokeApiToken(w http.ResponseWriter, r *http.Request) {
+	tokenName := chi.URLParam(r, "tokenName")
+	if tokenName == "" {
+		returnValidationError(w, fmt.Errorf("token name is required"))
+		return
+	}
+
+	resp, err := s.facade.RevokeApiToken(r.Context(), versource.RevokeApiTokenRequest{Name: tokenName})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
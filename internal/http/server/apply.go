@@ -2,7 +2,6 @@ package server
 
 import (
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 
@@ -16,7 +15,7 @@ func (s *Server) handleGetApply(w http.ResponseWriter, r *http.Request) {
 
 	applyID, err := strconv.ParseUint(applyIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid apply ID: %s", applyIDStr))
+		returnValidationError(w, fmt.Errorf("invalid apply ID: %s", applyIDStr))
 		return
 	}
 
@@ -38,12 +37,13 @@ func (s *Server) handleGetApplyLog(w http.ResponseWriter, r *http.Request) {
 
 	applyID, err := strconv.ParseUint(applyIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid apply ID: %s", applyIDStr))
+		returnValidationError(w, fmt.Errorf("invalid apply ID: %s", applyIDStr))
 		return
 	}
 
 	req := versource.GetApplyLogRequest{
 		ApplyID: uint(applyID),
+		Follow:  isFollow(r),
 	}
 
 	response, err := s.facade.GetApplyLog(r.Context(), req)
@@ -52,21 +52,45 @@ func (s *Server) handleGetApplyLog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(http.StatusOK)
+	err = returnLog(w, response.Content, req.Follow)
+	if err != nil {
+		log.WithError(err).Error("Failed to stream apply log content")
+	}
+}
 
-	_, err = io.Copy(w, response.Content)
+func (s *Server) handleListApplies(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePageRequest(r)
 	if err != nil {
-		log.WithError(err).Error("Failed to copy apply log content to response")
-		http.Error(w, "Failed to stream apply log content", http.StatusInternalServerError)
+		returnValidationError(w, err)
 		return
 	}
 
-	response.Content.Close()
-}
+	req := versource.ListAppliesRequest{
+		PageRequest: page,
+	}
 
-func (s *Server) handleListApplies(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.facade.ListApplies(r.Context(), versource.ListAppliesRequest{})
+	if changesetName := r.URL.Query().Get("changeset"); changesetName != "" {
+		req.ChangesetName = &changesetName
+	}
+
+	if state := r.URL.Query().Get("state"); state != "" {
+		taskState := versource.TaskState(state)
+		req.State = &taskState
+	}
+
+	req.CreatedAfter, err = parseTimeParam(r, "created-after")
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req.CreatedBefore, err = parseTimeParam(r, "created-before")
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	resp, err := s.facade.ListApplies(r.Context(), req)
 	if err != nil {
 		returnError(w, err)
 		return
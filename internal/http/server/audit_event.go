@@ -0,0 +1,35 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (s *Server) handleListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req := versource.ListAuditEventsRequest{
+		PageRequest: page,
+	}
+
+	if branch := r.URL.Query().Get("branch"); branch != "" {
+		req.Branch = &branch
+	}
+
+	if author := r.URL.Query().Get("author"); author != "" {
+		req.Author = &author
+	}
+
+	resp, err := s.facade.ListAuditEvents(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/marcbran/versource/internal"
+	"github.com/marcbran/versource/pkg/versource"
+	log "github.com/sirupsen/logrus"
+)
+
+type apiTokenContextKey struct{}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		apiToken, err := s.authenticateApiToken.Exec(r.Context(), token)
+		if errors.Is(err, internal.ErrUnauthenticated) {
+			returnUnauthorized(w, err)
+			return
+		}
+		if err != nil {
+			returnInternalServerError(w, err)
+			return
+		}
+		if apiToken != nil {
+			ctx := context.WithValue(r.Context(), apiTokenContextKey{}, apiToken)
+			ctx = versource.WithUser(ctx, apiToken.User)
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func requireApiToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.Context().Value(apiTokenContextKey{}).(*versource.ApiToken)
+		if !ok {
+			returnUnauthorized(w, internal.ErrUnauthenticated)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func authorizeByMethod(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role := versource.RoleEditor
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			role = versource.RoleViewer
+		}
+		requireRole(role)(next).ServeHTTP(w, r)
+	})
+}
+
+func requireRole(role versource.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiToken, ok := r.Context().Value(apiTokenContextKey{}).(*versource.ApiToken)
+			if ok && !apiToken.Role.Includes(role) {
+				returnForbidden(w, fmt.Errorf("role %s is required, api token %s has role %s", role, apiToken.Name, apiToken.Role))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func returnUnauthorized(w http.ResponseWriter, err error) {
+	log.WithError(err).Warn("Unauthorized request")
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	w.WriteHeader(http.StatusUnauthorized)
+	returnJSON(w, ErrorResponse{
+		Message: err.Error(),
+	})
+}
+
+func returnForbidden(w http.ResponseWriter, err error) {
+	log.WithError(err).Warn("Forbidden request")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	returnJSON(w, ErrorResponse{
+		Code:    versource.ErrorCodeForbidden,
+		Message: err.Error(),
+	})
+}
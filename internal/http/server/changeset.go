@@ -4,13 +4,50 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
 func (s *Server) handleListChangesets(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.facade.ListChangesets(r.Context(), versource.ListChangesetsRequest{})
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req := versource.ListChangesetsRequest{
+		PageRequest: page,
+	}
+
+	if includeClosedStr := r.URL.Query().Get("include-closed"); includeClosedStr != "" {
+		includeClosed, err := strconv.ParseBool(includeClosedStr)
+		if err != nil {
+			returnValidationError(w, fmt.Errorf("invalid include-closed"))
+			return
+		}
+		req.IncludeClosed = includeClosed
+	}
+
+	if state := r.URL.Query().Get("state"); state != "" {
+		changesetState := versource.ChangesetState(state)
+		req.State = &changesetState
+	}
+
+	req.CreatedAfter, err = parseTimeParam(r, "created-after")
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req.CreatedBefore, err = parseTimeParam(r, "created-before")
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	resp, err := s.facade.ListChangesets(r.Context(), req)
 	if err != nil {
 		returnError(w, err)
 		return
@@ -23,7 +60,7 @@ func (s *Server) handleCreateChangeset(w http.ResponseWriter, r *http.Request) {
 	var req versource.CreateChangesetRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid request body"))
+		returnValidationError(w, fmt.Errorf("invalid request body"))
 		return
 	}
 
@@ -39,7 +76,7 @@ func (s *Server) handleCreateChangeset(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleMergeChangeset(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 	if changesetName == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
 		return
 	}
 
@@ -59,7 +96,7 @@ func (s *Server) handleMergeChangeset(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleDeleteChangeset(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 	if changesetName == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
 		return
 	}
 
@@ -75,3 +112,133 @@ func (s *Server) handleDeleteChangeset(w http.ResponseWriter, r *http.Request) {
 
 	returnSuccess(w, resp)
 }
+
+func (s *Server) handleUpdateChangeset(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	var req versource.UpdateChangesetRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	req.ChangesetName = changesetName
+
+	resp, err := s.facade.UpdateChangeset(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleCloseChangeset(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	req := versource.CloseChangesetRequest{
+		ChangesetName: changesetName,
+	}
+
+	resp, err := s.facade.CloseChangeset(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleReopenChangeset(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	req := versource.ReopenChangesetRequest{
+		ChangesetName: changesetName,
+	}
+
+	resp, err := s.facade.ReopenChangeset(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleRevertChangeset(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	var req versource.RevertChangesetRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	req.ChangesetName = changesetName
+
+	resp, err := s.facade.RevertChangeset(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleApproveChangeset(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	req := versource.ApproveChangesetRequest{
+		ChangesetName: changesetName,
+	}
+
+	resp, err := s.facade.ApproveChangeset(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnCreated(w, resp)
+}
+
+func (s *Server) handleListChangesetApprovals(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	req := versource.ListChangesetApprovalsRequest{
+		ChangesetName: changesetName,
+	}
+
+	resp, err := s.facade.ListChangesetApprovals(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
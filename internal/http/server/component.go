@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/marcbran/versource/pkg/versource"
@@ -14,7 +15,7 @@ func (s *Server) handleGetComponent(w http.ResponseWriter, r *http.Request) {
 	componentIDStr := chi.URLParam(r, "componentID")
 	componentID, err := strconv.ParseUint(componentIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid component ID"))
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
 		return
 	}
 
@@ -26,26 +27,45 @@ func (s *Server) handleGetComponent(w http.ResponseWriter, r *http.Request) {
 		req.ChangesetName = &changesetName
 	}
 
+	if asOf := r.URL.Query().Get("as-of"); asOf != "" {
+		req.AsOf = &asOf
+	}
+
 	resp, err := s.facade.GetComponent(r.Context(), req)
 	if err != nil {
 		returnError(w, err)
 		return
 	}
 
+	if resp.Revision != "" {
+		w.Header().Set("ETag", strconv.Quote(resp.Revision))
+	}
 	returnSuccess(w, resp)
 }
 
 func (s *Server) handleListComponents(w http.ResponseWriter, r *http.Request) {
-	req := versource.ListComponentsRequest{}
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req := versource.ListComponentsRequest{
+		PageRequest: page,
+	}
 
 	if changesetName := chi.URLParam(r, "changesetName"); changesetName != "" {
 		req.ChangesetName = &changesetName
 	}
 
+	if asOf := r.URL.Query().Get("as-of"); asOf != "" {
+		req.AsOf = &asOf
+	}
+
 	if moduleIDStr := r.URL.Query().Get("module-id"); moduleIDStr != "" {
 		moduleID, err := strconv.ParseUint(moduleIDStr, 10, 32)
 		if err != nil {
-			returnBadRequest(w, fmt.Errorf("invalid module-id"))
+			returnValidationError(w, fmt.Errorf("invalid module-id"))
 			return
 		}
 		moduleIDUint := uint(moduleID)
@@ -55,13 +75,25 @@ func (s *Server) handleListComponents(w http.ResponseWriter, r *http.Request) {
 	if moduleVersionIDStr := r.URL.Query().Get("module-version-id"); moduleVersionIDStr != "" {
 		moduleVersionID, err := strconv.ParseUint(moduleVersionIDStr, 10, 32)
 		if err != nil {
-			returnBadRequest(w, fmt.Errorf("invalid module-version-id"))
+			returnValidationError(w, fmt.Errorf("invalid module-version-id"))
 			return
 		}
 		moduleVersionIDUint := uint(moduleVersionID)
 		req.ModuleVersionID = &moduleVersionIDUint
 	}
 
+	req.Selector = r.URL.Query().Get("selector")
+
+	if owner := r.URL.Query().Get("owner"); owner != "" {
+		req.Owner = &owner
+	}
+	if template := r.URL.Query().Get("template"); template != "" {
+		req.Template = &template
+	}
+	if environment := r.URL.Query().Get("environment"); environment != "" {
+		req.Environment = &environment
+	}
+
 	resp, err := s.facade.ListComponents(r.Context(), req)
 	if err != nil {
 		returnError(w, err)
@@ -74,14 +106,14 @@ func (s *Server) handleListComponents(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleGetComponentChange(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 	if changesetName == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
 		return
 	}
 
 	componentIDStr := chi.URLParam(r, "componentID")
 	componentID, err := strconv.ParseUint(componentIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid component ID"))
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
 		return
 	}
 
@@ -102,12 +134,20 @@ func (s *Server) handleGetComponentChange(w http.ResponseWriter, r *http.Request
 func (s *Server) handleListComponentChanges(w http.ResponseWriter, r *http.Request) {
 	changeset := chi.URLParam(r, "changesetName")
 	if changeset == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
 		return
 	}
 
 	req := versource.ListComponentChangesRequest{
+		PageRequest:   page,
 		ChangesetName: changeset,
+		Selector:      r.URL.Query().Get("selector"),
 	}
 
 	resp, err := s.facade.ListComponentChanges(r.Context(), req)
@@ -122,14 +162,14 @@ func (s *Server) handleListComponentChanges(w http.ResponseWriter, r *http.Reque
 func (s *Server) handleCreateComponent(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 	if changesetName == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
 		return
 	}
 
 	var req versource.CreateComponentRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid request body"))
+		returnValidationError(w, fmt.Errorf("invalid request body"))
 		return
 	}
 
@@ -147,47 +187,53 @@ func (s *Server) handleCreateComponent(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleUpdateComponent(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 	if changesetName == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
 		return
 	}
 
 	componentIDStr := chi.URLParam(r, "componentID")
 	componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid component ID"))
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
 		return
 	}
 
 	var req versource.UpdateComponentRequest
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid request body"))
+		returnValidationError(w, fmt.Errorf("invalid request body"))
 		return
 	}
 
 	req.ChangesetName = changesetName
 	req.ComponentID = uint(componentID)
 
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && req.ExpectedRevision == nil {
+		expectedRevision := strings.Trim(ifMatch, `"`)
+		req.ExpectedRevision = &expectedRevision
+	}
+
 	resp, err := s.facade.UpdateComponent(r.Context(), req)
 	if err != nil {
 		returnError(w, err)
 		return
 	}
 
+	w.Header().Set("ETag", strconv.Quote(resp.Revision))
 	returnSuccess(w, resp)
 }
 
 func (s *Server) handleDeleteComponent(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 	if changesetName == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
 		return
 	}
 
 	componentIDStr := chi.URLParam(r, "componentID")
 	componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid component ID"))
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
 		return
 	}
 
@@ -208,14 +254,14 @@ func (s *Server) handleDeleteComponent(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleRestoreComponent(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 	if changesetName == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
 		return
 	}
 
 	componentIDStr := chi.URLParam(r, "componentID")
 	componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid component ID"))
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
 		return
 	}
 
@@ -232,3 +278,142 @@ func (s *Server) handleRestoreComponent(w http.ResponseWriter, r *http.Request)
 
 	returnSuccess(w, resp)
 }
+
+func (s *Server) handleListComponentConflicts(w http.ResponseWriter, r *http.Request) {
+	changeset := chi.URLParam(r, "changesetName")
+	if changeset == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	req := versource.ListComponentConflictsRequest{
+		ChangesetName: changeset,
+	}
+
+	resp, err := s.facade.ListComponentConflicts(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleResolveComponentConflict(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	componentIDStr := chi.URLParam(r, "componentID")
+	componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
+		return
+	}
+
+	var req versource.ResolveComponentConflictRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	req.ChangesetName = changesetName
+	req.ComponentID = uint(componentID)
+
+	resp, err := s.facade.ResolveComponentConflict(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleListComponentHistory(w http.ResponseWriter, r *http.Request) {
+	componentIDStr := chi.URLParam(r, "componentID")
+	componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
+		return
+	}
+
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req := versource.ListComponentHistoryRequest{
+		PageRequest: page,
+		ComponentID: uint(componentID),
+	}
+
+	resp, err := s.facade.ListComponentHistory(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleRevertComponentToRevision(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	componentIDStr := chi.URLParam(r, "componentID")
+	componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
+		return
+	}
+
+	var req versource.RevertComponentToRevisionRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	req.ChangesetName = changesetName
+	req.ComponentID = uint(componentID)
+
+	resp, err := s.facade.RevertComponentToRevision(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleSyncComponents(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	var req versource.SyncComponentsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	req.ChangesetName = changesetName
+
+	resp, err := s.facade.SyncComponents(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (s *Server) handleListEnvironments(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.facade.ListEnvironments(r.Context(), versource.ListEnvironmentsRequest{})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleCreateEnvironment(w http.ResponseWriter, r *http.Request) {
+	var req versource.CreateEnvironmentRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	resp, err := s.facade.CreateEnvironment(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnCreated(w, resp)
+}
+
+func (s *Server) handleGetPromotionPath(w http.ResponseWriter, r *http.Request) {
+	template := chi.URLParam(r, "template")
+	if template == "" {
+		returnValidationError(w, fmt.Errorf("template is required"))
+		return
+	}
+
+	resp, err := s.facade.GetPromotionPath(r.Context(), versource.GetPromotionPathRequest{Template: template})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handlePromoteComponent(w http.ResponseWriter, r *http.Request) {
+	template := chi.URLParam(r, "template")
+	if template == "" {
+		returnValidationError(w, fmt.Errorf("template is required"))
+		return
+	}
+
+	var req versource.PromoteComponentRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+	req.Template = template
+
+	resp, err := s.facade.PromoteComponent(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnCreated(w, resp)
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+	log "github.com/sirupsen/logrus"
+)
+
+const eventKeepAliveInterval = 15 * time.Second
+
+func (s *Server) handleSubscribeEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		returnInternalServerError(w, fmt.Errorf("streaming is not supported"))
+		return
+	}
+
+	req := versource.SubscribeEventsRequest{}
+
+	if changesetName := r.URL.Query().Get("changeset"); changesetName != "" {
+		req.ChangesetName = &changesetName
+	}
+
+	for _, eventType := range r.URL.Query()["type"] {
+		req.Types = append(req.Types, versource.EventType(eventType))
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last-event-id")
+	}
+	if lastEventID != "" {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			returnValidationError(w, fmt.Errorf("invalid last event ID: %s", lastEventID))
+			return
+		}
+		req.LastEventID = id
+	}
+
+	resp, err := s.facade.SubscribeEvents(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			_, err := fmt.Fprint(w, ": keep-alive\n\n")
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-resp.Events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.WithError(err).Warn("Failed to encode event")
+				continue
+			}
+			_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (s *Server) handleExportInventory(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.facade.ExportInventory(r.Context(), versource.ExportInventoryRequest{})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleImportInventory(w http.ResponseWriter, r *http.Request) {
+	var req versource.ImportInventoryRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	resp, err := s.facade.ImportInventory(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
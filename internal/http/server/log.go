@@ -0,0 +1,41 @@
+package server
+
+import (
+	"io"
+	"net/http"
+)
+
+func isFollow(r *http.Request) bool {
+	return r.URL.Query().Get("follow") == "true"
+}
+
+func returnLog(w http.ResponseWriter, content io.ReadCloser, follow bool) error {
+	defer content.Close()
+
+	w.Header().Set("Content-Type", "text/plain")
+	if follow {
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	}
+	w.WriteHeader(http.StatusOK)
+
+	var dst io.Writer = w
+	if flusher, ok := w.(http.Flusher); ok && follow {
+		flusher.Flush()
+		dst = &flushWriter{w: w, flusher: flusher}
+	}
+
+	_, err := io.Copy(dst, content)
+	return err
+}
+
+type flushWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.flusher.Flush()
+	return n, err
+}
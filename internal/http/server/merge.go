@@ -15,7 +15,7 @@ func (s *Server) handleGetMerge(w http.ResponseWriter, r *http.Request) {
 
 	mergeID, err := strconv.ParseUint(mergeIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid merge ID: %s", mergeIDStr))
+		returnValidationError(w, fmt.Errorf("invalid merge ID: %s", mergeIDStr))
 		return
 	}
 
@@ -36,7 +36,14 @@ func (s *Server) handleGetMerge(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleListMerges(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
 	req := versource.ListMergesRequest{
+		PageRequest:   page,
 		ChangesetName: changesetName,
 	}
 
@@ -48,3 +55,35 @@ func (s *Server) handleListMerges(w http.ResponseWriter, r *http.Request) {
 
 	returnSuccess(w, resp)
 }
+
+func (s *Server) handleValidateMerge(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	req := versource.ValidateMergeRequest{
+		ChangesetName: changesetName,
+	}
+
+	resp, err := s.facade.ValidateMerge(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleListMergeQueue(w http.ResponseWriter, r *http.Request) {
+	req := versource.ListMergeQueueRequest{}
+
+	resp, err := s.facade.ListMergeQueue(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
@@ -14,7 +14,7 @@ func (s *Server) handleGetModule(w http.ResponseWriter, r *http.Request) {
 	moduleIDStr := chi.URLParam(r, "moduleID")
 	moduleID, err := strconv.ParseUint(moduleIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid module ID"))
+		returnValidationError(w, fmt.Errorf("invalid module ID"))
 		return
 	}
 
@@ -32,7 +32,21 @@ func (s *Server) handleGetModule(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleListModules(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.facade.ListModules(r.Context(), versource.ListModulesRequest{})
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req := versource.ListModulesRequest{
+		PageRequest: page,
+	}
+
+	if asOf := r.URL.Query().Get("as-of"); asOf != "" {
+		req.AsOf = &asOf
+	}
+
+	resp, err := s.facade.ListModules(r.Context(), req)
 	if err != nil {
 		returnError(w, err)
 		return
@@ -45,7 +59,7 @@ func (s *Server) handleCreateModule(w http.ResponseWriter, r *http.Request) {
 	var req versource.CreateModuleRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid request body"))
+		returnValidationError(w, fmt.Errorf("invalid request body"))
 		return
 	}
 
@@ -62,14 +76,14 @@ func (s *Server) handleUpdateModule(w http.ResponseWriter, r *http.Request) {
 	moduleIDStr := chi.URLParam(r, "moduleID")
 	moduleID, err := strconv.ParseUint(moduleIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid module ID"))
+		returnValidationError(w, fmt.Errorf("invalid module ID"))
 		return
 	}
 
 	var req versource.UpdateModuleRequest
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid request body"))
+		returnValidationError(w, fmt.Errorf("invalid request body"))
 		return
 	}
 
@@ -88,7 +102,7 @@ func (s *Server) handleDeleteModule(w http.ResponseWriter, r *http.Request) {
 	moduleIDStr := chi.URLParam(r, "moduleID")
 	moduleID, err := strconv.ParseUint(moduleIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid module ID"))
+		returnValidationError(w, fmt.Errorf("invalid module ID"))
 		return
 	}
 
@@ -109,7 +123,7 @@ func (s *Server) handleGetModuleVersion(w http.ResponseWriter, r *http.Request)
 	moduleVersionIDStr := chi.URLParam(r, "moduleVersionID")
 	moduleVersionID, err := strconv.ParseUint(moduleVersionIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid module version ID"))
+		returnValidationError(w, fmt.Errorf("invalid module version ID"))
 		return
 	}
 
@@ -127,7 +141,13 @@ func (s *Server) handleGetModuleVersion(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) handleListModuleVersions(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.facade.ListModuleVersions(r.Context(), versource.ListModuleVersionsRequest{})
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	resp, err := s.facade.ListModuleVersions(r.Context(), versource.ListModuleVersionsRequest{PageRequest: page})
 	if err != nil {
 		returnError(w, err)
 		return
@@ -140,12 +160,18 @@ func (s *Server) handleListModuleVersionsForModule(w http.ResponseWriter, r *htt
 	moduleIDStr := chi.URLParam(r, "moduleID")
 	moduleID, err := strconv.ParseUint(moduleIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid module ID"))
+		returnValidationError(w, fmt.Errorf("invalid module ID"))
+		return
+	}
+
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
 		return
 	}
 
 	moduleIDUint := uint(moduleID)
-	req := versource.ListModuleVersionsRequest{ModuleID: &moduleIDUint}
+	req := versource.ListModuleVersionsRequest{PageRequest: page, ModuleID: &moduleIDUint}
 
 	resp, err := s.facade.ListModuleVersions(r.Context(), req)
 	if err != nil {
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func parsePageRequest(r *http.Request) (versource.PageRequest, error) {
+	query := r.URL.Query()
+	page := versource.PageRequest{
+		Cursor: query.Get("cursor"),
+		Sort:   query.Get("sort"),
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return versource.PageRequest{}, fmt.Errorf("invalid limit")
+		}
+		page.Limit = limit
+	}
+	return page, nil
+}
+
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	return &t, nil
+}
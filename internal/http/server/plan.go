@@ -2,7 +2,6 @@ package server
 
 import (
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 
@@ -17,7 +16,7 @@ func (s *Server) handleGetPlan(w http.ResponseWriter, r *http.Request) {
 
 	planID, err := strconv.ParseUint(planIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid plan ID: %s", planIDStr))
+		returnValidationError(w, fmt.Errorf("invalid plan ID: %s", planIDStr))
 		return
 	}
 
@@ -43,12 +42,13 @@ func (s *Server) handleGetPlanLog(w http.ResponseWriter, r *http.Request) {
 
 	planID, err := strconv.ParseUint(planIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid plan ID: %s", planIDStr))
+		returnValidationError(w, fmt.Errorf("invalid plan ID: %s", planIDStr))
 		return
 	}
 
 	req := versource.GetPlanLogRequest{
 		PlanID: uint(planID),
+		Follow: isFollow(r),
 	}
 	if changesetName != "" {
 		req.ChangesetName = &changesetName
@@ -60,24 +60,42 @@ func (s *Server) handleGetPlanLog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(http.StatusOK)
-
-	_, err = io.Copy(w, response.Content)
+	err = returnLog(w, response.Content, req.Follow)
 	if err != nil {
-		log.WithError(err).Error("Failed to copy log content to response")
-		http.Error(w, "Failed to stream log content", http.StatusInternalServerError)
-		return
+		log.WithError(err).Error("Failed to stream plan log content")
 	}
-
-	response.Content.Close()
 }
 
 func (s *Server) handleListPlans(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
 	req := versource.ListPlansRequest{
+		PageRequest:   page,
 		ChangesetName: changesetName,
+		Selector:      r.URL.Query().Get("selector"),
+	}
+
+	if state := r.URL.Query().Get("state"); state != "" {
+		taskState := versource.TaskState(state)
+		req.State = &taskState
+	}
+
+	req.CreatedAfter, err = parseTimeParam(r, "created-after")
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req.CreatedBefore, err = parseTimeParam(r, "created-before")
+	if err != nil {
+		returnValidationError(w, err)
+		return
 	}
 
 	resp, err := s.facade.ListPlans(r.Context(), req)
@@ -92,14 +110,14 @@ func (s *Server) handleListPlans(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleCreatePlan(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 	if changesetName == "" {
-		returnBadRequest(w, fmt.Errorf("changeset name is required"))
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
 		return
 	}
 
 	componentIDStr := chi.URLParam(r, "componentID")
 	componentID, err := strconv.ParseUint(componentIDStr, 10, 64)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid component ID"))
+		returnValidationError(w, fmt.Errorf("invalid component ID"))
 		return
 	}
 
@@ -15,7 +15,7 @@ func (s *Server) handleGetRebase(w http.ResponseWriter, r *http.Request) {
 
 	rebaseID, err := strconv.ParseUint(rebaseIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid rebase ID: %s", rebaseIDStr))
+		returnValidationError(w, fmt.Errorf("invalid rebase ID: %s", rebaseIDStr))
 		return
 	}
 
@@ -36,7 +36,14 @@ func (s *Server) handleGetRebase(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleListRebases(w http.ResponseWriter, r *http.Request) {
 	changesetName := chi.URLParam(r, "changesetName")
 
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
 	req := versource.ListRebasesRequest{
+		PageRequest:   page,
 		ChangesetName: changesetName,
 	}
 
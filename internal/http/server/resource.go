@@ -7,7 +7,29 @@ import (
 )
 
 func (s *Server) handleListResources(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.facade.ListResources(r.Context(), versource.ListResourcesRequest{})
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	req := versource.ListResourcesRequest{
+		PageRequest: page,
+	}
+
+	if asOf := r.URL.Query().Get("as-of"); asOf != "" {
+		req.AsOf = &asOf
+	}
+
+	if provider := r.URL.Query().Get("provider"); provider != "" {
+		req.Provider = &provider
+	}
+
+	if resourceType := r.URL.Query().Get("resource-type"); resourceType != "" {
+		req.ResourceType = &resourceType
+	}
+
+	resp, err := s.facade.ListResources(r.Context(), req)
 	if err != nil {
 		returnError(w, err)
 		return
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -29,11 +30,18 @@ func Serve(ctx context.Context, config *versource.Config) error {
 
 	server.facade.Start(ctx)
 
+	baseCtx, cancelBase := context.WithCancel(ctx)
+	defer cancelBase()
+
 	addr := config.HTTP.Hostname + ":" + config.HTTP.Port
 	httpServer := &http.Server{
 		Addr:    addr,
 		Handler: server.router,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
+	httpServer.RegisterOnShutdown(cancelBase)
 
 	go func() {
 		log.WithField("addr", addr).Info("Starting HTTP server")
@@ -60,9 +68,10 @@ func Serve(ctx context.Context, config *versource.Config) error {
 }
 
 type Server struct {
-	config *versource.Config
-	router *chi.Mux
-	facade versource.Facade
+	config               *versource.Config
+	router               *chi.Mux
+	facade               versource.Facade
+	authenticateApiToken *internal.AuthenticateApiToken
 }
 
 func NewServer(config *versource.Config) (*Server, error) {
@@ -79,6 +88,7 @@ func NewServer(config *versource.Config) (*Server, error) {
 	planRepo := database.NewGormPlanRepo(db)
 	planStore := file.NewPlanStore(config.Terraform.WorkDir)
 	logStore := file.NewLogStore(config.Terraform.WorkDir)
+	stateStore := file.NewStateStore(config.Terraform.WorkDir)
 	applyRepo := database.NewGormApplyRepo(db)
 	mergeRepo := database.NewGormMergeRepo(db)
 	rebaseRepo := database.NewGormRebaseRepo(db)
@@ -86,6 +96,15 @@ func NewServer(config *versource.Config) (*Server, error) {
 	moduleRepo := database.NewGormModuleRepo(db)
 	moduleVersionRepo := database.NewGormModuleVersionRepo(db)
 	viewResourceRepo := database.NewGormViewResourceRepo(db)
+	inventoryRepo := database.NewGormInventoryRepo(db)
+	teamRepo := database.NewGormTeamRepo(db)
+	changesetApprovalRepo := database.NewGormChangesetApprovalRepo(db)
+	variableSetRepo := database.NewGormVariableSetRepo(db)
+	environmentRepo := database.NewGormEnvironmentRepo(db)
+	apiTokenRepo := database.NewGormApiTokenRepo(db)
+	auditEventRepo := database.NewGormAuditEventRepo(db)
+	webhookRepo := database.NewGormWebhookRepo(db)
+	webhookDeliveryRepo := database.NewGormWebhookDeliveryRepo(db)
 	queryParser := parser.NewSQLViewQueryParser()
 	transactionManager := database.NewGormTransactionManager(db)
 
@@ -101,6 +120,7 @@ func NewServer(config *versource.Config) (*Server, error) {
 		planRepo,
 		planStore,
 		logStore,
+		stateStore,
 		applyRepo,
 		mergeRepo,
 		rebaseRepo,
@@ -108,15 +128,25 @@ func NewServer(config *versource.Config) (*Server, error) {
 		moduleRepo,
 		moduleVersionRepo,
 		viewResourceRepo,
+		inventoryRepo,
+		teamRepo,
+		changesetApprovalRepo,
+		variableSetRepo,
+		environmentRepo,
+		apiTokenRepo,
+		auditEventRepo,
+		webhookRepo,
+		webhookDeliveryRepo,
 		queryParser,
 		transactionManager,
 		newExecutor,
 	)
 
 	s := &Server{
-		config: config,
-		router: chi.NewRouter(),
-		facade: facade,
+		config:               config,
+		router:               chi.NewRouter(),
+		facade:               facade,
+		authenticateApiToken: internal.NewAuthenticateApiToken(apiTokenRepo, transactionManager),
 	}
 
 	s.setupMiddleware()
@@ -129,16 +159,43 @@ func (s *Server) setupMiddleware() {
 	s.router.Use(middleware.Logger)
 	s.router.Use(middleware.Recoverer)
 	s.router.Use(middleware.RequestID)
+	if s.config.HTTP.TrustUserHeader {
+		s.router.Use(userMiddleware)
+	}
+}
+
+const UserHeader = "X-Versource-User"
+
+// userMiddleware takes the acting user from a client-supplied header. Any
+// caller can claim any user this way, so it is only installed when
+// http.trustUserHeader is set for trusted local use; otherwise the user comes
+// from the authenticated api token.
+func userMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user := r.Header.Get(UserHeader); user != "" {
+			if !versource.IsValidUser(user) {
+				returnValidationError(w, versource.UserErrf("invalid user in %s header", UserHeader))
+				return
+			}
+			r = r.WithContext(versource.WithUser(r.Context(), user))
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
 func (s *Server) setupRoutes() {
 	s.router.Route("/api/v1", func(r chi.Router) {
+		r.Use(s.authenticate)
+		r.Use(authorizeByMethod)
 		r.Get("/modules", s.handleListModules)
 		r.Get("/modules/{moduleID}", s.handleGetModule)
 		r.Get("/module-versions", s.handleListModuleVersions)
 		r.Get("/module-versions/{moduleVersionID}", s.handleGetModuleVersion)
 		r.Get("/components", s.handleListComponents)
 		r.Get("/components/{componentID}", s.handleGetComponent)
+		r.Get("/components/{componentID}/history", s.handleListComponentHistory)
+		r.Get("/variable-sets", s.handleListVariableSets)
+		r.Get("/variable-sets/{variableSetName}", s.handleGetVariableSet)
 		r.Route("/plans", func(r chi.Router) {
 			r.Get("/", s.handleListPlans)
 			r.Route("/{planID}", func(r chi.Router) {
@@ -147,12 +204,41 @@ func (s *Server) setupRoutes() {
 			})
 		})
 		r.Get("/applies", s.handleListApplies)
+		r.Get("/merges/queue", s.handleListMergeQueue)
 		r.Get("/resources", s.handleListResources)
 		r.Get("/view-resources", s.handleListViewResources)
 		r.Get("/view-resources/{viewResourceID}", s.handleGetViewResource)
 		r.Post("/view-resources", s.handleSaveViewResource)
 		r.Delete("/view-resources/{viewResourceID}", s.handleDeleteViewResource)
+		r.With(requireRole(versource.RoleAdmin)).Get("/inventory", s.handleExportInventory)
+		r.With(requireRole(versource.RoleAdmin)).Post("/inventory", s.handleImportInventory)
 		r.Get("/changesets", s.handleListChangesets)
+		r.Get("/teams", s.handleListTeams)
+		r.With(requireRole(versource.RoleAdmin)).Post("/teams", s.handleCreateTeam)
+		r.With(requireRole(versource.RoleAdmin)).Put("/teams/{teamName}/members/{user}", s.handleAddTeamMember)
+		r.With(requireRole(versource.RoleAdmin)).Delete("/teams/{teamName}/members/{user}", s.handleRemoveTeamMember)
+		r.Get("/environments", s.handleListEnvironments)
+		r.With(requireRole(versource.RoleAdmin)).Post("/environments", s.handleCreateEnvironment)
+		r.Route("/tokens", func(r chi.Router) {
+			r.Use(requireApiToken)
+			r.Use(requireRole(versource.RoleAdmin))
+			r.Get("/", s.handleListApiTokens)
+			r.Post("/", s.handleCreateApiToken)
+			r.Delete("/{tokenName}", s.handleRevokeApiToken)
+		})
+		r.Get("/audit", s.handleListAuditEvents)
+		r.Get("/events", s.handleSubscribeEvents)
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(requireRole(versource.RoleAdmin))
+			r.Get("/", s.handleListWebhooks)
+			r.Post("/", s.handleCreateWebhook)
+			r.Delete("/{webhookName}", s.handleDeleteWebhook)
+			r.Get("/{webhookName}/deliveries", s.handleListWebhookDeliveries)
+			r.Get("/{webhookName}/deliveries/{deliveryID}", s.handleGetWebhookDelivery)
+			r.Post("/{webhookName}/deliveries/{deliveryID}/redeliver", s.handleRedeliverWebhookDelivery)
+		})
+		r.Get("/templates/{template}/promotion", s.handleGetPromotionPath)
+		r.Post("/templates/{template}/promote", s.handlePromoteComponent)
 		r.Route("/applies/{applyID}", func(r chi.Router) {
 			r.Get("/", s.handleGetApply)
 			r.Get("/logs", s.handleGetApplyLog)
@@ -163,10 +249,22 @@ func (s *Server) setupRoutes() {
 		r.Delete("/modules/{moduleID}", s.handleDeleteModule)
 		r.Get("/modules/{moduleID}/versions", s.handleListModuleVersionsForModule)
 		r.Route("/changesets/{changesetName}", func(r chi.Router) {
+			r.Patch("/", s.handleUpdateChangeset)
 			r.Delete("/", s.handleDeleteChangeset)
+			r.Post("/close", s.handleCloseChangeset)
+			r.Post("/reopen", s.handleReopenChangeset)
+			r.Post("/revert", s.handleRevertChangeset)
+			r.Get("/approvals", s.handleListChangesetApprovals)
+			r.With(requireRole(versource.RoleMerger)).Post("/approvals", s.handleApproveChangeset)
 			r.Get("/components", s.handleListComponents)
 			r.Post("/components", s.handleCreateComponent)
 			r.Get("/components/changes", s.handleListComponentChanges)
+			r.Get("/components/conflicts", s.handleListComponentConflicts)
+			r.Post("/components/sync", s.handleSyncComponents)
+			r.Get("/variable-sets", s.handleListVariableSets)
+			r.Post("/variable-sets", s.handleCreateVariableSet)
+			r.Get("/variable-sets/{variableSetName}", s.handleGetVariableSet)
+			r.Patch("/variable-sets/{variableSetName}", s.handleUpdateVariableSet)
 			r.Route("/plans", func(r chi.Router) {
 				r.Get("/", s.handleListPlans)
 				r.Route("/{planID}", func(r chi.Router) {
@@ -180,18 +278,21 @@ func (s *Server) setupRoutes() {
 				r.Patch("/", s.handleUpdateComponent)
 				r.Delete("/", s.handleDeleteComponent)
 				r.Post("/restore", s.handleRestoreComponent)
+				r.Post("/resolve", s.handleResolveComponentConflict)
+				r.Post("/revert", s.handleRevertComponentToRevision)
 				r.Post("/plans", s.handleCreatePlan)
 			})
-			r.Post("/merge", s.handleMergeChangeset)
+			r.With(requireRole(versource.RoleMerger)).Post("/merge", s.handleMergeChangeset)
 			r.Route("/merges", func(r chi.Router) {
 				r.Get("/", s.handleListMerges)
+				r.Post("/validate", s.handleValidateMerge)
 				r.Route("/{mergeID}", func(r chi.Router) {
 					r.Get("/", s.handleGetMerge)
 				})
 			})
 			r.Route("/rebases", func(r chi.Router) {
 				r.Get("/", s.handleListRebases)
-				r.Post("/", s.handleCreateRebase)
+				r.With(requireRole(versource.RoleMerger)).Post("/", s.handleCreateRebase)
 				r.Route("/{rebaseID}", func(r chi.Router) {
 					r.Get("/", s.handleGetRebase)
 				})
@@ -201,7 +302,8 @@ func (s *Server) setupRoutes() {
 }
 
 type ErrorResponse struct {
-	Message string `json:"message" yaml:"message"`
+	Code    versource.ErrorCode `json:"code,omitempty" yaml:"code,omitempty"`
+	Message string              `json:"message" yaml:"message"`
 }
 
 func returnSuccess(w http.ResponseWriter, data any) {
@@ -216,11 +318,12 @@ func returnCreated(w http.ResponseWriter, data any) {
 	returnJSON(w, data)
 }
 
-func returnBadRequest(w http.ResponseWriter, err error) {
-	log.WithError(err).Warn("Bad request error")
+func returnValidationError(w http.ResponseWriter, err error) {
+	log.WithError(err).Warn("Validation error")
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
+	w.WriteHeader(errorStatus(versource.ErrorCodeValidation))
 	returnJSON(w, ErrorResponse{
+		Code:    versource.ErrorCodeValidation,
 		Message: err.Error(),
 	})
 }
@@ -230,16 +333,41 @@ func returnInternalServerError(w http.ResponseWriter, err error) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusInternalServerError)
 	returnJSON(w, ErrorResponse{
+		Code:    versource.ErrorCodeInternal,
 		Message: err.Error(),
 	})
 }
 
 func returnError(w http.ResponseWriter, err error) {
-	if versource.IsUserError(err) {
-		returnBadRequest(w, err)
+	code := versource.ErrorCodeOf(err)
+	if code == versource.ErrorCodeInternal {
+		returnInternalServerError(w, err)
 		return
 	}
-	returnInternalServerError(w, err)
+	log.WithError(err).WithField("code", code).Warn("User error")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errorStatus(code))
+	returnJSON(w, ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	})
+}
+
+func errorStatus(code versource.ErrorCode) int {
+	switch code {
+	case versource.ErrorCodeNotFound:
+		return http.StatusNotFound
+	case versource.ErrorCodeConflict:
+		return http.StatusConflict
+	case versource.ErrorCodeValidation:
+		return http.StatusUnprocessableEntity
+	case versource.ErrorCodeForbidden:
+		return http.StatusForbidden
+	case versource.ErrorCodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 func returnJSON(w http.ResponseWriter, data any) {
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (s *Server) handleListTeams(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.facade.ListTeams(r.Context(), versource.ListTeamsRequest{})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleCreateTeam(w http.ResponseWriter, r *http.Request) {
+	var req versource.CreateTeamRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	resp, err := s.facade.CreateTeam(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnCreated(w, resp)
+}
+
+func (s *Server) handleAddTeamMember(w http.ResponseWriter, r *http.Request) {
+	teamName := chi.URLParam(r, "teamName")
+	if teamName == "" {
+		returnValidationError(w, fmt.Errorf("team name is required"))
+		return
+	}
+
+	req := versource.AddTeamMemberRequest{
+		TeamName: teamName,
+		User:     chi.URLParam(r, "user"),
+	}
+
+	resp, err := s.facade.AddTeamMember(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleRemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	teamName := chi.URLParam(r, "teamName")
+	if teamName == "" {
+		returnValidationError(w, fmt.Errorf("team name is required"))
+		return
+	}
+
+	req := versource.RemoveTeamMemberRequest{
+		TeamName: teamName,
+		User:     chi.URLParam(r, "user"),
+	}
+
+	resp, err := s.facade.RemoveTeamMember(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (s *Server) handleListVariableSets(w http.ResponseWriter, r *http.Request) {
+	req := versource.ListVariableSetsRequest{}
+
+	if changesetName := chi.URLParam(r, "changesetName"); changesetName != "" {
+		req.ChangesetName = &changesetName
+	}
+
+	resp, err := s.facade.ListVariableSets(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleGetVariableSet(w http.ResponseWriter, r *http.Request) {
+	req := versource.GetVariableSetRequest{
+		Name: chi.URLParam(r, "variableSetName"),
+	}
+
+	if changesetName := chi.URLParam(r, "changesetName"); changesetName != "" {
+		req.ChangesetName = &changesetName
+	}
+
+	resp, err := s.facade.GetVariableSet(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleCreateVariableSet(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	var req versource.CreateVariableSetRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	req.ChangesetName = changesetName
+
+	resp, err := s.facade.CreateVariableSet(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnCreated(w, resp)
+}
+
+func (s *Server) handleUpdateVariableSet(w http.ResponseWriter, r *http.Request) {
+	changesetName := chi.URLParam(r, "changesetName")
+	if changesetName == "" {
+		returnValidationError(w, fmt.Errorf("changeset name is required"))
+		return
+	}
+
+	var req versource.UpdateVariableSetRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	req.ChangesetName = changesetName
+	req.Name = chi.URLParam(r, "variableSetName")
+
+	resp, err := s.facade.UpdateVariableSet(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
@@ -14,7 +14,7 @@ func (s *Server) handleGetViewResource(w http.ResponseWriter, r *http.Request) {
 	viewResourceIDStr := chi.URLParam(r, "viewResourceID")
 	viewResourceID, err := strconv.ParseUint(viewResourceIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid view resource ID"))
+		returnValidationError(w, fmt.Errorf("invalid view resource ID"))
 		return
 	}
 
@@ -32,7 +32,13 @@ func (s *Server) handleGetViewResource(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleListViewResources(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.facade.ListViewResources(r.Context(), versource.ListViewResourcesRequest{})
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	resp, err := s.facade.ListViewResources(r.Context(), versource.ListViewResourcesRequest{PageRequest: page})
 	if err != nil {
 		returnError(w, err)
 		return
@@ -45,7 +51,7 @@ func (s *Server) handleSaveViewResource(w http.ResponseWriter, r *http.Request)
 	var req versource.SaveViewResourceRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid request body"))
+		returnValidationError(w, fmt.Errorf("invalid request body"))
 		return
 	}
 
@@ -62,7 +68,7 @@ func (s *Server) handleDeleteViewResource(w http.ResponseWriter, r *http.Request
 	viewResourceIDStr := chi.URLParam(r, "viewResourceID")
 	viewResourceID, err := strconv.ParseUint(viewResourceIDStr, 10, 32)
 	if err != nil {
-		returnBadRequest(w, fmt.Errorf("invalid view resource ID"))
+		returnValidationError(w, fmt.Errorf("invalid view resource ID"))
 		return
 	}
 
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func (s *Server) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.facade.ListWebhooks(r.Context(), versource.ListWebhooksRequest{})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req versource.CreateWebhookRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid request body"))
+		return
+	}
+
+	resp, err := s.facade.CreateWebhook(r.Context(), req)
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnCreated(w, resp)
+}
+
+func (s *Server) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookName := chi.URLParam(r, "webhookName")
+	if webhookName == "" {
+		returnValidationError(w, fmt.Errorf("webhook name is required"))
+		return
+	}
+
+	resp, err := s.facade.DeleteWebhook(r.Context(), versource.DeleteWebhookRequest{Name: webhookName})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookName := chi.URLParam(r, "webhookName")
+	if webhookName == "" {
+		returnValidationError(w, fmt.Errorf("webhook name is required"))
+		return
+	}
+
+	page, err := parsePageRequest(r)
+	if err != nil {
+		returnValidationError(w, err)
+		return
+	}
+
+	resp, err := s.facade.ListWebhookDeliveries(r.Context(), versource.ListWebhookDeliveriesRequest{PageRequest: page, WebhookName: webhookName})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleGetWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookName := chi.URLParam(r, "webhookName")
+	deliveryIDStr := chi.URLParam(r, "deliveryID")
+
+	deliveryID, err := strconv.ParseUint(deliveryIDStr, 10, 32)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid delivery ID: %s", deliveryIDStr))
+		return
+	}
+
+	resp, err := s.facade.GetWebhookDelivery(r.Context(), versource.GetWebhookDeliveryRequest{
+		WebhookName: webhookName,
+		DeliveryID:  uint(deliveryID),
+	})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnSuccess(w, resp)
+}
+
+func (s *Server) handleRedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookName := chi.URLParam(r, "webhookName")
+	deliveryIDStr := chi.URLParam(r, "deliveryID")
+
+	deliveryID, err := strconv.ParseUint(deliveryIDStr, 10, 32)
+	if err != nil {
+		returnValidationError(w, fmt.Errorf("invalid delivery ID: %s", deliveryIDStr))
+		return
+	}
+
+	resp, err := s.facade.RedeliverWebhookDelivery(r.Context(), versource.RedeliverWebhookDeliveryRequest{
+		WebhookName: webhookName,
+		DeliveryID:  uint(deliveryID),
+	})
+	if err != nil {
+		returnError(w, err)
+		return
+	}
+
+	returnCreated(w, resp)
+}
@@ -2,6 +2,7 @@ package tfjsonnet
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -87,9 +88,28 @@ func (e Executor) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = writeMovedBlocks(terraformDir, e.component.Moves)
+	if err != nil {
+		return err
+	}
 	return e.delegate.Init(ctx)
 }
 
+func writeMovedBlocks(terraformDir string, moves []versource.ResourceMove) error {
+	if len(moves) == 0 {
+		return nil
+	}
+	jsonData, err := json.MarshalIndent(map[string]any{"moved": moves}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal moved blocks: %w", err)
+	}
+	err = os.WriteFile(filepath.Join(terraformDir, "moved.tf.json"), jsonData, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write moved blocks: %w", err)
+	}
+	return nil
+}
+
 func (e Executor) Plan(ctx context.Context) (internal.PlanPath, internal.PlanResourceCounts, error) {
 	return e.delegate.Plan(ctx)
 }
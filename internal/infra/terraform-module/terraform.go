@@ -86,6 +86,13 @@ func newTerraformStackFromComponent(component *versource.Component, workDir stri
 			},
 		})
 
+	for _, move := range component.Moves {
+		terraformStack = terraformStack.AddMoved(TerraformMoved{
+			From: move.From,
+			To:   move.To,
+		})
+	}
+
 	return terraformStack, nil
 }
 
@@ -178,6 +185,22 @@ func (tc TerraformStack) AddOutput(name string, output TerraformOutput) Terrafor
 	return append(tc, container)
 }
 
+type TerraformMoved struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type TerraformMovedContainer struct {
+	Moved []TerraformMoved `json:"moved"`
+}
+
+func (tc TerraformStack) AddMoved(moved TerraformMoved) TerraformStack {
+	container := TerraformMovedContainer{
+		Moved: []TerraformMoved{moved},
+	}
+	return append(tc, container)
+}
+
 type TerraformTerraformContainer struct {
 	Terraform TerraformBackendContainer `json:"terraform"`
 }
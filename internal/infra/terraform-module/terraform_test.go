@@ -156,6 +156,35 @@ func TestTerraformStack_AddBackend(t *testing.T) {
 	}
 }
 
+func TestTerraformStack_AddMoved(t *testing.T) {
+	stack := NewTerraformStack()
+
+	stack = stack.AddMoved(TerraformMoved{
+		From: "module.component.local_file.old",
+		To:   "module.component.local_file.new",
+	})
+
+	jsonData, err := json.MarshalIndent(stack, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+
+	expected := `[
+  {
+    "moved": [
+      {
+        "from": "module.component.local_file.old",
+        "to": "module.component.local_file.new"
+      }
+    ]
+  }
+]`
+
+	if string(jsonData) != expected {
+		t.Errorf("Expected JSON:\n%s\n\nGot JSON:\n%s", expected, string(jsonData))
+	}
+}
+
 func TestTerraformStack_JSONMarshalingWithBackend(t *testing.T) {
 	stack := NewTerraformStack()
 
@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type InventoryRepo interface {
+	GetInventory(ctx context.Context) (*versource.Inventory, error)
+	IsInventoryEmpty(ctx context.Context) (bool, error)
+	InsertInventory(ctx context.Context, inventory *versource.Inventory) error
+}
+
+type StateStore interface {
+	LoadState(ctx context.Context, componentID uint) ([]byte, error)
+	StoreState(ctx context.Context, componentID uint, content []byte) error
+}
+
+type ExportInventory struct {
+	inventoryRepo InventoryRepo
+	teamRepo      TeamRepo
+	stateStore    StateStore
+	tx            TransactionManager
+}
+
+func NewExportInventory(inventoryRepo InventoryRepo, teamRepo TeamRepo, stateStore StateStore, tx TransactionManager) *ExportInventory {
+	return &ExportInventory{
+		inventoryRepo: inventoryRepo,
+		teamRepo:      teamRepo,
+		stateStore:    stateStore,
+		tx:            tx,
+	}
+}
+
+func (e *ExportInventory) Exec(ctx context.Context, req versource.ExportInventoryRequest) (*versource.ExportInventoryResponse, error) {
+	var inventory *versource.Inventory
+	err := e.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+		var err error
+		inventory, err = e.inventoryRepo.GetInventory(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to export inventory", err)
+	}
+
+	err = e.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		inventory.Teams, err = e.teamRepo.ListTeams(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to export teams", err)
+	}
+
+	inventory.FormatVersion = versource.InventoryFormatVersion
+	for _, component := range inventory.Components {
+		if len(component.Secrets) == 0 {
+			continue
+		}
+		inventory.Secrets = append(inventory.Secrets, versource.ComponentSecrets{
+			ComponentID: component.ID,
+			Ciphertexts: map[string]string(component.Secrets),
+		})
+	}
+	inventory.TerraformStates = make([]versource.TerraformState, 0, len(inventory.States))
+	for _, state := range inventory.States {
+		content, err := e.stateStore.LoadState(ctx, state.ComponentID)
+		if err != nil {
+			return nil, versource.InternalErrE("failed to load terraform state", err)
+		}
+		if content == nil {
+			continue
+		}
+		inventory.TerraformStates = append(inventory.TerraformStates, versource.TerraformState{
+			ComponentID: state.ComponentID,
+			Content:     content,
+		})
+	}
+
+	return &versource.ExportInventoryResponse{
+		Inventory: *inventory,
+	}, nil
+}
+
+type ImportInventory struct {
+	inventoryRepo    InventoryRepo
+	teamRepo         TeamRepo
+	viewResourceRepo ViewResourceRepo
+	queryParser      ViewQueryParser
+	stateStore       StateStore
+	secretCipher     *SecretCipher
+	tx               TransactionManager
+}
+
+func NewImportInventory(inventoryRepo InventoryRepo, teamRepo TeamRepo, viewResourceRepo ViewResourceRepo, queryParser ViewQueryParser, stateStore StateStore, secretCipher *SecretCipher, tx TransactionManager) *ImportInventory {
+	return &ImportInventory{
+		inventoryRepo:    inventoryRepo,
+		teamRepo:         teamRepo,
+		viewResourceRepo: viewResourceRepo,
+		queryParser:      queryParser,
+		stateStore:       stateStore,
+		secretCipher:     secretCipher,
+		tx:               tx,
+	}
+}
+
+func (i *ImportInventory) Exec(ctx context.Context, req versource.ImportInventoryRequest) (*versource.ImportInventoryResponse, error) {
+	inventory := req.Inventory
+	if inventory.FormatVersion != versource.InventoryFormatVersion {
+		return nil, versource.UserErrf("unsupported inventory format version %d", inventory.FormatVersion)
+	}
+
+	ciphertexts := make(map[uint]map[string]string, len(inventory.Secrets))
+	for _, secrets := range inventory.Secrets {
+		ciphertexts[secrets.ComponentID] = secrets.Ciphertexts
+	}
+
+	componentIDs := make(map[uint]bool, len(inventory.Components))
+	var lostSecrets []string
+	for index, component := range inventory.Components {
+		componentIDs[component.ID] = true
+		secrets, err := i.importSecrets(component, ciphertexts[component.ID])
+		if err != nil {
+			return nil, err
+		}
+		if secrets == nil && len(component.Secrets) > 0 {
+			lostSecrets = append(lostSecrets, component.Name)
+			continue
+		}
+		inventory.Components[index].Secrets = secrets
+	}
+	if len(lostSecrets) > 0 {
+		return nil, versource.UserErrf("components %s have masked secrets without ciphertexts and would lose them", strings.Join(lostSecrets, ", "))
+	}
+	for _, secrets := range inventory.Secrets {
+		if !componentIDs[secrets.ComponentID] {
+			return nil, versource.UserErrf("secrets reference unknown component %d", secrets.ComponentID)
+		}
+	}
+	for _, terraformState := range inventory.TerraformStates {
+		if !componentIDs[terraformState.ComponentID] {
+			return nil, versource.UserErrf("terraform state references unknown component %d", terraformState.ComponentID)
+		}
+	}
+
+	for _, viewResource := range inventory.ViewResources {
+		parsed, err := i.queryParser.Parse(viewResource.Query)
+		if err != nil {
+			return nil, versource.UserErrE(fmt.Sprintf("invalid query for view resource %s", viewResource.Name), err)
+		}
+		if parsed.Name != viewResource.Name {
+			return nil, versource.UserErrf("view resource %s does not match its query", viewResource.Name)
+		}
+	}
+
+	teamNames := make(map[string]bool, len(inventory.Teams))
+	for _, team := range inventory.Teams {
+		if team.Name == "" {
+			return nil, versource.UserErr("team name is required")
+		}
+		if teamNames[team.Name] {
+			return nil, versource.UserErrf("team %s is listed more than once", team.Name)
+		}
+		teamNames[team.Name] = true
+	}
+
+	err := i.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		for _, team := range inventory.Teams {
+			exists, err := i.teamRepo.HasTeamWithName(ctx, team.Name)
+			if err != nil {
+				return versource.InternalErrE("failed to check team existence", err)
+			}
+			if exists {
+				return versource.ConflictErrf("team %s already exists", team.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import inventory: %w", err)
+	}
+
+	err = i.tx.Do(ctx, MainBranch, "import inventory", func(ctx context.Context) error {
+		empty, err := i.inventoryRepo.IsInventoryEmpty(ctx)
+		if err != nil {
+			return versource.InternalErrE("failed to check inventory", err)
+		}
+		if !empty {
+			return versource.PreconditionFailedErr("inventory can only be imported into an empty instance")
+		}
+
+		err = i.inventoryRepo.InsertInventory(ctx, &inventory)
+		if err != nil {
+			return versource.InternalErrE("failed to insert inventory", err)
+		}
+
+		for _, viewResource := range inventory.ViewResources {
+			err = i.viewResourceRepo.SaveDatabaseView(ctx, viewResource.Name, viewResource.Query)
+			if err != nil {
+				return versource.InternalErrE("failed to save database view", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import inventory: %w", err)
+	}
+
+	if len(inventory.Teams) > 0 {
+		err = i.tx.Do(ctx, AdminBranch, "import teams", func(ctx context.Context) error {
+			for _, team := range inventory.Teams {
+				team.ID = 0
+				members := make([]versource.TeamMember, 0, len(team.Members))
+				for _, member := range team.Members {
+					members = append(members, versource.TeamMember{User: member.User})
+				}
+				team.Members = members
+				err := i.teamRepo.CreateTeam(ctx, &team)
+				if err != nil {
+					return versource.InternalErrE("failed to create team", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to import teams: %w", err)
+		}
+	}
+
+	for _, terraformState := range inventory.TerraformStates {
+		err = i.stateStore.StoreState(ctx, terraformState.ComponentID, terraformState.Content)
+		if err != nil {
+			return nil, versource.InternalErrE("failed to store terraform state", err)
+		}
+	}
+
+	return &versource.ImportInventoryResponse{
+		Modules:        len(inventory.Modules),
+		ModuleVersions: len(inventory.ModuleVersions),
+		Components:     len(inventory.Components),
+		States:         len(inventory.States),
+		ViewResources:  len(inventory.ViewResources),
+		Teams:          len(inventory.Teams),
+	}, nil
+}
+
+func (i *ImportInventory) importSecrets(component versource.Component, ciphertexts map[string]string) (versource.SecretVariables, error) {
+	if ciphertexts == nil && !component.Secrets.IsMasked() {
+		ciphertexts = component.Secrets
+	}
+	for name := range component.Secrets {
+		if _, ok := ciphertexts[name]; !ok {
+			return nil, nil
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(ciphertexts)) {
+		_, err := i.secretCipher.Decrypt(ciphertexts[name])
+		if errors.Is(err, ErrNoSecretKey) {
+			return nil, versource.PreconditionFailedErr("importing secret variables requires a configured secrets key")
+		}
+		if err != nil {
+			return nil, versource.UserErrE(fmt.Sprintf("secret %s of component %s cannot be decrypted with the configured key", name, component.Name), err)
+		}
+	}
+	return ciphertexts, nil
+}
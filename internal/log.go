@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+var logFollowInterval = time.Second
+
+type followLogReader struct {
+	ctx       context.Context
+	open      func(ctx context.Context) (io.ReadCloser, error)
+	completed func(ctx context.Context) (bool, error)
+	interval  time.Duration
+
+	reader   io.ReadCloser
+	finished bool
+}
+
+func newFollowLogReader(ctx context.Context, reader io.ReadCloser, open func(ctx context.Context) (io.ReadCloser, error), completed func(ctx context.Context) (bool, error)) *followLogReader {
+	return &followLogReader{
+		ctx:       ctx,
+		open:      open,
+		completed: completed,
+		interval:  logFollowInterval,
+		reader:    reader,
+	}
+}
+
+func (r *followLogReader) Read(p []byte) (int, error) {
+	for {
+		if r.reader != nil {
+			n, err := r.reader.Read(p)
+			if n > 0 {
+				return n, nil
+			}
+			if !errors.Is(err, io.EOF) {
+				return 0, err
+			}
+		}
+		if r.finished {
+			return 0, io.EOF
+		}
+
+		completed, err := r.completed(r.ctx)
+		if err != nil {
+			return 0, err
+		}
+		if r.reader == nil {
+			reader, err := r.open(r.ctx)
+			if err == nil {
+				r.reader = reader
+			}
+		}
+		if completed {
+			r.finished = true
+			continue
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+func (r *followLogReader) Close() error {
+	if r.reader == nil {
+		return nil
+	}
+	return r.reader.Close()
+}
+
+func isTaskFinished(state versource.TaskState) bool {
+	return versource.IsTaskCompleted(state) || state == versource.TaskStateAborted
+}
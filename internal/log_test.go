@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+type growingLog struct {
+	data []byte
+	pos  int
+}
+
+func (l *growingLog) Read(p []byte) (int, error) {
+	if l.pos >= len(l.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, l.data[l.pos:])
+	l.pos += n
+	return n, nil
+}
+
+func (l *growingLog) Close() error {
+	return nil
+}
+
+func TestFollowLogReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  string
+		appended []string
+		opened   bool
+		expected string
+	}{
+		{
+			name:     "completed task",
+			initial:  "done\n",
+			opened:   true,
+			expected: "done\n",
+		},
+		{
+			name:     "lines written while following",
+			initial:  "line 1\n",
+			appended: []string{"line 2\n", "line 3\n"},
+			opened:   true,
+			expected: "line 1\nline 2\nline 3\n",
+		},
+		{
+			name:     "log created while following",
+			appended: []string{"line 1\n"},
+			expected: "line 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &growingLog{data: []byte(tt.initial)}
+			created := tt.opened
+			calls := 0
+			open := func(ctx context.Context) (io.ReadCloser, error) {
+				if !created {
+					return nil, errors.New("log not found")
+				}
+				return log, nil
+			}
+			completed := func(ctx context.Context) (bool, error) {
+				if calls < len(tt.appended) {
+					log.data = append(log.data, tt.appended[calls]...)
+					created = true
+				}
+				calls++
+				return calls > len(tt.appended), nil
+			}
+
+			var initial io.ReadCloser
+			if tt.opened {
+				initial = log
+			}
+			reader := newFollowLogReader(context.Background(), initial, open, completed)
+			reader.interval = time.Millisecond
+
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(content) != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, string(content))
+			}
+		})
+	}
+}
+
+func TestFollowLogReaderCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	completed := func(ctx context.Context) (bool, error) {
+		return false, nil
+	}
+	open := func(ctx context.Context) (io.ReadCloser, error) {
+		return &growingLog{}, nil
+	}
+	reader := newFollowLogReader(ctx, nil, open, completed)
+	reader.interval = time.Hour
+
+	_, err := io.ReadAll(reader)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled, got %v", err)
+	}
+}
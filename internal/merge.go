@@ -2,7 +2,9 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/marcbran/versource/pkg/versource"
@@ -13,10 +15,37 @@ type MergeRepo interface {
 	GetMerge(ctx context.Context, mergeID uint) (*versource.Merge, error)
 	GetQueuedMerges(ctx context.Context) ([]uint, error)
 	GetQueuedMergesByChangeset(ctx context.Context, changesetID uint) ([]uint, error)
-	ListMerges(ctx context.Context) ([]versource.Merge, error)
+	ListMerges(ctx context.Context, filter MergeFilter, page PageQuery) ([]versource.Merge, error)
 	ListMergesByChangesetName(ctx context.Context, changesetName string) ([]versource.Merge, error)
+	ListMergeQueue(ctx context.Context) ([]versource.Merge, error)
 	CreateMerge(ctx context.Context, merge *versource.Merge) error
 	UpdateMergeState(ctx context.Context, mergeID uint, state versource.TaskState) error
+	UpdateMergePhase(ctx context.Context, mergeID uint, phase versource.MergePhase) error
+	UpdateMergeRevision(ctx context.Context, mergeID uint, mergeBase, head string) error
+	UpdateMergeFindings(ctx context.Context, mergeID uint, findings []versource.MergeFinding) error
+}
+
+type MergeFilter struct {
+	ChangesetName *string
+}
+
+var mergePageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":    "id",
+		"state": "state",
+	},
+	DefaultSort: "-id",
+}
+
+func mergePageKey(merge versource.Merge, field string) (string, string) {
+	id := pageID(merge.ID)
+	switch field {
+	case "state":
+		return string(merge.State), id
+	default:
+		return id, id
+	}
 }
 
 type GetMerge struct {
@@ -47,7 +76,7 @@ func (g *GetMerge) Exec(ctx context.Context, req versource.GetMergeRequest) (*ve
 	}
 
 	if merge == nil {
-		return nil, versource.UserErr("merge not found")
+		return nil, versource.NotFoundErr("merge not found")
 	}
 
 	return &versource.GetMergeResponse{
@@ -68,38 +97,89 @@ func NewListMerges(mergeRepo MergeRepo, tx TransactionManager) *ListMerges {
 }
 
 func (l *ListMerges) Exec(ctx context.Context, req versource.ListMergesRequest) (*versource.ListMergesResponse, error) {
+	page, err := NewPageQuery(req.PageRequest, mergePageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := MergeFilter{}
+	if req.ChangesetName != "" {
+		filter.ChangesetName = &req.ChangesetName
+	}
+
 	var merges []versource.Merge
-	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+	err = l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
 		var err error
-		if req.ChangesetName == "" {
-			merges, err = l.mergeRepo.ListMerges(ctx)
-		} else {
-			merges, err = l.mergeRepo.ListMergesByChangesetName(ctx, req.ChangesetName)
-		}
+		merges, err = l.mergeRepo.ListMerges(ctx, filter, page)
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list merges", err)
 	}
 
+	merges, nextCursor := nextPage(merges, page, mergePageKey)
+
 	return &versource.ListMergesResponse{
-		Merges: merges,
+		Merges:     merges,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+type ListMergeQueue struct {
+	mergeRepo MergeRepo
+	tx        TransactionManager
+}
+
+func NewListMergeQueue(mergeRepo MergeRepo, tx TransactionManager) *ListMergeQueue {
+	return &ListMergeQueue{
+		mergeRepo: mergeRepo,
+		tx:        tx,
+	}
+}
+
+func (l *ListMergeQueue) Exec(ctx context.Context, req versource.ListMergeQueueRequest) (*versource.ListMergeQueueResponse, error) {
+	var merges []versource.Merge
+	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		merges, err = l.mergeRepo.ListMergeQueue(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list merge queue", err)
+	}
+
+	entries := make([]versource.MergeQueueEntry, len(merges))
+	for i, merge := range merges {
+		entries[i] = versource.MergeQueueEntry{
+			Position: i + 1,
+			Merge:    merge,
+		}
+	}
+
+	return &versource.ListMergeQueueResponse{
+		Entries: entries,
 	}, nil
 }
 
 type CreateMerge struct {
-	changesetRepo ChangesetRepo
-	mergeRepo     MergeRepo
-	tx            TransactionManager
-	mergeWorker   *MergeWorker
+	changesetRepo         ChangesetRepo
+	mergeRepo             MergeRepo
+	teamRepo              TeamRepo
+	changesetApprovalRepo ChangesetApprovalRepo
+	listComponentChanges  *ListComponentChanges
+	tx                    TransactionManager
+	mergeWorker           *MergeWorker
 }
 
-func NewCreateMerge(changesetRepo ChangesetRepo, mergeRepo MergeRepo, tx TransactionManager, mergeWorker *MergeWorker) *CreateMerge {
+func NewCreateMerge(changesetRepo ChangesetRepo, mergeRepo MergeRepo, teamRepo TeamRepo, changesetApprovalRepo ChangesetApprovalRepo, listComponentChanges *ListComponentChanges, tx TransactionManager, mergeWorker *MergeWorker) *CreateMerge {
 	return &CreateMerge{
-		changesetRepo: changesetRepo,
-		mergeRepo:     mergeRepo,
-		tx:            tx,
-		mergeWorker:   mergeWorker,
+		changesetRepo:         changesetRepo,
+		mergeRepo:             mergeRepo,
+		teamRepo:              teamRepo,
+		changesetApprovalRepo: changesetApprovalRepo,
+		listComponentChanges:  listComponentChanges,
+		tx:                    tx,
+		mergeWorker:           mergeWorker,
 	}
 }
 
@@ -129,11 +209,41 @@ func (c *CreateMerge) Exec(ctx context.Context, req versource.CreateMergeRequest
 		return nil, err
 	}
 
+	changesResp, err := c.listComponentChanges.listAll(ctx, versource.ListComponentChangesRequest{ChangesetName: req.ChangesetName})
+	if err != nil {
+		return nil, err
+	}
+
 	var response *versource.CreateMergeResponse
-	err = c.tx.Do(ctx, AdminBranch, "create merge", func(ctx context.Context) error {
+	err = c.tx.Do(ctx, AdminBranch, fmt.Sprintf("create merge for changeset %s", req.ChangesetName), func(ctx context.Context) error {
 		changeset, err := c.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
 		if err != nil {
-			return versource.UserErrE("changeset not found", err)
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
+		}
+		if changeset.State == versource.ChangesetStateClosed {
+			return versource.PreconditionFailedErr("cannot merge changeset: changeset is closed")
+		}
+		findings, err := mergePreconditionFindings(ctx, c.changesetRepo, c.changesetApprovalRepo, c.teamRepo, changeset, head, changesResp.Changes)
+		if err != nil {
+			return versource.InternalErrE("failed to check merge preconditions", err)
+		}
+		if len(findings) > 0 {
+			messages := make([]string, 0, len(findings))
+			for _, finding := range findings {
+				messages = append(messages, finding.Message)
+			}
+			return versource.PreconditionFailedErrf("cannot merge changeset: %s", strings.Join(messages, "; "))
+		}
+
+		queuedMerges, err := c.mergeRepo.GetQueuedMergesByChangeset(ctx, changeset.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to get queued merges", err)
+		}
+		if len(queuedMerges) > 0 {
+			return versource.ConflictErr("changeset is already queued for merge")
 		}
 
 		merge := &versource.Merge{
@@ -141,6 +251,7 @@ func (c *CreateMerge) Exec(ctx context.Context, req versource.CreateMergeRequest
 			Changeset:   *changeset,
 			MergeBase:   mergeBase,
 			Head:        head,
+			RequestedBy: versource.UserFromContext(ctx),
 		}
 
 		err = c.mergeRepo.CreateMerge(ctx, merge)
@@ -165,19 +276,121 @@ func (c *CreateMerge) Exec(ctx context.Context, req versource.CreateMergeRequest
 	return response, nil
 }
 
+type ValidateMerge struct {
+	changesetRepo         ChangesetRepo
+	changesetApprovalRepo ChangesetApprovalRepo
+	teamRepo              TeamRepo
+	componentChangeRepo   ComponentChangeRepo
+	listComponentChanges  *ListComponentChanges
+	tx                    TransactionManager
+}
+
+func NewValidateMerge(changesetRepo ChangesetRepo, changesetApprovalRepo ChangesetApprovalRepo, teamRepo TeamRepo, componentChangeRepo ComponentChangeRepo, listComponentChanges *ListComponentChanges, tx TransactionManager) *ValidateMerge {
+	return &ValidateMerge{
+		changesetRepo:         changesetRepo,
+		changesetApprovalRepo: changesetApprovalRepo,
+		teamRepo:              teamRepo,
+		componentChangeRepo:   componentChangeRepo,
+		listComponentChanges:  listComponentChanges,
+		tx:                    tx,
+	}
+}
+
+func (v *ValidateMerge) Exec(ctx context.Context, req versource.ValidateMergeRequest) (*versource.ValidateMergeResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset name is required")
+	}
+
+	var changeset *versource.Changeset
+	err := v.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		changeset, err = v.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if changeset == nil {
+		return nil, versource.NotFoundErr("changeset not found")
+	}
+	if changeset.State == versource.ChangesetStateClosed {
+		return nil, versource.PreconditionFailedErr("cannot validate merge: changeset is closed")
+	}
+
+	var findings []versource.MergeFinding
+	var head string
+	var changes []versource.ComponentChange
+	err = v.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
+		mergeBase, err := v.tx.GetMergeBase(ctx, MainBranch, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get merge base", err)
+		}
+
+		head, err = v.tx.GetHead(ctx)
+		if err != nil {
+			return versource.InternalErrE("failed to get head", err)
+		}
+
+		changesResp, err := v.listComponentChanges.listAll(ctx, versource.ListComponentChangesRequest{
+			ChangesetName: req.ChangesetName,
+		})
+		if err != nil {
+			return err
+		}
+
+		merge := &versource.Merge{
+			ChangesetID: changeset.ID,
+			Changeset:   *changeset,
+			MergeBase:   mergeBase,
+			Head:        head,
+		}
+		changes = changesResp.Changes
+		findings, err = validateMerge(ctx, v.tx, v.componentChangeRepo, merge, changes)
+		if err != nil {
+			return versource.InternalErrE("failed to validate merge", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		preconditionFindings, err := mergePreconditionFindings(ctx, v.changesetRepo, v.changesetApprovalRepo, v.teamRepo, changeset, head, changes)
+		if err != nil {
+			return versource.InternalErrE("failed to check merge preconditions", err)
+		}
+		findings = append(preconditionFindings, findings...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &versource.ValidateMergeResponse{
+		Valid:    len(findings) == 0,
+		Findings: findings,
+	}, nil
+}
+
 type MergeWorker struct {
-	runMerge  *RunMerge
-	mergeRepo MergeRepo
-	tx        TransactionManager
-	mergeChan chan uint
+	runMerge             *RunMerge
+	mergeRepo            MergeRepo
+	tx                   TransactionManager
+	staleChangesetWorker *StaleChangesetWorker
+	mergeChan            chan uint
 }
 
-func NewMergeWorker(runMerge *RunMerge, mergeRepo MergeRepo, tx TransactionManager) *MergeWorker {
+func NewMergeWorker(runMerge *RunMerge, mergeRepo MergeRepo, tx TransactionManager, staleChangesetWorker *StaleChangesetWorker) *MergeWorker {
 	return &MergeWorker{
-		runMerge:  runMerge,
-		mergeRepo: mergeRepo,
-		tx:        tx,
-		mergeChan: make(chan uint, 100),
+		runMerge:             runMerge,
+		mergeRepo:            mergeRepo,
+		tx:                   tx,
+		staleChangesetWorker: staleChangesetWorker,
+		mergeChan:            make(chan uint, 100),
 	}
 }
 
@@ -202,26 +415,28 @@ func (mw *MergeWorker) processMerges(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case mergeID := <-mw.mergeChan:
-			mw.runMergeInBackground(ctx, mergeID)
+		case <-mw.mergeChan:
+			mw.processQueuedMerges(ctx)
 		case <-ticker.C:
 			mw.processQueuedMerges(ctx)
 		}
 	}
 }
 
-func (mw *MergeWorker) runMergeInBackground(ctx context.Context, mergeID uint) {
-	go func() {
-		workerCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
-		defer cancel()
+func (mw *MergeWorker) runQueuedMerge(ctx context.Context, mergeID uint) {
+	workerCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
 
-		err := mw.runMerge.Exec(workerCtx, mergeID)
-		if err != nil {
-			log.WithError(err).WithField("merge_id", mergeID).Error("Failed to run merge")
-		} else {
-			log.WithField("merge_id", mergeID).Info("Merge completed")
-		}
-	}()
+	err := mw.runMerge.Exec(workerCtx, mergeID)
+	if err != nil {
+		log.WithError(err).WithField("merge_id", mergeID).Error("Failed to run merge")
+	} else {
+		log.WithField("merge_id", mergeID).Info("Merge completed")
+	}
+
+	if mw.staleChangesetWorker != nil {
+		mw.staleChangesetWorker.QueueDetection()
+	}
 }
 
 func (mw *MergeWorker) processQueuedMerges(ctx context.Context) {
@@ -237,44 +452,57 @@ func (mw *MergeWorker) processQueuedMerges(ctx context.Context) {
 	}
 
 	for _, mergeID := range mergeIDs {
-		mw.runMergeInBackground(ctx, mergeID)
+		if ctx.Err() != nil {
+			return
+		}
+		mw.runQueuedMerge(ctx, mergeID)
 	}
 }
 
 type RunMerge struct {
-	config               *versource.Config
-	mergeRepo            MergeRepo
-	changesetRepo        ChangesetRepo
-	planRepo             PlanRepo
-	planStore            PlanStore
-	logStore             LogStore
-	tx                   TransactionManager
-	listComponentChanges *ListComponentChanges
-	componentChangeRepo  ComponentChangeRepo
-	applyRepo            ApplyRepo
-	applyWorker          *ApplyWorker
+	config                *versource.Config
+	mergeRepo             MergeRepo
+	changesetRepo         ChangesetRepo
+	changesetApprovalRepo ChangesetApprovalRepo
+	teamRepo              TeamRepo
+	rebaseRepo            RebaseRepo
+	planRepo              PlanRepo
+	planStore             PlanStore
+	logStore              LogStore
+	tx                    TransactionManager
+	listComponentChanges  *ListComponentChanges
+	componentChangeRepo   ComponentChangeRepo
+	applyRepo             ApplyRepo
+	applyWorker           *ApplyWorker
+	runRebase             *RunRebase
+	planPollInterval      time.Duration
 }
 
-func NewRunMerge(config *versource.Config, mergeRepo MergeRepo, changesetRepo ChangesetRepo, planRepo PlanRepo, planStore PlanStore, logStore LogStore, tx TransactionManager, listComponentChanges *ListComponentChanges, componentChangeRepo ComponentChangeRepo, applyRepo ApplyRepo, applyWorker *ApplyWorker) *RunMerge {
+func NewRunMerge(config *versource.Config, mergeRepo MergeRepo, changesetRepo ChangesetRepo, changesetApprovalRepo ChangesetApprovalRepo, teamRepo TeamRepo, rebaseRepo RebaseRepo, planRepo PlanRepo, planStore PlanStore, logStore LogStore, tx TransactionManager, listComponentChanges *ListComponentChanges, componentChangeRepo ComponentChangeRepo, applyRepo ApplyRepo, applyWorker *ApplyWorker, runRebase *RunRebase) *RunMerge {
 	return &RunMerge{
-		config:               config,
-		mergeRepo:            mergeRepo,
-		changesetRepo:        changesetRepo,
-		planRepo:             planRepo,
-		planStore:            planStore,
-		logStore:             logStore,
-		tx:                   tx,
-		listComponentChanges: listComponentChanges,
-		componentChangeRepo:  componentChangeRepo,
-		applyRepo:            applyRepo,
-		applyWorker:          applyWorker,
+		config:                config,
+		mergeRepo:             mergeRepo,
+		changesetRepo:         changesetRepo,
+		changesetApprovalRepo: changesetApprovalRepo,
+		teamRepo:              teamRepo,
+		rebaseRepo:            rebaseRepo,
+		planRepo:              planRepo,
+		planStore:             planStore,
+		logStore:              logStore,
+		tx:                    tx,
+		listComponentChanges:  listComponentChanges,
+		componentChangeRepo:   componentChangeRepo,
+		applyRepo:             applyRepo,
+		applyWorker:           applyWorker,
+		runRebase:             runRebase,
+		planPollInterval:      5 * time.Second,
 	}
 }
 
 func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 	var merge *versource.Merge
 
-	err := r.tx.Do(ctx, AdminBranch, "start merge", func(ctx context.Context) error {
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("start merge %d", mergeID), func(ctx context.Context) error {
 		var err error
 		merge, err = r.mergeRepo.GetMerge(ctx, mergeID)
 		if err != nil {
@@ -296,14 +524,41 @@ func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 		return err
 	}
 
+	ctx = versource.WithUser(ctx, merge.RequestedBy)
+
+	headFindings, err := r.mergeHeadFindings(ctx, merge)
+	if err != nil {
+		stateErr := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail merge %d head check", mergeID), func(ctx context.Context) error {
+			return r.mergeRepo.UpdateMergeState(ctx, mergeID, versource.TaskStateFailed)
+		})
+		if stateErr != nil {
+			return fmt.Errorf("merge head check failed: %w, and failed to update merge state: %w", err, stateErr)
+		}
+		return fmt.Errorf("merge head check failed: %w", err)
+	}
+	if len(headFindings) > 0 {
+		return r.rejectMerge(ctx, merge, headFindings)
+	}
+
+	err = r.rebaseIfBehind(ctx, merge)
+	if err != nil {
+		stateErr := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail merge %d rebase", mergeID), func(ctx context.Context) error {
+			return r.mergeRepo.UpdateMergeState(ctx, mergeID, versource.TaskStateFailed)
+		})
+		if stateErr != nil {
+			return fmt.Errorf("merge rebase failed: %w, and failed to update merge state: %w", err, stateErr)
+		}
+		return fmt.Errorf("merge rebase failed: %w", err)
+	}
+
 	log.Info("Starting merge preparation")
 
 	changesetName := merge.Changeset.Name
 	var changes []versource.ComponentChange
-	var canMerge bool
+	var findings []versource.MergeFinding
 
-	err = r.tx.Do(ctx, changesetName, "prepare merge", func(ctx context.Context) error {
-		changesResp, err := r.listComponentChanges.Exec(ctx, versource.ListComponentChangesRequest{
+	err = r.tx.Do(ctx, changesetName, fmt.Sprintf("prepare merge %d", mergeID), func(ctx context.Context) error {
+		changesResp, err := r.listComponentChanges.listAll(ctx, versource.ListComponentChangesRequest{
 			ChangesetName: changesetName,
 		})
 		if err != nil {
@@ -311,7 +566,7 @@ func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 		}
 		changes = changesResp.Changes
 
-		canMerge, err = r.validateMerge(ctx, merge, changes)
+		findings, err = validateMerge(ctx, r.tx, r.componentChangeRepo, merge, changes)
 		if err != nil {
 			return err
 		}
@@ -319,7 +574,7 @@ func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 		return nil
 	})
 	if err != nil {
-		stateErr := r.tx.Do(ctx, AdminBranch, "fail merge preparation", func(ctx context.Context) error {
+		stateErr := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail merge %d preparation", mergeID), func(ctx context.Context) error {
 			return r.mergeRepo.UpdateMergeState(ctx, mergeID, versource.TaskStateFailed)
 		})
 		if stateErr != nil {
@@ -328,37 +583,32 @@ func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 		return fmt.Errorf("merge preparation failed: %w", err)
 	}
 
-	if !canMerge {
-		log.Info("Merge validation failed, marking changeset as rejected")
-		err = r.tx.Do(ctx, AdminBranch, "reject changeset", func(ctx context.Context) error {
-			err = r.mergeRepo.UpdateMergeState(ctx, mergeID, versource.TaskStateFailed)
-			if err != nil {
-				return fmt.Errorf("failed to update merge state: %w", err)
-			}
-			err = r.changesetRepo.UpdateChangesetReviewState(ctx, merge.ChangesetID, versource.ChangesetReviewStateRejected)
-			if err != nil {
-				return fmt.Errorf("failed to update changeset review state: %w", err)
-			}
-			return nil
-		})
-		if err != nil {
-			return fmt.Errorf("failed to reject changeset: %w", err)
-		}
-		return nil
+	if len(findings) > 0 {
+		return r.rejectMerge(ctx, merge, findings)
 	}
 
 	log.Info("Merge preparation completed, starting merge operation")
 
+	err = r.updatePhase(ctx, mergeID, versource.MergePhaseMerging)
+	if err != nil {
+		return err
+	}
+
 	err = r.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
 		err = r.tx.MergeBranch(ctx, merge.Changeset.Name)
 		if err != nil {
 			return versource.InternalErrE("failed to create changeset branch", err)
 		}
+
+		tagErr := r.tx.CreateTag(ctx, MergeTag(merge.Changeset.Name), "HEAD")
+		if tagErr != nil {
+			log.WithError(tagErr).WithField("changeset", merge.Changeset.Name).Warn("Failed to tag merge")
+		}
 		return nil
 	})
 
 	var createdApplies []uint
-	err = r.tx.Do(ctx, AdminBranch, "complete merge", func(ctx context.Context) error {
+	err = r.tx.Do(ctx, AdminBranch, fmt.Sprintf("complete merge %d", mergeID), func(ctx context.Context) error {
 		for _, change := range changes {
 			if change.Plan == nil {
 				continue
@@ -372,6 +622,7 @@ func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 			apply := &versource.Apply{
 				PlanID:      change.Plan.ID,
 				ChangesetID: change.Plan.ChangesetID,
+				RequestedBy: merge.RequestedBy,
 			}
 
 			err = r.applyRepo.CreateApply(ctx, apply)
@@ -385,7 +636,7 @@ func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 
 		err = r.changesetRepo.UpdateChangesetState(ctx, merge.ChangesetID, versource.ChangesetStateMerged)
 		if err != nil {
-			stateErr := r.tx.Do(ctx, AdminBranch, "fail changeset merge", func(ctx context.Context) error {
+			stateErr := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail changeset merge %d", mergeID), func(ctx context.Context) error {
 				return r.mergeRepo.UpdateMergeState(ctx, mergeID, versource.TaskStateFailed)
 			})
 			if stateErr != nil {
@@ -404,7 +655,7 @@ func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 		return nil
 	})
 	if err != nil {
-		stateErr := r.tx.Do(ctx, AdminBranch, "fail merge", func(ctx context.Context) error {
+		stateErr := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail merge %d", mergeID), func(ctx context.Context) error {
 			return r.mergeRepo.UpdateMergeState(ctx, mergeID, versource.TaskStateFailed)
 		})
 		if stateErr != nil {
@@ -419,44 +670,385 @@ func (r *RunMerge) Exec(ctx context.Context, mergeID uint) error {
 		}
 	}
 
+	r.rebaseChildren(ctx, merge)
+
+	return nil
+}
+
+func (r *RunMerge) rejectMerge(ctx context.Context, merge *versource.Merge, findings []versource.MergeFinding) error {
+	log.WithField("findings", len(findings)).Info("Merge validation failed, marking changeset as rejected")
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("reject changeset for merge %d", merge.ID), func(ctx context.Context) error {
+		err := r.mergeRepo.UpdateMergeFindings(ctx, merge.ID, findings)
+		if err != nil {
+			return fmt.Errorf("failed to update merge findings: %w", err)
+		}
+		err = r.mergeRepo.UpdateMergeState(ctx, merge.ID, versource.TaskStateFailed)
+		if err != nil {
+			return fmt.Errorf("failed to update merge state: %w", err)
+		}
+		err = r.changesetRepo.UpdateChangesetReviewState(ctx, merge.ChangesetID, versource.ChangesetReviewStateRejected)
+		if err != nil {
+			return fmt.Errorf("failed to update changeset review state: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reject changeset: %w", err)
+	}
+	return nil
+}
+
+// mergeHeadFindings checks the changeset against the head recorded when the
+// merge was requested, before the queue rebases it and moves that head.
+func (r *RunMerge) mergeHeadFindings(ctx context.Context, merge *versource.Merge) ([]versource.MergeFinding, error) {
+	changesetName := merge.Changeset.Name
+
+	var hasCommitsAfter bool
+	err := r.tx.Checkout(ctx, changesetName, func(ctx context.Context) error {
+		var err error
+		hasCommitsAfter, err = r.tx.HasCommitsAfter(ctx, changesetName, merge.Head)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check commits after head: %w", err)
+	}
+	if hasCommitsAfter {
+		return []versource.MergeFinding{commitsAfterHeadFinding(merge.Head)}, nil
+	}
+
+	changesResp, err := r.listComponentChanges.listAll(ctx, versource.ListComponentChangesRequest{
+		ChangesetName: changesetName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component changes: %w", err)
+	}
+
+	var findings []versource.MergeFinding
+	err = r.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		changeset, err := r.changesetRepo.GetChangeset(ctx, merge.ChangesetID)
+		if err != nil {
+			return fmt.Errorf("failed to get changeset: %w", err)
+		}
+		findings, err = mergePreconditionFindings(ctx, r.changesetRepo, r.changesetApprovalRepo, r.teamRepo, changeset, merge.Head, changesResp.Changes)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check merge preconditions: %w", err)
+	}
+	return findings, nil
+}
+
+func (r *RunMerge) rebaseChildren(ctx context.Context, merge *versource.Merge) {
+	var children []versource.Changeset
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("unstack child changesets of merge %d", merge.ID), func(ctx context.Context) error {
+		var err error
+		children, err = unstackChildChangesets(ctx, r.changesetRepo, merge.ChangesetID)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).WithField("changeset", merge.Changeset.Name).Warn("Failed to unstack child changesets")
+		return
+	}
+
+	for _, child := range children {
+		err = r.rebaseChild(ctx, child)
+		if err != nil {
+			log.WithError(err).WithField("changeset", child.Name).Warn("Failed to rebase child changeset onto main")
+		}
+	}
+}
+
+func (r *RunMerge) rebaseChild(ctx context.Context, child versource.Changeset) error {
+	rebase := &versource.Rebase{
+		ChangesetID: child.ID,
+		Changeset:   child,
+	}
+
+	err := r.tx.Checkout(ctx, child.Name, func(ctx context.Context) error {
+		var err error
+		rebase.MergeBase, err = r.tx.GetMergeBase(ctx, MainBranch, child.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get merge base: %w", err)
+		}
+
+		rebase.Head, err = r.tx.GetHead(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get head: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = r.tx.Do(ctx, AdminBranch, fmt.Sprintf("create rebase for child changeset %s", child.Name), func(ctx context.Context) error {
+		return r.rebaseRepo.CreateRebase(ctx, rebase)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rebase: %w", err)
+	}
+
+	return r.runRebase.Exec(ctx, rebase.ID)
+}
+
+func (r *RunMerge) rebaseIfBehind(ctx context.Context, merge *versource.Merge) error {
+	changesetName := merge.Changeset.Name
+
+	var mergeBase string
+	var mainHead string
+	err := r.tx.Checkout(ctx, changesetName, func(ctx context.Context) error {
+		var err error
+		mergeBase, err = r.tx.GetMergeBase(ctx, MainBranch, changesetName)
+		if err != nil {
+			return fmt.Errorf("failed to get merge base: %w", err)
+		}
+
+		mainHead, err = r.tx.GetBranchHead(ctx, MainBranch)
+		if err != nil {
+			return fmt.Errorf("failed to get main head: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if mergeBase == mainHead {
+		return nil
+	}
+
+	log.WithField("merge_id", merge.ID).Info("Main has moved, rebasing changeset before merge")
+
+	err = r.updatePhase(ctx, merge.ID, versource.MergePhaseRebasing)
+	if err != nil {
+		return err
+	}
+
+	rebase := &versource.Rebase{
+		ChangesetID: merge.ChangesetID,
+		Changeset:   merge.Changeset,
+		MergeBase:   mergeBase,
+		Head:        merge.Head,
+	}
+	err = r.tx.Do(ctx, AdminBranch, fmt.Sprintf("create rebase for merge %d", merge.ID), func(ctx context.Context) error {
+		return r.rebaseRepo.CreateRebase(ctx, rebase)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rebase: %w", err)
+	}
+
+	err = r.runRebase.Exec(ctx, rebase.ID)
+	if err != nil {
+		return fmt.Errorf("failed to rebase changeset: %w", err)
+	}
+
+	err = r.updatePhase(ctx, merge.ID, versource.MergePhasePlanning)
+	if err != nil {
+		return err
+	}
+
+	err = r.waitForPlans(ctx, changesetName)
+	if err != nil {
+		return err
+	}
+
+	approvedHead := merge.Head
+	err = r.tx.Checkout(ctx, changesetName, func(ctx context.Context) error {
+		var err error
+		merge.MergeBase, err = r.tx.GetMergeBase(ctx, MainBranch, changesetName)
+		if err != nil {
+			return fmt.Errorf("failed to get merge base: %w", err)
+		}
+
+		merge.Head, err = r.tx.GetHead(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get head: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.tx.Do(ctx, AdminBranch, fmt.Sprintf("update merge %d revision", merge.ID), func(ctx context.Context) error {
+		err := r.mergeRepo.UpdateMergeRevision(ctx, merge.ID, merge.MergeBase, merge.Head)
+		if err != nil {
+			return err
+		}
+		return r.carryApprovals(ctx, merge.ChangesetID, approvedHead, merge.Head)
+	})
+}
+
+// carryApprovals moves the approvals given for the head the merge was
+// requested at onto the head the merge queue rebased it to, since the rebase
+// only replays the approved changes onto main.
+func (r *RunMerge) carryApprovals(ctx context.Context, changesetID uint, fromHead, toHead string) error {
+	approvals, err := r.changesetApprovalRepo.ListChangesetApprovals(ctx, changesetID)
+	if err != nil {
+		return fmt.Errorf("failed to list changeset approvals: %w", err)
+	}
+	for _, approval := range currentApprovals(approvals, fromHead) {
+		approval.Head = toHead
+		err = r.changesetApprovalRepo.UpdateChangesetApproval(ctx, &approval)
+		if err != nil {
+			return fmt.Errorf("failed to carry changeset approval: %w", err)
+		}
+	}
 	return nil
 }
 
-func (r *RunMerge) validateMerge(ctx context.Context, merge *versource.Merge, changes []versource.ComponentChange) (bool, error) {
+func (r *RunMerge) waitForPlans(ctx context.Context, changesetName string) error {
+	ticker := time.NewTicker(r.planPollInterval)
+	defer ticker.Stop()
+
+	for {
+		changesResp, err := r.listComponentChanges.listAll(ctx, versource.ListComponentChangesRequest{
+			ChangesetName: changesetName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list component changes: %w", err)
+		}
+
+		pending := false
+		for _, change := range changesResp.Changes {
+			if change.Plan != nil && !versource.IsTaskCompleted(change.Plan.State) {
+				pending = true
+				break
+			}
+		}
+		if !pending {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *RunMerge) updatePhase(ctx context.Context, mergeID uint, phase versource.MergePhase) error {
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("update merge %d phase to %s", mergeID, phase), func(ctx context.Context) error {
+		return r.mergeRepo.UpdateMergePhase(ctx, mergeID, phase)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update merge phase: %w", err)
+	}
+	return nil
+}
+
+func mergePreconditionFindings(ctx context.Context, changesetRepo ChangesetRepo, changesetApprovalRepo ChangesetApprovalRepo, teamRepo TeamRepo, changeset *versource.Changeset, head string, changes []versource.ComponentChange) ([]versource.MergeFinding, error) {
+	findings := []versource.MergeFinding{}
+
+	if changeset.ParentID != nil {
+		parent, err := changesetRepo.GetChangeset(ctx, *changeset.ParentID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("failed to get parent changeset: %w", err)
+		}
+		if parent != nil && parent.State == versource.ChangesetStateOpen {
+			findings = append(findings, versource.MergeFinding{
+				Type:    versource.MergeFindingParentNotMerged,
+				Message: fmt.Sprintf("parent changeset %s must be merged first", parent.Name),
+			})
+		}
+	}
+
+	teams := requiredApprovalTeams(changes)
+	if len(teams) > 0 {
+		approvals, err := changesetApprovalRepo.ListChangesetApprovals(ctx, changeset.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list changeset approvals: %w", err)
+		}
+		missing, err := missingApprovalTeams(ctx, teamRepo, currentApprovals(approvals, head), teams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check changeset approvals: %w", err)
+		}
+		if len(missing) > 0 {
+			findings = append(findings, versource.MergeFinding{
+				Type:    versource.MergeFindingMissingApproval,
+				Message: fmt.Sprintf("approval required from team %s", strings.Join(missing, ", ")),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+func validateMerge(ctx context.Context, tx TransactionManager, componentChangeRepo ComponentChangeRepo, merge *versource.Merge, changes []versource.ComponentChange) ([]versource.MergeFinding, error) {
 	changesetName := merge.Changeset.Name
+	findings := []versource.MergeFinding{}
 
-	hasCommitsAfter, err := r.tx.HasCommitsAfter(ctx, changesetName, merge.Head)
+	hasCommitsAfter, err := tx.HasCommitsAfter(ctx, changesetName, merge.Head)
 	if err != nil {
-		return false, fmt.Errorf("failed to check commits after head: %w", err)
+		return nil, fmt.Errorf("failed to check commits after head: %w", err)
 	}
 	if hasCommitsAfter {
-		return false, nil
+		findings = append(findings, commitsAfterHeadFinding(merge.Head))
 	}
 
-	currentMergeBase, err := r.tx.GetMergeBase(ctx, MainBranch, changesetName)
+	currentMergeBase, err := tx.GetMergeBase(ctx, MainBranch, changesetName)
 	if err != nil {
-		return false, fmt.Errorf("failed to get current merge base: %w", err)
+		return nil, fmt.Errorf("failed to get current merge base: %w", err)
 	}
 	if currentMergeBase != merge.MergeBase {
-		return false, nil
+		findings = append(findings, versource.MergeFinding{
+			Type:    versource.MergeFindingMergeBaseMoved,
+			Message: fmt.Sprintf("merge base moved from %s to %s", merge.MergeBase, currentMergeBase),
+		})
 	}
 
-	hasConflicts, err := r.componentChangeRepo.HasComponentConflicts(ctx, changesetName)
+	hasConflicts, err := componentChangeRepo.HasComponentConflicts(ctx, changesetName)
 	if err != nil {
-		return false, fmt.Errorf("failed to check component conflicts: %w", err)
+		return nil, fmt.Errorf("failed to check component conflicts: %w", err)
 	}
 	if hasConflicts {
-		return false, nil
+		findings = append(findings, versource.MergeFinding{
+			Type:    versource.MergeFindingComponentConflicts,
+			Message: "changeset has component conflicts with main",
+		})
 	}
 
 	for _, change := range changes {
+		componentID := changeComponentID(change)
 		if change.Plan == nil {
-			return false, nil
+			findings = append(findings, versource.MergeFinding{
+				Type:        versource.MergeFindingMissingPlan,
+				Message:     fmt.Sprintf("component %d has no plan for its latest change", componentID),
+				ComponentID: &componentID,
+			})
+			continue
 		}
 		if change.Plan.State != versource.TaskStateSucceeded {
-			return false, nil
+			planID := change.Plan.ID
+			findings = append(findings, versource.MergeFinding{
+				Type:        versource.MergeFindingPlanNotSucceeded,
+				Message:     fmt.Sprintf("plan %d for component %d is %s", planID, componentID, change.Plan.State),
+				ComponentID: &componentID,
+				PlanID:      &planID,
+			})
 		}
 	}
 
-	return true, nil
+	return findings, nil
+}
+
+func commitsAfterHeadFinding(head string) versource.MergeFinding {
+	return versource.MergeFinding{
+		Type:    versource.MergeFindingCommitsAfterHead,
+		Message: fmt.Sprintf("changeset has new commits after %s", head),
+	}
+}
+
+func changeComponentID(change versource.ComponentChange) uint {
+	if change.ToComponent != nil {
+		return change.ToComponent.ID
+	}
+	if change.FromComponent != nil {
+		return change.FromComponent.ID
+	}
+	return 0
 }
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/marcbran/versource/pkg/versource"
 )
@@ -11,6 +12,8 @@ type ModuleRepo interface {
 	GetModuleByName(ctx context.Context, name string) (*versource.Module, error)
 	GetModuleBySource(ctx context.Context, source string) (*versource.Module, error)
 	ListModules(ctx context.Context) ([]versource.Module, error)
+	ListModulesPage(ctx context.Context, page PageQuery) ([]versource.Module, error)
+	ListModulesPageAtCommit(ctx context.Context, commit string, page PageQuery) ([]versource.Module, error)
 	CreateModule(ctx context.Context, module *versource.Module) error
 	DeleteModule(ctx context.Context, moduleID uint) error
 }
@@ -19,10 +22,55 @@ type ModuleVersionRepo interface {
 	GetModuleVersion(ctx context.Context, moduleVersionID uint) (*versource.ModuleVersion, error)
 	GetLatestModuleVersion(ctx context.Context, moduleID uint) (*versource.ModuleVersion, error)
 	ListModuleVersions(ctx context.Context) ([]versource.ModuleVersion, error)
-	ListModuleVersionsForModule(ctx context.Context, moduleID uint) ([]versource.ModuleVersion, error)
+	ListModuleVersionsPage(ctx context.Context, filter ModuleVersionFilter, page PageQuery) ([]versource.ModuleVersion, error)
 	CreateModuleVersion(ctx context.Context, moduleVersion *versource.ModuleVersion) error
 }
 
+type ModuleVersionFilter struct {
+	ModuleID *uint
+}
+
+var modulePageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":    "id",
+		"name":  "name",
+		"owner": "owner",
+	},
+	DefaultSort: "id",
+}
+
+func modulePageKey(module versource.Module, field string) (string, string) {
+	id := pageID(module.ID)
+	switch field {
+	case "name":
+		return module.Name, id
+	case "owner":
+		return module.Owner, id
+	default:
+		return id, id
+	}
+}
+
+var moduleVersionPageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":      "id",
+		"version": "version",
+	},
+	DefaultSort: "id",
+}
+
+func moduleVersionPageKey(moduleVersion versource.ModuleVersion, field string) (string, string) {
+	id := pageID(moduleVersion.ID)
+	switch field {
+	case "version":
+		return moduleVersion.Version, id
+	default:
+		return id, id
+	}
+}
+
 type GetModule struct {
 	moduleRepo        ModuleRepo
 	moduleVersionRepo ModuleVersionRepo
@@ -55,7 +103,7 @@ func (g *GetModule) Exec(ctx context.Context, req versource.GetModuleRequest) (*
 	}
 
 	if module == nil {
-		return nil, versource.UserErr("module not found")
+		return nil, versource.NotFoundErr("module not found")
 	}
 
 	return &versource.GetModuleResponse{
@@ -77,31 +125,49 @@ func NewListModules(moduleRepo ModuleRepo, tx TransactionManager) *ListModules {
 }
 
 func (l *ListModules) Exec(ctx context.Context, req versource.ListModulesRequest) (*versource.ListModulesResponse, error) {
+	if req.AsOf != nil && !IsValidRevision(*req.AsOf) {
+		return nil, versource.UserErr("invalid as-of revision")
+	}
+
+	page, err := NewPageQuery(req.PageRequest, modulePageSpec)
+	if err != nil {
+		return nil, err
+	}
+
 	var modules []versource.Module
-	err := l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+	err = l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
 		var err error
-		modules, err = l.moduleRepo.ListModules(ctx)
+		if req.AsOf != nil {
+			modules, err = l.moduleRepo.ListModulesPageAtCommit(ctx, *req.AsOf, page)
+		} else {
+			modules, err = l.moduleRepo.ListModulesPage(ctx, page)
+		}
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list modules", err)
 	}
 
+	modules, nextCursor := nextPage(modules, page, modulePageKey)
+
 	return &versource.ListModulesResponse{
-		Modules: modules,
+		Modules:    modules,
+		NextCursor: nextCursor,
 	}, nil
 }
 
 type CreateModule struct {
 	moduleRepo        ModuleRepo
 	moduleVersionRepo ModuleVersionRepo
+	teamRepo          TeamRepo
 	tx                TransactionManager
 }
 
-func NewCreateModule(moduleRepo ModuleRepo, moduleVersionRepo ModuleVersionRepo, tx TransactionManager) *CreateModule {
+func NewCreateModule(moduleRepo ModuleRepo, moduleVersionRepo ModuleVersionRepo, teamRepo TeamRepo, tx TransactionManager) *CreateModule {
 	return &CreateModule{
 		moduleRepo:        moduleRepo,
 		moduleVersionRepo: moduleVersionRepo,
+		teamRepo:          teamRepo,
 		tx:                tx,
 	}
 }
@@ -119,10 +185,16 @@ func (c *CreateModule) Exec(ctx context.Context, req versource.CreateModuleReque
 		return nil, versource.UserErr("executor type is required")
 	}
 
+	err := ensureTeamExists(ctx, c.tx, c.teamRepo, req.Owner)
+	if err != nil {
+		return nil, err
+	}
+
 	module := &versource.Module{
 		Name:         req.Name,
 		Source:       req.Source,
 		ExecutorType: req.ExecutorType,
+		Owner:        req.Owner,
 	}
 
 	moduleVersion := &versource.ModuleVersion{
@@ -130,7 +202,7 @@ func (c *CreateModule) Exec(ctx context.Context, req versource.CreateModuleReque
 	}
 
 	var response *versource.CreateModuleResponse
-	err := c.tx.Do(ctx, MainBranch, "create module", func(ctx context.Context) error {
+	err = c.tx.Do(ctx, MainBranch, fmt.Sprintf("create module %s", req.Name), func(ctx context.Context) error {
 		err := c.moduleRepo.CreateModule(ctx, module)
 		if err != nil {
 			return versource.InternalErrE("failed to create module", err)
@@ -176,13 +248,13 @@ func (u *UpdateModule) Exec(ctx context.Context, req versource.UpdateModuleReque
 	}
 
 	var response *versource.UpdateModuleResponse
-	err := u.tx.Do(ctx, MainBranch, "update module", func(ctx context.Context) error {
+	err := u.tx.Do(ctx, MainBranch, fmt.Sprintf("update module %d", req.ModuleID), func(ctx context.Context) error {
 		module, err := u.moduleRepo.GetModule(ctx, req.ModuleID)
 		if err != nil {
 			return versource.InternalErrE("failed to get module", err)
 		}
 		if module == nil {
-			return versource.UserErr("module not found")
+			return versource.NotFoundErr("module not found")
 		}
 
 		currentVersion, err := u.moduleVersionRepo.GetLatestModuleVersion(ctx, req.ModuleID)
@@ -240,13 +312,13 @@ func (d *DeleteModule) Exec(ctx context.Context, req versource.DeleteModuleReque
 	}
 
 	var response *versource.DeleteModuleResponse
-	err := d.tx.Do(ctx, MainBranch, "delete module", func(ctx context.Context) error {
+	err := d.tx.Do(ctx, MainBranch, fmt.Sprintf("delete module %d", req.ModuleID), func(ctx context.Context) error {
 		module, err := d.moduleRepo.GetModule(ctx, req.ModuleID)
 		if err != nil {
 			return versource.InternalErrE("failed to get module", err)
 		}
 		if module == nil {
-			return versource.UserErr("module not found")
+			return versource.NotFoundErr("module not found")
 		}
 
 		components, err := d.componentRepo.ListComponentsByModule(ctx, req.ModuleID)
@@ -255,7 +327,7 @@ func (d *DeleteModule) Exec(ctx context.Context, req versource.DeleteModuleReque
 		}
 
 		if len(components) > 0 {
-			return versource.UserErr("cannot delete module that is referenced by components")
+			return versource.ConflictErr("cannot delete module that is referenced by components")
 		}
 
 		err = d.moduleRepo.DeleteModule(ctx, req.ModuleID)
@@ -299,7 +371,7 @@ func (g *GetModuleVersion) Exec(ctx context.Context, req versource.GetModuleVers
 	}
 
 	if moduleVersion == nil {
-		return nil, versource.UserErr("module version not found")
+		return nil, versource.NotFoundErr("module version not found")
 	}
 
 	return &versource.GetModuleVersionResponse{
@@ -320,21 +392,29 @@ func NewListModuleVersions(moduleVersionRepo ModuleVersionRepo, tx TransactionMa
 }
 
 func (l *ListModuleVersions) Exec(ctx context.Context, req versource.ListModuleVersionsRequest) (*versource.ListModuleVersionsResponse, error) {
+	page, err := NewPageQuery(req.PageRequest, moduleVersionPageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ModuleVersionFilter{
+		ModuleID: req.ModuleID,
+	}
+
 	var moduleVersions []versource.ModuleVersion
-	err := l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+	err = l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
 		var err error
-		if req.ModuleID != nil {
-			moduleVersions, err = l.moduleVersionRepo.ListModuleVersionsForModule(ctx, *req.ModuleID)
-		} else {
-			moduleVersions, err = l.moduleVersionRepo.ListModuleVersions(ctx)
-		}
+		moduleVersions, err = l.moduleVersionRepo.ListModuleVersionsPage(ctx, filter, page)
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list module versions", err)
 	}
 
+	moduleVersions, nextCursor := nextPage(moduleVersions, page, moduleVersionPageKey)
+
 	return &versource.ListModuleVersionsResponse{
 		ModuleVersions: moduleVersions,
+		NextCursor:     nextCursor,
 	}, nil
 }
@@ -0,0 +1,191 @@
+package internal
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+type PageSpec struct {
+	IDColumn    string
+	Columns     map[string]string
+	DefaultSort string
+}
+
+type PageQuery struct {
+	Limit      int
+	Sort       string
+	Field      string
+	Column     string
+	IDColumn   string
+	Descending bool
+	After      *PageCursor
+}
+
+type PageCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func NewPageQuery(req versource.PageRequest, spec PageSpec) (PageQuery, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	if limit < 0 || limit > maxPageLimit {
+		return PageQuery{}, versource.UserErrf("limit must be between 1 and %d", maxPageLimit)
+	}
+
+	sort := req.Sort
+	if sort == "" {
+		sort = spec.DefaultSort
+	}
+	field, descending := strings.CutPrefix(sort, "-")
+	column, ok := spec.Columns[field]
+	if !ok {
+		return PageQuery{}, versource.UserErrf("invalid sort field: %s", field)
+	}
+
+	query := PageQuery{
+		Limit:      limit,
+		Sort:       sort,
+		Field:      field,
+		Column:     column,
+		IDColumn:   spec.IDColumn,
+		Descending: descending,
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodePageCursor(req.Cursor)
+		if err != nil {
+			return PageQuery{}, versource.UserErrE("invalid cursor", err)
+		}
+		if cursor.Sort != sort {
+			return PageQuery{}, versource.UserErr("cursor does not match sort")
+		}
+		query.After = cursor
+	}
+
+	return query, nil
+}
+
+func nextPage[T any](items []T, page PageQuery, key func(item T, field string) (string, string)) ([]T, string) {
+	if len(items) <= page.Limit {
+		return items, ""
+	}
+	items = items[:page.Limit]
+	value, id := key(items[len(items)-1], page.Field)
+	return items, encodePageCursor(PageCursor{Sort: page.Sort, Value: value, ID: id})
+}
+
+func collectPage[T any](page PageQuery, fetch func(page PageQuery) ([]T, error), keep func(items []T) ([]T, error), key func(item T, field string) (string, string)) ([]T, string, error) {
+	collected := make([]T, 0, page.Limit+1)
+	for {
+		items, err := fetch(page)
+		if err != nil {
+			return nil, "", err
+		}
+		more := len(items) > page.Limit
+		if more {
+			items = items[:page.Limit]
+		}
+
+		kept, err := keep(items)
+		if err != nil {
+			return nil, "", err
+		}
+		collected = append(collected, kept...)
+
+		if len(collected) > page.Limit {
+			collected = collected[:page.Limit]
+			value, id := key(collected[len(collected)-1], page.Field)
+			return collected, encodePageCursor(PageCursor{Sort: page.Sort, Value: value, ID: id}), nil
+		}
+		if !more {
+			return collected, "", nil
+		}
+
+		value, id := key(items[len(items)-1], page.Field)
+		page.After = &PageCursor{Sort: page.Sort, Value: value, ID: id}
+	}
+}
+
+func pageSlice[T any](items []T, page PageQuery, key func(item T, field string) (string, string)) ([]T, string) {
+	compare := func(aValue, aID, bValue, bID string) int {
+		c := comparePageValues(aValue, bValue)
+		if c == 0 {
+			c = comparePageValues(aID, bID)
+		}
+		if page.Descending {
+			return -c
+		}
+		return c
+	}
+
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		aValue, aID := key(a, page.Field)
+		bValue, bID := key(b, page.Field)
+		return compare(aValue, aID, bValue, bID)
+	})
+
+	if page.After != nil {
+		start := slices.IndexFunc(sorted, func(item T) bool {
+			value, id := key(item, page.Field)
+			return compare(value, id, page.After.Value, page.After.ID) > 0
+		})
+		if start < 0 {
+			start = len(sorted)
+		}
+		sorted = sorted[start:]
+	}
+
+	return nextPage(sorted, page, key)
+}
+
+func comparePageValues(a, b string) int {
+	aNum, aErr := strconv.ParseUint(a, 10, 64)
+	bNum, bErr := strconv.ParseUint(b, 10, 64)
+	if aErr == nil && bErr == nil {
+		return cmp.Compare(aNum, bNum)
+	}
+	return strings.Compare(a, b)
+}
+
+func encodePageCursor(cursor PageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageCursor(s string) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var cursor PageCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+func pageID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func pageTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05.999999")
+}
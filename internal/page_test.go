@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func TestNewPageQuery(t *testing.T) {
+	cursor := encodePageCursor(PageCursor{Sort: "-created", Value: "2026-01-02 03:04:05", ID: "7"})
+
+	tests := []struct {
+		name       string
+		req        versource.PageRequest
+		expectErr  bool
+		limit      int
+		column     string
+		descending bool
+		after      *PageCursor
+	}{
+		{
+			name:   "defaults",
+			req:    versource.PageRequest{},
+			limit:  defaultPageLimit,
+			column: "id",
+		},
+		{
+			name:       "descending sort",
+			req:        versource.PageRequest{Limit: 10, Sort: "-created"},
+			limit:      10,
+			column:     "created_at",
+			descending: true,
+		},
+		{
+			name:       "cursor",
+			req:        versource.PageRequest{Sort: "-created", Cursor: cursor},
+			limit:      defaultPageLimit,
+			column:     "created_at",
+			descending: true,
+			after:      &PageCursor{Sort: "-created", Value: "2026-01-02 03:04:05", ID: "7"},
+		},
+		{
+			name:      "negative limit",
+			req:       versource.PageRequest{Limit: -1},
+			expectErr: true,
+		},
+		{
+			name:      "limit too large",
+			req:       versource.PageRequest{Limit: maxPageLimit + 1},
+			expectErr: true,
+		},
+		{
+			name:      "unknown sort",
+			req:       versource.PageRequest{Sort: "size"},
+			expectErr: true,
+		},
+		{
+			name:      "malformed cursor",
+			req:       versource.PageRequest{Cursor: "not a cursor"},
+			expectErr: true,
+		},
+		{
+			name:      "cursor for other sort",
+			req:       versource.PageRequest{Sort: "created", Cursor: cursor},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := NewPageQuery(tt.req, planPageSpec)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if !versource.IsUserError(err) {
+					t.Errorf("expected user error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query.Limit != tt.limit {
+				t.Errorf("expected limit %d, got %d", tt.limit, query.Limit)
+			}
+			if query.Column != tt.column {
+				t.Errorf("expected column %s, got %s", tt.column, query.Column)
+			}
+			if query.Descending != tt.descending {
+				t.Errorf("expected descending %t, got %t", tt.descending, query.Descending)
+			}
+			if (query.After == nil) != (tt.after == nil) || (query.After != nil && *query.After != *tt.after) {
+				t.Errorf("expected cursor %v, got %v", tt.after, query.After)
+			}
+		})
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	plans := []versource.Plan{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	page, err := NewPageQuery(versource.PageRequest{Limit: 2}, planPageSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, next := nextPage(plans, page, planPageKey)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	if next == "" {
+		t.Fatal("expected next cursor")
+	}
+
+	page, err = NewPageQuery(versource.PageRequest{Limit: 2, Cursor: next}, planPageSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.After == nil || page.After.ID != "2" {
+		t.Errorf("expected cursor after plan 2, got %v", page.After)
+	}
+
+	items, next = nextPage(plans[2:], page, planPageKey)
+	if len(items) != 1 {
+		t.Errorf("expected 1 item, got %d", len(items))
+	}
+	if next != "" {
+		t.Errorf("expected no next cursor, got %s", next)
+	}
+}
+
+func TestCollectPage(t *testing.T) {
+	plans := []versource.Plan{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}, {ID: 7}}
+
+	fetch := func(page PageQuery) ([]versource.Plan, error) {
+		start := 0
+		if page.After != nil {
+			for i, plan := range plans {
+				if pageID(plan.ID) == page.After.ID {
+					start = i + 1
+				}
+			}
+		}
+		end := min(start+page.Limit+1, len(plans))
+		return plans[start:end], nil
+	}
+	keep := func(items []versource.Plan) ([]versource.Plan, error) {
+		var kept []versource.Plan
+		for _, item := range items {
+			if item.ID%3 == 0 {
+				kept = append(kept, item)
+			}
+		}
+		return kept, nil
+	}
+
+	page, err := NewPageQuery(versource.PageRequest{Limit: 1}, planPageSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, next, err := collectPage(page, fetch, keep, planPageKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != 3 {
+		t.Fatalf("expected plan 3, got %v", items)
+	}
+	if next == "" {
+		t.Fatal("expected next cursor")
+	}
+
+	page, err = NewPageQuery(versource.PageRequest{Limit: 1, Cursor: next}, planPageSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, next, err = collectPage(page, fetch, keep, planPageKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != 6 {
+		t.Fatalf("expected plan 6, got %v", items)
+	}
+	if next != "" {
+		t.Errorf("expected no next cursor, got %s", next)
+	}
+}
+
+func TestPageSlice(t *testing.T) {
+	plans := []versource.Plan{{ID: 10}, {ID: 2}, {ID: 9}, {ID: 1}}
+
+	page, err := NewPageQuery(versource.PageRequest{Limit: 2}, planPageSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, next := pageSlice(plans, page, planPageKey)
+	if len(items) != 2 || items[0].ID != 1 || items[1].ID != 2 {
+		t.Fatalf("expected plans 1 and 2, got %v", items)
+	}
+	if next == "" {
+		t.Fatal("expected next cursor")
+	}
+
+	page, err = NewPageQuery(versource.PageRequest{Limit: 2, Cursor: next}, planPageSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, next = pageSlice(plans, page, planPageKey)
+	if len(items) != 2 || items[0].ID != 9 || items[1].ID != 10 {
+		t.Fatalf("expected plans 9 and 10, got %v", items)
+	}
+	if next != "" {
+		t.Errorf("expected no next cursor, got %s", next)
+	}
+	if plans[0].ID != 10 {
+		t.Errorf("expected input to be left unsorted, got %v", plans)
+	}
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -13,15 +14,43 @@ import (
 type PlanRepo interface {
 	GetPlan(ctx context.Context, planID uint) (*versource.Plan, error)
 	GetQueuedPlans(ctx context.Context) ([]uint, error)
-	ListPlans(ctx context.Context) ([]versource.Plan, error)
+	ListPlans(ctx context.Context, filter PlanFilter, page PageQuery) ([]versource.Plan, error)
 	ListPlansByChangeset(ctx context.Context, changesetID uint) ([]versource.Plan, error)
-	ListPlansByChangesetName(ctx context.Context, changesetName string) ([]versource.Plan, error)
 	CreatePlan(ctx context.Context, plan *versource.Plan) error
 	UpdatePlanState(ctx context.Context, planID uint, state versource.TaskState) error
 	UpdatePlanResourceCounts(ctx context.Context, planID uint, counts PlanResourceCounts) error
 	DeletePlan(ctx context.Context, planID uint) error
 }
 
+type PlanFilter struct {
+	ChangesetName *string
+	State         *versource.TaskState
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+var planPageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":      "id",
+		"state":   "state",
+		"created": "created_at",
+	},
+	DefaultSort: "id",
+}
+
+func planPageKey(plan versource.Plan, field string) (string, string) {
+	id := pageID(plan.ID)
+	switch field {
+	case "state":
+		return string(plan.State), id
+	case "created":
+		return pageTime(plan.CreatedAt), id
+	default:
+		return id, id
+	}
+}
+
 type PlanStore interface {
 	StorePlan(ctx context.Context, planID uint, planPath PlanPath) error
 	LoadPlan(ctx context.Context, planID uint) (PlanPath, error)
@@ -53,8 +82,11 @@ func (g *GetPlan) Exec(ctx context.Context, req versource.GetPlanRequest) (*vers
 		plan, err = g.planRepo.GetPlan(ctx, req.PlanID)
 		return err
 	})
+	if errors.Is(err, ErrNotFound) {
+		return nil, versource.NotFoundErrf("plan %d not found", req.PlanID)
+	}
 	if err != nil {
-		return nil, versource.InternalErrE("failed to list plans", err)
+		return nil, versource.InternalErrE("failed to get plan", err)
 	}
 
 	var component *versource.Component
@@ -79,12 +111,14 @@ func (g *GetPlan) Exec(ctx context.Context, req versource.GetPlanRequest) (*vers
 }
 
 type GetPlanLog struct {
+	planRepo PlanRepo
 	logStore LogStore
 	tx       TransactionManager
 }
 
-func NewGetPlanLog(logStore LogStore, tx TransactionManager) *GetPlanLog {
+func NewGetPlanLog(planRepo PlanRepo, logStore LogStore, tx TransactionManager) *GetPlanLog {
 	return &GetPlanLog{
+		planRepo: planRepo,
 		logStore: logStore,
 		tx:       tx,
 	}
@@ -95,6 +129,10 @@ func (g *GetPlanLog) Exec(ctx context.Context, req versource.GetPlanLogRequest)
 		return nil, versource.UserErr("plan ID is required")
 	}
 
+	if req.Follow {
+		return g.follow(ctx, req.PlanID)
+	}
+
 	var reader io.ReadCloser
 	err := g.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
 		var err error
@@ -110,39 +148,134 @@ func (g *GetPlanLog) Exec(ctx context.Context, req versource.GetPlanLogRequest)
 	}, nil
 }
 
+func (g *GetPlanLog) follow(ctx context.Context, planID uint) (*versource.GetPlanLogResponse, error) {
+	completed := func(ctx context.Context) (bool, error) {
+		var plan *versource.Plan
+		err := g.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+			var err error
+			plan, err = g.planRepo.GetPlan(ctx, planID)
+			return err
+		})
+		if errors.Is(err, ErrNotFound) {
+			return false, versource.NotFoundErrf("plan %d not found", planID)
+		}
+		if err != nil {
+			return false, err
+		}
+		return isTaskFinished(plan.State), nil
+	}
+	open := func(ctx context.Context) (io.ReadCloser, error) {
+		return g.logStore.LoadLog(ctx, "plan", planID)
+	}
+
+	done, err := completed(ctx)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to get plan", err)
+	}
+	reader, err := open(ctx)
+	if err != nil && done {
+		return nil, versource.InternalErrE("failed to load plan log", err)
+	}
+
+	return &versource.GetPlanLogResponse{
+		Content: newFollowLogReader(ctx, reader, open, completed),
+	}, nil
+}
+
 type ListPlans struct {
-	planRepo PlanRepo
-	tx       TransactionManager
+	planRepo      PlanRepo
+	componentRepo ComponentRepo
+	tx            TransactionManager
 }
 
-func NewListPlans(planRepo PlanRepo, tx TransactionManager) *ListPlans {
+func NewListPlans(planRepo PlanRepo, componentRepo ComponentRepo, tx TransactionManager) *ListPlans {
 	return &ListPlans{
-		planRepo: planRepo,
-		tx:       tx,
+		planRepo:      planRepo,
+		componentRepo: componentRepo,
+		tx:            tx,
 	}
 }
 
 func (l *ListPlans) Exec(ctx context.Context, req versource.ListPlansRequest) (*versource.ListPlansResponse, error) {
-	var plans []versource.Plan
+	selector, err := ParseLabelSelector(req.Selector)
+	if err != nil {
+		return nil, versource.UserErrE("invalid label selector", err)
+	}
 
-	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
-		var err error
-		if req.ChangesetName == "" {
-			plans, err = l.planRepo.ListPlans(ctx)
-		} else {
-			plans, err = l.planRepo.ListPlansByChangesetName(ctx, req.ChangesetName)
+	page, err := NewPageQuery(req.PageRequest, planPageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := PlanFilter{
+		State:         req.State,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+	}
+	if req.ChangesetName != "" {
+		filter.ChangesetName = &req.ChangesetName
+	}
+
+	fetch := func(page PageQuery) ([]versource.Plan, error) {
+		var plans []versource.Plan
+		err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+			var err error
+			plans, err = l.planRepo.ListPlans(ctx, filter, page)
+			return err
+		})
+		if err != nil {
+			return nil, versource.InternalErrE("failed to list plans", err)
 		}
-		return err
-	})
+		return plans, nil
+	}
+	keep := func(plans []versource.Plan) ([]versource.Plan, error) {
+		if len(selector) == 0 {
+			return plans, nil
+		}
+		plans, err := l.filterPlansBySelector(ctx, plans, selector)
+		if err != nil {
+			return nil, versource.InternalErrE("failed to filter plans", err)
+		}
+		return plans, nil
+	}
+
+	plans, nextCursor, err := collectPage(page, fetch, keep, planPageKey)
 	if err != nil {
-		return nil, versource.InternalErrE("failed to list plans", err)
+		return nil, err
 	}
 
 	return &versource.ListPlansResponse{
-		Plans: plans,
+		Plans:      plans,
+		NextCursor: nextCursor,
 	}, nil
 }
 
+func (l *ListPlans) filterPlansBySelector(ctx context.Context, plans []versource.Plan, selector LabelSelector) ([]versource.Plan, error) {
+	labelsByCommit := make(map[string]map[uint]map[string]string)
+	filtered := make([]versource.Plan, 0, len(plans))
+	for _, plan := range plans {
+		if !IsValidCommitHash(plan.To) {
+			continue
+		}
+		labels, ok := labelsByCommit[plan.To]
+		if !ok {
+			components, err := l.componentRepo.ListComponentsAtCommit(ctx, plan.To)
+			if err != nil {
+				return nil, err
+			}
+			labels = make(map[uint]map[string]string, len(components))
+			for _, component := range components {
+				labels[component.ID] = component.Labels
+			}
+			labelsByCommit[plan.To] = labels
+		}
+		if selector.Matches(labels[plan.ComponentID]) {
+			filtered = append(filtered, plan)
+		}
+	}
+	return filtered, nil
+}
+
 type CreatePlan struct {
 	componentRepo       ComponentRepo
 	componentChangeRepo ComponentChangeRepo
@@ -168,13 +301,26 @@ func (c *CreatePlan) Exec(ctx context.Context, req versource.CreatePlanRequest)
 		return nil, versource.UserErr("changeset is required")
 	}
 
+	var baseBranch string
 	var from string
 	var to string
 
-	err := c.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+	err := c.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
+		var err error
+		baseBranch, err = c.componentChangeRepo.GetChangesetBaseBranch(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset base branch", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.tx.Checkout(ctx, baseBranch, func(ctx context.Context) error {
 		commit, err := c.componentRepo.GetLastCommitOfComponent(ctx, req.ComponentID)
 		if err != nil {
-			return versource.InternalErrE("failed to get last commit of component from main", err)
+			return versource.InternalErrE("failed to get last commit of component from base branch", err)
 		}
 
 		from = commit
@@ -185,7 +331,7 @@ func (c *CreatePlan) Exec(ctx context.Context, req versource.CreatePlanRequest)
 	}
 
 	err = c.tx.Checkout(ctx, req.ChangesetName, func(ctx context.Context) error {
-		change, err := c.componentChangeRepo.GetComponentChange(ctx, req.ComponentID)
+		change, err := c.componentChangeRepo.GetComponentChange(ctx, baseBranch, req.ComponentID)
 		if err != nil {
 			return versource.InternalErrE("failed to get component change from changeset", err)
 		}
@@ -202,10 +348,16 @@ func (c *CreatePlan) Exec(ctx context.Context, req versource.CreatePlanRequest)
 	}
 
 	var response *versource.CreatePlanResponse
-	err = c.tx.Do(ctx, AdminBranch, "create plan", func(ctx context.Context) error {
+	err = c.tx.Do(ctx, AdminBranch, fmt.Sprintf("create plan for component %d", req.ComponentID), func(ctx context.Context) error {
 		changeset, err := c.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
 		if err != nil {
-			return versource.UserErrE("changeset not found", err)
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
+		}
+		if changeset.State == versource.ChangesetStateClosed {
+			return versource.PreconditionFailedErr("cannot create plan: changeset is closed")
 		}
 
 		plan := &versource.Plan{
@@ -213,6 +365,7 @@ func (c *CreatePlan) Exec(ctx context.Context, req versource.CreatePlanRequest)
 			ChangesetID: changeset.ID,
 			From:        from,
 			To:          to,
+			RequestedBy: versource.UserFromContext(ctx),
 		}
 
 		err = c.planRepo.CreatePlan(ctx, plan)
@@ -289,7 +442,7 @@ func (pw *PlanWorker) runPlanInBackground(ctx context.Context, planID uint) {
 
 		err := pw.runPlan.Exec(workerCtx, planID)
 		if err != nil {
-			stateErr := pw.tx.Do(ctx, AdminBranch, "fail plan", func(ctx context.Context) error {
+			stateErr := pw.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail plan %d", planID), func(ctx context.Context) error {
 				return pw.planRepo.UpdatePlanState(ctx, planID, versource.TaskStateFailed)
 			})
 			if stateErr != nil {
@@ -325,31 +478,35 @@ func (pw *PlanWorker) processQueuedPlans(ctx context.Context) {
 }
 
 type RunPlan struct {
-	config        *versource.Config
-	planRepo      PlanRepo
-	planStore     PlanStore
-	logStore      LogStore
-	tx            TransactionManager
-	newExecutor   NewExecutor
-	componentRepo ComponentRepo
+	config          *versource.Config
+	planRepo        PlanRepo
+	planStore       PlanStore
+	logStore        LogStore
+	tx              TransactionManager
+	newExecutor     NewExecutor
+	componentRepo   ComponentRepo
+	variableSetRepo VariableSetRepo
+	secretCipher    *SecretCipher
 }
 
-func NewRunPlan(config *versource.Config, planRepo PlanRepo, planStore PlanStore, logStore LogStore, tx TransactionManager, newExecutor NewExecutor, componentRepo ComponentRepo) *RunPlan {
+func NewRunPlan(config *versource.Config, planRepo PlanRepo, planStore PlanStore, logStore LogStore, tx TransactionManager, newExecutor NewExecutor, componentRepo ComponentRepo, variableSetRepo VariableSetRepo, secretCipher *SecretCipher) *RunPlan {
 	return &RunPlan{
-		config:        config,
-		planRepo:      planRepo,
-		planStore:     planStore,
-		logStore:      logStore,
-		tx:            tx,
-		newExecutor:   newExecutor,
-		componentRepo: componentRepo,
+		config:          config,
+		planRepo:        planRepo,
+		planStore:       planStore,
+		logStore:        logStore,
+		tx:              tx,
+		newExecutor:     newExecutor,
+		componentRepo:   componentRepo,
+		variableSetRepo: variableSetRepo,
+		secretCipher:    secretCipher,
 	}
 }
 
 func (r *RunPlan) Exec(ctx context.Context, planID uint) error {
 	var plan *versource.Plan
 
-	err := r.tx.Do(ctx, AdminBranch, "start plan", func(ctx context.Context) error {
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("start plan %d", planID), func(ctx context.Context) error {
 		var err error
 		plan, err = r.planRepo.GetPlan(ctx, planID)
 		if err != nil {
@@ -371,10 +528,20 @@ func (r *RunPlan) Exec(ctx context.Context, planID uint) error {
 		return err
 	}
 
+	ctx = versource.WithUser(ctx, plan.RequestedBy)
+
 	var component *versource.Component
 	err = r.tx.Checkout(ctx, plan.Changeset.Name, func(ctx context.Context) error {
 		var err error
 		component, err = r.componentRepo.GetComponentAtCommit(ctx, plan.ComponentID, plan.To)
+		if err != nil {
+			return err
+		}
+		component.Variables, err = effectiveVariables(ctx, r.variableSetRepo, component, plan.To)
+		if err != nil {
+			return err
+		}
+		component.Variables, err = withSecretVariables(r.secretCipher, component.Variables, component.Secrets)
 		return err
 	})
 	if err != nil {
@@ -412,7 +579,7 @@ func (r *RunPlan) Exec(ctx context.Context, planID uint) error {
 		return err
 	}
 
-	err = r.tx.Do(ctx, AdminBranch, "succeed plan", func(ctx context.Context) error {
+	err = r.tx.Do(ctx, AdminBranch, fmt.Sprintf("succeed plan %d", planID), func(ctx context.Context) error {
 		updateErr := r.planRepo.UpdatePlanResourceCounts(ctx, planID, resourceCounts)
 		if updateErr != nil {
 			return fmt.Errorf("failed to update plan resource counts: %w", updateErr)
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,12 +14,35 @@ type RebaseRepo interface {
 	GetRebase(ctx context.Context, rebaseID uint) (*versource.Rebase, error)
 	GetQueuedRebases(ctx context.Context) ([]uint, error)
 	GetQueuedRebasesByChangeset(ctx context.Context, changesetID uint) ([]uint, error)
-	ListRebases(ctx context.Context) ([]versource.Rebase, error)
+	ListRebases(ctx context.Context, filter RebaseFilter, page PageQuery) ([]versource.Rebase, error)
 	ListRebasesByChangesetName(ctx context.Context, changesetName string) ([]versource.Rebase, error)
 	CreateRebase(ctx context.Context, rebase *versource.Rebase) error
 	UpdateRebaseState(ctx context.Context, rebaseID uint, state versource.TaskState) error
 }
 
+type RebaseFilter struct {
+	ChangesetName *string
+}
+
+var rebasePageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":    "id",
+		"state": "state",
+	},
+	DefaultSort: "-id",
+}
+
+func rebasePageKey(rebase versource.Rebase, field string) (string, string) {
+	id := pageID(rebase.ID)
+	switch field {
+	case "state":
+		return string(rebase.State), id
+	default:
+		return id, id
+	}
+}
+
 type GetRebase struct {
 	rebaseRepo RebaseRepo
 	tx         TransactionManager
@@ -47,7 +71,7 @@ func (g *GetRebase) Exec(ctx context.Context, req versource.GetRebaseRequest) (*
 	}
 
 	if rebase == nil {
-		return nil, versource.UserErr("rebase not found")
+		return nil, versource.NotFoundErr("rebase not found")
 	}
 
 	return &versource.GetRebaseResponse{
@@ -68,22 +92,31 @@ func NewListRebases(rebaseRepo RebaseRepo, tx TransactionManager) *ListRebases {
 }
 
 func (l *ListRebases) Exec(ctx context.Context, req versource.ListRebasesRequest) (*versource.ListRebasesResponse, error) {
+	page, err := NewPageQuery(req.PageRequest, rebasePageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := RebaseFilter{}
+	if req.ChangesetName != "" {
+		filter.ChangesetName = &req.ChangesetName
+	}
+
 	var rebases []versource.Rebase
-	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+	err = l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
 		var err error
-		if req.ChangesetName == "" {
-			rebases, err = l.rebaseRepo.ListRebases(ctx)
-		} else {
-			rebases, err = l.rebaseRepo.ListRebasesByChangesetName(ctx, req.ChangesetName)
-		}
+		rebases, err = l.rebaseRepo.ListRebases(ctx, filter, page)
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list rebases", err)
 	}
 
+	rebases, nextCursor := nextPage(rebases, page, rebasePageKey)
+
 	return &versource.ListRebasesResponse{
-		Rebases: rebases,
+		Rebases:    rebases,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -130,10 +163,13 @@ func (c *CreateRebase) Exec(ctx context.Context, req versource.CreateRebaseReque
 	}
 
 	var response *versource.CreateRebaseResponse
-	err = c.tx.Do(ctx, AdminBranch, "create rebase", func(ctx context.Context) error {
+	err = c.tx.Do(ctx, AdminBranch, fmt.Sprintf("create rebase for changeset %s", req.ChangesetName), func(ctx context.Context) error {
 		changeset, err := c.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
 		if err != nil {
-			return versource.UserErrE("changeset not found", err)
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
 		}
 
 		rebase := &versource.Rebase{
@@ -263,8 +299,9 @@ func NewRunRebase(config *versource.Config, rebaseRepo RebaseRepo, changesetRepo
 
 func (r *RunRebase) Exec(ctx context.Context, rebaseID uint) error {
 	var rebase *versource.Rebase
+	baseBranch := MainBranch
 
-	err := r.tx.Do(ctx, AdminBranch, "start rebase", func(ctx context.Context) error {
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("start rebase %d", rebaseID), func(ctx context.Context) error {
 		var err error
 		rebase, err = r.rebaseRepo.GetRebase(ctx, rebaseID)
 		if err != nil {
@@ -275,6 +312,16 @@ func (r *RunRebase) Exec(ctx context.Context, rebaseID uint) error {
 			return fmt.Errorf("rebase ID mismatch")
 		}
 
+		if rebase.Changeset.ParentID != nil {
+			parent, err := r.changesetRepo.GetChangeset(ctx, *rebase.Changeset.ParentID)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("failed to get parent changeset: %w", err)
+			}
+			if parent != nil && parent.State == versource.ChangesetStateOpen {
+				baseBranch = parent.Name
+			}
+		}
+
 		err = r.rebaseRepo.UpdateRebaseState(ctx, rebaseID, versource.TaskStateStarted)
 		if err != nil {
 			return fmt.Errorf("failed to update rebase state: %w", err)
@@ -290,12 +337,12 @@ func (r *RunRebase) Exec(ctx context.Context, rebaseID uint) error {
 
 	var changesResp *versource.ListComponentChangesResponse
 	err = r.tx.Checkout(ctx, rebase.Changeset.Name, func(ctx context.Context) error {
-		err = r.tx.RebaseBranch(ctx, MainBranch)
+		err = r.tx.RebaseBranch(ctx, baseBranch)
 		if err != nil {
 			return err
 		}
 
-		changesResp, err = r.listComponentChanges.Exec(ctx, versource.ListComponentChangesRequest{
+		changesResp, err = r.listComponentChanges.listAll(ctx, versource.ListComponentChangesRequest{
 			ChangesetName: rebase.Changeset.Name,
 		})
 		if err != nil {
@@ -305,7 +352,7 @@ func (r *RunRebase) Exec(ctx context.Context, rebaseID uint) error {
 		return nil
 	})
 	if err != nil {
-		stateErr := r.tx.Do(ctx, AdminBranch, "fail rebase", func(ctx context.Context) error {
+		stateErr := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail rebase %d", rebaseID), func(ctx context.Context) error {
 			return r.rebaseRepo.UpdateRebaseState(ctx, rebaseID, versource.TaskStateFailed)
 		})
 		if stateErr != nil {
@@ -332,18 +379,23 @@ func (r *RunRebase) Exec(ctx context.Context, rebaseID uint) error {
 		}
 	}
 
-	err = r.tx.Do(ctx, AdminBranch, "complete rebase", func(ctx context.Context) error {
+	err = r.tx.Do(ctx, AdminBranch, fmt.Sprintf("complete rebase %d", rebaseID), func(ctx context.Context) error {
 		err = r.rebaseRepo.UpdateRebaseState(ctx, rebaseID, versource.TaskStateSucceeded)
 		if err != nil {
 			return fmt.Errorf("failed to update rebase state: %w", err)
 		}
 
+		err = r.changesetRepo.UpdateChangesetStaleness(ctx, rebase.ChangesetID, false, nil)
+		if err != nil {
+			return fmt.Errorf("failed to update changeset staleness: %w", err)
+		}
+
 		log.WithField("rebase_id", rebaseID).WithField("changeset_id", rebase.ChangesetID).Info("Rebase completed")
 
 		return nil
 	})
 	if err != nil {
-		stateErr := r.tx.Do(ctx, AdminBranch, "fail rebase completion", func(ctx context.Context) error {
+		stateErr := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("fail rebase %d completion", rebaseID), func(ctx context.Context) error {
 			return r.rebaseRepo.UpdateRebaseState(ctx, rebaseID, versource.TaskStateFailed)
 		})
 		if stateErr != nil {
@@ -10,7 +10,37 @@ type ResourceRepo interface {
 	InsertResources(ctx context.Context, resources []versource.Resource) error
 	UpdateResources(ctx context.Context, resources []versource.Resource) error
 	DeleteResources(ctx context.Context, resourceUUIDs []string) error
-	ListResources(ctx context.Context) ([]versource.Resource, error)
+	ListResources(ctx context.Context, filter ResourceFilter, page PageQuery) ([]versource.Resource, error)
+	ListResourcesAtCommit(ctx context.Context, commit string, filter ResourceFilter, page PageQuery) ([]versource.Resource, error)
+}
+
+type ResourceFilter struct {
+	Provider     *string
+	ResourceType *string
+}
+
+var resourcePageSpec = PageSpec{
+	IDColumn: "uuid",
+	Columns: map[string]string{
+		"uuid":     "uuid",
+		"provider": "provider",
+		"type":     "resource_type",
+		"name":     "name",
+	},
+	DefaultSort: "uuid",
+}
+
+func resourcePageKey(resource versource.Resource, field string) (string, string) {
+	switch field {
+	case "provider":
+		return resource.Provider, resource.UUID
+	case "type":
+		return resource.ResourceType, resource.UUID
+	case "name":
+		return resource.Name, resource.UUID
+	default:
+		return resource.UUID, resource.UUID
+	}
 }
 
 type ListResources struct {
@@ -26,18 +56,39 @@ func NewListResources(resourceRepo ResourceRepo, tx TransactionManager) *ListRes
 }
 
 func (l *ListResources) Exec(ctx context.Context, req versource.ListResourcesRequest) (*versource.ListResourcesResponse, error) {
+	if req.AsOf != nil && !IsValidRevision(*req.AsOf) {
+		return nil, versource.UserErr("invalid as-of revision")
+	}
+
+	page, err := NewPageQuery(req.PageRequest, resourcePageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ResourceFilter{
+		Provider:     req.Provider,
+		ResourceType: req.ResourceType,
+	}
+
 	var resources []versource.Resource
-	err := l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+	err = l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
 		var err error
-		resources, err = l.resourceRepo.ListResources(ctx)
+		if req.AsOf != nil {
+			resources, err = l.resourceRepo.ListResourcesAtCommit(ctx, *req.AsOf, filter, page)
+		} else {
+			resources, err = l.resourceRepo.ListResources(ctx, filter, page)
+		}
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list resources", err)
 	}
 
+	resources, nextCursor := nextPage(resources, page, resourcePageKey)
+
 	return &versource.ListResourcesResponse{
-		Resources: resources,
+		Resources:  resources,
+		NextCursor: nextCursor,
 	}, nil
 }
 
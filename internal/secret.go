@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/datatypes"
+)
+
+var ErrNoSecretKey = errors.New("no secret key configured")
+
+type SecretCipher struct {
+	key []byte
+}
+
+func NewSecretCipher(config *versource.SecretsConfig) *SecretCipher {
+	if config == nil || config.Key == "" {
+		return &SecretCipher{}
+	}
+	sum := sha256.Sum256([]byte(config.Key))
+	return &SecretCipher{key: sum[:]}
+}
+
+func (c *SecretCipher) Encrypt(plaintext []byte) (string, error) {
+	aead, err := c.aead()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	ciphertext := aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (c *SecretCipher) Decrypt(encoded string) ([]byte, error) {
+	aead, err := c.aead()
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode secret: %w", err)
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("secret is too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (c *SecretCipher) aead() (cipher.AEAD, error) {
+	if len(c.key) == 0 {
+		return nil, ErrNoSecretKey
+	}
+	block, err := aes.NewCipher(c.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptSecrets(secretCipher *SecretCipher, existing versource.SecretVariables, secrets map[string]any, remove []string) (versource.SecretVariables, error) {
+	if len(secrets) == 0 && len(remove) == 0 {
+		return existing, nil
+	}
+	for _, name := range remove {
+		if _, ok := secrets[name]; ok {
+			return nil, versource.UserErrf("secret %s cannot be set and removed at once", name)
+		}
+	}
+
+	encrypted := maps.Clone(existing)
+	if encrypted == nil {
+		encrypted = make(versource.SecretVariables, len(secrets))
+	}
+	for _, name := range slices.Sorted(maps.Keys(secrets)) {
+		plaintext, err := json.Marshal(secrets[name])
+		if err != nil {
+			return nil, versource.UserErrE(fmt.Sprintf("invalid value for secret %s", name), err)
+		}
+		ciphertext, err := secretCipher.Encrypt(plaintext)
+		if errors.Is(err, ErrNoSecretKey) {
+			return nil, versource.PreconditionFailedErr("secret variables require a configured secrets key")
+		}
+		if err != nil {
+			return nil, versource.InternalErrE("failed to encrypt secret", err)
+		}
+		encrypted[name] = ciphertext
+	}
+	for _, name := range remove {
+		if _, ok := encrypted[name]; !ok {
+			return nil, versource.UserErrf("secret %s not found", name)
+		}
+		delete(encrypted, name)
+	}
+	if len(encrypted) == 0 {
+		return nil, nil
+	}
+	return encrypted, nil
+}
+
+func withSecretVariables(secretCipher *SecretCipher, variables datatypes.JSON, secrets versource.SecretVariables) (datatypes.JSON, error) {
+	if len(secrets) == 0 {
+		return variables, nil
+	}
+
+	merged := make(map[string]any, len(secrets))
+	err := mergeVariables(merged, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge variables: %w", err)
+	}
+	for name, ciphertext := range secrets {
+		plaintext, err := secretCipher.Decrypt(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt secret %s: %w", name, err)
+		}
+		var value any
+		err = json.Unmarshal(plaintext, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal secret %s: %w", name, err)
+		}
+		merged[name] = value
+	}
+
+	variablesJSON, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal variables: %w", err)
+	}
+	return datatypes.JSON(variablesJSON), nil
+}
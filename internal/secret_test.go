@@ -0,0 +1,225 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/datatypes"
+)
+
+func TestSecretCipherRoundTrip(t *testing.T) {
+	secretCipher := NewSecretCipher(&versource.SecretsConfig{Key: "test-key"})
+
+	first, err := secretCipher.Encrypt([]byte(`"hunter2"`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := secretCipher.Encrypt([]byte(`"hunter2"`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first == second {
+		t.Errorf("expected different ciphertexts for repeated encryption")
+	}
+	if strings.Contains(first, "hunter2") {
+		t.Errorf("ciphertext contains plaintext")
+	}
+
+	plaintext, err := secretCipher.Decrypt(first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(plaintext) != `"hunter2"` {
+		t.Errorf("expected %q, got %q", `"hunter2"`, plaintext)
+	}
+
+	_, err = NewSecretCipher(&versource.SecretsConfig{Key: "other-key"}).Decrypt(first)
+	if err == nil {
+		t.Errorf("expected error when decrypting with a different key")
+	}
+}
+
+func TestSecretCipherWithoutKey(t *testing.T) {
+	_, err := NewSecretCipher(nil).Encrypt([]byte(`"hunter2"`))
+	if !errors.Is(err, ErrNoSecretKey) {
+		t.Errorf("expected ErrNoSecretKey, got %v", err)
+	}
+}
+
+func TestEncryptSecrets(t *testing.T) {
+	secretCipher := NewSecretCipher(&versource.SecretsConfig{Key: "test-key"})
+
+	existing, err := encryptSecrets(secretCipher, nil, map[string]any{"password": "a", "token": "b"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		secrets   map[string]any
+		remove    []string
+		expected  []string
+		rotated   []string
+		expectErr bool
+	}{
+		{
+			name:     "nothing to change",
+			expected: []string{"password", "token"},
+		},
+		{
+			name:     "rotate one secret",
+			secrets:  map[string]any{"password": "c"},
+			expected: []string{"password", "token"},
+			rotated:  []string{"password"},
+		},
+		{
+			name:     "remove one secret",
+			remove:   []string{"token"},
+			expected: []string{"password"},
+		},
+		{
+			name:      "remove unknown secret",
+			remove:    []string{"unknown"},
+			expectErr: true,
+		},
+		{
+			name:      "set and remove the same secret",
+			secrets:   map[string]any{"token": "c"},
+			remove:    []string{"token"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := encryptSecrets(secretCipher, existing, tt.secrets, tt.remove)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result) != len(tt.expected) {
+				t.Fatalf("expected secrets %v, got %v", tt.expected, result)
+			}
+			for _, name := range tt.expected {
+				changed := result[name] != existing[name]
+				rotated := false
+				for _, r := range tt.rotated {
+					rotated = rotated || r == name
+				}
+				if changed != rotated {
+					t.Errorf("secret %s: expected rotated %t, got %t", name, rotated, changed)
+				}
+			}
+		})
+	}
+}
+
+func TestWithSecretVariables(t *testing.T) {
+	secretCipher := NewSecretCipher(&versource.SecretsConfig{Key: "test-key"})
+	secrets, err := encryptSecrets(secretCipher, nil, map[string]any{"password": "hunter2", "name": "secret"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := withSecretVariables(secretCipher, datatypes.JSON(`{"name":"a","size":"small"}`), secrets)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var actual map[string]any
+	err = json.Unmarshal(result, &actual)
+	if err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	expected := map[string]any{"name": "secret", "size": "small", "password": "hunter2"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	masked, err := json.Marshal(secrets)
+	if err != nil {
+		t.Fatalf("failed to marshal secrets: %v", err)
+	}
+	if strings.Contains(string(masked), "hunter2") || strings.Contains(string(masked), secrets["password"]) {
+		t.Errorf("marshalled secrets are not masked: %s", masked)
+	}
+}
+
+func TestImportSecretsAfterInventoryRoundTrip(t *testing.T) {
+	secretCipher := NewSecretCipher(&versource.SecretsConfig{Key: "test-key"})
+	ciphertext, err := secretCipher.Encrypt([]byte(`"hunter2"`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	component := versource.Component{ID: 1, Name: "component1", Secrets: versource.SecretVariables{"password": ciphertext}}
+	exported := versource.Inventory{
+		Components: []versource.Component{component},
+		Secrets:    []versource.ComponentSecrets{{ComponentID: 1, Ciphertexts: map[string]string(component.Secrets)}},
+	}
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var imported versource.Inventory
+	err = json.Unmarshal(data, &imported)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !imported.Components[0].Secrets.IsMasked() {
+		t.Fatalf("expected exported component secrets to be masked")
+	}
+
+	tests := []struct {
+		name        string
+		cipher      *SecretCipher
+		ciphertexts map[string]string
+		want        versource.SecretVariables
+		wantErr     bool
+	}{
+		{
+			name:        "restores ciphertexts",
+			cipher:      secretCipher,
+			ciphertexts: imported.Secrets[0].Ciphertexts,
+			want:        versource.SecretVariables{"password": ciphertext},
+		},
+		{
+			name:   "loses masked secrets without ciphertexts",
+			cipher: secretCipher,
+		},
+		{
+			name:        "rejects ciphertexts of another key",
+			cipher:      NewSecretCipher(&versource.SecretsConfig{Key: "other-key"}),
+			ciphertexts: imported.Secrets[0].Ciphertexts,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importInventory := &ImportInventory{secretCipher: tt.cipher}
+			secrets, err := importInventory.importSecrets(imported.Components[0], tt.ciphertexts)
+			if tt.wantErr {
+				if !versource.IsUserError(err) {
+					t.Errorf("expected user error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(secrets, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, secrets)
+			}
+		})
+	}
+}
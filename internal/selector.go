@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type selectorOperator string
+
+const (
+	selectorEquals    selectorOperator = "="
+	selectorNotEquals selectorOperator = "!="
+	selectorIn        selectorOperator = "in"
+	selectorNotIn     selectorOperator = "notin"
+	selectorExists    selectorOperator = "exists"
+	selectorNotExists selectorOperator = "!"
+)
+
+type labelRequirement struct {
+	key      string
+	operator selectorOperator
+	values   []string
+}
+
+type LabelSelector []labelRequirement
+
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var requirements LabelSelector
+	for _, term := range splitSelectorTerms(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		requirement, err := parseLabelRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+func splitSelectorTerms(selector string) []string {
+	var terms []string
+	depth := 0
+	start := 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, selector[start:])
+}
+
+func parseLabelRequirement(term string) (labelRequirement, error) {
+	if strings.HasPrefix(term, "!") {
+		key := strings.TrimSpace(term[1:])
+		if !isValidLabelKey(key) {
+			return labelRequirement{}, fmt.Errorf("invalid label key in %q", term)
+		}
+		return labelRequirement{key: key, operator: selectorNotExists}, nil
+	}
+
+	for _, operator := range []selectorOperator{selectorNotIn, selectorIn} {
+		key, values, found := strings.Cut(term, " "+string(operator)+" ")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		values = strings.TrimSpace(values)
+		if !isValidLabelKey(key) {
+			return labelRequirement{}, fmt.Errorf("invalid label key in %q", term)
+		}
+		if !strings.HasPrefix(values, "(") || !strings.HasSuffix(values, ")") {
+			return labelRequirement{}, fmt.Errorf("expected parenthesized values in %q", term)
+		}
+		var parsed []string
+		for _, value := range strings.Split(values[1:len(values)-1], ",") {
+			parsed = append(parsed, strings.TrimSpace(value))
+		}
+		return labelRequirement{key: key, operator: operator, values: parsed}, nil
+	}
+
+	if key, value, found := strings.Cut(term, "!="); found {
+		return newEqualityRequirement(term, key, selectorNotEquals, value)
+	}
+	if key, value, found := strings.Cut(term, "=="); found {
+		return newEqualityRequirement(term, key, selectorEquals, value)
+	}
+	if key, value, found := strings.Cut(term, "="); found {
+		return newEqualityRequirement(term, key, selectorEquals, value)
+	}
+
+	if !isValidLabelKey(term) {
+		return labelRequirement{}, fmt.Errorf("invalid label key in %q", term)
+	}
+	return labelRequirement{key: term, operator: selectorExists}, nil
+}
+
+func newEqualityRequirement(term, key string, operator selectorOperator, value string) (labelRequirement, error) {
+	key = strings.TrimSpace(key)
+	if !isValidLabelKey(key) {
+		return labelRequirement{}, fmt.Errorf("invalid label key in %q", term)
+	}
+	return labelRequirement{key: key, operator: operator, values: []string{strings.TrimSpace(value)}}, nil
+}
+
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if !isValidLabelKey(key) {
+			return fmt.Errorf("invalid label key %q", key)
+		}
+		if strings.ContainsAny(value, " =!(),") {
+			return fmt.Errorf("invalid value %q for label %s", value, key)
+		}
+	}
+	return nil
+}
+
+func isValidLabelKey(key string) bool {
+	return key != "" && !strings.ContainsAny(key, " =!(),")
+}
+
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		value, exists := labels[requirement.key]
+		switch requirement.operator {
+		case selectorEquals:
+			if !exists || value != requirement.values[0] {
+				return false
+			}
+		case selectorNotEquals:
+			if exists && value == requirement.values[0] {
+				return false
+			}
+		case selectorIn:
+			if !exists || !slices.Contains(requirement.values, value) {
+				return false
+			}
+		case selectorNotIn:
+			if exists && slices.Contains(requirement.values, value) {
+				return false
+			}
+		case selectorExists:
+			if !exists {
+				return false
+			}
+		case selectorNotExists:
+			if exists {
+				return false
+			}
+		}
+	}
+	return true
+}
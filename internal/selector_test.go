@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestLabelSelectorMatches(t *testing.T) {
+	labels := map[string]string{
+		"env":    "prod",
+		"team":   "platform",
+		"region": "eu-west-1",
+	}
+
+	tests := []struct {
+		name     string
+		selector string
+		expected bool
+	}{
+		{name: "empty selector", selector: "", expected: true},
+		{name: "equals", selector: "env=prod", expected: true},
+		{name: "double equals", selector: "env==prod", expected: true},
+		{name: "equals mismatch", selector: "env=dev", expected: false},
+		{name: "not equals", selector: "team!=data", expected: true},
+		{name: "not equals mismatch", selector: "team!=platform", expected: false},
+		{name: "not equals on missing key", selector: "owner!=data", expected: true},
+		{name: "combined", selector: "env=prod,team!=data", expected: true},
+		{name: "combined mismatch", selector: "env=prod,team=data", expected: false},
+		{name: "in", selector: "region in (eu-west-1, us-east-1)", expected: true},
+		{name: "in mismatch", selector: "region in (us-east-1)", expected: false},
+		{name: "notin", selector: "env notin (dev,staging)", expected: true},
+		{name: "notin mismatch", selector: "env notin (prod)", expected: false},
+		{name: "exists", selector: "team", expected: true},
+		{name: "exists mismatch", selector: "owner", expected: false},
+		{name: "not exists", selector: "!owner", expected: true},
+		{name: "not exists mismatch", selector: "!team", expected: false},
+		{name: "set and equality combined", selector: "env in (prod,dev),team=platform", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := ParseLabelSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("Failed to parse selector %q: %v", tt.selector, err)
+			}
+			if selector.Matches(labels) != tt.expected {
+				t.Errorf("Expected selector %q to match %v", tt.selector, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseLabelSelectorInvalid(t *testing.T) {
+	tests := []string{
+		"=prod",
+		"!=prod",
+		"env in prod",
+		"!",
+	}
+
+	for _, selector := range tests {
+		t.Run(selector, func(t *testing.T) {
+			_, err := ParseLabelSelector(selector)
+			if err == nil {
+				t.Errorf("Expected selector %q to be invalid", selector)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gopkg.in/yaml.v3"
+)
+
+type InventoryArchive struct {
+	dir string
+}
+
+func NewInventoryArchive(dir string) *InventoryArchive {
+	return &InventoryArchive{
+		dir: dir,
+	}
+}
+
+type inventoryMetadata struct {
+	FormatVersion int `json:"formatVersion" yaml:"formatVersion"`
+}
+
+func (a *InventoryArchive) Write(inventory versource.Inventory, format string) error {
+	if format != "yaml" && format != "json" {
+		return fmt.Errorf("unsupported archive format: %s", format)
+	}
+
+	err := os.MkdirAll(a.dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	err = writeArchiveFile(filepath.Join(a.dir, "inventory."+format), inventoryMetadata{FormatVersion: inventory.FormatVersion}, format)
+	if err != nil {
+		return err
+	}
+
+	err = writeArchiveEntities(a.dir, "modules", format, inventory.Modules, func(e versource.Module) string { return idKey(e.ID) })
+	if err != nil {
+		return err
+	}
+	err = writeArchiveEntities(a.dir, "module-versions", format, inventory.ModuleVersions, func(e versource.ModuleVersion) string { return idKey(e.ID) })
+	if err != nil {
+		return err
+	}
+	err = writeArchiveEntities(a.dir, "components", format, inventory.Components, func(e versource.Component) string { return idKey(e.ID) })
+	if err != nil {
+		return err
+	}
+	err = writeArchiveEntities(a.dir, "resources", format, inventory.Resources, func(e versource.Resource) string { return e.UUID })
+	if err != nil {
+		return err
+	}
+	err = writeArchiveEntities(a.dir, "states", format, inventory.States, func(e versource.State) string { return idKey(e.ID) })
+	if err != nil {
+		return err
+	}
+	err = writeArchiveEntities(a.dir, "state-resources", format, inventory.StateResources, func(e versource.StateResource) string { return idKey(e.ID) })
+	if err != nil {
+		return err
+	}
+	err = writeArchiveEntities(a.dir, "view-resources", format, inventory.ViewResources, func(e versource.ViewResource) string { return idKey(e.ID) })
+	if err != nil {
+		return err
+	}
+	err = writeArchiveEntities(a.dir, "secrets", format, inventory.Secrets, func(e versource.ComponentSecrets) string { return idKey(e.ComponentID) })
+	if err != nil {
+		return err
+	}
+	err = writeArchiveEntities(a.dir, "teams", format, inventory.Teams, func(e versource.Team) string { return idKey(e.ID) })
+	if err != nil {
+		return err
+	}
+
+	statesDir := filepath.Join(a.dir, "terraform-states")
+	err = os.MkdirAll(statesDir, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create terraform states directory: %w", err)
+	}
+	for _, terraformState := range inventory.TerraformStates {
+		statePath := filepath.Join(statesDir, fmt.Sprintf("%d.tfstate", terraformState.ComponentID))
+		err = os.WriteFile(statePath, terraformState.Content, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to write terraform state: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *InventoryArchive) Read() (*versource.Inventory, error) {
+	var metadata inventoryMetadata
+	metadataPath, err := findArchiveFile(a.dir, "inventory")
+	if err != nil {
+		return nil, err
+	}
+	err = readArchiveFile(metadataPath, &metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := versource.Inventory{
+		FormatVersion: metadata.FormatVersion,
+	}
+
+	inventory.Modules, err = readArchiveEntities[versource.Module](a.dir, "modules")
+	if err != nil {
+		return nil, err
+	}
+	inventory.ModuleVersions, err = readArchiveEntities[versource.ModuleVersion](a.dir, "module-versions")
+	if err != nil {
+		return nil, err
+	}
+	inventory.Components, err = readArchiveEntities[versource.Component](a.dir, "components")
+	if err != nil {
+		return nil, err
+	}
+	inventory.Resources, err = readArchiveEntities[versource.Resource](a.dir, "resources")
+	if err != nil {
+		return nil, err
+	}
+	inventory.States, err = readArchiveEntities[versource.State](a.dir, "states")
+	if err != nil {
+		return nil, err
+	}
+	inventory.StateResources, err = readArchiveEntities[versource.StateResource](a.dir, "state-resources")
+	if err != nil {
+		return nil, err
+	}
+	inventory.ViewResources, err = readArchiveEntities[versource.ViewResource](a.dir, "view-resources")
+	if err != nil {
+		return nil, err
+	}
+	inventory.Secrets, err = readArchiveEntities[versource.ComponentSecrets](a.dir, "secrets")
+	if err != nil {
+		return nil, err
+	}
+	inventory.Teams, err = readArchiveEntities[versource.Team](a.dir, "teams")
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(a.dir, "terraform-states"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read terraform states directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tfstate" {
+			continue
+		}
+		componentID, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), ".tfstate"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid terraform state file name: %s", entry.Name())
+		}
+		content, err := os.ReadFile(filepath.Join(a.dir, "terraform-states", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read terraform state: %w", err)
+		}
+		inventory.TerraformStates = append(inventory.TerraformStates, versource.TerraformState{
+			ComponentID: uint(componentID),
+			Content:     content,
+		})
+	}
+
+	return &inventory, nil
+}
+
+func idKey(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func writeArchiveEntities[T any](dir, kind, format string, entities []T, key func(T) string) error {
+	kindDir := filepath.Join(dir, kind)
+	err := os.MkdirAll(kindDir, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", kind, err)
+	}
+
+	for _, entity := range entities {
+		err = writeArchiveFile(filepath.Join(kindDir, key(entity)+"."+format), entity, format)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeArchiveFile(path string, value any, format string) error {
+	jsonData, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+
+	data := jsonData
+	if format == "yaml" {
+		var generic any
+		err = json.Unmarshal(jsonData, &generic)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", path, err)
+		}
+		data, err = yaml.Marshal(generic)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", path, err)
+		}
+	}
+
+	err = os.WriteFile(path, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func readArchiveEntities[T any](dir, kind string) ([]T, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, kind))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s directory: %w", kind, err)
+	}
+
+	var entities []T
+	for _, entry := range entries {
+		if entry.IsDir() || !isArchiveFile(entry.Name()) {
+			continue
+		}
+		var entity T
+		err = readArchiveFile(filepath.Join(dir, kind, entry.Name()), &entity)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+func readArchiveFile(path string, value any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	jsonData := data
+	if filepath.Ext(path) != ".json" {
+		var generic any
+		err = yaml.Unmarshal(data, &generic)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		jsonData, err = json.Marshal(generic)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", path, err)
+		}
+	}
+
+	err = json.Unmarshal(jsonData, value)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func findArchiveFile(dir, name string) (string, error) {
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		path := filepath.Join(dir, name+ext)
+		_, err := os.Stat(path)
+		if err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no %s file found in %s", name, dir)
+}
+
+func isArchiveFile(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
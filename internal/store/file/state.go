@@ -0,0 +1,48 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type StateStore struct {
+	workDir string
+}
+
+func NewStateStore(workDir string) *StateStore {
+	return &StateStore{
+		workDir: workDir,
+	}
+}
+
+func (s *StateStore) LoadState(ctx context.Context, componentID uint) ([]byte, error) {
+	statePath := filepath.Join(s.workDir, "states", fmt.Sprintf("%d.tfstate", componentID))
+
+	content, err := os.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	return content, nil
+}
+
+func (s *StateStore) StoreState(ctx context.Context, componentID uint, content []byte) error {
+	statesDir := filepath.Join(s.workDir, "states")
+	err := os.MkdirAll(statesDir, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create states directory: %w", err)
+	}
+
+	statePath := filepath.Join(statesDir, fmt.Sprintf("%d.tfstate", componentID))
+	err = os.WriteFile(statePath, content, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,426 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type TeamRepo interface {
+	GetTeamByName(ctx context.Context, name string) (*versource.Team, error)
+	ListTeams(ctx context.Context) ([]versource.Team, error)
+	HasTeamWithName(ctx context.Context, name string) (bool, error)
+	CreateTeam(ctx context.Context, team *versource.Team) error
+	AddTeamMember(ctx context.Context, member *versource.TeamMember) error
+	RemoveTeamMember(ctx context.Context, teamID uint, user string) error
+}
+
+type ChangesetApprovalRepo interface {
+	ListChangesetApprovals(ctx context.Context, changesetID uint) ([]versource.ChangesetApproval, error)
+	CreateChangesetApproval(ctx context.Context, approval *versource.ChangesetApproval) error
+	UpdateChangesetApproval(ctx context.Context, approval *versource.ChangesetApproval) error
+}
+
+type ListTeams struct {
+	teamRepo TeamRepo
+	tx       TransactionManager
+}
+
+func NewListTeams(teamRepo TeamRepo, tx TransactionManager) *ListTeams {
+	return &ListTeams{
+		teamRepo: teamRepo,
+		tx:       tx,
+	}
+}
+
+func (l *ListTeams) Exec(ctx context.Context, req versource.ListTeamsRequest) (*versource.ListTeamsResponse, error) {
+	var teams []versource.Team
+	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		teams, err = l.teamRepo.ListTeams(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list teams", err)
+	}
+
+	return &versource.ListTeamsResponse{
+		Teams: teams,
+	}, nil
+}
+
+type CreateTeam struct {
+	teamRepo TeamRepo
+	tx       TransactionManager
+}
+
+func NewCreateTeam(teamRepo TeamRepo, tx TransactionManager) *CreateTeam {
+	return &CreateTeam{
+		teamRepo: teamRepo,
+		tx:       tx,
+	}
+}
+
+func (c *CreateTeam) Exec(ctx context.Context, req versource.CreateTeamRequest) (*versource.CreateTeamResponse, error) {
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+
+	var response *versource.CreateTeamResponse
+	err := c.tx.Do(ctx, AdminBranch, fmt.Sprintf("create team %s", req.Name), func(ctx context.Context) error {
+		exists, err := c.teamRepo.HasTeamWithName(ctx, req.Name)
+		if err != nil {
+			return versource.InternalErrE("failed to check team existence", err)
+		}
+		if exists {
+			return versource.ConflictErrf("team %s already exists", req.Name)
+		}
+
+		team := &versource.Team{
+			Name:    req.Name,
+			Members: []versource.TeamMember{},
+		}
+		err = c.teamRepo.CreateTeam(ctx, team)
+		if err != nil {
+			return versource.InternalErrE("failed to create team", err)
+		}
+
+		response = &versource.CreateTeamResponse{
+			Team: *team,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type AddTeamMember struct {
+	teamRepo TeamRepo
+	tx       TransactionManager
+}
+
+func NewAddTeamMember(teamRepo TeamRepo, tx TransactionManager) *AddTeamMember {
+	return &AddTeamMember{
+		teamRepo: teamRepo,
+		tx:       tx,
+	}
+}
+
+func (a *AddTeamMember) Exec(ctx context.Context, req versource.AddTeamMemberRequest) (*versource.AddTeamMemberResponse, error) {
+	if req.TeamName == "" {
+		return nil, versource.UserErr("team is required")
+	}
+	if req.User == "" {
+		return nil, versource.UserErr("user is required")
+	}
+	if !versource.IsValidUser(req.User) {
+		return nil, versource.UserErr("user must not contain angle brackets or control characters")
+	}
+
+	var response *versource.AddTeamMemberResponse
+	err := a.tx.Do(ctx, AdminBranch, fmt.Sprintf("add member %s to team %s", req.User, req.TeamName), func(ctx context.Context) error {
+		team, err := a.teamRepo.GetTeamByName(ctx, req.TeamName)
+		if err != nil {
+			return versource.InternalErrE("failed to get team", err)
+		}
+		if team == nil {
+			return versource.NotFoundErrf("team %s not found", req.TeamName)
+		}
+
+		if !isTeamMember(*team, req.User) {
+			member := versource.TeamMember{
+				TeamID: team.ID,
+				User:   req.User,
+			}
+			err = a.teamRepo.AddTeamMember(ctx, &member)
+			if err != nil {
+				return versource.InternalErrE("failed to add team member", err)
+			}
+			team.Members = append(team.Members, member)
+		}
+
+		response = &versource.AddTeamMemberResponse{
+			Team: *team,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type RemoveTeamMember struct {
+	teamRepo TeamRepo
+	tx       TransactionManager
+}
+
+func NewRemoveTeamMember(teamRepo TeamRepo, tx TransactionManager) *RemoveTeamMember {
+	return &RemoveTeamMember{
+		teamRepo: teamRepo,
+		tx:       tx,
+	}
+}
+
+func (r *RemoveTeamMember) Exec(ctx context.Context, req versource.RemoveTeamMemberRequest) (*versource.RemoveTeamMemberResponse, error) {
+	if req.TeamName == "" {
+		return nil, versource.UserErr("team is required")
+	}
+	if req.User == "" {
+		return nil, versource.UserErr("user is required")
+	}
+
+	var response *versource.RemoveTeamMemberResponse
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("remove member %s from team %s", req.User, req.TeamName), func(ctx context.Context) error {
+		team, err := r.teamRepo.GetTeamByName(ctx, req.TeamName)
+		if err != nil {
+			return versource.InternalErrE("failed to get team", err)
+		}
+		if team == nil {
+			return versource.NotFoundErrf("team %s not found", req.TeamName)
+		}
+		if !isTeamMember(*team, req.User) {
+			return versource.UserErrf("user %s is not a member of team %s", req.User, req.TeamName)
+		}
+
+		err = r.teamRepo.RemoveTeamMember(ctx, team.ID, req.User)
+		if err != nil {
+			return versource.InternalErrE("failed to remove team member", err)
+		}
+		team.Members = slices.DeleteFunc(team.Members, func(member versource.TeamMember) bool {
+			return member.User == req.User
+		})
+
+		response = &versource.RemoveTeamMemberResponse{
+			Team: *team,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type ApproveChangeset struct {
+	changesetRepo         ChangesetRepo
+	changesetApprovalRepo ChangesetApprovalRepo
+	teamRepo              TeamRepo
+	listComponentChanges  *ListComponentChanges
+	tx                    TransactionManager
+}
+
+func NewApproveChangeset(changesetRepo ChangesetRepo, changesetApprovalRepo ChangesetApprovalRepo, teamRepo TeamRepo, listComponentChanges *ListComponentChanges, tx TransactionManager) *ApproveChangeset {
+	return &ApproveChangeset{
+		changesetRepo:         changesetRepo,
+		changesetApprovalRepo: changesetApprovalRepo,
+		teamRepo:              teamRepo,
+		listComponentChanges:  listComponentChanges,
+		tx:                    tx,
+	}
+}
+
+func (a *ApproveChangeset) Exec(ctx context.Context, req versource.ApproveChangesetRequest) (*versource.ApproveChangesetResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset name is required")
+	}
+	user := versource.UserFromContext(ctx)
+	if user == "" {
+		return nil, versource.ForbiddenErr("an authenticated user is required to approve a changeset")
+	}
+
+	changesResp, err := a.listComponentChanges.listAll(ctx, versource.ListComponentChangesRequest{ChangesetName: req.ChangesetName})
+	if err != nil {
+		return nil, err
+	}
+	teams := requiredApprovalTeams(changesResp.Changes)
+
+	head, err := a.tx.GetBranchHead(ctx, req.ChangesetName)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to get changeset head", err)
+	}
+
+	var response *versource.ApproveChangesetResponse
+	err = a.tx.Do(ctx, AdminBranch, fmt.Sprintf("approve changeset %s", req.ChangesetName), func(ctx context.Context) error {
+		changeset, err := a.changesetRepo.GetOpenChangesetByName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found or not open")
+		}
+
+		approvals, err := a.changesetApprovalRepo.ListChangesetApprovals(ctx, changeset.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to list changeset approvals", err)
+		}
+
+		index := slices.IndexFunc(approvals, func(approval versource.ChangesetApproval) bool {
+			return approval.User == user
+		})
+		var approval versource.ChangesetApproval
+		if index >= 0 {
+			approval = approvals[index]
+			if approval.Head != head {
+				approval.Head = head
+				err = a.changesetApprovalRepo.UpdateChangesetApproval(ctx, &approval)
+				if err != nil {
+					return versource.InternalErrE("failed to update changeset approval", err)
+				}
+				approvals[index] = approval
+			}
+		} else {
+			approval = versource.ChangesetApproval{
+				ChangesetID: changeset.ID,
+				User:        user,
+				Head:        head,
+			}
+			err = a.changesetApprovalRepo.CreateChangesetApproval(ctx, &approval)
+			if err != nil {
+				return versource.InternalErrE("failed to create changeset approval", err)
+			}
+			approvals = append(approvals, approval)
+		}
+
+		missing, err := missingApprovalTeams(ctx, a.teamRepo, currentApprovals(approvals, head), teams)
+		if err != nil {
+			return versource.InternalErrE("failed to check changeset approvals", err)
+		}
+
+		reviewState := versource.ChangesetReviewStatePending
+		if len(missing) == 0 {
+			reviewState = versource.ChangesetReviewStateApproved
+		}
+		err = a.changesetRepo.UpdateChangesetReviewState(ctx, changeset.ID, reviewState)
+		if err != nil {
+			return versource.InternalErrE("failed to update changeset review state", err)
+		}
+
+		response = &versource.ApproveChangesetResponse{
+			Approval:         approval,
+			MissingApprovals: missing,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type ListChangesetApprovals struct {
+	changesetRepo         ChangesetRepo
+	changesetApprovalRepo ChangesetApprovalRepo
+	tx                    TransactionManager
+}
+
+func NewListChangesetApprovals(changesetRepo ChangesetRepo, changesetApprovalRepo ChangesetApprovalRepo, tx TransactionManager) *ListChangesetApprovals {
+	return &ListChangesetApprovals{
+		changesetRepo:         changesetRepo,
+		changesetApprovalRepo: changesetApprovalRepo,
+		tx:                    tx,
+	}
+}
+
+func (l *ListChangesetApprovals) Exec(ctx context.Context, req versource.ListChangesetApprovalsRequest) (*versource.ListChangesetApprovalsResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset name is required")
+	}
+
+	var approvals []versource.ChangesetApproval
+	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		changeset, err := l.changesetRepo.GetChangesetByName(ctx, req.ChangesetName)
+		if err != nil {
+			return versource.InternalErrE("failed to get changeset", err)
+		}
+		if changeset == nil {
+			return versource.NotFoundErr("changeset not found")
+		}
+
+		approvals, err = l.changesetApprovalRepo.ListChangesetApprovals(ctx, changeset.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to list changeset approvals", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &versource.ListChangesetApprovalsResponse{
+		Approvals: approvals,
+	}, nil
+}
+
+func ensureTeamExists(ctx context.Context, tx TransactionManager, teamRepo TeamRepo, name string) error {
+	if name == "" {
+		return nil
+	}
+	var exists bool
+	err := tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		exists, err = teamRepo.HasTeamWithName(ctx, name)
+		return err
+	})
+	if err != nil {
+		return versource.InternalErrE("failed to check team existence", err)
+	}
+	if !exists {
+		return versource.NotFoundErrf("team %s not found", name)
+	}
+	return nil
+}
+
+func requiredApprovalTeams(changes []versource.ComponentChange) []string {
+	teams := make([]string, 0)
+	for _, change := range changes {
+		for _, component := range []*versource.Component{change.FromComponent, change.ToComponent} {
+			if component != nil && component.Owner != "" && !slices.Contains(teams, component.Owner) {
+				teams = append(teams, component.Owner)
+			}
+		}
+	}
+	slices.Sort(teams)
+	return teams
+}
+
+func currentApprovals(approvals []versource.ChangesetApproval, head string) []versource.ChangesetApproval {
+	current := make([]versource.ChangesetApproval, 0, len(approvals))
+	for _, approval := range approvals {
+		if approval.Head == head {
+			current = append(current, approval)
+		}
+	}
+	return current
+}
+
+func missingApprovalTeams(ctx context.Context, teamRepo TeamRepo, approvals []versource.ChangesetApproval, teams []string) ([]string, error) {
+	missing := make([]string, 0)
+	for _, name := range teams {
+		team, err := teamRepo.GetTeamByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		approved := team != nil && slices.ContainsFunc(approvals, func(approval versource.ChangesetApproval) bool {
+			return isTeamMember(*team, approval.User)
+		})
+		if !approved {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+func isTeamMember(team versource.Team, user string) bool {
+	return slices.ContainsFunc(team.Members, func(member versource.TeamMember) bool {
+		return member.User == user
+	})
+}
@@ -1,12 +1,17 @@
 package internal
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 const (
 	MainBranch  = "main"
 	AdminBranch = "admin"
 )
 
+var ErrNotFound = errors.New("record not found")
+
 type TransactionManager interface {
 	Do(ctx context.Context, branch, message string, fn func(ctx context.Context) error) error
 	Checkout(ctx context.Context, branch string, fn func(ctx context.Context) error) error
@@ -16,6 +21,8 @@ type TransactionManager interface {
 	MergeBranch(ctx context.Context, branch string) error
 	DeleteBranch(ctx context.Context, branch string) error
 	RebaseBranch(ctx context.Context, onto string) error
+	CreateTag(ctx context.Context, tag, ref string) error
+	ListConflictedTables(ctx context.Context) ([]string, error)
 
 	GetMergeBase(ctx context.Context, source, branch string) (string, error)
 	GetHead(ctx context.Context) (string, error)
@@ -37,6 +44,14 @@ func IsValidCommitHash(hash string) bool {
 	return true
 }
 
+func MergeTag(changesetName string) string {
+	return "merge/" + changesetName
+}
+
+func IsValidRevision(revision string) bool {
+	return IsValidCommitHash(revision) || IsValidBranch(revision)
+}
+
 func IsValidBranch(branch string) bool {
 	if branch == "" {
 		return false
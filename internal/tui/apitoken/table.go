@@ -0,0 +1,66 @@
+package apitoken
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type TableData struct {
+	facade versource.Facade
+}
+
+func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewTableData(facade))
+	}
+}
+
+func NewTableData(facade versource.Facade) *TableData {
+	return &TableData{
+		facade: facade,
+	}
+}
+
+func (p *TableData) LoadData() ([]versource.ApiToken, error) {
+	ctx := context.Background()
+	resp, err := p.facade.ListApiTokens(ctx, versource.ListApiTokensRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.ApiTokens, nil
+}
+
+func (p *TableData) ResolveData(data []versource.ApiToken) ([]table.Column, []table.Row, []versource.ApiToken) {
+	columns := []table.Column{
+		{Title: "ID", Width: 1},
+		{Title: "Name", Width: 4},
+		{Title: "User", Width: 4},
+		{Title: "Role", Width: 2},
+	}
+
+	var rows []table.Row
+	var elems []versource.ApiToken
+	for _, apiToken := range data {
+		rows = append(rows, table.Row{
+			strconv.FormatUint(uint64(apiToken.ID), 10),
+			apiToken.Name,
+			apiToken.User,
+			string(apiToken.Role),
+		})
+		elems = append(elems, apiToken)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *TableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *TableData) ElemKeyBindings(elem versource.ApiToken) platform.KeyBindings {
+	return platform.KeyBindings{}
+}
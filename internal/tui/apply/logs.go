@@ -17,7 +17,7 @@ type LogsData struct {
 
 func NewLogs(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataViewport(NewLogsData(
+		return platform.NewStreamViewport(NewLogsData(
 			facade,
 			params["applyID"],
 		))
@@ -31,7 +31,7 @@ func NewLogsData(facade versource.Facade, applyID string) *LogsData {
 	}
 }
 
-func (p *LogsData) LoadData() (*versource.GetApplyLogResponse, error) {
+func (p *LogsData) OpenStream() (io.ReadCloser, error) {
 	ctx := context.Background()
 
 	applyIDUint, err := strconv.ParseUint(p.applyID, 10, 32)
@@ -39,26 +39,16 @@ func (p *LogsData) LoadData() (*versource.GetApplyLogResponse, error) {
 		return nil, err
 	}
 
-	req := versource.GetApplyLogRequest{ApplyID: uint(applyIDUint)}
+	req := versource.GetApplyLogRequest{ApplyID: uint(applyIDUint), Follow: true}
 
 	resp, err := p.facade.GetApplyLog(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return resp.Content, nil
 }
 
-func (p *LogsData) ResolveData(data versource.GetApplyLogResponse) string {
-	content, err := io.ReadAll(data.Content)
-	if err != nil {
-		return "Failed to read log content"
-	}
-	defer data.Content.Close()
-
-	return string(content)
-}
-
-func (p *LogsData) KeyBindings(elem versource.GetApplyLogResponse) platform.KeyBindings {
+func (p *LogsData) KeyBindings() platform.KeyBindings {
 	return platform.KeyBindings{
 		{Key: "esc", Help: "View apply", Command: fmt.Sprintf("applies/%s", p.applyID)},
 	}
@@ -11,26 +11,69 @@ import (
 )
 
 type TableData struct {
-	facade versource.Facade
+	facade        versource.Facade
+	changesetName string
+	state         string
+	createdAfter  string
+	createdBefore string
+	page          versource.PageRequest
 }
 
 func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataTable(NewTableData(facade))
+		return platform.NewDataTable(NewTableData(facade, params["changeset"], params["state"], params["created-after"], params["created-before"], versource.PageRequest{}))
 	}
 }
 
-func NewTableData(facade versource.Facade) *TableData {
-	return &TableData{facade: facade}
+func NewTableData(facade versource.Facade, changesetName, state, createdAfter, createdBefore string, page versource.PageRequest) *TableData {
+	return &TableData{
+		facade:        facade,
+		changesetName: changesetName,
+		state:         state,
+		createdAfter:  createdAfter,
+		createdBefore: createdBefore,
+		page:          page,
+	}
 }
 
 func (p *TableData) LoadData() ([]versource.Apply, error) {
+	applies, _, err := p.LoadPage("")
+	return applies, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.Apply, string, error) {
 	ctx := context.Background()
-	resp, err := p.facade.ListApplies(ctx, versource.ListAppliesRequest{})
+	req := versource.ListAppliesRequest{
+		PageRequest: p.page,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
+
+	if p.changesetName != "" {
+		req.ChangesetName = &p.changesetName
+	}
+
+	if p.state != "" {
+		state := versource.TaskState(p.state)
+		req.State = &state
+	}
+
+	var err error
+	req.CreatedAfter, err = platform.ParseTimeParam("created-after", p.createdAfter)
+	if err != nil {
+		return nil, "", err
+	}
+	req.CreatedBefore, err = platform.ParseTimeParam("created-before", p.createdBefore)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := p.facade.ListApplies(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.Applies, nil
+	return resp.Applies, resp.NextCursor, nil
 }
 
 func (p *TableData) ResolveData(data []versource.Apply) ([]table.Column, []table.Row, []versource.Apply) {
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type TableData struct {
+	facade versource.Facade
+	branch string
+	author string
+	page   versource.PageRequest
+}
+
+func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewTableData(facade, params["branch"], params["author"], versource.PageRequest{}))
+	}
+}
+
+func NewTableData(facade versource.Facade, branch, author string, page versource.PageRequest) *TableData {
+	return &TableData{
+		facade: facade,
+		branch: branch,
+		author: author,
+		page:   page,
+	}
+}
+
+func (p *TableData) LoadData() ([]versource.AuditEvent, error) {
+	events, _, err := p.LoadPage("")
+	return events, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.AuditEvent, string, error) {
+	ctx := context.Background()
+
+	req := versource.ListAuditEventsRequest{
+		PageRequest: p.page,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
+	if p.branch != "" {
+		req.Branch = &p.branch
+	}
+	if p.author != "" {
+		req.Author = &p.author
+	}
+
+	resp, err := p.facade.ListAuditEvents(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.AuditEvents, resp.NextCursor, nil
+}
+
+func (p *TableData) ResolveData(data []versource.AuditEvent) ([]table.Column, []table.Row, []versource.AuditEvent) {
+	columns := []table.Column{
+		{Title: "Date", Width: 3},
+		{Title: "Branch", Width: 2},
+		{Title: "Author", Width: 2},
+		{Title: "Commit", Width: 2},
+		{Title: "Message", Width: 6},
+	}
+
+	var rows []table.Row
+	var elems []versource.AuditEvent
+	for _, event := range data {
+		rows = append(rows, table.Row{
+			event.Date.Format(time.DateTime),
+			event.Branch,
+			event.Author,
+			event.Commit,
+			event.Message,
+		})
+		elems = append(elems, event)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *TableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *TableData) ElemKeyBindings(elem versource.AuditEvent) platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "enter", Help: "View events by author", Command: "audit?author=" + elem.Author},
+	}
+}
@@ -0,0 +1,67 @@
+package changeset
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type ApprovalsTableData struct {
+	facade        versource.Facade
+	changesetName string
+}
+
+func NewApprovalsTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewApprovalsTableData(facade, params["changesetName"]))
+	}
+}
+
+func NewApprovalsTableData(facade versource.Facade, changesetName string) *ApprovalsTableData {
+	return &ApprovalsTableData{
+		facade:        facade,
+		changesetName: changesetName,
+	}
+}
+
+func (p *ApprovalsTableData) LoadData() ([]versource.ChangesetApproval, error) {
+	ctx := context.Background()
+	req := versource.ListChangesetApprovalsRequest{
+		ChangesetName: p.changesetName,
+	}
+	resp, err := p.facade.ListChangesetApprovals(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Approvals, nil
+}
+
+func (p *ApprovalsTableData) ResolveData(data []versource.ChangesetApproval) ([]table.Column, []table.Row, []versource.ChangesetApproval) {
+	columns := []table.Column{
+		{Title: "ID", Width: 1},
+		{Title: "User", Width: 6},
+	}
+
+	var rows []table.Row
+	var elems []versource.ChangesetApproval
+	for _, approval := range data {
+		rows = append(rows, table.Row{
+			strconv.FormatUint(uint64(approval.ID), 10),
+			approval.User,
+		})
+		elems = append(elems, approval)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *ApprovalsTableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *ApprovalsTableData) ElemKeyBindings(elem versource.ChangesetApproval) platform.KeyBindings {
+	return platform.KeyBindings{}
+}
@@ -0,0 +1,37 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type ApproveChangesetData struct {
+	facade        versource.Facade
+	changesetName string
+}
+
+func NewApproveChangeset(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewConfirmationPage(&ApproveChangesetData{facade: facade, changesetName: params["changesetName"]})
+	}
+}
+
+func (c *ApproveChangesetData) GetConfirmationDialog() platform.ConfirmationDialog {
+	return platform.ConfirmationDialog{
+		Title:       "Approve Changeset",
+		Message:     fmt.Sprintf("Are you sure you want to approve changeset '%s'?", c.changesetName),
+		ConfirmText: "approve",
+		CancelText:  "cancel",
+	}
+}
+
+func (c *ApproveChangesetData) OnConfirm(ctx context.Context) (string, error) {
+	_, err := c.facade.ApproveChangeset(ctx, versource.ApproveChangesetRequest{ChangesetName: c.changesetName})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("changesets/%s/approvals", c.changesetName), nil
+}
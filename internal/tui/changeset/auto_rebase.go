@@ -0,0 +1,53 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type AutoRebaseChangesetData struct {
+	facade        versource.Facade
+	changesetName string
+	enabled       bool
+}
+
+func NewAutoRebaseChangeset(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewConfirmationPage(&AutoRebaseChangesetData{
+			facade:        facade,
+			changesetName: params["changesetName"],
+			enabled:       params["enabled"] == "true",
+		})
+	}
+}
+
+func (a *AutoRebaseChangesetData) GetConfirmationDialog() platform.ConfirmationDialog {
+	if a.enabled {
+		return platform.ConfirmationDialog{
+			Title:       "Enable Auto Rebase",
+			Message:     fmt.Sprintf("Are you sure you want to enable automatic rebasing for changeset '%s'?\n\nThe changeset will be rebased as soon as main moves and none of its components overlap.", a.changesetName),
+			ConfirmText: "enable",
+			CancelText:  "cancel",
+		}
+	}
+	return platform.ConfirmationDialog{
+		Title:       "Disable Auto Rebase",
+		Message:     fmt.Sprintf("Are you sure you want to disable automatic rebasing for changeset '%s'?", a.changesetName),
+		ConfirmText: "disable",
+		CancelText:  "cancel",
+	}
+}
+
+func (a *AutoRebaseChangesetData) OnConfirm(ctx context.Context) (string, error) {
+	_, err := a.facade.UpdateChangeset(ctx, versource.UpdateChangesetRequest{
+		ChangesetName: a.changesetName,
+		AutoRebase:    &a.enabled,
+	})
+	if err != nil {
+		return "", err
+	}
+	return "changesets", nil
+}
@@ -0,0 +1,37 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type CloseChangesetData struct {
+	facade        versource.Facade
+	changesetName string
+}
+
+func NewCloseChangeset(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewConfirmationPage(&CloseChangesetData{facade: facade, changesetName: params["changesetName"]})
+	}
+}
+
+func (c *CloseChangesetData) GetConfirmationDialog() platform.ConfirmationDialog {
+	return platform.ConfirmationDialog{
+		Title:       "Close Changeset",
+		Message:     fmt.Sprintf("Are you sure you want to close changeset '%s'?\n\nThe branch, plans and logs are kept and the changeset can be reopened later.", c.changesetName),
+		ConfirmText: "close",
+		CancelText:  "cancel",
+	}
+}
+
+func (c *CloseChangesetData) OnConfirm(ctx context.Context) (string, error) {
+	_, err := c.facade.CloseChangeset(ctx, versource.CloseChangesetRequest{ChangesetName: c.changesetName})
+	if err != nil {
+		return "", err
+	}
+	return "changesets", nil
+}
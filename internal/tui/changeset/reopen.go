@@ -0,0 +1,40 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type ReopenChangesetData struct {
+	facade        versource.Facade
+	changesetName string
+}
+
+func NewReopenChangeset(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewConfirmationPage(&ReopenChangesetData{facade: facade, changesetName: params["changesetName"]})
+	}
+}
+
+func (r *ReopenChangesetData) GetConfirmationDialog() platform.ConfirmationDialog {
+	return platform.ConfirmationDialog{
+		Title:       "Reopen Changeset",
+		Message:     fmt.Sprintf("Are you sure you want to reopen changeset '%s'?\n\nIf main has moved since the changeset was closed, you will be asked to rebase it.", r.changesetName),
+		ConfirmText: "reopen",
+		CancelText:  "cancel",
+	}
+}
+
+func (r *ReopenChangesetData) OnConfirm(ctx context.Context) (string, error) {
+	resp, err := r.facade.ReopenChangeset(ctx, versource.ReopenChangesetRequest{ChangesetName: r.changesetName})
+	if err != nil {
+		return "", err
+	}
+	if resp.RebaseRequired {
+		return fmt.Sprintf("changesets/%s/rebase", r.changesetName), nil
+	}
+	return "changesets", nil
+}
@@ -0,0 +1,37 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type RevertChangesetData struct {
+	facade        versource.Facade
+	changesetName string
+}
+
+func NewRevertChangeset(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewConfirmationPage(&RevertChangesetData{facade: facade, changesetName: params["changesetName"]})
+	}
+}
+
+func (r *RevertChangesetData) GetConfirmationDialog() platform.ConfirmationDialog {
+	return platform.ConfirmationDialog{
+		Title:       "Revert Changeset",
+		Message:     fmt.Sprintf("Are you sure you want to revert changeset '%s'?\n\nA new changeset will be created that restores every component it touched to its state before the merge.", r.changesetName),
+		ConfirmText: "revert",
+		CancelText:  "cancel",
+	}
+}
+
+func (r *RevertChangesetData) OnConfirm(ctx context.Context) (string, error) {
+	resp, err := r.facade.RevertChangeset(ctx, versource.RevertChangesetRequest{ChangesetName: r.changesetName})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("changesets/%s/changes", resp.Changeset.Name), nil
+}
@@ -11,26 +11,67 @@ import (
 )
 
 type TableData struct {
-	facade versource.Facade
+	facade        versource.Facade
+	includeClosed bool
+	state         string
+	createdAfter  string
+	createdBefore string
+	page          versource.PageRequest
 }
 
 func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataTable(NewTableData(facade))
+		includeClosed := params["include-closed"] == "true"
+		return platform.NewDataTable(NewTableData(facade, includeClosed, params["state"], params["created-after"], params["created-before"], versource.PageRequest{}))
 	}
 }
 
-func NewTableData(facade versource.Facade) *TableData {
-	return &TableData{facade: facade}
+func NewTableData(facade versource.Facade, includeClosed bool, state, createdAfter, createdBefore string, page versource.PageRequest) *TableData {
+	return &TableData{
+		facade:        facade,
+		includeClosed: includeClosed,
+		state:         state,
+		createdAfter:  createdAfter,
+		createdBefore: createdBefore,
+		page:          page,
+	}
 }
 
 func (p *TableData) LoadData() ([]versource.Changeset, error) {
+	changesets, _, err := p.LoadPage("")
+	return changesets, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.Changeset, string, error) {
 	ctx := context.Background()
-	resp, err := p.facade.ListChangesets(ctx, versource.ListChangesetsRequest{})
+	req := versource.ListChangesetsRequest{
+		PageRequest:   p.page,
+		IncludeClosed: p.includeClosed,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
+
+	if p.state != "" {
+		state := versource.ChangesetState(p.state)
+		req.State = &state
+	}
+
+	var err error
+	req.CreatedAfter, err = platform.ParseTimeParam("created-after", p.createdAfter)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.Changesets, nil
+	req.CreatedBefore, err = platform.ParseTimeParam("created-before", p.createdBefore)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := p.facade.ListChangesets(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Changesets, resp.NextCursor, nil
 }
 
 func (p *TableData) ResolveData(data []versource.Changeset) ([]table.Column, []table.Row, []versource.Changeset) {
@@ -39,16 +80,26 @@ func (p *TableData) ResolveData(data []versource.Changeset) ([]table.Column, []t
 		{Title: "Name", Width: 7},
 		{Title: "State", Width: 2},
 		{Title: "Review", Width: 2},
+		{Title: "Stale", Width: 1},
+		{Title: "Auto Rebase", Width: 2},
+		{Title: "Parent", Width: 1},
 	}
 
 	var rows []table.Row
 	var elems []versource.Changeset
 	for _, changeset := range data {
+		parent := ""
+		if changeset.ParentID != nil {
+			parent = strconv.FormatUint(uint64(*changeset.ParentID), 10)
+		}
 		rows = append(rows, table.Row{
 			strconv.FormatUint(uint64(changeset.ID), 10),
 			changeset.Name,
 			string(changeset.State),
 			string(changeset.ReviewState),
+			strconv.FormatBool(changeset.Stale),
+			strconv.FormatBool(changeset.AutoRebase),
+			parent,
 		})
 		elems = append(elems, changeset)
 	}
@@ -57,14 +108,44 @@ func (p *TableData) ResolveData(data []versource.Changeset) ([]table.Column, []t
 }
 
 func (p *TableData) KeyBindings() platform.KeyBindings {
-	return platform.KeyBindings{}
+	if p.includeClosed {
+		return platform.KeyBindings{
+			{Key: "H", Help: "Hide closed changesets", Command: "changesets"},
+		}
+	}
+	return platform.KeyBindings{
+		{Key: "H", Help: "Show closed changesets", Command: "changesets?include-closed=true"},
+	}
 }
 
 func (p *TableData) ElemKeyBindings(elem versource.Changeset) platform.KeyBindings {
+	if elem.State == versource.ChangesetStateClosed {
+		return platform.KeyBindings{
+			{Key: "enter", Help: "View changes", Command: fmt.Sprintf("changesets/%s/changes", elem.Name)},
+			{Key: "O", Help: "Reopen changeset", Command: fmt.Sprintf("changesets/%s/reopen", elem.Name)},
+			{Key: "D", Help: "Delete changeset", Command: fmt.Sprintf("changesets/%s/delete", elem.Name)},
+		}
+	}
+	if elem.State == versource.ChangesetStateMerged {
+		return platform.KeyBindings{
+			{Key: "enter", Help: "View changes", Command: fmt.Sprintf("changesets/%s/changes", elem.Name)},
+			{Key: "V", Help: "Revert changeset", Command: fmt.Sprintf("changesets/%s/revert", elem.Name)},
+		}
+	}
 	return platform.KeyBindings{
 		{Key: "enter", Help: "View changes", Command: fmt.Sprintf("changesets/%s/changes", elem.Name)},
 		{Key: "M", Help: "Merge changeset", Command: fmt.Sprintf("changesets/%s/merge", elem.Name)},
+		{Key: "P", Help: "Approve changeset", Command: fmt.Sprintf("changesets/%s/approve", elem.Name)},
 		{Key: "R", Help: "Rebase changeset", Command: fmt.Sprintf("changesets/%s/rebase", elem.Name)},
+		{Key: "A", Help: autoRebaseHelp(elem), Command: fmt.Sprintf("changesets/%s/auto-rebase?enabled=%t", elem.Name, !elem.AutoRebase)},
+		{Key: "X", Help: "Close changeset", Command: fmt.Sprintf("changesets/%s/close", elem.Name)},
 		{Key: "D", Help: "Delete changeset", Command: fmt.Sprintf("changesets/%s/delete", elem.Name)},
 	}
 }
+
+func autoRebaseHelp(elem versource.Changeset) string {
+	if elem.AutoRebase {
+		return "Disable auto rebase"
+	}
+	return "Enable auto rebase"
+}
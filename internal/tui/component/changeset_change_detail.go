@@ -98,11 +98,13 @@ func (p *ChangesetChangeDetailData) componentToYAML(component *versource.Compone
 				Version string `yaml:"version"`
 			} `yaml:"version,omitempty"`
 		} `yaml:"module,omitempty"`
-		Variables map[string]any `yaml:"variables,omitempty"`
+		Variables map[string]any    `yaml:"variables,omitempty"`
+		Secrets   map[string]string `yaml:"secrets,omitempty"`
 	}{
-		ID:     component.ID,
-		Name:   component.Name,
-		Status: string(component.Status),
+		ID:      component.ID,
+		Name:    component.Name,
+		Status:  string(component.Status),
+		Secrets: component.Secrets.Masked(),
 	}
 
 	if component.ModuleVersion.Module.ID != 0 {
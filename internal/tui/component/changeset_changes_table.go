@@ -13,31 +13,45 @@ import (
 type ChangesetChangesTableData struct {
 	facade        versource.Facade
 	changesetName string
+	selector      string
+	page          versource.PageRequest
 }
 
 func NewChangesetChangesTable(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataTable(NewChangesetChangesTableData(facade, params["changesetName"]))
+		return platform.NewDataTable(NewChangesetChangesTableData(facade, params["changesetName"], params["selector"], versource.PageRequest{}))
 	}
 }
 
-func NewChangesetChangesTableData(facade versource.Facade, changesetName string) *ChangesetChangesTableData {
+func NewChangesetChangesTableData(facade versource.Facade, changesetName, selector string, page versource.PageRequest) *ChangesetChangesTableData {
 	return &ChangesetChangesTableData{
 		facade:        facade,
 		changesetName: changesetName,
+		selector:      selector,
+		page:          page,
 	}
 }
 
 func (p *ChangesetChangesTableData) LoadData() ([]versource.ComponentChange, error) {
+	changes, _, err := p.LoadPage("")
+	return changes, err
+}
+
+func (p *ChangesetChangesTableData) LoadPage(cursor string) ([]versource.ComponentChange, string, error) {
 	ctx := context.Background()
 	req := versource.ListComponentChangesRequest{
+		PageRequest:   p.page,
 		ChangesetName: p.changesetName,
+		Selector:      p.selector,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
 	}
 	resp, err := p.facade.ListComponentChanges(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.Changes, nil
+	return resp.Changes, resp.NextCursor, nil
 }
 
 func (p *ChangesetChangesTableData) ResolveData(data []versource.ComponentChange) ([]table.Column, []table.Row, []versource.ComponentChange) {
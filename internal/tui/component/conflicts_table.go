@@ -0,0 +1,90 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type ConflictsTableData struct {
+	facade        versource.Facade
+	changesetName string
+}
+
+func NewConflictsTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewConflictsTableData(facade, params["changesetName"]))
+	}
+}
+
+func NewConflictsTableData(facade versource.Facade, changesetName string) *ConflictsTableData {
+	return &ConflictsTableData{
+		facade:        facade,
+		changesetName: changesetName,
+	}
+}
+
+func (p *ConflictsTableData) LoadData() ([]versource.ComponentConflict, error) {
+	ctx := context.Background()
+	req := versource.ListComponentConflictsRequest{
+		ChangesetName: p.changesetName,
+	}
+	resp, err := p.facade.ListComponentConflicts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Conflicts, nil
+}
+
+func (p *ConflictsTableData) ResolveData(data []versource.ComponentConflict) ([]table.Column, []table.Row, []versource.ComponentConflict) {
+	columns := []table.Column{
+		{Title: "ID", Width: 1},
+		{Title: "Name", Width: 10},
+		{Title: "Changeset Variables", Width: 15},
+		{Title: "Main Variables", Width: 15},
+	}
+
+	var rows []table.Row
+	var elems []versource.ComponentConflict
+	for _, conflict := range data {
+		name := "N/A"
+		changesetVariables := "N/A"
+		if conflict.Changeset != nil {
+			name = conflict.Changeset.Name
+			changesetVariables = string(conflict.Changeset.Variables)
+		}
+
+		mainVariables := "N/A"
+		if conflict.Main != nil {
+			mainVariables = string(conflict.Main.Variables)
+		}
+
+		rows = append(rows, table.Row{
+			strconv.FormatUint(uint64(conflict.ComponentID), 10),
+			name,
+			changesetVariables,
+			mainVariables,
+		})
+		elems = append(elems, conflict)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *ConflictsTableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "esc", Help: "View changes", Command: fmt.Sprintf("changesets/%s/changes", p.changesetName)},
+	}
+}
+
+func (p *ConflictsTableData) ElemKeyBindings(elem versource.ComponentConflict) platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "O", Help: "Keep changeset version", Command: fmt.Sprintf("changesets/%s/conflicts/%d/resolve?resolution=%s", p.changesetName, elem.ComponentID, versource.ConflictResolutionOurs)},
+		{Key: "T", Help: "Take main version", Command: fmt.Sprintf("changesets/%s/conflicts/%d/resolve?resolution=%s", p.changesetName, elem.ComponentID, versource.ConflictResolutionTheirs)},
+		{Key: "E", Help: "Edit resolution", Command: fmt.Sprintf("changesets/%s/conflicts/%d/edit", p.changesetName, elem.ComponentID)},
+	}
+}
@@ -14,6 +14,7 @@ type DetailData struct {
 	facade        versource.Facade
 	componentID   string
 	changesetName string
+	asOf          string
 }
 
 type DetailViewModel struct {
@@ -28,7 +29,8 @@ type DetailViewModel struct {
 			Version string `yaml:"version"`
 		} `yaml:"version,omitempty"`
 	} `yaml:"module,omitempty"`
-	Variables map[string]any `yaml:"variables,omitempty"`
+	Variables map[string]any    `yaml:"variables,omitempty"`
+	Secrets   map[string]string `yaml:"secrets,omitempty"`
 }
 
 func NewDetail(facade versource.Facade) func(params map[string]string) platform.Page {
@@ -37,15 +39,17 @@ func NewDetail(facade versource.Facade) func(params map[string]string) platform.
 			facade,
 			params["componentID"],
 			params["changesetName"],
+			params["as-of"],
 		))
 	}
 }
 
-func NewDetailData(facade versource.Facade, componentID string, changesetName string) *DetailData {
+func NewDetailData(facade versource.Facade, componentID string, changesetName string, asOf string) *DetailData {
 	return &DetailData{
 		facade:        facade,
 		componentID:   componentID,
 		changesetName: changesetName,
+		asOf:          asOf,
 	}
 }
 
@@ -57,7 +61,14 @@ func (p *DetailData) LoadData() (*versource.GetComponentResponse, error) {
 		return nil, err
 	}
 
-	componentResp, err := p.facade.GetComponent(ctx, versource.GetComponentRequest{ComponentID: uint(componentIDUint), ChangesetName: &p.changesetName})
+	req := versource.GetComponentRequest{ComponentID: uint(componentIDUint)}
+	if p.asOf != "" {
+		req.AsOf = &p.asOf
+	} else {
+		req.ChangesetName = &p.changesetName
+	}
+
+	componentResp, err := p.facade.GetComponent(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -117,10 +128,17 @@ func (p *DetailData) ResolveData(data versource.GetComponentResponse) DetailView
 		Status:    string(data.Component.Status),
 		Module:    module,
 		Variables: variables,
+		Secrets:   data.Component.Secrets.Masked(),
 	}
 }
 
 func (p *DetailData) KeyBindings(elem versource.GetComponentResponse) platform.KeyBindings {
+	if p.asOf != "" {
+		return platform.KeyBindings{
+			{Key: "esc", Help: "View components", Command: fmt.Sprintf("components?as-of=%s", p.asOf)},
+			{Key: "h", Help: "View component history", Command: fmt.Sprintf("components/%d/history", elem.Component.ID)},
+		}
+	}
 	changesetPrefix := ""
 	if p.changesetName != "" {
 		changesetPrefix = fmt.Sprintf("changesets/%s", p.changesetName)
@@ -131,5 +149,6 @@ func (p *DetailData) KeyBindings(elem versource.GetComponentResponse) platform.K
 		{Key: "esc", Help: "View changes", Command: fmt.Sprintf("%s/components", changesetPrefix)},
 		{Key: "E", Help: "Edit component", Command: fmt.Sprintf("%s/components/%d/edit", changesetPrefix, elem.Component.ID)},
 		{Key: "D", Help: "Delete component", Command: fmt.Sprintf("%s/components/%d/delete", changesetPrefix, elem.Component.ID)},
+		{Key: "h", Help: "View component history", Command: fmt.Sprintf("components/%d/history", elem.Component.ID)},
 	}
 }
@@ -55,12 +55,18 @@ func (e *EditComponentData) GetInitialValue() (versource.UpdateComponentRequest,
 		changesetName = generateDefaultChangesetName(fmt.Sprintf("%s-update", componentResp.Component.Name))
 	}
 
-	return versource.UpdateComponentRequest{
+	req := versource.UpdateComponentRequest{
 		ComponentID:   componentID,
 		ChangesetName: changesetName,
+		Name:          &componentResp.Component.Name,
 		ModuleID:      &componentResp.Component.ModuleVersion.Module.ID,
 		Variables:     &variables,
-	}, nil
+		Moves:         componentResp.Component.Moves,
+	}
+	if componentResp.Revision != "" {
+		req.ExpectedRevision = &componentResp.Revision
+	}
+	return req, nil
 }
 
 func (e *EditComponentData) SaveData(ctx context.Context, data versource.UpdateComponentRequest) (string, error) {
@@ -73,6 +79,9 @@ func (e *EditComponentData) SaveData(ctx context.Context, data versource.UpdateC
 	}
 
 	_, err := e.facade.UpdateComponent(ctx, data)
+	if versource.IsPreconditionFailedError(err) {
+		return "", fmt.Errorf("%w, reopen the editor to load the latest revision", err)
+	}
 	if err != nil {
 		return "", err
 	}
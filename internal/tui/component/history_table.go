@@ -0,0 +1,124 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type HistoryTableData struct {
+	facade      versource.Facade
+	componentID string
+	page        versource.PageRequest
+}
+
+func NewHistoryTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewHistoryTableData(facade, params["componentID"], versource.PageRequest{}))
+	}
+}
+
+func NewHistoryTableData(facade versource.Facade, componentID string, page versource.PageRequest) *HistoryTableData {
+	return &HistoryTableData{
+		facade:      facade,
+		componentID: componentID,
+		page:        page,
+	}
+}
+
+func (p *HistoryTableData) LoadData() ([]versource.ComponentRevision, error) {
+	revisions, _, err := p.LoadPage("")
+	return revisions, err
+}
+
+func (p *HistoryTableData) LoadPage(cursor string) ([]versource.ComponentRevision, string, error) {
+	componentID, err := strconv.ParseUint(p.componentID, 10, 32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ctx := context.Background()
+	req := versource.ListComponentHistoryRequest{
+		PageRequest: p.page,
+		ComponentID: uint(componentID),
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
+	resp, err := p.facade.ListComponentHistory(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Revisions, resp.NextCursor, nil
+}
+
+func (p *HistoryTableData) ResolveData(data []versource.ComponentRevision) ([]table.Column, []table.Row, []versource.ComponentRevision) {
+	columns := []table.Column{
+		{Title: "Commit", Width: 8},
+		{Title: "Date", Width: 10},
+		{Title: "Message", Width: 15},
+		{Title: "Changeset", Width: 10},
+		{Title: "Plan", Width: 5},
+		{Title: "Apply", Width: 5},
+	}
+
+	var rows []table.Row
+	var elems []versource.ComponentRevision
+	for _, revision := range data {
+		commit := revision.Commit
+		if len(commit) > 8 {
+			commit = commit[:8]
+		}
+
+		changeset := "N/A"
+		if revision.ChangesetName != "" {
+			changeset = revision.ChangesetName
+		}
+
+		planState := "None"
+		if revision.Plan != nil {
+			planState = string(revision.Plan.State)
+		}
+
+		applyState := "None"
+		if revision.Apply != nil {
+			applyState = string(revision.Apply.State)
+		}
+
+		rows = append(rows, table.Row{
+			commit,
+			revision.CommitDate,
+			revision.Message,
+			changeset,
+			planState,
+			applyState,
+		})
+		elems = append(elems, revision)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *HistoryTableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "esc", Help: "View component", Command: fmt.Sprintf("components/%s", p.componentID)},
+	}
+}
+
+func (p *HistoryTableData) ElemKeyBindings(elem versource.ComponentRevision) platform.KeyBindings {
+	keyBindings := platform.KeyBindings{
+		{Key: "R", Help: "Revert to revision", Command: fmt.Sprintf("components/%s/history/%s/revert", p.componentID, elem.Commit)},
+		{Key: "i", Help: "View inventory at revision", Command: fmt.Sprintf("components?as-of=%s", elem.Commit)},
+	}
+	if elem.Plan != nil {
+		keyBindings = append(keyBindings, platform.KeyBinding{Key: "p", Help: "View plan", Command: fmt.Sprintf("plans/%d", elem.Plan.ID)})
+	}
+	if elem.Apply != nil {
+		keyBindings = append(keyBindings, platform.KeyBinding{Key: "a", Help: "View apply", Command: fmt.Sprintf("applies/%d", elem.Apply.ID)})
+	}
+	return keyBindings
+}
@@ -0,0 +1,127 @@
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type ResolveConflictData struct {
+	facade        versource.Facade
+	componentID   string
+	changesetName string
+	resolution    versource.ConflictResolution
+}
+
+func NewResolveConflict(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewConfirmationPage(&ResolveConflictData{
+			facade:        facade,
+			componentID:   params["componentID"],
+			changesetName: params["changesetName"],
+			resolution:    versource.ConflictResolution(params["resolution"]),
+		})
+	}
+}
+
+func (r *ResolveConflictData) GetConfirmationDialog() platform.ConfirmationDialog {
+	side := "the changeset"
+	if r.resolution == versource.ConflictResolutionTheirs {
+		side = "main"
+	}
+	return platform.ConfirmationDialog{
+		Title:       "Resolve Conflict",
+		Message:     fmt.Sprintf("Are you sure you want to resolve the conflict of component %s with the version from %s? Main will be merged into changeset '%s' and the component will be planned again.", r.componentID, side, r.changesetName),
+		ConfirmText: "Resolve",
+		CancelText:  "Cancel",
+	}
+}
+
+func (r *ResolveConflictData) OnConfirm(ctx context.Context) (string, error) {
+	componentID, err := strconv.ParseUint(r.componentID, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid component ID: %w", err)
+	}
+
+	req := versource.ResolveComponentConflictRequest{
+		ComponentID:   uint(componentID),
+		ChangesetName: r.changesetName,
+		Resolution:    r.resolution,
+	}
+
+	_, err = r.facade.ResolveComponentConflict(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("changesets/%s/changes", r.changesetName), nil
+}
+
+type EditConflictResolutionData struct {
+	facade        versource.Facade
+	componentID   string
+	changesetName string
+}
+
+func NewEditConflictResolution(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewEditor(&EditConflictResolutionData{
+			facade:        facade,
+			componentID:   params["componentID"],
+			changesetName: params["changesetName"],
+		})
+	}
+}
+
+func (e *EditConflictResolutionData) GetInitialValue() (versource.ResolveComponentConflictRequest, error) {
+	id, err := strconv.ParseUint(e.componentID, 10, 32)
+	if err != nil {
+		return versource.ResolveComponentConflictRequest{}, err
+	}
+	componentID := uint(id)
+
+	ctx := context.Background()
+	resp, err := e.facade.ListComponentConflicts(ctx, versource.ListComponentConflictsRequest{ChangesetName: e.changesetName})
+	if err != nil {
+		return versource.ResolveComponentConflictRequest{}, err
+	}
+
+	var variables map[string]any
+	for _, conflict := range resp.Conflicts {
+		if conflict.ComponentID != componentID || conflict.Changeset == nil || conflict.Changeset.Variables == nil {
+			continue
+		}
+		err := json.Unmarshal(conflict.Changeset.Variables, &variables)
+		if err != nil {
+			return versource.ResolveComponentConflictRequest{}, err
+		}
+	}
+
+	return versource.ResolveComponentConflictRequest{
+		ComponentID:   componentID,
+		ChangesetName: e.changesetName,
+		Resolution:    versource.ConflictResolutionManual,
+		Variables:     &variables,
+	}, nil
+}
+
+func (e *EditConflictResolutionData) SaveData(ctx context.Context, data versource.ResolveComponentConflictRequest) (string, error) {
+	if data.ComponentID == 0 {
+		return "", fmt.Errorf("component ID is required")
+	}
+
+	if data.ChangesetName == "" {
+		return "", fmt.Errorf("changeset is required")
+	}
+
+	_, err := e.facade.ResolveComponentConflict(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("changesets/%s/changes", data.ChangesetName), nil
+}
@@ -0,0 +1,56 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type RevertToRevisionData struct {
+	facade      versource.Facade
+	componentID string
+	commit      string
+}
+
+func NewRevertToRevision(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewEditor(&RevertToRevisionData{
+			facade:      facade,
+			componentID: params["componentID"],
+			commit:      params["commit"],
+		})
+	}
+}
+
+func (r *RevertToRevisionData) GetInitialValue() (versource.RevertComponentToRevisionRequest, error) {
+	componentID, err := strconv.ParseUint(r.componentID, 10, 32)
+	if err != nil {
+		return versource.RevertComponentToRevisionRequest{}, err
+	}
+
+	return versource.RevertComponentToRevisionRequest{
+		ComponentID:   uint(componentID),
+		ChangesetName: generateDefaultChangesetName("revert"),
+		Commit:        r.commit,
+	}, nil
+}
+
+func (r *RevertToRevisionData) SaveData(ctx context.Context, data versource.RevertComponentToRevisionRequest) (string, error) {
+	if data.ComponentID == 0 {
+		return "", fmt.Errorf("component ID is required")
+	}
+
+	if data.ChangesetName == "" {
+		return "", fmt.Errorf("changeset is required")
+	}
+
+	_, err := r.facade.RevertComponentToRevision(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("changesets/%s/changes", data.ChangesetName), nil
+}
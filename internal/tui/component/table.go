@@ -3,7 +3,9 @@ package component
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/marcbran/versource/internal/tui/platform"
@@ -15,6 +17,12 @@ type TableData struct {
 	moduleID        string
 	moduleVersionID string
 	changesetName   string
+	asOf            string
+	selector        string
+	owner           string
+	template        string
+	environment     string
+	page            versource.PageRequest
 }
 
 func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
@@ -31,23 +39,39 @@ func NewTable(facade versource.Facade) func(params map[string]string) platform.P
 		if changesetNameParam, ok := params["changesetName"]; ok {
 			changesetName = changesetNameParam
 		}
-		return platform.NewDataTable(NewTableData(facade, moduleId, moduleVersionId, changesetName))
+		return platform.NewDataTable(NewTableData(facade, moduleId, moduleVersionId, changesetName, params["as-of"], params["selector"], params["owner"], params["template"], params["environment"], versource.PageRequest{}))
 	}
 }
 
-func NewTableData(facade versource.Facade, moduleID, moduleVersionID, changesetName string) *TableData {
+func NewTableData(facade versource.Facade, moduleID, moduleVersionID, changesetName, asOf, selector, owner, template, environment string, page versource.PageRequest) *TableData {
 	return &TableData{
 		facade:          facade,
 		moduleID:        moduleID,
 		moduleVersionID: moduleVersionID,
 		changesetName:   changesetName,
+		asOf:            asOf,
+		selector:        selector,
+		owner:           owner,
+		template:        template,
+		environment:     environment,
+		page:            page,
 	}
 }
 
 func (p *TableData) LoadData() ([]versource.Component, error) {
+	components, _, err := p.LoadPage("")
+	return components, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.Component, string, error) {
 	ctx := context.Background()
 
-	req := versource.ListComponentsRequest{}
+	req := versource.ListComponentsRequest{
+		PageRequest: p.page,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
 
 	if p.moduleID != "" {
 		moduleID, err := strconv.ParseUint(p.moduleID, 10, 32)
@@ -69,12 +93,30 @@ func (p *TableData) LoadData() ([]versource.Component, error) {
 		req.ChangesetName = &p.changesetName
 	}
 
+	if p.asOf != "" {
+		req.AsOf = &p.asOf
+	}
+
+	req.Selector = p.selector
+
+	if p.owner != "" {
+		req.Owner = &p.owner
+	}
+
+	if p.template != "" {
+		req.Template = &p.template
+	}
+
+	if p.environment != "" {
+		req.Environment = &p.environment
+	}
+
 	resp, err := p.facade.ListComponents(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return resp.Components, nil
+	return resp.Components, resp.NextCursor, nil
 }
 
 func (p *TableData) ResolveData(data []versource.Component) ([]table.Column, []table.Row, []versource.Component) {
@@ -84,6 +126,9 @@ func (p *TableData) ResolveData(data []versource.Component) ([]table.Column, []t
 		{Title: "Module", Width: 3},
 		{Title: "Version", Width: 3},
 		{Title: "Status", Width: 1},
+		{Title: "Owner", Width: 2},
+		{Title: "Environment", Width: 2},
+		{Title: "Labels", Width: 3},
 	}
 
 	var rows []table.Row
@@ -103,6 +148,9 @@ func (p *TableData) ResolveData(data []versource.Component) ([]table.Column, []t
 			module,
 			version,
 			string(component.Status),
+			component.Owner,
+			component.Environment,
+			formatLabels(component.Labels),
 		})
 		elems = append(elems, component)
 	}
@@ -111,6 +159,9 @@ func (p *TableData) ResolveData(data []versource.Component) ([]table.Column, []t
 }
 
 func (p *TableData) KeyBindings() platform.KeyBindings {
+	if p.asOf != "" {
+		return platform.KeyBindings{}
+	}
 	command := "components/create"
 	if p.moduleID != "" {
 		command = fmt.Sprintf("components/create?module-id=%s", p.moduleID)
@@ -121,9 +172,29 @@ func (p *TableData) KeyBindings() platform.KeyBindings {
 }
 
 func (p *TableData) ElemKeyBindings(elem versource.Component) platform.KeyBindings {
-	return platform.KeyBindings{
+	if p.asOf != "" {
+		return platform.KeyBindings{
+			{Key: "enter", Help: "View component detail", Command: fmt.Sprintf("components/%d?as-of=%s", elem.ID, p.asOf)},
+			{Key: "h", Help: "View component history", Command: fmt.Sprintf("components/%d/history", elem.ID)},
+		}
+	}
+	keyBindings := platform.KeyBindings{
 		{Key: "enter", Help: "View component detail", Command: fmt.Sprintf("components/%d", elem.ID)},
 		{Key: "E", Help: "Edit component", Command: fmt.Sprintf("components/%d/edit", elem.ID)},
 		{Key: "D", Help: "Delete component", Command: fmt.Sprintf("components/%d/delete", elem.ID)},
+		{Key: "h", Help: "View component history", Command: fmt.Sprintf("components/%d/history", elem.ID)},
+	}
+	if elem.Template != "" {
+		keyBindings = append(keyBindings, platform.KeyBinding{Key: "T", Help: "View promotion path", Command: fmt.Sprintf("templates/%s/promotion", elem.Template)})
+	}
+	return keyBindings
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
 	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
 }
@@ -0,0 +1,41 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type PromoteComponentData struct {
+	facade          versource.Facade
+	template        string
+	fromEnvironment string
+}
+
+func NewPromoteComponent(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewConfirmationPage(&PromoteComponentData{facade: facade, template: params["template"], fromEnvironment: params["from"]})
+	}
+}
+
+func (c *PromoteComponentData) GetConfirmationDialog() platform.ConfirmationDialog {
+	return platform.ConfirmationDialog{
+		Title:       "Promote Component",
+		Message:     fmt.Sprintf("Are you sure you want to promote template '%s' from environment '%s'?", c.template, c.fromEnvironment),
+		ConfirmText: "promote",
+		CancelText:  "cancel",
+	}
+}
+
+func (c *PromoteComponentData) OnConfirm(ctx context.Context) (string, error) {
+	resp, err := c.facade.PromoteComponent(ctx, versource.PromoteComponentRequest{
+		Template:        c.template,
+		FromEnvironment: c.fromEnvironment,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("changesets/%s/changes", resp.Changeset.Name), nil
+}
@@ -0,0 +1,85 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type PromotionTableData struct {
+	facade   versource.Facade
+	template string
+}
+
+func NewPromotionTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewPromotionTableData(facade, params["template"]))
+	}
+}
+
+func NewPromotionTableData(facade versource.Facade, template string) *PromotionTableData {
+	return &PromotionTableData{
+		facade:   facade,
+		template: template,
+	}
+}
+
+func (p *PromotionTableData) LoadData() ([]versource.PromotionStage, error) {
+	ctx := context.Background()
+	resp, err := p.facade.GetPromotionPath(ctx, versource.GetPromotionPathRequest{Template: p.template})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Stages, nil
+}
+
+func (p *PromotionTableData) ResolveData(data []versource.PromotionStage) ([]table.Column, []table.Row, []versource.PromotionStage) {
+	columns := []table.Column{
+		{Title: "Environment", Width: 2},
+		{Title: "Component ID", Width: 1},
+		{Title: "Component", Width: 3},
+		{Title: "Version", Width: 2},
+		{Title: "Promoted", Width: 1},
+	}
+
+	var rows []table.Row
+	var elems []versource.PromotionStage
+	for _, stage := range data {
+		componentID := ""
+		name := ""
+		version := ""
+		if stage.Component != nil {
+			componentID = strconv.FormatUint(uint64(stage.Component.ID), 10)
+			name = stage.Component.Name
+			version = stage.Component.ModuleVersion.Version
+		}
+		rows = append(rows, table.Row{
+			stage.Environment,
+			componentID,
+			name,
+			version,
+			strconv.FormatBool(stage.Promoted),
+		})
+		elems = append(elems, stage)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *PromotionTableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *PromotionTableData) ElemKeyBindings(elem versource.PromotionStage) platform.KeyBindings {
+	if elem.Component == nil {
+		return platform.KeyBindings{}
+	}
+	return platform.KeyBindings{
+		{Key: "enter", Help: "View component detail", Command: fmt.Sprintf("components/%d", elem.Component.ID)},
+		{Key: "P", Help: "Promote to next environment", Command: fmt.Sprintf("templates/%s/promote?from=%s", p.template, elem.Environment)},
+	}
+}
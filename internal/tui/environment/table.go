@@ -0,0 +1,66 @@
+package environment
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type TableData struct {
+	facade versource.Facade
+}
+
+func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewTableData(facade))
+	}
+}
+
+func NewTableData(facade versource.Facade) *TableData {
+	return &TableData{
+		facade: facade,
+	}
+}
+
+func (p *TableData) LoadData() ([]versource.Environment, error) {
+	ctx := context.Background()
+	resp, err := p.facade.ListEnvironments(ctx, versource.ListEnvironmentsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Environments, nil
+}
+
+func (p *TableData) ResolveData(data []versource.Environment) ([]table.Column, []table.Row, []versource.Environment) {
+	columns := []table.Column{
+		{Title: "ID", Width: 1},
+		{Title: "Position", Width: 1},
+		{Title: "Name", Width: 6},
+	}
+
+	var rows []table.Row
+	var elems []versource.Environment
+	for _, environment := range data {
+		rows = append(rows, table.Row{
+			strconv.FormatUint(uint64(environment.ID), 10),
+			strconv.Itoa(environment.Position),
+			environment.Name,
+		})
+		elems = append(elems, environment)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *TableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *TableData) ElemKeyBindings(elem versource.Environment) platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "enter", Help: "View environment components", Command: "components?environment=" + elem.Name},
+	}
+}
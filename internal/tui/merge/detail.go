@@ -16,11 +16,13 @@ type DetailData struct {
 }
 
 type DetailViewModel struct {
-	ID          uint   `yaml:"id"`
-	ChangesetID uint   `yaml:"changeset_id"`
-	State       string `yaml:"state"`
-	MergeBase   string `yaml:"merge_base"`
-	Head        string `yaml:"head"`
+	ID          uint                     `yaml:"id"`
+	ChangesetID uint                     `yaml:"changeset_id"`
+	State       string                   `yaml:"state"`
+	Phase       string                   `yaml:"phase"`
+	MergeBase   string                   `yaml:"merge_base"`
+	Head        string                   `yaml:"head"`
+	Findings    []versource.MergeFinding `yaml:"findings,omitempty"`
 }
 
 func NewDetail(facade versource.Facade) func(params map[string]string) platform.Page {
@@ -62,8 +64,10 @@ func (p *DetailData) ResolveData(data versource.GetMergeResponse) DetailViewMode
 		ID:          data.Merge.ID,
 		ChangesetID: data.Merge.ChangesetID,
 		State:       string(data.Merge.State),
+		Phase:       string(data.Merge.Phase),
 		MergeBase:   data.Merge.MergeBase,
 		Head:        data.Merge.Head,
+		Findings:    data.Merge.Findings,
 	}
 }
 
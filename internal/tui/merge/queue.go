@@ -0,0 +1,71 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type QueueTableData struct {
+	facade versource.Facade
+}
+
+func NewQueueTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewQueueTableData(facade))
+	}
+}
+
+func NewQueueTableData(facade versource.Facade) *QueueTableData {
+	return &QueueTableData{
+		facade: facade,
+	}
+}
+
+func (p *QueueTableData) LoadData() ([]versource.MergeQueueEntry, error) {
+	ctx := context.Background()
+	resp, err := p.facade.ListMergeQueue(ctx, versource.ListMergeQueueRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Entries, nil
+}
+
+func (p *QueueTableData) ResolveData(data []versource.MergeQueueEntry) ([]table.Column, []table.Row, []versource.MergeQueueEntry) {
+	columns := []table.Column{
+		{Title: "Position", Width: 1},
+		{Title: "ID", Width: 1},
+		{Title: "Changeset", Width: 6},
+		{Title: "State", Width: 2},
+		{Title: "Phase", Width: 2},
+	}
+
+	var rows []table.Row
+	var elems []versource.MergeQueueEntry
+	for _, entry := range data {
+		rows = append(rows, table.Row{
+			strconv.Itoa(entry.Position),
+			strconv.FormatUint(uint64(entry.Merge.ID), 10),
+			entry.Merge.Changeset.Name,
+			string(entry.Merge.State),
+			string(entry.Merge.Phase),
+		})
+		elems = append(elems, entry)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *QueueTableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *QueueTableData) ElemKeyBindings(elem versource.MergeQueueEntry) platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "enter", Help: "View merge detail", Command: fmt.Sprintf("changesets/%s/merges/%d", elem.Merge.Changeset.Name, elem.Merge.ID)},
+	}
+}
@@ -13,31 +13,42 @@ import (
 type TableData struct {
 	facade        versource.Facade
 	changesetName string
+	page          versource.PageRequest
 }
 
 func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataTable(NewTableData(facade, params["changesetName"]))
+		return platform.NewDataTable(NewTableData(facade, params["changesetName"], versource.PageRequest{}))
 	}
 }
 
-func NewTableData(facade versource.Facade, changesetName string) *TableData {
+func NewTableData(facade versource.Facade, changesetName string, page versource.PageRequest) *TableData {
 	return &TableData{
 		facade:        facade,
 		changesetName: changesetName,
+		page:          page,
 	}
 }
 
 func (p *TableData) LoadData() ([]versource.Merge, error) {
+	merges, _, err := p.LoadPage("")
+	return merges, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.Merge, string, error) {
 	ctx := context.Background()
 	req := versource.ListMergesRequest{
+		PageRequest:   p.page,
 		ChangesetName: p.changesetName,
 	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
 	resp, err := p.facade.ListMerges(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.Merges, nil
+	return resp.Merges, resp.NextCursor, nil
 }
 
 func (p *TableData) ResolveData(data []versource.Merge) ([]table.Column, []table.Row, []versource.Merge) {
@@ -45,6 +56,7 @@ func (p *TableData) ResolveData(data []versource.Merge) ([]table.Column, []table
 		{Title: "ID", Width: 1},
 		{Title: "Changeset", Width: 6},
 		{Title: "State", Width: 2},
+		{Title: "Phase", Width: 2},
 		{Title: "Merge Base", Width: 8},
 		{Title: "Head", Width: 8},
 	}
@@ -56,6 +68,7 @@ func (p *TableData) ResolveData(data []versource.Merge) ([]table.Column, []table
 			strconv.FormatUint(uint64(merge.ID), 10),
 			merge.Changeset.Name,
 			string(merge.State),
+			string(merge.Phase),
 			merge.MergeBase,
 			merge.Head,
 		})
@@ -66,7 +79,12 @@ func (p *TableData) ResolveData(data []versource.Merge) ([]table.Column, []table
 }
 
 func (p *TableData) KeyBindings() platform.KeyBindings {
-	return platform.KeyBindings{}
+	if p.changesetName == "" {
+		return platform.KeyBindings{}
+	}
+	return platform.KeyBindings{
+		{Key: "v", Help: "Validate merge", Command: fmt.Sprintf("changesets/%s/merge-validation", p.changesetName)},
+	}
 }
 
 func (p *TableData) ElemKeyBindings(elem versource.Merge) platform.KeyBindings {
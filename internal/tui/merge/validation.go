@@ -0,0 +1,95 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type ValidationTableData struct {
+	facade        versource.Facade
+	changesetName string
+}
+
+func NewValidationTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewValidationTableData(facade, params["changesetName"]))
+	}
+}
+
+func NewValidationTableData(facade versource.Facade, changesetName string) *ValidationTableData {
+	return &ValidationTableData{
+		facade:        facade,
+		changesetName: changesetName,
+	}
+}
+
+func (p *ValidationTableData) LoadData() ([]versource.MergeFinding, error) {
+	ctx := context.Background()
+	resp, err := p.facade.ValidateMerge(ctx, versource.ValidateMergeRequest{
+		ChangesetName: p.changesetName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Findings, nil
+}
+
+func (p *ValidationTableData) ResolveData(data []versource.MergeFinding) ([]table.Column, []table.Row, []versource.MergeFinding) {
+	return findingsTable(data)
+}
+
+func (p *ValidationTableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "esc", Help: "View merges", Command: fmt.Sprintf("changesets/%s/merges", p.changesetName)},
+	}
+}
+
+func (p *ValidationTableData) ElemKeyBindings(elem versource.MergeFinding) platform.KeyBindings {
+	if elem.PlanID != nil {
+		return platform.KeyBindings{
+			{Key: "enter", Help: "View plan detail", Command: fmt.Sprintf("changesets/%s/plans/%d", p.changesetName, *elem.PlanID)},
+		}
+	}
+	if elem.ComponentID != nil {
+		return platform.KeyBindings{
+			{Key: "enter", Help: "View change detail", Command: fmt.Sprintf("changesets/%s/changes/%d", p.changesetName, *elem.ComponentID)},
+		}
+	}
+	return platform.KeyBindings{}
+}
+
+func findingsTable(findings []versource.MergeFinding) ([]table.Column, []table.Row, []versource.MergeFinding) {
+	columns := []table.Column{
+		{Title: "Type", Width: 3},
+		{Title: "Component", Width: 1},
+		{Title: "Plan", Width: 1},
+		{Title: "Message", Width: 8},
+	}
+
+	var rows []table.Row
+	var elems []versource.MergeFinding
+	for _, finding := range findings {
+		componentID := ""
+		if finding.ComponentID != nil {
+			componentID = strconv.FormatUint(uint64(*finding.ComponentID), 10)
+		}
+		planID := ""
+		if finding.PlanID != nil {
+			planID = strconv.FormatUint(uint64(*finding.PlanID), 10)
+		}
+		rows = append(rows, table.Row{
+			string(finding.Type),
+			componentID,
+			planID,
+			finding.Message,
+		})
+		elems = append(elems, finding)
+	}
+
+	return columns, rows, elems
+}
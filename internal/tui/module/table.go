@@ -12,25 +12,41 @@ import (
 
 type TableData struct {
 	facade versource.Facade
+	asOf   string
+	page   versource.PageRequest
 }
 
 func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataTable(NewTableData(facade))
+		return platform.NewDataTable(NewTableData(facade, params["as-of"], versource.PageRequest{}))
 	}
 }
 
-func NewTableData(facade versource.Facade) *TableData {
-	return &TableData{facade: facade}
+func NewTableData(facade versource.Facade, asOf string, page versource.PageRequest) *TableData {
+	return &TableData{facade: facade, asOf: asOf, page: page}
 }
 
 func (p *TableData) LoadData() ([]versource.Module, error) {
+	modules, _, err := p.LoadPage("")
+	return modules, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.Module, string, error) {
 	ctx := context.Background()
-	resp, err := p.facade.ListModules(ctx, versource.ListModulesRequest{})
+	req := versource.ListModulesRequest{
+		PageRequest: p.page,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
+	if p.asOf != "" {
+		req.AsOf = &p.asOf
+	}
+	resp, err := p.facade.ListModules(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.Modules, nil
+	return resp.Modules, resp.NextCursor, nil
 }
 
 func (p *TableData) ResolveData(data []versource.Module) ([]table.Column, []table.Row, []versource.Module) {
@@ -38,6 +54,7 @@ func (p *TableData) ResolveData(data []versource.Module) ([]table.Column, []tabl
 		{Title: "ID", Width: 1},
 		{Title: "Name", Width: 5},
 		{Title: "Source", Width: 15},
+		{Title: "Owner", Width: 3},
 	}
 
 	var rows []table.Row
@@ -47,6 +64,7 @@ func (p *TableData) ResolveData(data []versource.Module) ([]table.Column, []tabl
 			strconv.FormatUint(uint64(module.ID), 10),
 			module.Name,
 			module.Source,
+			module.Owner,
 		})
 		elems = append(elems, module)
 	}
@@ -55,12 +73,20 @@ func (p *TableData) ResolveData(data []versource.Module) ([]table.Column, []tabl
 }
 
 func (p *TableData) KeyBindings() platform.KeyBindings {
+	if p.asOf != "" {
+		return platform.KeyBindings{}
+	}
 	return platform.KeyBindings{
 		{Key: "C", Help: "Create module", Command: "modules/create"},
 	}
 }
 
 func (p *TableData) ElemKeyBindings(elem versource.Module) platform.KeyBindings {
+	if p.asOf != "" {
+		return platform.KeyBindings{
+			{Key: "c", Help: "View components", Command: fmt.Sprintf("components?module-id=%d&as-of=%s", elem.ID, p.asOf)},
+		}
+	}
 	return platform.KeyBindings{
 		{Key: "enter", Help: "View module detail", Command: fmt.Sprintf("modules/%d", elem.ID)},
 		{Key: "v", Help: "View module versions", Command: fmt.Sprintf("modules/%d/moduleversions", elem.ID)},
@@ -13,6 +13,7 @@ import (
 type VersionsTableData struct {
 	facade   versource.Facade
 	moduleID *string
+	page     versource.PageRequest
 }
 
 func NewVersionsTable(facade versource.Facade) func(params map[string]string) platform.Page {
@@ -21,22 +22,32 @@ func NewVersionsTable(facade versource.Facade) func(params map[string]string) pl
 		if moduleIDStr, exists := params["moduleID"]; exists && moduleIDStr != "" {
 			moduleID = &moduleIDStr
 		}
-		return platform.NewDataTable(NewVersionsTableData(facade, moduleID))
+		return platform.NewDataTable(NewVersionsTableData(facade, moduleID, versource.PageRequest{}))
 	}
 }
 
-func NewVersionsTableData(facade versource.Facade, moduleID *string) *VersionsTableData {
-	return &VersionsTableData{facade: facade, moduleID: moduleID}
+func NewVersionsTableData(facade versource.Facade, moduleID *string, page versource.PageRequest) *VersionsTableData {
+	return &VersionsTableData{facade: facade, moduleID: moduleID, page: page}
 }
 
 func (p *VersionsTableData) LoadData() ([]versource.ModuleVersion, error) {
+	moduleVersions, _, err := p.LoadPage("")
+	return moduleVersions, err
+}
+
+func (p *VersionsTableData) LoadPage(cursor string) ([]versource.ModuleVersion, string, error) {
 	ctx := context.Background()
 
-	req := versource.ListModuleVersionsRequest{}
+	req := versource.ListModuleVersionsRequest{
+		PageRequest: p.page,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
 	if p.moduleID != nil {
 		moduleIDUint, err := strconv.ParseUint(*p.moduleID, 10, 32)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		moduleID := uint(moduleIDUint)
 		req.ModuleID = &moduleID
@@ -44,9 +55,9 @@ func (p *VersionsTableData) LoadData() ([]versource.ModuleVersion, error) {
 
 	resp, err := p.facade.ListModuleVersions(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.ModuleVersions, nil
+	return resp.ModuleVersions, resp.NextCursor, nil
 }
 
 func (p *VersionsTableData) ResolveData(data []versource.ModuleVersion) ([]table.Column, []table.Row, []versource.ModuleVersion) {
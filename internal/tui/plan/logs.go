@@ -18,7 +18,7 @@ type LogsData struct {
 
 func NewLogs(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataViewport(NewLogsData(
+		return platform.NewStreamViewport(NewLogsData(
 			facade,
 			params["changesetName"],
 			params["planID"],
@@ -34,7 +34,7 @@ func NewLogsData(facade versource.Facade, changesetName string, planID string) *
 	}
 }
 
-func (p *LogsData) LoadData() (*versource.GetPlanLogResponse, error) {
+func (p *LogsData) OpenStream() (io.ReadCloser, error) {
 	ctx := context.Background()
 
 	planIDUint, err := strconv.ParseUint(p.planID, 10, 32)
@@ -42,7 +42,7 @@ func (p *LogsData) LoadData() (*versource.GetPlanLogResponse, error) {
 		return nil, err
 	}
 
-	req := versource.GetPlanLogRequest{PlanID: uint(planIDUint)}
+	req := versource.GetPlanLogRequest{PlanID: uint(planIDUint), Follow: true}
 	if p.changesetName != "" {
 		req.ChangesetName = &p.changesetName
 	}
@@ -51,20 +51,10 @@ func (p *LogsData) LoadData() (*versource.GetPlanLogResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return resp.Content, nil
 }
 
-func (p *LogsData) ResolveData(data versource.GetPlanLogResponse) string {
-	content, err := io.ReadAll(data.Content)
-	if err != nil {
-		return "Failed to read log content"
-	}
-	defer data.Content.Close()
-
-	return string(content)
-}
-
-func (p *LogsData) KeyBindings(elem versource.GetPlanLogResponse) platform.KeyBindings {
+func (p *LogsData) KeyBindings() platform.KeyBindings {
 	changesetPrefix := ""
 	if p.changesetName != "" {
 		changesetPrefix = fmt.Sprintf("changesets/%s", p.changesetName)
@@ -13,31 +13,67 @@ import (
 type TableData struct {
 	facade        versource.Facade
 	changesetName string
+	selector      string
+	state         string
+	createdAfter  string
+	createdBefore string
+	page          versource.PageRequest
 }
 
 func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataTable(NewTableData(facade, params["changesetName"]))
+		return platform.NewDataTable(NewTableData(facade, params["changesetName"], params["selector"], params["state"], params["created-after"], params["created-before"], versource.PageRequest{}))
 	}
 }
 
-func NewTableData(facade versource.Facade, changesetName string) *TableData {
+func NewTableData(facade versource.Facade, changesetName, selector, state, createdAfter, createdBefore string, page versource.PageRequest) *TableData {
 	return &TableData{
 		facade:        facade,
 		changesetName: changesetName,
+		selector:      selector,
+		state:         state,
+		createdAfter:  createdAfter,
+		createdBefore: createdBefore,
+		page:          page,
 	}
 }
 
 func (p *TableData) LoadData() ([]versource.Plan, error) {
+	plans, _, err := p.LoadPage("")
+	return plans, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.Plan, string, error) {
 	ctx := context.Background()
 	req := versource.ListPlansRequest{
+		PageRequest:   p.page,
 		ChangesetName: p.changesetName,
+		Selector:      p.selector,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
 	}
+
+	if p.state != "" {
+		state := versource.TaskState(p.state)
+		req.State = &state
+	}
+
+	var err error
+	req.CreatedAfter, err = platform.ParseTimeParam("created-after", p.createdAfter)
+	if err != nil {
+		return nil, "", err
+	}
+	req.CreatedBefore, err = platform.ParseTimeParam("created-before", p.createdBefore)
+	if err != nil {
+		return nil, "", err
+	}
+
 	resp, err := p.facade.ListPlans(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.Plans, nil
+	return resp.Plans, resp.NextCursor, nil
 }
 
 func (p *TableData) ResolveData(data []versource.Plan) ([]table.Column, []table.Row, []versource.Plan) {
@@ -1,6 +1,8 @@
 package platform
 
 import (
+	"context"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -15,6 +17,8 @@ type Commandable struct {
 	showInput bool
 
 	size Size
+
+	events <-chan versource.Event
 }
 
 func (c *Commandable) contentSize() Size {
@@ -44,7 +48,22 @@ func NewCommandable(
 }
 
 func (c *Commandable) Init() tea.Cmd {
-	return c.router.Init()
+	resp, err := c.facade.SubscribeEvents(context.Background(), versource.SubscribeEventsRequest{})
+	if err != nil {
+		return c.router.Init()
+	}
+	c.events = resp.Events
+	return tea.Batch(c.router.Init(), c.waitForEvent())
+}
+
+func (c *Commandable) waitForEvent() tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-c.events
+		if !ok {
+			return nil
+		}
+		return eventReceivedMsg{event: event}
+	}
 }
 
 func (c *Commandable) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -56,6 +75,8 @@ func (c *Commandable) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c":
 			return c, tea.Quit
 		}
+	case eventReceivedMsg:
+		return c, tea.Batch(c.router.Refresh(), c.waitForEvent())
 	}
 
 	if c.showInput {
@@ -124,3 +145,7 @@ func (c *Commandable) View() string {
 
 	return content
 }
+
+type eventReceivedMsg struct {
+	event versource.Event
+}
@@ -173,6 +173,17 @@ func (r *Router) refresh() tea.Msg {
 	return nil
 }
 
+func (r *Router) Refresh() tea.Cmd {
+	if r.currentRoute == nil || r.currentRoute.IsLoading() {
+		return nil
+	}
+	refresher, ok := r.currentRoute.page.(Refresher)
+	if !ok {
+		return nil
+	}
+	return refresher.Refresh()
+}
+
 func (r *Router) goBack() tea.Msg {
 	return goBackRequestedMsg{}
 }
@@ -558,3 +569,7 @@ type Focuser interface {
 	Focus()
 	Blur()
 }
+
+type Refresher interface {
+	Refresh() tea.Cmd
+}
@@ -0,0 +1,170 @@
+package platform
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type StreamViewport struct {
+	viewport viewport.Model
+	content  strings.Builder
+	reader   io.ReadCloser
+	tail     bool
+	closed   bool
+
+	size Size
+	data StreamData
+}
+
+func NewStreamViewport(data StreamData) *StreamViewport {
+	vp := viewport.New(0, 0)
+
+	return &StreamViewport{
+		viewport: vp,
+		tail:     true,
+		data:     data,
+	}
+}
+
+func (v *StreamViewport) Init() tea.Cmd {
+	return func() tea.Msg {
+		reader, err := v.data.OpenStream()
+		if err != nil {
+			return errorMsg{err: err}
+		}
+		return streamOpenedMsg{viewport: v, reader: reader}
+	}
+}
+
+func (v *StreamViewport) Resize(size Size) {
+	v.viewport.Width = size.Width
+	v.viewport.Height = size.Height
+	v.size = size
+	if v.tail {
+		v.viewport.GotoBottom()
+	}
+}
+
+func (v *StreamViewport) Update(msg tea.Msg) (Page, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			v.viewport.ScrollDown(1)
+		case "k", "up":
+			v.viewport.ScrollUp(1)
+		case "g":
+			v.viewport.GotoTop()
+		case "G":
+			v.viewport.GotoBottom()
+		case "ctrl+d":
+			v.viewport.ScrollDown(v.viewport.Height / 2)
+		case "ctrl+u":
+			v.viewport.ScrollUp(v.viewport.Height / 2)
+		}
+		v.tail = v.viewport.AtBottom()
+		return v, nil
+	case streamOpenedMsg:
+		if msg.viewport != v {
+			return v, nil
+		}
+		v.reader = msg.reader
+		return v, v.readChunk()
+	case streamChunkMsg:
+		if msg.viewport != v {
+			return v, nil
+		}
+		v.content.Write(msg.chunk)
+		v.viewport.SetContent(v.content.String())
+		if v.tail {
+			v.viewport.GotoBottom()
+		}
+		return v, v.readChunk()
+	case streamClosedMsg:
+		if msg.viewport != v {
+			return v, nil
+		}
+		v.close()
+		if msg.err != nil {
+			v.content.WriteString("\n" + msg.err.Error())
+		}
+		if v.content.Len() == 0 {
+			v.content.WriteString("No data available")
+		}
+		v.viewport.SetContent(v.content.String())
+		if v.tail {
+			v.viewport.GotoBottom()
+		}
+		return v, nil
+	}
+
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd
+}
+
+func (v *StreamViewport) readChunk() tea.Cmd {
+	reader := v.reader
+	return func() tea.Msg {
+		buf := make([]byte, 4096)
+		n, err := reader.Read(buf)
+		if n > 0 {
+			return streamChunkMsg{viewport: v, chunk: buf[:n]}
+		}
+		if errors.Is(err, io.EOF) {
+			return streamClosedMsg{viewport: v}
+		}
+		return streamClosedMsg{viewport: v, err: err}
+	}
+}
+
+func (v *StreamViewport) close() {
+	if v.closed || v.reader == nil {
+		return
+	}
+	v.closed = true
+	v.reader.Close()
+}
+
+func (v *StreamViewport) View() string {
+	return v.viewport.View()
+}
+
+func (v *StreamViewport) KeyBindings() KeyBindings {
+	return v.data.KeyBindings()
+}
+
+func (v *StreamViewport) ExcludeFromHistory() bool {
+	return false
+}
+
+func (v *StreamViewport) Focus() {
+}
+
+func (v *StreamViewport) Blur() {
+}
+
+type StreamData interface {
+	OpenStream() (io.ReadCloser, error)
+	KeyBindings() KeyBindings
+}
+
+type streamOpenedMsg struct {
+	viewport *StreamViewport
+	reader   io.ReadCloser
+}
+
+type streamChunkMsg struct {
+	viewport *StreamViewport
+	chunk    []byte
+}
+
+type streamClosedMsg struct {
+	viewport *StreamViewport
+	err      error
+}
@@ -12,6 +12,10 @@ type DataTable[T any] struct {
 	rows    []table.Row
 	elems   []T
 
+	loaded      []T
+	nextCursor  string
+	loadingPage bool
+
 	size    Size
 	data    TableData[T]
 	focused bool
@@ -25,6 +29,9 @@ func NewDataTable[T any](data TableData[T]) *DataTable[T] {
 }
 
 func (t DataTable[T]) Init() tea.Cmd {
+	if paged, ok := t.data.(PagedTableData[T]); ok {
+		return loadTablePage(paged, "", false)
+	}
 	return func() tea.Msg {
 		data, err := t.data.LoadData()
 		if err != nil {
@@ -34,6 +41,10 @@ func (t DataTable[T]) Init() tea.Cmd {
 	}
 }
 
+func (t DataTable[T]) Refresh() tea.Cmd {
+	return t.Init()
+}
+
 func (t *DataTable[T]) Resize(size Size) {
 	t.table.SetWidth(size.Width)
 	t.table.SetHeight(size.Height)
@@ -44,16 +55,56 @@ func (t *DataTable[T]) Update(msg tea.Msg) (Page, tea.Cmd) {
 	switch msg := msg.(type) {
 	case dataLoadedMsg:
 		if data, ok := msg.data.([]T); ok {
-			t.columns, t.rows, t.elems = t.data.ResolveData(data)
-			t.table = newTable(t.columns, t.rows, t.size)
-			if t.focused {
-				t.table.Focus()
+			t.loaded = data
+			t.resolve()
+		}
+	case tablePageLoadedMsg:
+		if data, ok := msg.data.([]T); ok {
+			if msg.more {
+				t.loaded = append(t.loaded, data...)
+			} else {
+				t.loaded = data
 			}
+			t.nextCursor = msg.nextCursor
+			t.loadingPage = false
+			t.resolve()
 		}
 	}
 	var cmd tea.Cmd
 	t.table, cmd = t.table.Update(msg)
-	return t, cmd
+	return t, tea.Batch(cmd, t.loadNextPage())
+}
+
+func (t *DataTable[T]) resolve() {
+	t.columns, t.rows, t.elems = t.data.ResolveData(t.loaded)
+	cursor := t.table.Cursor()
+	t.table = newTable(t.columns, t.rows, t.size)
+	t.table.SetCursor(max(min(cursor, len(t.rows)-1), 0))
+	if t.focused {
+		t.table.Focus()
+	}
+}
+
+func (t *DataTable[T]) loadNextPage() tea.Cmd {
+	paged, ok := t.data.(PagedTableData[T])
+	if !ok || t.nextCursor == "" || t.loadingPage {
+		return nil
+	}
+	if t.table.Cursor() < len(t.rows)-1 {
+		return nil
+	}
+	t.loadingPage = true
+	return loadTablePage(paged, t.nextCursor, true)
+}
+
+func loadTablePage[T any](data PagedTableData[T], cursor string, more bool) tea.Cmd {
+	return func() tea.Msg {
+		items, nextCursor, err := data.LoadPage(cursor)
+		if err != nil {
+			return errorMsg{err: err}
+		}
+		return tablePageLoadedMsg{data: items, nextCursor: nextCursor, more: more}
+	}
 }
 
 func (t DataTable[T]) View() string {
@@ -93,6 +144,17 @@ type TableData[T any] interface {
 	ElemKeyBindings(elem T) KeyBindings
 }
 
+type PagedTableData[T any] interface {
+	TableData[T]
+	LoadPage(cursor string) ([]T, string, error)
+}
+
+type tablePageLoadedMsg struct {
+	data       any
+	nextCursor string
+	more       bool
+}
+
 func newTable(columns []table.Column, rows []table.Row, size Size) table.Model {
 	if len(rows) == 0 {
 		placeholderRow := make(table.Row, len(columns))
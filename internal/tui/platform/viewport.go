@@ -45,6 +45,10 @@ func (v *DataViewport[T]) Init() tea.Cmd {
 	}
 }
 
+func (v *DataViewport[T]) Refresh() tea.Cmd {
+	return v.Init()
+}
+
 func (v *DataViewport[T]) Resize(size Size) {
 	v.viewport.Width = size.Width
 	v.viewport.Height = size.Height
@@ -13,31 +13,42 @@ import (
 type TableData struct {
 	facade        versource.Facade
 	changesetName string
+	page          versource.PageRequest
 }
 
 func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataTable(NewTableData(facade, params["changesetName"]))
+		return platform.NewDataTable(NewTableData(facade, params["changesetName"], versource.PageRequest{}))
 	}
 }
 
-func NewTableData(facade versource.Facade, changesetName string) *TableData {
+func NewTableData(facade versource.Facade, changesetName string, page versource.PageRequest) *TableData {
 	return &TableData{
 		facade:        facade,
 		changesetName: changesetName,
+		page:          page,
 	}
 }
 
 func (p *TableData) LoadData() ([]versource.Rebase, error) {
+	rebases, _, err := p.LoadPage("")
+	return rebases, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.Rebase, string, error) {
 	ctx := context.Background()
 	req := versource.ListRebasesRequest{
+		PageRequest:   p.page,
 		ChangesetName: p.changesetName,
 	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
 	resp, err := p.facade.ListRebases(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.Rebases, nil
+	return resp.Rebases, resp.NextCursor, nil
 }
 
 func (p *TableData) ResolveData(data []versource.Rebase) ([]table.Column, []table.Row, []versource.Rebase) {
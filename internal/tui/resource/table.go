@@ -10,26 +10,56 @@ import (
 )
 
 type TableData struct {
-	facade versource.Facade
+	facade       versource.Facade
+	asOf         string
+	provider     string
+	resourceType string
+	page         versource.PageRequest
 }
 
 func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
 	return func(params map[string]string) platform.Page {
-		return platform.NewDataTable(NewTableData(facade))
+		return platform.NewDataTable(NewTableData(facade, params["as-of"], params["provider"], params["resource-type"], versource.PageRequest{}))
 	}
 }
 
-func NewTableData(facade versource.Facade) *TableData {
-	return &TableData{facade: facade}
+func NewTableData(facade versource.Facade, asOf, provider, resourceType string, page versource.PageRequest) *TableData {
+	return &TableData{
+		facade:       facade,
+		asOf:         asOf,
+		provider:     provider,
+		resourceType: resourceType,
+		page:         page,
+	}
 }
 
 func (p *TableData) LoadData() ([]versource.Resource, error) {
+	resources, _, err := p.LoadPage("")
+	return resources, err
+}
+
+func (p *TableData) LoadPage(cursor string) ([]versource.Resource, string, error) {
 	ctx := context.Background()
-	resp, err := p.facade.ListResources(ctx, versource.ListResourcesRequest{})
+	req := versource.ListResourcesRequest{
+		PageRequest: p.page,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
+	if p.asOf != "" {
+		req.AsOf = &p.asOf
+	}
+	if p.provider != "" {
+		req.Provider = &p.provider
+	}
+	if p.resourceType != "" {
+		req.ResourceType = &p.resourceType
+	}
+	resp, err := p.facade.ListResources(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return resp.Resources, nil
+	return resp.Resources, resp.NextCursor, nil
 }
 
 func (p *TableData) ResolveData(data []versource.Resource) ([]table.Column, []table.Row, []versource.Resource) {
@@ -0,0 +1,71 @@
+package team
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type TableData struct {
+	facade versource.Facade
+}
+
+func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewTableData(facade))
+	}
+}
+
+func NewTableData(facade versource.Facade) *TableData {
+	return &TableData{
+		facade: facade,
+	}
+}
+
+func (p *TableData) LoadData() ([]versource.Team, error) {
+	ctx := context.Background()
+	resp, err := p.facade.ListTeams(ctx, versource.ListTeamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Teams, nil
+}
+
+func (p *TableData) ResolveData(data []versource.Team) ([]table.Column, []table.Row, []versource.Team) {
+	columns := []table.Column{
+		{Title: "ID", Width: 1},
+		{Title: "Name", Width: 3},
+		{Title: "Members", Width: 8},
+	}
+
+	var rows []table.Row
+	var elems []versource.Team
+	for _, team := range data {
+		members := make([]string, 0, len(team.Members))
+		for _, member := range team.Members {
+			members = append(members, member.User)
+		}
+		rows = append(rows, table.Row{
+			strconv.FormatUint(uint64(team.ID), 10),
+			team.Name,
+			strings.Join(members, ", "),
+		})
+		elems = append(elems, team)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *TableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *TableData) ElemKeyBindings(elem versource.Team) platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "enter", Help: "View owned components", Command: "components?owner=" + elem.Name},
+	}
+}
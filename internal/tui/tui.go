@@ -4,15 +4,21 @@ import (
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/marcbran/versource/internal/tui/apitoken"
 	"github.com/marcbran/versource/internal/tui/apply"
+	"github.com/marcbran/versource/internal/tui/audit"
 	"github.com/marcbran/versource/internal/tui/changeset"
 	"github.com/marcbran/versource/internal/tui/component"
+	"github.com/marcbran/versource/internal/tui/environment"
 	"github.com/marcbran/versource/internal/tui/merge"
 	"github.com/marcbran/versource/internal/tui/module"
 	"github.com/marcbran/versource/internal/tui/plan"
 	"github.com/marcbran/versource/internal/tui/platform"
 	"github.com/marcbran/versource/internal/tui/rebase"
 	"github.com/marcbran/versource/internal/tui/resource"
+	"github.com/marcbran/versource/internal/tui/team"
+	"github.com/marcbran/versource/internal/tui/variableset"
+	"github.com/marcbran/versource/internal/tui/webhook"
 	"github.com/marcbran/versource/pkg/versource"
 )
 
@@ -29,6 +35,13 @@ func RunApp(facade versource.Facade) error {
 				{Key: "p", Help: "View plans", Command: "plans"},
 				{Key: "a", Help: "View applies", Command: "applies"},
 				{Key: "e", Help: "View resources", Command: "resources"},
+				{Key: "u", Help: "View merge queue", Command: "merges/queue"},
+				{Key: "t", Help: "View teams", Command: "teams"},
+				{Key: "w", Help: "View variable sets", Command: "variablesets"},
+				{Key: "n", Help: "View environments", Command: "environments"},
+				{Key: "y", Help: "View API tokens", Command: "tokens"},
+				{Key: "z", Help: "View audit log", Command: "audit"},
+				{Key: "h", Help: "View webhooks", Command: "webhooks"},
 			}
 		}).
 		KeyBinding("changesets/{changesetName}", func(params map[string]string, currentPath string) platform.KeyBindings {
@@ -39,6 +52,9 @@ func RunApp(facade versource.Facade) error {
 				{Key: "p", Help: "View plans", Command: fmt.Sprintf("changesets/%s/plans", changesetName)},
 				{Key: "e", Help: "View merges", Command: fmt.Sprintf("changesets/%s/merges", changesetName)},
 				{Key: "s", Help: "View rebases", Command: fmt.Sprintf("changesets/%s/rebases", changesetName)},
+				{Key: "f", Help: "View conflicts", Command: fmt.Sprintf("changesets/%s/conflicts", changesetName)},
+				{Key: "o", Help: "View approvals", Command: fmt.Sprintf("changesets/%s/approvals", changesetName)},
+				{Key: "w", Help: "View variable sets", Command: fmt.Sprintf("changesets/%s/variablesets", changesetName)},
 			}
 		}).
 		Route("modules", module.NewTable(facade)).
@@ -53,12 +69,15 @@ func RunApp(facade versource.Facade) error {
 		Route("components/{componentID}", component.NewDetail(facade)).
 		Route("components/{componentID}/edit", component.NewEdit(facade)).
 		Route("components/{componentID}/delete", component.NewDelete(facade)).
+		Route("components/{componentID}/history", component.NewHistoryTable(facade)).
+		Route("components/{componentID}/history/{commit}/revert", component.NewRevertToRevision(facade)).
 		Route("plans", plan.NewTable(facade)).
 		Route("plans/{planID}", plan.NewDetail(facade)).
 		Route("plans/{planID}/logs", plan.NewLogs(facade)).
 		Route("applies", apply.NewTable(facade)).
 		Route("applies/{applyID}", apply.NewDetail(facade)).
 		Route("applies/{applyID}/logs", apply.NewLogs(facade)).
+		Route("merges/queue", merge.NewQueueTable(facade)).
 		Route("changesets", changeset.NewTable(facade)).
 		Route("changesets/{changesetName}/components", component.NewTable(facade)).
 		Route("changesets/{changesetName}/components/create", component.NewCreateComponent(facade)).
@@ -69,16 +88,38 @@ func RunApp(facade versource.Facade) error {
 		Route("changesets/{changesetName}/components/{componentID}/restore", component.NewRestore(facade)).
 		Route("changesets/{changesetName}/changes", component.NewChangesetChangesTable(facade)).
 		Route("changesets/{changesetName}/changes/{componentID}", component.NewChangesetChangeDetail(facade)).
+		Route("changesets/{changesetName}/conflicts", component.NewConflictsTable(facade)).
+		Route("changesets/{changesetName}/conflicts/{componentID}/resolve", component.NewResolveConflict(facade)).
+		Route("changesets/{changesetName}/conflicts/{componentID}/edit", component.NewEditConflictResolution(facade)).
 		Route("changesets/{changesetName}/plans", plan.NewTable(facade)).
 		Route("changesets/{changesetName}/plans/{planID}", plan.NewDetail(facade)).
 		Route("changesets/{changesetName}/plans/{planID}/logs", plan.NewLogs(facade)).
 		Route("changesets/{changesetName}/merge", changeset.NewMergeChangeset(facade)).
 		Route("changesets/{changesetName}/merges", merge.NewTable(facade)).
 		Route("changesets/{changesetName}/merges/{mergeID}", merge.NewDetail(facade)).
+		Route("changesets/{changesetName}/merge-validation", merge.NewValidationTable(facade)).
 		Route("changesets/{changesetName}/rebase", changeset.NewRebaseChangeset(facade)).
 		Route("changesets/{changesetName}/rebases", rebase.NewTable(facade)).
 		Route("changesets/{changesetName}/rebases/{rebaseID}", rebase.NewDetail(facade)).
 		Route("changesets/{changesetName}/delete", changeset.NewDeleteChangeset(facade)).
+		Route("changesets/{changesetName}/auto-rebase", changeset.NewAutoRebaseChangeset(facade)).
+		Route("changesets/{changesetName}/close", changeset.NewCloseChangeset(facade)).
+		Route("changesets/{changesetName}/reopen", changeset.NewReopenChangeset(facade)).
+		Route("changesets/{changesetName}/revert", changeset.NewRevertChangeset(facade)).
+		Route("changesets/{changesetName}/approve", changeset.NewApproveChangeset(facade)).
+		Route("changesets/{changesetName}/approvals", changeset.NewApprovalsTable(facade)).
+		Route("teams", team.NewTable(facade)).
+		Route("variablesets", variableset.NewTable(facade)).
+		Route("changesets/{changesetName}/variablesets", variableset.NewTable(facade)).
+		Route("environments", environment.NewTable(facade)).
+		Route("templates/{template}/promotion", environment.NewPromotionTable(facade)).
+		Route("templates/{template}/promote", environment.NewPromoteComponent(facade)).
+		Route("tokens", apitoken.NewTable(facade)).
+		Route("audit", audit.NewTable(facade)).
+		Route("webhooks", webhook.NewTable(facade)).
+		Route("webhooks/{webhookName}/deliveries", webhook.NewDeliveriesTable(facade)).
+		Route("webhooks/{webhookName}/deliveries/{deliveryID}", webhook.NewDeliveryDetail(facade)).
+		Route("webhooks/{webhookName}/deliveries/{deliveryID}/redeliver", webhook.NewRedeliver(facade)).
 		Route("resources", resource.NewTable(facade))
 
 	app := platform.NewCommandable(router, facade)
@@ -0,0 +1,74 @@
+package variableset
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type TableData struct {
+	facade        versource.Facade
+	changesetName string
+}
+
+func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewTableData(facade, params["changesetName"]))
+	}
+}
+
+func NewTableData(facade versource.Facade, changesetName string) *TableData {
+	return &TableData{
+		facade:        facade,
+		changesetName: changesetName,
+	}
+}
+
+func (p *TableData) LoadData() ([]versource.VariableSet, error) {
+	ctx := context.Background()
+
+	req := versource.ListVariableSetsRequest{}
+	if p.changesetName != "" {
+		req.ChangesetName = &p.changesetName
+	}
+
+	resp, err := p.facade.ListVariableSets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.VariableSets, nil
+}
+
+func (p *TableData) ResolveData(data []versource.VariableSet) ([]table.Column, []table.Row, []versource.VariableSet) {
+	columns := []table.Column{
+		{Title: "ID", Width: 1},
+		{Title: "Name", Width: 3},
+		{Title: "Version", Width: 1},
+		{Title: "Variables", Width: 8},
+	}
+
+	var rows []table.Row
+	var elems []versource.VariableSet
+	for _, variableSet := range data {
+		rows = append(rows, table.Row{
+			strconv.FormatUint(uint64(variableSet.ID), 10),
+			variableSet.Name,
+			strconv.FormatUint(uint64(variableSet.Version), 10),
+			string(variableSet.Variables),
+		})
+		elems = append(elems, variableSet)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *TableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *TableData) ElemKeyBindings(elem versource.VariableSet) platform.KeyBindings {
+	return platform.KeyBindings{}
+}
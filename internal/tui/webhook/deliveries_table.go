@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type DeliveriesTableData struct {
+	facade      versource.Facade
+	webhookName string
+	page        versource.PageRequest
+}
+
+func NewDeliveriesTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewDeliveriesTableData(facade, params["webhookName"], versource.PageRequest{}))
+	}
+}
+
+func NewDeliveriesTableData(facade versource.Facade, webhookName string, page versource.PageRequest) *DeliveriesTableData {
+	return &DeliveriesTableData{
+		facade:      facade,
+		webhookName: webhookName,
+		page:        page,
+	}
+}
+
+func (p *DeliveriesTableData) LoadData() ([]versource.WebhookDelivery, error) {
+	deliveries, _, err := p.LoadPage("")
+	return deliveries, err
+}
+
+func (p *DeliveriesTableData) LoadPage(cursor string) ([]versource.WebhookDelivery, string, error) {
+	ctx := context.Background()
+	req := versource.ListWebhookDeliveriesRequest{
+		PageRequest: p.page,
+		WebhookName: p.webhookName,
+	}
+	if cursor != "" {
+		req.Cursor = cursor
+	}
+	resp, err := p.facade.ListWebhookDeliveries(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Deliveries, resp.NextCursor, nil
+}
+
+func (p *DeliveriesTableData) ResolveData(data []versource.WebhookDelivery) ([]table.Column, []table.Row, []versource.WebhookDelivery) {
+	columns := []table.Column{
+		{Title: "ID", Width: 1},
+		{Title: "Trigger", Width: 3},
+		{Title: "State", Width: 2},
+		{Title: "Attempts", Width: 1},
+		{Title: "Status", Width: 1},
+		{Title: "Created", Width: 3},
+	}
+
+	var rows []table.Row
+	var elems []versource.WebhookDelivery
+	for _, delivery := range data {
+		statusCode := ""
+		if delivery.StatusCode != nil {
+			statusCode = strconv.Itoa(*delivery.StatusCode)
+		}
+		rows = append(rows, table.Row{
+			strconv.FormatUint(uint64(delivery.ID), 10),
+			string(delivery.Trigger),
+			string(delivery.State),
+			strconv.Itoa(delivery.Attempts),
+			statusCode,
+			delivery.CreatedAt.Format(time.DateTime),
+		})
+		elems = append(elems, delivery)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *DeliveriesTableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "esc", Help: "View webhooks", Command: "webhooks"},
+	}
+}
+
+func (p *DeliveriesTableData) ElemKeyBindings(elem versource.WebhookDelivery) platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "enter", Help: "View delivery", Command: fmt.Sprintf("webhooks/%s/deliveries/%d", p.webhookName, elem.ID)},
+		{Key: "R", Help: "Redeliver", Command: fmt.Sprintf("webhooks/%s/deliveries/%d/redeliver", p.webhookName, elem.ID)},
+	}
+}
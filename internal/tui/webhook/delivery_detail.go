@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type DeliveryDetailData struct {
+	facade      versource.Facade
+	webhookName string
+	deliveryID  string
+}
+
+type DeliveryDetailViewModel struct {
+	ID            uint   `yaml:"id"`
+	Webhook       string `yaml:"webhook"`
+	Trigger       string `yaml:"trigger"`
+	State         string `yaml:"state"`
+	Attempts      int    `yaml:"attempts"`
+	StatusCode    *int   `yaml:"statusCode,omitempty"`
+	Error         string `yaml:"error,omitempty"`
+	NextAttemptAt string `yaml:"nextAttemptAt,omitempty"`
+	Payload       string `yaml:"payload"`
+}
+
+func NewDeliveryDetail(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewViewDataViewport(NewDeliveryDetailData(
+			facade,
+			params["webhookName"],
+			params["deliveryID"],
+		))
+	}
+}
+
+func NewDeliveryDetailData(facade versource.Facade, webhookName string, deliveryID string) *DeliveryDetailData {
+	return &DeliveryDetailData{
+		facade:      facade,
+		webhookName: webhookName,
+		deliveryID:  deliveryID,
+	}
+}
+
+func (p *DeliveryDetailData) LoadData() (*versource.GetWebhookDeliveryResponse, error) {
+	ctx := context.Background()
+
+	deliveryIDUint, err := strconv.ParseUint(p.deliveryID, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.facade.GetWebhookDelivery(ctx, versource.GetWebhookDeliveryRequest{
+		WebhookName: p.webhookName,
+		DeliveryID:  uint(deliveryIDUint),
+	})
+}
+
+func (p *DeliveryDetailData) ResolveData(data versource.GetWebhookDeliveryResponse) DeliveryDetailViewModel {
+	nextAttemptAt := ""
+	if data.Delivery.NextAttemptAt != nil {
+		nextAttemptAt = data.Delivery.NextAttemptAt.Format(time.DateTime)
+	}
+	return DeliveryDetailViewModel{
+		ID:            data.Delivery.ID,
+		Webhook:       data.Delivery.Webhook.Name,
+		Trigger:       string(data.Delivery.Trigger),
+		State:         string(data.Delivery.State),
+		Attempts:      data.Delivery.Attempts,
+		StatusCode:    data.Delivery.StatusCode,
+		Error:         data.Delivery.Error,
+		NextAttemptAt: nextAttemptAt,
+		Payload:       data.Delivery.Payload,
+	}
+}
+
+func (p *DeliveryDetailData) KeyBindings(elem versource.GetWebhookDeliveryResponse) platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "esc", Help: "View deliveries", Command: fmt.Sprintf("webhooks/%s/deliveries", p.webhookName)},
+		{Key: "R", Help: "Redeliver", Command: fmt.Sprintf("webhooks/%s/deliveries/%s/redeliver", p.webhookName, p.deliveryID)},
+	}
+}
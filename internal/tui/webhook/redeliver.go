@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type RedeliverData struct {
+	facade      versource.Facade
+	webhookName string
+	deliveryID  string
+}
+
+func NewRedeliver(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewConfirmationPage(&RedeliverData{
+			facade:      facade,
+			webhookName: params["webhookName"],
+			deliveryID:  params["deliveryID"],
+		})
+	}
+}
+
+func (r *RedeliverData) GetConfirmationDialog() platform.ConfirmationDialog {
+	return platform.ConfirmationDialog{
+		Title:       "Redeliver Webhook",
+		Message:     fmt.Sprintf("Are you sure you want to redeliver delivery %s of webhook '%s'?", r.deliveryID, r.webhookName),
+		ConfirmText: "redeliver",
+		CancelText:  "cancel",
+	}
+}
+
+func (r *RedeliverData) OnConfirm(ctx context.Context) (string, error) {
+	deliveryID, err := strconv.ParseUint(r.deliveryID, 10, 32)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.facade.RedeliverWebhookDelivery(ctx, versource.RedeliverWebhookDeliveryRequest{
+		WebhookName: r.webhookName,
+		DeliveryID:  uint(deliveryID),
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("webhooks/%s/deliveries", r.webhookName), nil
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/marcbran/versource/internal/tui/platform"
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+type TableData struct {
+	facade versource.Facade
+}
+
+func NewTable(facade versource.Facade) func(params map[string]string) platform.Page {
+	return func(params map[string]string) platform.Page {
+		return platform.NewDataTable(NewTableData(facade))
+	}
+}
+
+func NewTableData(facade versource.Facade) *TableData {
+	return &TableData{
+		facade: facade,
+	}
+}
+
+func (p *TableData) LoadData() ([]versource.Webhook, error) {
+	ctx := context.Background()
+	resp, err := p.facade.ListWebhooks(ctx, versource.ListWebhooksRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Webhooks, nil
+}
+
+func (p *TableData) ResolveData(data []versource.Webhook) ([]table.Column, []table.Row, []versource.Webhook) {
+	columns := []table.Column{
+		{Title: "ID", Width: 1},
+		{Title: "Name", Width: 3},
+		{Title: "URL", Width: 5},
+		{Title: "Triggers", Width: 5},
+	}
+
+	var rows []table.Row
+	var elems []versource.Webhook
+	for _, webhook := range data {
+		triggers := make([]string, len(webhook.Triggers))
+		for i, trigger := range webhook.Triggers {
+			triggers[i] = string(trigger)
+		}
+		rows = append(rows, table.Row{
+			strconv.FormatUint(uint64(webhook.ID), 10),
+			webhook.Name,
+			webhook.URL,
+			strings.Join(triggers, ","),
+		})
+		elems = append(elems, webhook)
+	}
+
+	return columns, rows, elems
+}
+
+func (p *TableData) KeyBindings() platform.KeyBindings {
+	return platform.KeyBindings{}
+}
+
+func (p *TableData) ElemKeyBindings(elem versource.Webhook) platform.KeyBindings {
+	return platform.KeyBindings{
+		{Key: "enter", Help: "View deliveries", Command: fmt.Sprintf("webhooks/%s/deliveries", elem.Name)},
+	}
+}
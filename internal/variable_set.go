@@ -0,0 +1,335 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/datatypes"
+)
+
+type VariableSetRepo interface {
+	GetVariableSetByName(ctx context.Context, name string) (*versource.VariableSet, error)
+	ListVariableSets(ctx context.Context) ([]versource.VariableSet, error)
+	ListVariableSetsAtCommit(ctx context.Context, commit string) ([]versource.VariableSet, error)
+	CreateVariableSet(ctx context.Context, variableSet *versource.VariableSet) error
+	UpdateVariableSet(ctx context.Context, variableSet *versource.VariableSet) error
+}
+
+type GetVariableSet struct {
+	variableSetRepo VariableSetRepo
+	tx              TransactionManager
+}
+
+func NewGetVariableSet(variableSetRepo VariableSetRepo, tx TransactionManager) *GetVariableSet {
+	return &GetVariableSet{
+		variableSetRepo: variableSetRepo,
+		tx:              tx,
+	}
+}
+
+func (g *GetVariableSet) Exec(ctx context.Context, req versource.GetVariableSetRequest) (*versource.GetVariableSetResponse, error) {
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+
+	branch := MainBranch
+	if req.ChangesetName != nil {
+		branch = *req.ChangesetName
+	}
+
+	var variableSet *versource.VariableSet
+	err := g.tx.Checkout(ctx, branch, func(ctx context.Context) error {
+		var err error
+		variableSet, err = g.variableSetRepo.GetVariableSetByName(ctx, req.Name)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to get variable set", err)
+	}
+	if variableSet == nil {
+		return nil, versource.NotFoundErrf("variable set %s not found", req.Name)
+	}
+
+	return &versource.GetVariableSetResponse{
+		VariableSet: *variableSet,
+	}, nil
+}
+
+type ListVariableSets struct {
+	variableSetRepo VariableSetRepo
+	tx              TransactionManager
+}
+
+func NewListVariableSets(variableSetRepo VariableSetRepo, tx TransactionManager) *ListVariableSets {
+	return &ListVariableSets{
+		variableSetRepo: variableSetRepo,
+		tx:              tx,
+	}
+}
+
+func (l *ListVariableSets) Exec(ctx context.Context, req versource.ListVariableSetsRequest) (*versource.ListVariableSetsResponse, error) {
+	branch := MainBranch
+	if req.ChangesetName != nil {
+		branch = *req.ChangesetName
+	}
+
+	var variableSets []versource.VariableSet
+	err := l.tx.Checkout(ctx, branch, func(ctx context.Context) error {
+		var err error
+		variableSets, err = l.variableSetRepo.ListVariableSets(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list variable sets", err)
+	}
+
+	return &versource.ListVariableSetsResponse{
+		VariableSets: variableSets,
+	}, nil
+}
+
+type CreateVariableSet struct {
+	variableSetRepo VariableSetRepo
+	ensureChangeset *EnsureChangeset
+	tx              TransactionManager
+}
+
+func NewCreateVariableSet(variableSetRepo VariableSetRepo, ensureChangeset *EnsureChangeset, tx TransactionManager) *CreateVariableSet {
+	return &CreateVariableSet{
+		variableSetRepo: variableSetRepo,
+		ensureChangeset: ensureChangeset,
+		tx:              tx,
+	}
+}
+
+func (c *CreateVariableSet) Exec(ctx context.Context, req versource.CreateVariableSetRequest) (*versource.CreateVariableSetResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset is required")
+	}
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+
+	variablesJSON, err := json.Marshal(req.Variables)
+	if err != nil {
+		return nil, versource.UserErrE("invalid variables format", err)
+	}
+
+	_, err = c.ensureChangeset.Exec(ctx, versource.EnsureChangesetRequest{Name: req.ChangesetName})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to ensure changeset", err)
+	}
+
+	var response *versource.CreateVariableSetResponse
+	err = c.tx.Do(ctx, req.ChangesetName, fmt.Sprintf("create variable set %s", req.Name), func(ctx context.Context) error {
+		existing, err := c.variableSetRepo.GetVariableSetByName(ctx, req.Name)
+		if err != nil {
+			return versource.InternalErrE("failed to check variable set name", err)
+		}
+		if existing != nil {
+			return versource.ConflictErrf("variable set with name %s already exists", req.Name)
+		}
+
+		variableSet := &versource.VariableSet{
+			Name:      req.Name,
+			Variables: datatypes.JSON(variablesJSON),
+			Version:   1,
+		}
+		err = c.variableSetRepo.CreateVariableSet(ctx, variableSet)
+		if err != nil {
+			return versource.InternalErrE("failed to create variable set", err)
+		}
+
+		response = &versource.CreateVariableSetResponse{
+			VariableSet: *variableSet,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type UpdateVariableSet struct {
+	variableSetRepo VariableSetRepo
+	componentRepo   ComponentRepo
+	ensureChangeset *EnsureChangeset
+	createPlan      *CreatePlan
+	tx              TransactionManager
+}
+
+func NewUpdateVariableSet(variableSetRepo VariableSetRepo, componentRepo ComponentRepo, ensureChangeset *EnsureChangeset, createPlan *CreatePlan, tx TransactionManager) *UpdateVariableSet {
+	return &UpdateVariableSet{
+		variableSetRepo: variableSetRepo,
+		componentRepo:   componentRepo,
+		ensureChangeset: ensureChangeset,
+		createPlan:      createPlan,
+		tx:              tx,
+	}
+}
+
+func (u *UpdateVariableSet) Exec(ctx context.Context, req versource.UpdateVariableSetRequest) (*versource.UpdateVariableSetResponse, error) {
+	if req.ChangesetName == "" {
+		return nil, versource.UserErr("changeset is required")
+	}
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+
+	variablesJSON, err := json.Marshal(req.Variables)
+	if err != nil {
+		return nil, versource.UserErrE("invalid variables format", err)
+	}
+
+	_, err = u.ensureChangeset.Exec(ctx, versource.EnsureChangesetRequest{Name: req.ChangesetName})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to ensure changeset", err)
+	}
+
+	var response *versource.UpdateVariableSetResponse
+	var componentIDs []uint
+	err = u.tx.Do(ctx, req.ChangesetName, fmt.Sprintf("update variable set %s", req.Name), func(ctx context.Context) error {
+		variableSet, err := u.variableSetRepo.GetVariableSetByName(ctx, req.Name)
+		if err != nil {
+			return versource.InternalErrE("failed to get variable set", err)
+		}
+		if variableSet == nil {
+			return versource.NotFoundErrf("variable set %s not found", req.Name)
+		}
+
+		variableSet.Variables = datatypes.JSON(variablesJSON)
+		variableSet.Version++
+		err = u.variableSetRepo.UpdateVariableSet(ctx, variableSet)
+		if err != nil {
+			return versource.InternalErrE("failed to update variable set", err)
+		}
+
+		components, err := u.componentRepo.ListComponents(ctx)
+		if err != nil {
+			return versource.InternalErrE("failed to list components", err)
+		}
+		for _, component := range components {
+			if component.Status == versource.ComponentStatusDeleted {
+				continue
+			}
+			index := slices.IndexFunc(component.VariableSets, func(attachment versource.VariableSetAttachment) bool {
+				return attachment.Name == variableSet.Name
+			})
+			if index < 0 {
+				continue
+			}
+			component.VariableSets[index].Version = variableSet.Version
+			component.ModuleVersion = versource.ModuleVersion{}
+			err = u.componentRepo.UpdateComponent(ctx, &component)
+			if err != nil {
+				return versource.InternalErrE("failed to update component", err)
+			}
+			componentIDs = append(componentIDs, component.ID)
+		}
+
+		response = &versource.UpdateVariableSetResponse{
+			VariableSet: *variableSet,
+			Plans:       []versource.Plan{},
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, componentID := range componentIDs {
+		planResp, err := u.createPlan.Exec(ctx, versource.CreatePlanRequest{
+			ComponentID:   componentID,
+			ChangesetName: req.ChangesetName,
+		})
+		if err != nil {
+			return nil, versource.InternalErrE("failed to create plan after variable set update", err)
+		}
+		response.Plans = append(response.Plans, planResp.Plan)
+	}
+
+	return response, nil
+}
+
+func attachVariableSets(ctx context.Context, variableSetRepo VariableSetRepo, names []string) ([]versource.VariableSetAttachment, error) {
+	attachments := make([]versource.VariableSetAttachment, 0, len(names))
+	for i, name := range names {
+		if slices.Contains(names[:i], name) {
+			return nil, versource.UserErrf("variable set %s is attached more than once", name)
+		}
+		variableSet, err := variableSetRepo.GetVariableSetByName(ctx, name)
+		if err != nil {
+			return nil, versource.InternalErrE("failed to get variable set", err)
+		}
+		if variableSet == nil {
+			return nil, versource.UserErrf("variable set %s not found", name)
+		}
+		attachments = append(attachments, versource.VariableSetAttachment{
+			Name:    variableSet.Name,
+			Version: variableSet.Version,
+		})
+	}
+	return attachments, nil
+}
+
+func effectiveVariables(ctx context.Context, variableSetRepo VariableSetRepo, component *versource.Component, commit string) (datatypes.JSON, error) {
+	if len(component.VariableSets) == 0 && len(component.Overrides) == 0 {
+		return component.Variables, nil
+	}
+
+	variableSetsByName := make(map[string]versource.VariableSet, len(component.VariableSets))
+	if len(component.VariableSets) > 0 {
+		variableSets, err := variableSetRepo.ListVariableSetsAtCommit(ctx, commit)
+		if err != nil {
+			return nil, err
+		}
+		for _, variableSet := range variableSets {
+			variableSetsByName[variableSet.Name] = variableSet
+		}
+	}
+
+	merged := make(map[string]any)
+	for _, attachment := range component.VariableSets {
+		variableSet, ok := variableSetsByName[attachment.Name]
+		if !ok {
+			return nil, fmt.Errorf("variable set %s not found at commit %s", attachment.Name, commit)
+		}
+		err := mergeVariables(merged, variableSet.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge variable set %s: %w", attachment.Name, err)
+		}
+	}
+	err := mergeVariables(merged, component.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge component variables: %w", err)
+	}
+	err = mergeVariables(merged, component.Overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge environment overrides: %w", err)
+	}
+
+	variablesJSON, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal effective variables: %w", err)
+	}
+	return datatypes.JSON(variablesJSON), nil
+}
+
+func mergeVariables(merged map[string]any, data datatypes.JSON) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var variables map[string]any
+	err := json.Unmarshal(data, &variables)
+	if err != nil {
+		return err
+	}
+	maps.Copy(merged, variables)
+	return nil
+}
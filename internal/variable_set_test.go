@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"gorm.io/datatypes"
+)
+
+type fakeVariableSetRepo struct {
+	VariableSetRepo
+	variableSets []versource.VariableSet
+}
+
+func (r *fakeVariableSetRepo) ListVariableSetsAtCommit(ctx context.Context, commit string) ([]versource.VariableSet, error) {
+	return r.variableSets, nil
+}
+
+func TestEffectiveVariables(t *testing.T) {
+	repo := &fakeVariableSetRepo{
+		variableSets: []versource.VariableSet{
+			{Name: "global", Variables: datatypes.JSON(`{"region":"eu-west-1","tags":{"team":"platform"},"size":"small"}`)},
+			{Name: "prod", Variables: datatypes.JSON(`{"size":"large"}`)},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		variableSets []versource.VariableSetAttachment
+		variables    datatypes.JSON
+		overrides    datatypes.JSON
+		expected     map[string]any
+		expectErr    bool
+	}{
+		{
+			name:      "no variable sets",
+			variables: datatypes.JSON(`{"name":"a"}`),
+			expected:  map[string]any{"name": "a"},
+		},
+		{
+			name:         "later sets take precedence",
+			variableSets: []versource.VariableSetAttachment{{Name: "global"}, {Name: "prod"}},
+			variables:    datatypes.JSON(`{"name":"a"}`),
+			expected:     map[string]any{"name": "a", "region": "eu-west-1", "tags": map[string]any{"team": "platform"}, "size": "large"},
+		},
+		{
+			name:         "component variables take precedence",
+			variableSets: []versource.VariableSetAttachment{{Name: "prod"}, {Name: "global"}},
+			variables:    datatypes.JSON(`{"region":"us-east-1"}`),
+			expected:     map[string]any{"region": "us-east-1", "tags": map[string]any{"team": "platform"}, "size": "small"},
+		},
+		{
+			name:         "environment overrides take precedence",
+			variableSets: []versource.VariableSetAttachment{{Name: "global"}},
+			variables:    datatypes.JSON(`{"name":"a","size":"medium"}`),
+			overrides:    datatypes.JSON(`{"size":"large"}`),
+			expected:     map[string]any{"name": "a", "region": "eu-west-1", "tags": map[string]any{"team": "platform"}, "size": "large"},
+		},
+		{
+			name:      "overrides without variable sets",
+			variables: datatypes.JSON(`{"name":"a"}`),
+			overrides: datatypes.JSON(`{"name":"b"}`),
+			expected:  map[string]any{"name": "b"},
+		},
+		{
+			name:         "missing variable set",
+			variableSets: []versource.VariableSetAttachment{{Name: "staging"}},
+			variables:    datatypes.JSON(`{}`),
+			expectErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := &versource.Component{Variables: tt.variables, VariableSets: tt.variableSets, Overrides: tt.overrides}
+			result, err := effectiveVariables(context.Background(), repo, component, "commit")
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var actual map[string]any
+			err = json.Unmarshal(result, &actual)
+			if err != nil {
+				t.Fatalf("failed to unmarshal result: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
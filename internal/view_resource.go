@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/marcbran/versource/pkg/versource"
 )
@@ -13,7 +14,7 @@ type ViewQueryParser interface {
 type ViewResourceRepo interface {
 	GetViewResource(ctx context.Context, viewResourceID uint) (*versource.ViewResource, error)
 	GetViewResourceByName(ctx context.Context, name string) (*versource.ViewResource, error)
-	ListViewResources(ctx context.Context) ([]versource.ViewResource, error)
+	ListViewResources(ctx context.Context, page PageQuery) ([]versource.ViewResource, error)
 	CreateViewResource(ctx context.Context, viewResource *versource.ViewResource) error
 	UpdateViewResource(ctx context.Context, viewResource *versource.ViewResource) error
 	DeleteViewResource(ctx context.Context, viewResourceID uint) error
@@ -21,6 +22,25 @@ type ViewResourceRepo interface {
 	DropDatabaseView(ctx context.Context, name string) error
 }
 
+var viewResourcePageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":   "id",
+		"name": "name",
+	},
+	DefaultSort: "id",
+}
+
+func viewResourcePageKey(viewResource versource.ViewResource, field string) (string, string) {
+	id := pageID(viewResource.ID)
+	switch field {
+	case "name":
+		return viewResource.Name, id
+	default:
+		return id, id
+	}
+}
+
 type GetViewResource struct {
 	viewResourceRepo ViewResourceRepo
 	tx               TransactionManager
@@ -45,7 +65,7 @@ func (g *GetViewResource) Exec(ctx context.Context, req versource.GetViewResourc
 	}
 
 	if viewResource == nil {
-		return nil, versource.UserErr("view resource not found")
+		return nil, versource.NotFoundErr("view resource not found")
 	}
 
 	return &versource.GetViewResourceResponse{
@@ -66,18 +86,26 @@ func NewListViewResources(viewResourceRepo ViewResourceRepo, tx TransactionManag
 }
 
 func (l *ListViewResources) Exec(ctx context.Context, req versource.ListViewResourcesRequest) (*versource.ListViewResourcesResponse, error) {
+	page, err := NewPageQuery(req.PageRequest, viewResourcePageSpec)
+	if err != nil {
+		return nil, err
+	}
+
 	var viewResources []versource.ViewResource
-	err := l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
+	err = l.tx.Checkout(ctx, MainBranch, func(ctx context.Context) error {
 		var err error
-		viewResources, err = l.viewResourceRepo.ListViewResources(ctx)
+		viewResources, err = l.viewResourceRepo.ListViewResources(ctx, page)
 		return err
 	})
 	if err != nil {
 		return nil, versource.InternalErrE("failed to list view resources", err)
 	}
 
+	viewResources, nextCursor := nextPage(viewResources, page, viewResourcePageKey)
+
 	return &versource.ListViewResourcesResponse{
 		ViewResources: viewResources,
+		NextCursor:    nextCursor,
 	}, nil
 }
 
@@ -106,7 +134,7 @@ func (s *SaveViewResource) Exec(ctx context.Context, req versource.SaveViewResou
 	}
 
 	var response *versource.SaveViewResourceResponse
-	err = s.tx.Do(ctx, MainBranch, "save view resource", func(ctx context.Context) error {
+	err = s.tx.Do(ctx, MainBranch, fmt.Sprintf("save view resource %s", viewResource.Name), func(ctx context.Context) error {
 		existing, err := s.viewResourceRepo.GetViewResourceByName(ctx, viewResource.Name)
 		if err != nil {
 			return versource.InternalErrE("failed to check existing view resource", err)
@@ -163,13 +191,13 @@ func (d *DeleteViewResource) Exec(ctx context.Context, req versource.DeleteViewR
 	}
 
 	var response *versource.DeleteViewResourceResponse
-	err := d.tx.Do(ctx, MainBranch, "delete view resource", func(ctx context.Context) error {
+	err := d.tx.Do(ctx, MainBranch, fmt.Sprintf("delete view resource %d", req.ViewResourceID), func(ctx context.Context) error {
 		viewResource, err := d.viewResourceRepo.GetViewResource(ctx, req.ViewResourceID)
 		if err != nil {
 			return versource.InternalErrE("failed to get view resource", err)
 		}
 		if viewResource == nil {
-			return versource.UserErr("view resource not found")
+			return versource.NotFoundErr("view resource not found")
 		}
 
 		err = d.viewResourceRepo.DeleteViewResource(ctx, req.ViewResourceID)
@@ -0,0 +1,706 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	webhookMaxAttempts     = 5
+	webhookInitialBackoff  = 10 * time.Second
+	webhookDeliveryTimeout = 10 * time.Second
+
+	webhookSignatureHeader = "X-Versource-Signature"
+	webhookTriggerHeader   = "X-Versource-Trigger"
+	webhookDeliveryHeader  = "X-Versource-Delivery"
+
+	webhookDroppedEventError = "event was dropped because the webhook worker fell behind, redeliver to send it"
+)
+
+type WebhookRepo interface {
+	ListWebhooks(ctx context.Context) ([]versource.Webhook, error)
+	GetWebhookByName(ctx context.Context, name string) (*versource.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook *versource.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID uint) error
+}
+
+type WebhookDeliveryRepo interface {
+	ListWebhookDeliveries(ctx context.Context, webhookID uint, page PageQuery) ([]versource.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, deliveryID uint) (*versource.WebhookDelivery, error)
+	GetDueWebhookDeliveries(ctx context.Context, now time.Time) ([]uint, error)
+	CreateWebhookDelivery(ctx context.Context, delivery *versource.WebhookDelivery) error
+	UpdateWebhookDelivery(ctx context.Context, delivery *versource.WebhookDelivery) error
+}
+
+var webhookDeliveryPageSpec = PageSpec{
+	IDColumn: "id",
+	Columns: map[string]string{
+		"id":      "id",
+		"state":   "state",
+		"created": "created_at",
+	},
+	DefaultSort: "-id",
+}
+
+func webhookDeliveryPageKey(delivery versource.WebhookDelivery, field string) (string, string) {
+	id := pageID(delivery.ID)
+	switch field {
+	case "state":
+		return string(delivery.State), id
+	case "created":
+		return pageTime(delivery.CreatedAt), id
+	default:
+		return id, id
+	}
+}
+
+type ListWebhooks struct {
+	webhookRepo WebhookRepo
+	tx          TransactionManager
+}
+
+func NewListWebhooks(webhookRepo WebhookRepo, tx TransactionManager) *ListWebhooks {
+	return &ListWebhooks{
+		webhookRepo: webhookRepo,
+		tx:          tx,
+	}
+}
+
+func (l *ListWebhooks) Exec(ctx context.Context, req versource.ListWebhooksRequest) (*versource.ListWebhooksResponse, error) {
+	var webhooks []versource.Webhook
+	err := l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		webhooks, err = l.webhookRepo.ListWebhooks(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, versource.InternalErrE("failed to list webhooks", err)
+	}
+
+	return &versource.ListWebhooksResponse{
+		Webhooks: webhooks,
+	}, nil
+}
+
+type CreateWebhook struct {
+	webhookRepo  WebhookRepo
+	secretCipher *SecretCipher
+	tx           TransactionManager
+}
+
+func NewCreateWebhook(webhookRepo WebhookRepo, secretCipher *SecretCipher, tx TransactionManager) *CreateWebhook {
+	return &CreateWebhook{
+		webhookRepo:  webhookRepo,
+		secretCipher: secretCipher,
+		tx:           tx,
+	}
+}
+
+func (c *CreateWebhook) Exec(ctx context.Context, req versource.CreateWebhookRequest) (*versource.CreateWebhookResponse, error) {
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+	if req.URL == "" {
+		return nil, versource.UserErr("url is required")
+	}
+	parsedURL, err := url.Parse(req.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, versource.UserErrf("invalid url %s, must be an absolute http or https url", req.URL)
+	}
+	if len(req.Triggers) == 0 {
+		return nil, versource.UserErr("at least one trigger is required")
+	}
+	for _, trigger := range req.Triggers {
+		if !versource.IsValidWebhookTrigger(trigger) {
+			return nil, versource.UserErrf("invalid trigger %s", trigger)
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret, err = generateWebhookSecret()
+		if err != nil {
+			return nil, versource.InternalErrE("failed to generate webhook secret", err)
+		}
+	}
+	encryptedSecret, err := c.secretCipher.Encrypt([]byte(secret))
+	if err != nil {
+		if errors.Is(err, ErrNoSecretKey) {
+			return nil, versource.PreconditionFailedErr("webhooks require a secret key to be configured on the server")
+		}
+		return nil, versource.InternalErrE("failed to encrypt webhook secret", err)
+	}
+
+	var response *versource.CreateWebhookResponse
+	err = c.tx.Do(ctx, AdminBranch, fmt.Sprintf("create webhook %s", req.Name), func(ctx context.Context) error {
+		existing, err := c.webhookRepo.GetWebhookByName(ctx, req.Name)
+		if err != nil {
+			return versource.InternalErrE("failed to check webhook name", err)
+		}
+		if existing != nil {
+			return versource.ConflictErrf("webhook with name %s already exists", req.Name)
+		}
+
+		webhook := &versource.Webhook{
+			Name:     req.Name,
+			URL:      req.URL,
+			Triggers: req.Triggers,
+			Secret:   encryptedSecret,
+		}
+		err = c.webhookRepo.CreateWebhook(ctx, webhook)
+		if err != nil {
+			return versource.InternalErrE("failed to create webhook", err)
+		}
+
+		response = &versource.CreateWebhookResponse{
+			Webhook: *webhook,
+			Secret:  secret,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type DeleteWebhook struct {
+	webhookRepo WebhookRepo
+	tx          TransactionManager
+}
+
+func NewDeleteWebhook(webhookRepo WebhookRepo, tx TransactionManager) *DeleteWebhook {
+	return &DeleteWebhook{
+		webhookRepo: webhookRepo,
+		tx:          tx,
+	}
+}
+
+func (d *DeleteWebhook) Exec(ctx context.Context, req versource.DeleteWebhookRequest) (*versource.DeleteWebhookResponse, error) {
+	if req.Name == "" {
+		return nil, versource.UserErr("name is required")
+	}
+
+	var response *versource.DeleteWebhookResponse
+	err := d.tx.Do(ctx, AdminBranch, fmt.Sprintf("delete webhook %s", req.Name), func(ctx context.Context) error {
+		webhook, err := d.webhookRepo.GetWebhookByName(ctx, req.Name)
+		if err != nil {
+			return versource.InternalErrE("failed to get webhook", err)
+		}
+		if webhook == nil {
+			return versource.NotFoundErrf("webhook %s not found", req.Name)
+		}
+
+		err = d.webhookRepo.DeleteWebhook(ctx, webhook.ID)
+		if err != nil {
+			return versource.InternalErrE("failed to delete webhook", err)
+		}
+
+		response = &versource.DeleteWebhookResponse{
+			Webhook: *webhook,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type ListWebhookDeliveries struct {
+	webhookRepo         WebhookRepo
+	webhookDeliveryRepo WebhookDeliveryRepo
+	tx                  TransactionManager
+}
+
+func NewListWebhookDeliveries(webhookRepo WebhookRepo, webhookDeliveryRepo WebhookDeliveryRepo, tx TransactionManager) *ListWebhookDeliveries {
+	return &ListWebhookDeliveries{
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+		tx:                  tx,
+	}
+}
+
+func (l *ListWebhookDeliveries) Exec(ctx context.Context, req versource.ListWebhookDeliveriesRequest) (*versource.ListWebhookDeliveriesResponse, error) {
+	if req.WebhookName == "" {
+		return nil, versource.UserErr("webhook name is required")
+	}
+
+	page, err := NewPageQuery(req.PageRequest, webhookDeliveryPageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []versource.WebhookDelivery
+	err = l.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		webhook, err := l.webhookRepo.GetWebhookByName(ctx, req.WebhookName)
+		if err != nil {
+			return versource.InternalErrE("failed to get webhook", err)
+		}
+		if webhook == nil {
+			return versource.NotFoundErrf("webhook %s not found", req.WebhookName)
+		}
+
+		deliveries, err = l.webhookDeliveryRepo.ListWebhookDeliveries(ctx, webhook.ID, page)
+		if err != nil {
+			return versource.InternalErrE("failed to list webhook deliveries", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, nextCursor := nextPage(deliveries, page, webhookDeliveryPageKey)
+
+	return &versource.ListWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+type GetWebhookDelivery struct {
+	webhookRepo         WebhookRepo
+	webhookDeliveryRepo WebhookDeliveryRepo
+	tx                  TransactionManager
+}
+
+func NewGetWebhookDelivery(webhookRepo WebhookRepo, webhookDeliveryRepo WebhookDeliveryRepo, tx TransactionManager) *GetWebhookDelivery {
+	return &GetWebhookDelivery{
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+		tx:                  tx,
+	}
+}
+
+func (g *GetWebhookDelivery) Exec(ctx context.Context, req versource.GetWebhookDeliveryRequest) (*versource.GetWebhookDeliveryResponse, error) {
+	var delivery *versource.WebhookDelivery
+	err := g.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		delivery, err = getWebhookDelivery(ctx, g.webhookRepo, g.webhookDeliveryRepo, req.WebhookName, req.DeliveryID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &versource.GetWebhookDeliveryResponse{
+		Delivery: *delivery,
+	}, nil
+}
+
+type RedeliverWebhookDelivery struct {
+	webhookRepo         WebhookRepo
+	webhookDeliveryRepo WebhookDeliveryRepo
+	tx                  TransactionManager
+	webhookWorker       *WebhookWorker
+}
+
+func NewRedeliverWebhookDelivery(webhookRepo WebhookRepo, webhookDeliveryRepo WebhookDeliveryRepo, tx TransactionManager, webhookWorker *WebhookWorker) *RedeliverWebhookDelivery {
+	return &RedeliverWebhookDelivery{
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+		tx:                  tx,
+		webhookWorker:       webhookWorker,
+	}
+}
+
+func (r *RedeliverWebhookDelivery) Exec(ctx context.Context, req versource.RedeliverWebhookDeliveryRequest) (*versource.RedeliverWebhookDeliveryResponse, error) {
+	var response *versource.RedeliverWebhookDeliveryResponse
+	err := r.tx.Do(ctx, AdminBranch, fmt.Sprintf("redeliver webhook delivery %d", req.DeliveryID), func(ctx context.Context) error {
+		delivery, err := getWebhookDelivery(ctx, r.webhookRepo, r.webhookDeliveryRepo, req.WebhookName, req.DeliveryID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		redelivery := &versource.WebhookDelivery{
+			WebhookID:     delivery.WebhookID,
+			Trigger:       delivery.Trigger,
+			Payload:       delivery.Payload,
+			State:         versource.WebhookDeliveryStatePending,
+			NextAttemptAt: &now,
+		}
+		err = r.webhookDeliveryRepo.CreateWebhookDelivery(ctx, redelivery)
+		if err != nil {
+			return versource.InternalErrE("failed to create webhook delivery", err)
+		}
+
+		response = &versource.RedeliverWebhookDeliveryResponse{
+			Delivery: *redelivery,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if r.webhookWorker != nil {
+		r.webhookWorker.QueueDelivery(response.Delivery.ID)
+	}
+
+	return response, nil
+}
+
+func getWebhookDelivery(ctx context.Context, webhookRepo WebhookRepo, webhookDeliveryRepo WebhookDeliveryRepo, webhookName string, deliveryID uint) (*versource.WebhookDelivery, error) {
+	webhook, err := webhookRepo.GetWebhookByName(ctx, webhookName)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to get webhook", err)
+	}
+	if webhook == nil {
+		return nil, versource.NotFoundErrf("webhook %s not found", webhookName)
+	}
+
+	delivery, err := webhookDeliveryRepo.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, versource.InternalErrE("failed to get webhook delivery", err)
+	}
+	if delivery == nil || delivery.WebhookID != webhook.ID {
+		return nil, versource.NotFoundErrf("delivery %d not found for webhook %s", deliveryID, webhookName)
+	}
+	return delivery, nil
+}
+
+type WebhookWorker struct {
+	eventBus            *EventBus
+	webhookRepo         WebhookRepo
+	webhookDeliveryRepo WebhookDeliveryRepo
+	planRepo            PlanRepo
+	applyRepo           ApplyRepo
+	mergeRepo           MergeRepo
+	rebaseRepo          RebaseRepo
+	secretCipher        *SecretCipher
+	tx                  TransactionManager
+	client              *http.Client
+	deliveryChan        chan uint
+}
+
+func NewWebhookWorker(eventBus *EventBus, webhookRepo WebhookRepo, webhookDeliveryRepo WebhookDeliveryRepo, planRepo PlanRepo, applyRepo ApplyRepo, mergeRepo MergeRepo, rebaseRepo RebaseRepo, secretCipher *SecretCipher, tx TransactionManager) *WebhookWorker {
+	return &WebhookWorker{
+		eventBus:            eventBus,
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+		planRepo:            planRepo,
+		applyRepo:           applyRepo,
+		mergeRepo:           mergeRepo,
+		rebaseRepo:          rebaseRepo,
+		secretCipher:        secretCipher,
+		tx:                  tx,
+		client:              &http.Client{Timeout: webhookDeliveryTimeout},
+		deliveryChan:        make(chan uint, 100),
+	}
+}
+
+func (ww *WebhookWorker) Start(ctx context.Context) {
+	events := ww.eventBus.SubscribeWithDropHandler(ctx, versource.SubscribeEventsRequest{}, func(event versource.Event) {
+		ww.recordDroppedEvent(ctx, event)
+	})
+	go ww.processEvents(ctx, events)
+	go ww.processDeliveries(ctx)
+}
+
+func (ww *WebhookWorker) QueueDelivery(deliveryID uint) {
+	select {
+	case ww.deliveryChan <- deliveryID:
+		log.WithField("delivery_id", deliveryID).Debug("Queued webhook delivery for processing")
+	default:
+		log.WithField("delivery_id", deliveryID).Warn("Webhook delivery channel full, delivery will be picked up by polling")
+	}
+}
+
+func (ww *WebhookWorker) processEvents(ctx context.Context, events <-chan versource.Event) {
+	for event := range events {
+		err := ww.dispatchEvent(ctx, event)
+		if err != nil {
+			log.WithError(err).
+				WithField("event_id", event.ID).
+				Error("Failed to dispatch event to webhooks")
+		}
+	}
+}
+
+func (ww *WebhookWorker) recordDroppedEvent(ctx context.Context, event versource.Event) {
+	deliveries, err := ww.createDeliveries(ctx, event, versource.WebhookDeliveryStateFailed, webhookDroppedEventError)
+	if err != nil {
+		log.WithError(err).
+			WithField("event_id", event.ID).
+			Error("Failed to record dropped event as failed webhook deliveries")
+		return
+	}
+	if len(deliveries) > 0 {
+		log.WithField("event_id", event.ID).
+			WithField("deliveries", len(deliveries)).
+			Warn("Recorded dropped event as failed webhook deliveries")
+	}
+}
+
+func (ww *WebhookWorker) dispatchEvent(ctx context.Context, event versource.Event) error {
+	deliveries, err := ww.createDeliveries(ctx, event, versource.WebhookDeliveryStatePending, "")
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		ww.QueueDelivery(delivery.ID)
+	}
+	return nil
+}
+
+func (ww *WebhookWorker) createDeliveries(ctx context.Context, event versource.Event, state versource.WebhookDeliveryState, deliveryError string) ([]*versource.WebhookDelivery, error) {
+	var payload versource.WebhookPayload
+	var webhooks []versource.Webhook
+	err := ww.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		webhooks, err = ww.webhookRepo.ListWebhooks(ctx)
+		if err != nil || len(webhooks) == 0 {
+			return err
+		}
+		payload, err = ww.loadPayload(ctx, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*versource.WebhookDelivery
+	for _, trigger := range webhookTriggers(payload) {
+		payload.Trigger = trigger
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+		}
+		for _, webhook := range webhooks {
+			if !slices.Contains(webhook.Triggers, trigger) {
+				continue
+			}
+			delivery := &versource.WebhookDelivery{
+				WebhookID: webhook.ID,
+				Trigger:   trigger,
+				Payload:   string(body),
+				State:     state,
+				Error:     deliveryError,
+			}
+			if state == versource.WebhookDeliveryStatePending {
+				now := time.Now()
+				delivery.NextAttemptAt = &now
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	err = ww.tx.Do(ctx, AdminBranch, fmt.Sprintf("queue webhook deliveries for %s %d", event.Type, event.EntityID), func(ctx context.Context) error {
+		for _, delivery := range deliveries {
+			err := ww.webhookDeliveryRepo.CreateWebhookDelivery(ctx, delivery)
+			if err != nil {
+				return fmt.Errorf("failed to create webhook delivery: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (ww *WebhookWorker) loadPayload(ctx context.Context, event versource.Event) (versource.WebhookPayload, error) {
+	payload := versource.WebhookPayload{Event: event}
+	var err error
+	switch event.Type {
+	case versource.EventTypePlanStateChanged:
+		payload.Plan, err = ww.planRepo.GetPlan(ctx, event.EntityID)
+	case versource.EventTypeApplyStateChanged:
+		payload.Apply, err = ww.applyRepo.GetApply(ctx, event.EntityID)
+	case versource.EventTypeMergeStateChanged:
+		payload.Merge, err = ww.mergeRepo.GetMerge(ctx, event.EntityID)
+	case versource.EventTypeRebaseStateChanged:
+		payload.Rebase, err = ww.rebaseRepo.GetRebase(ctx, event.EntityID)
+	}
+	return payload, err
+}
+
+func webhookTriggers(payload versource.WebhookPayload) []versource.WebhookTrigger {
+	state := versource.TaskState(payload.Event.State)
+	switch payload.Event.Type {
+	case versource.EventTypePlanStateChanged:
+		switch state {
+		case versource.TaskStateSucceeded:
+			triggers := []versource.WebhookTrigger{versource.WebhookTriggerPlanSucceeded}
+			if payload.Plan != nil && payload.Plan.Destroy != nil && *payload.Plan.Destroy > 0 {
+				triggers = append(triggers, versource.WebhookTriggerPlanDestroys)
+			}
+			return triggers
+		case versource.TaskStateFailed:
+			return []versource.WebhookTrigger{versource.WebhookTriggerPlanFailed}
+		}
+	case versource.EventTypeApplyStateChanged:
+		switch state {
+		case versource.TaskStateSucceeded:
+			return []versource.WebhookTrigger{versource.WebhookTriggerApplySucceeded}
+		case versource.TaskStateFailed:
+			return []versource.WebhookTrigger{versource.WebhookTriggerApplyFailed}
+		}
+	case versource.EventTypeMergeStateChanged:
+		switch state {
+		case versource.TaskStateSucceeded:
+			return []versource.WebhookTrigger{versource.WebhookTriggerMergeSucceeded}
+		case versource.TaskStateFailed:
+			triggers := []versource.WebhookTrigger{versource.WebhookTriggerMergeFailed}
+			if payload.Merge != nil && len(payload.Merge.Findings) > 0 {
+				triggers = append(triggers, versource.WebhookTriggerMergeRejected)
+			}
+			return triggers
+		}
+	case versource.EventTypeRebaseStateChanged:
+		if state == versource.TaskStateFailed {
+			return []versource.WebhookTrigger{versource.WebhookTriggerRebaseFailed}
+		}
+	case versource.EventTypeChangesetCreated:
+		return []versource.WebhookTrigger{versource.WebhookTriggerChangesetCreated}
+	case versource.EventTypeChangesetMerged:
+		return []versource.WebhookTrigger{versource.WebhookTriggerChangesetMerged}
+	}
+	return nil
+}
+
+func (ww *WebhookWorker) processDeliveries(ctx context.Context) {
+	ticker := time.NewTicker(webhookInitialBackoff)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case deliveryID := <-ww.deliveryChan:
+			ww.deliver(ctx, deliveryID)
+		case <-ticker.C:
+			ww.processDueDeliveries(ctx)
+		}
+	}
+}
+
+func (ww *WebhookWorker) processDueDeliveries(ctx context.Context) {
+	var deliveryIDs []uint
+	err := ww.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		deliveryIDs, err = ww.webhookDeliveryRepo.GetDueWebhookDeliveries(ctx, time.Now())
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to get due webhook deliveries")
+		return
+	}
+
+	for _, deliveryID := range deliveryIDs {
+		ww.deliver(ctx, deliveryID)
+	}
+}
+
+func (ww *WebhookWorker) deliver(ctx context.Context, deliveryID uint) {
+	var delivery *versource.WebhookDelivery
+	err := ww.tx.Checkout(ctx, AdminBranch, func(ctx context.Context) error {
+		var err error
+		delivery, err = ww.webhookDeliveryRepo.GetWebhookDelivery(ctx, deliveryID)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).WithField("delivery_id", deliveryID).Error("Failed to get webhook delivery")
+		return
+	}
+	now := time.Now()
+	if delivery == nil || delivery.State != versource.WebhookDeliveryStatePending ||
+		(delivery.NextAttemptAt != nil && delivery.NextAttemptAt.After(now)) {
+		return
+	}
+
+	statusCode, err := ww.send(ctx, delivery)
+	delivery.Attempts++
+	delivery.StatusCode = statusCode
+	delivery.Error = ""
+	delivery.NextAttemptAt = nil
+	switch {
+	case err == nil:
+		delivery.State = versource.WebhookDeliveryStateSucceeded
+	case delivery.Attempts >= webhookMaxAttempts:
+		delivery.State = versource.WebhookDeliveryStateFailed
+		delivery.Error = err.Error()
+	default:
+		delivery.Error = err.Error()
+		nextAttemptAt := now.Add(webhookBackoff(delivery.Attempts))
+		delivery.NextAttemptAt = &nextAttemptAt
+	}
+
+	err = ww.tx.Do(ctx, AdminBranch, fmt.Sprintf("record webhook delivery %d attempt %d", delivery.ID, delivery.Attempts), func(ctx context.Context) error {
+		return ww.webhookDeliveryRepo.UpdateWebhookDelivery(ctx, delivery)
+	})
+	if err != nil {
+		log.WithError(err).WithField("delivery_id", deliveryID).Error("Failed to record webhook delivery attempt")
+	}
+}
+
+func (ww *WebhookWorker) send(ctx context.Context, delivery *versource.WebhookDelivery) (*int, error) {
+	secret, err := ww.secretCipher.Decrypt(delivery.Webhook.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, "POST", delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookTriggerHeader, string(delivery.Trigger))
+	req.Header.Set(webhookDeliveryHeader, fmt.Sprintf("%d", delivery.ID))
+	req.Header.Set(webhookSignatureHeader, signWebhookPayload(secret, body))
+
+	resp, err := ww.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	statusCode := resp.StatusCode
+	if statusCode < 200 || statusCode >= 300 {
+		return &statusCode, fmt.Errorf("unexpected status code %d", statusCode)
+	}
+	return &statusCode, nil
+}
+
+func signWebhookPayload(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	return webhookInitialBackoff * time.Duration(1<<(attempts-1))
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func TestWebhookTriggers(t *testing.T) {
+	zero := 0
+	two := 2
+
+	tests := []struct {
+		name     string
+		payload  versource.WebhookPayload
+		expected []versource.WebhookTrigger
+	}{
+		{
+			name: "plan succeeded without destroys",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypePlanStateChanged, State: string(versource.TaskStateSucceeded)},
+				Plan:  &versource.Plan{Destroy: &zero},
+			},
+			expected: []versource.WebhookTrigger{versource.WebhookTriggerPlanSucceeded},
+		},
+		{
+			name: "plan succeeded with destroys",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypePlanStateChanged, State: string(versource.TaskStateSucceeded)},
+				Plan:  &versource.Plan{Destroy: &two},
+			},
+			expected: []versource.WebhookTrigger{versource.WebhookTriggerPlanSucceeded, versource.WebhookTriggerPlanDestroys},
+		},
+		{
+			name: "plan started",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypePlanStateChanged, State: string(versource.TaskStateStarted)},
+			},
+			expected: nil,
+		},
+		{
+			name: "apply failed",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypeApplyStateChanged, State: string(versource.TaskStateFailed)},
+			},
+			expected: []versource.WebhookTrigger{versource.WebhookTriggerApplyFailed},
+		},
+		{
+			name: "merge failed without findings",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypeMergeStateChanged, State: string(versource.TaskStateFailed)},
+				Merge: &versource.Merge{},
+			},
+			expected: []versource.WebhookTrigger{versource.WebhookTriggerMergeFailed},
+		},
+		{
+			name: "merge rejected with findings",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypeMergeStateChanged, State: string(versource.TaskStateFailed)},
+				Merge: &versource.Merge{Findings: []versource.MergeFinding{{Type: versource.MergeFindingMissingPlan}}},
+			},
+			expected: []versource.WebhookTrigger{versource.WebhookTriggerMergeFailed, versource.WebhookTriggerMergeRejected},
+		},
+		{
+			name: "rebase succeeded",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypeRebaseStateChanged, State: string(versource.TaskStateSucceeded)},
+			},
+			expected: nil,
+		},
+		{
+			name: "changeset created",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypeChangesetCreated, State: string(versource.ChangesetStateOpen)},
+			},
+			expected: []versource.WebhookTrigger{versource.WebhookTriggerChangesetCreated},
+		},
+		{
+			name: "component changed",
+			payload: versource.WebhookPayload{
+				Event: versource.Event{Type: versource.EventTypeComponentChanged},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			triggers := webhookTriggers(tt.payload)
+			if !reflect.DeepEqual(triggers, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, triggers)
+			}
+		})
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	signature := signWebhookPayload([]byte("secret"), []byte(`{"trigger":"plan.failed"}`))
+	expected := "sha256=69fe28ee45b8981a04b115a41626998d53fba9e42cc14dcaf76945343e057adf"
+	if signature != expected {
+		t.Errorf("expected %s, got %s", expected, signature)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	expected := []time.Duration{
+		10 * time.Second,
+		20 * time.Second,
+		40 * time.Second,
+		80 * time.Second,
+	}
+	for i, backoff := range expected {
+		actual := webhookBackoff(i + 1)
+		if actual != backoff {
+			t.Errorf("attempt %d: expected %s, got %s", i+1, backoff, actual)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package versource
+
+import (
+	"slices"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleMerger Role = "merger"
+	RoleAdmin  Role = "admin"
+)
+
+var roles = []Role{RoleViewer, RoleEditor, RoleMerger, RoleAdmin}
+
+func IsValidRole(role Role) bool {
+	return slices.Contains(roles, role)
+}
+
+func (r Role) Includes(required Role) bool {
+	return slices.Index(roles, r) >= slices.Index(roles, required)
+}
+
+type ApiToken struct {
+	ID        uint       `gorm:"primarykey" json:"id" yaml:"id"`
+	Name      string     `gorm:"uniqueIndex;not null" json:"name" yaml:"name"`
+	User      string     `gorm:"column:username;not null" json:"user" yaml:"user"`
+	Role      Role       `gorm:"not null" json:"role" yaml:"role"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-" yaml:"-"`
+	CreatedAt time.Time  `json:"createdAt" yaml:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty" yaml:"revokedAt,omitempty"`
+}
+
+type ListApiTokensRequest struct{}
+
+type ListApiTokensResponse struct {
+	ApiTokens []ApiToken `json:"apiTokens" yaml:"apiTokens"`
+}
+
+type CreateApiTokenRequest struct {
+	Name string `json:"name" yaml:"name"`
+	User string `json:"user" yaml:"user"`
+	Role Role   `json:"role" yaml:"role"`
+}
+
+type CreateApiTokenResponse struct {
+	ApiToken ApiToken `json:"apiToken" yaml:"apiToken"`
+	Token    string   `json:"token" yaml:"token"`
+}
+
+type BootstrapApiTokenRequest struct {
+	Name string `json:"name" yaml:"name"`
+	User string `json:"user" yaml:"user"`
+}
+
+type RevokeApiTokenRequest struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type RevokeApiTokenResponse struct {
+	ApiToken ApiToken `json:"apiToken" yaml:"apiToken"`
+}
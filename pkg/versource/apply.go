@@ -2,6 +2,7 @@ package versource
 
 import (
 	"io"
+	"time"
 )
 
 type TaskState string
@@ -26,6 +27,8 @@ type Apply struct {
 	Changeset   Changeset `gorm:"foreignKey:ChangesetID" json:"changeset" yaml:"changeset"`
 	ChangesetID uint      `json:"changesetId" yaml:"changesetId"`
 	State       TaskState `gorm:"default:Queued" json:"state" yaml:"state"`
+	RequestedBy string    `gorm:"column:requested_by;not null;default:''" json:"requestedBy,omitempty" yaml:"requestedBy,omitempty"`
+	CreatedAt   time.Time `json:"createdAt" yaml:"createdAt"`
 }
 
 type GetApplyRequest struct {
@@ -39,14 +42,22 @@ type GetApplyResponse struct {
 
 type GetApplyLogRequest struct {
 	ApplyID uint `json:"applyId" yaml:"applyId"`
+	Follow  bool `json:"follow" yaml:"follow"`
 }
 
 type GetApplyLogResponse struct {
 	Content io.ReadCloser `json:"content" yaml:"content"`
 }
 
-type ListAppliesRequest struct{}
+type ListAppliesRequest struct {
+	PageRequest   `yaml:",inline"`
+	ChangesetName *string    `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+	State         *TaskState `json:"state,omitempty" yaml:"state,omitempty"`
+	CreatedAfter  *time.Time `json:"createdAfter,omitempty" yaml:"createdAfter,omitempty"`
+	CreatedBefore *time.Time `json:"createdBefore,omitempty" yaml:"createdBefore,omitempty"`
+}
 
 type ListAppliesResponse struct {
-	Applies []Apply `json:"applies" yaml:"applies"`
+	Applies    []Apply `json:"applies" yaml:"applies"`
+	NextCursor string  `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
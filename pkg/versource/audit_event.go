@@ -0,0 +1,23 @@
+package versource
+
+import "time"
+
+type AuditEvent struct {
+	Commit  string    `json:"commit" yaml:"commit"`
+	Branch  string    `json:"branch" yaml:"branch"`
+	Author  string    `json:"author" yaml:"author"`
+	Email   string    `json:"email" yaml:"email"`
+	Date    time.Time `json:"date" yaml:"date"`
+	Message string    `json:"message" yaml:"message"`
+}
+
+type ListAuditEventsRequest struct {
+	PageRequest `yaml:",inline"`
+	Branch      *string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Author      *string `json:"author,omitempty" yaml:"author,omitempty"`
+}
+
+type ListAuditEventsResponse struct {
+	AuditEvents []AuditEvent `json:"auditEvents" yaml:"auditEvents"`
+	NextCursor  string       `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
+}
@@ -1,10 +1,17 @@
 package versource
 
+import "time"
+
 type Changeset struct {
-	ID          uint                 `gorm:"primarykey" json:"id" yaml:"id"`
-	Name        string               `gorm:"index" json:"name" yaml:"name"`
-	State       ChangesetState       `gorm:"default:Open" json:"state" yaml:"state"`
-	ReviewState ChangesetReviewState `gorm:"default:Draft" json:"reviewState" yaml:"reviewState"`
+	ID                uint                 `gorm:"primarykey" json:"id" yaml:"id"`
+	Name              string               `gorm:"index" json:"name" yaml:"name"`
+	State             ChangesetState       `gorm:"default:Open" json:"state" yaml:"state"`
+	ReviewState       ChangesetReviewState `gorm:"default:Draft" json:"reviewState" yaml:"reviewState"`
+	Stale             bool                 `json:"stale" yaml:"stale"`
+	StaleComponentIDs []uint               `gorm:"column:stale_component_ids;serializer:json" json:"staleComponentIds" yaml:"staleComponentIds"`
+	AutoRebase        bool                 `json:"autoRebase" yaml:"autoRebase"`
+	ParentID          *uint                `json:"parentId,omitempty" yaml:"parentId,omitempty"`
+	CreatedAt         time.Time            `json:"createdAt" yaml:"createdAt"`
 }
 
 type ChangesetState string
@@ -24,14 +31,22 @@ const (
 	ChangesetReviewStateRejected ChangesetReviewState = "Rejected"
 )
 
-type ListChangesetsRequest struct{}
+type ListChangesetsRequest struct {
+	PageRequest   `yaml:",inline"`
+	IncludeClosed bool            `json:"includeClosed" yaml:"includeClosed"`
+	State         *ChangesetState `json:"state,omitempty" yaml:"state,omitempty"`
+	CreatedAfter  *time.Time      `json:"createdAfter,omitempty" yaml:"createdAfter,omitempty"`
+	CreatedBefore *time.Time      `json:"createdBefore,omitempty" yaml:"createdBefore,omitempty"`
+}
 
 type ListChangesetsResponse struct {
 	Changesets []Changeset `json:"changesets" yaml:"changesets"`
+	NextCursor string      `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
 
 type CreateChangesetRequest struct {
-	Name string `json:"name" yaml:"name"`
+	Name   string `json:"name" yaml:"name"`
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
 }
 
 type CreateChangesetResponse struct {
@@ -39,7 +54,8 @@ type CreateChangesetResponse struct {
 }
 
 type EnsureChangesetRequest struct {
-	Name string `json:"name" yaml:"name"`
+	Name   string `json:"name" yaml:"name"`
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
 }
 
 type EnsureChangesetResponse struct {
@@ -53,3 +69,64 @@ type DeleteChangesetRequest struct {
 type DeleteChangesetResponse struct {
 	ID uint `json:"id" yaml:"id"`
 }
+
+type CloseChangesetRequest struct {
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+}
+
+type CloseChangesetResponse struct {
+	Changeset Changeset `json:"changeset" yaml:"changeset"`
+}
+
+type ReopenChangesetRequest struct {
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+}
+
+type ReopenChangesetResponse struct {
+	Changeset      Changeset `json:"changeset" yaml:"changeset"`
+	RebaseRequired bool      `json:"rebaseRequired" yaml:"rebaseRequired"`
+}
+
+type UpdateChangesetRequest struct {
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+	AutoRebase    *bool  `json:"autoRebase" yaml:"autoRebase"`
+}
+
+type UpdateChangesetResponse struct {
+	Changeset Changeset `json:"changeset" yaml:"changeset"`
+}
+
+type RevertChangesetRequest struct {
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+	Name          string `json:"name,omitempty" yaml:"name,omitempty"`
+	Selector      string `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
+type RevertChangesetResponse struct {
+	Changeset Changeset `json:"changeset" yaml:"changeset"`
+	Plans     []Plan    `json:"plans" yaml:"plans"`
+}
+
+type ChangesetApproval struct {
+	ID          uint   `gorm:"primarykey" json:"id" yaml:"id"`
+	ChangesetID uint   `json:"changesetId" yaml:"changesetId"`
+	User        string `gorm:"column:username" json:"user" yaml:"user"`
+	Head        string `json:"head" yaml:"head"`
+}
+
+type ApproveChangesetRequest struct {
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+}
+
+type ApproveChangesetResponse struct {
+	Approval         ChangesetApproval `json:"approval" yaml:"approval"`
+	MissingApprovals []string          `json:"missingApprovals" yaml:"missingApprovals"`
+}
+
+type ListChangesetApprovalsRequest struct {
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+}
+
+type ListChangesetApprovalsResponse struct {
+	Approvals []ChangesetApproval `json:"approvals" yaml:"approvals"`
+}
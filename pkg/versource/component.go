@@ -5,12 +5,25 @@ import (
 )
 
 type Component struct {
-	ID              uint            `gorm:"primarykey" json:"id" yaml:"id"`
-	Name            string          `gorm:"not null;default:'';uniqueIndex" json:"name" yaml:"name"`
-	ModuleVersion   ModuleVersion   `gorm:"foreignKey:ModuleVersionID" json:"moduleVersion" yaml:"moduleVersion"`
-	ModuleVersionID uint            `json:"moduleVersionId" yaml:"moduleVersionId"`
-	Variables       datatypes.JSON  `gorm:"type:jsonb" json:"variables" yaml:"variables"`
-	Status          ComponentStatus `gorm:"default:Ready" json:"status" yaml:"status"`
+	ID              uint                    `gorm:"primarykey" json:"id" yaml:"id"`
+	Name            string                  `gorm:"not null;default:'';uniqueIndex" json:"name" yaml:"name"`
+	ModuleVersion   ModuleVersion           `gorm:"foreignKey:ModuleVersionID" json:"moduleVersion" yaml:"moduleVersion"`
+	ModuleVersionID uint                    `json:"moduleVersionId" yaml:"moduleVersionId"`
+	Variables       datatypes.JSON          `gorm:"type:jsonb" json:"variables" yaml:"variables"`
+	Status          ComponentStatus         `gorm:"default:Ready" json:"status" yaml:"status"`
+	Moves           []ResourceMove          `gorm:"column:moves;serializer:json" json:"moves,omitempty" yaml:"moves,omitempty"`
+	Labels          map[string]string       `gorm:"column:labels;serializer:json" json:"labels,omitempty" yaml:"labels,omitempty"`
+	Owner           string                  `gorm:"column:owner;not null;default:''" json:"owner,omitempty" yaml:"owner,omitempty"`
+	VariableSets    []VariableSetAttachment `gorm:"column:variable_sets;serializer:json" json:"variableSets,omitempty" yaml:"variableSets,omitempty"`
+	Template        string                  `gorm:"column:template;not null;default:''" json:"template,omitempty" yaml:"template,omitempty"`
+	Environment     string                  `gorm:"column:environment;not null;default:''" json:"environment,omitempty" yaml:"environment,omitempty"`
+	Overrides       datatypes.JSON          `gorm:"column:overrides" json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	Secrets         SecretVariables         `gorm:"column:secrets" json:"secrets,omitempty" yaml:"secrets,omitempty"`
+}
+
+type ResourceMove struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
 }
 
 type ComponentStatus string
@@ -40,20 +53,29 @@ const (
 type GetComponentRequest struct {
 	ComponentID   uint    `json:"componentId" yaml:"componentId"`
 	ChangesetName *string `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+	AsOf          *string `json:"asOf,omitempty" yaml:"asOf,omitempty"`
 }
 
 type GetComponentResponse struct {
 	Component Component `json:"component" yaml:"component"`
+	Revision  string    `json:"revision,omitempty" yaml:"revision,omitempty"`
 }
 
 type ListComponentsRequest struct {
+	PageRequest     `yaml:",inline"`
 	ModuleID        *uint   `json:"moduleId,omitempty" yaml:"moduleId,omitempty"`
 	ModuleVersionID *uint   `json:"moduleVersionId,omitempty" yaml:"moduleVersionId,omitempty"`
 	ChangesetName   *string `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+	AsOf            *string `json:"asOf,omitempty" yaml:"asOf,omitempty"`
+	Selector        string  `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Owner           *string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Template        *string `json:"template,omitempty" yaml:"template,omitempty"`
+	Environment     *string `json:"environment,omitempty" yaml:"environment,omitempty"`
 }
 
 type ListComponentsResponse struct {
 	Components []Component `json:"components" yaml:"components"`
+	NextCursor string      `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
 
 type GetComponentChangeRequest struct {
@@ -66,18 +88,28 @@ type GetComponentChangeResponse struct {
 }
 
 type ListComponentChangesRequest struct {
+	PageRequest   `yaml:",inline"`
 	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+	Selector      string `json:"selector,omitempty" yaml:"selector,omitempty"`
 }
 
 type ListComponentChangesResponse struct {
-	Changes []ComponentChange `json:"changes" yaml:"changes"`
+	Changes    []ComponentChange `json:"changes" yaml:"changes"`
+	NextCursor string            `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
 
 type CreateComponentRequest struct {
-	ChangesetName string         `json:"changesetName" yaml:"changesetName"`
-	ModuleID      uint           `json:"moduleId" yaml:"moduleId"`
-	Name          string         `json:"name" yaml:"name"`
-	Variables     map[string]any `json:"variables" yaml:"variables"`
+	ChangesetName string            `json:"changesetName" yaml:"changesetName"`
+	ModuleID      uint              `json:"moduleId" yaml:"moduleId"`
+	Name          string            `json:"name" yaml:"name"`
+	Variables     map[string]any    `json:"variables" yaml:"variables"`
+	Labels        map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Owner         string            `json:"owner,omitempty" yaml:"owner,omitempty"`
+	VariableSets  []string          `json:"variableSets,omitempty" yaml:"variableSets,omitempty"`
+	Template      string            `json:"template,omitempty" yaml:"template,omitempty"`
+	Environment   string            `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Overrides     map[string]any    `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	Secrets       map[string]any    `json:"secrets,omitempty" yaml:"secrets,omitempty"`
 }
 
 type CreateComponentResponse struct {
@@ -86,15 +118,25 @@ type CreateComponentResponse struct {
 }
 
 type UpdateComponentRequest struct {
-	ComponentID   uint            `json:"componentId" yaml:"componentId"`
-	ChangesetName string          `json:"changesetName" yaml:"changesetName"`
-	ModuleID      *uint           `json:"moduleId,omitempty" yaml:"moduleId,omitempty"`
-	Variables     *map[string]any `json:"variables,omitempty" yaml:"variables,omitempty"`
+	ComponentID      uint               `json:"componentId" yaml:"componentId"`
+	ChangesetName    string             `json:"changesetName" yaml:"changesetName"`
+	Name             *string            `json:"name,omitempty" yaml:"name,omitempty"`
+	ModuleID         *uint              `json:"moduleId,omitempty" yaml:"moduleId,omitempty"`
+	Variables        *map[string]any    `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Labels           *map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Owner            *string            `json:"owner,omitempty" yaml:"owner,omitempty"`
+	VariableSets     *[]string          `json:"variableSets,omitempty" yaml:"variableSets,omitempty"`
+	Overrides        *map[string]any    `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	Secrets          map[string]any     `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	RemoveSecrets    []string           `json:"removeSecrets,omitempty" yaml:"removeSecrets,omitempty"`
+	Moves            []ResourceMove     `json:"moves,omitempty" yaml:"moves,omitempty"`
+	ExpectedRevision *string            `json:"expectedRevision,omitempty" yaml:"expectedRevision,omitempty"`
 }
 
 type UpdateComponentResponse struct {
 	Component Component `json:"component" yaml:"component"`
 	Plan      Plan      `json:"plan" yaml:"plan"`
+	Revision  string    `json:"revision" yaml:"revision"`
 }
 
 type DeleteComponentRequest struct {
@@ -116,3 +158,98 @@ type RestoreComponentResponse struct {
 	Component Component `json:"component" yaml:"component"`
 	Plan      Plan      `json:"plan" yaml:"plan"`
 }
+
+type ComponentConflict struct {
+	ComponentID uint       `json:"componentId" yaml:"componentId"`
+	Base        *Component `json:"base,omitempty" yaml:"base,omitempty"`
+	Changeset   *Component `json:"changeset,omitempty" yaml:"changeset,omitempty"`
+	Main        *Component `json:"main,omitempty" yaml:"main,omitempty"`
+}
+
+type ConflictResolution string
+
+const (
+	ConflictResolutionOurs   ConflictResolution = "Ours"
+	ConflictResolutionTheirs ConflictResolution = "Theirs"
+	ConflictResolutionManual ConflictResolution = "Manual"
+)
+
+type ListComponentConflictsRequest struct {
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+}
+
+type ListComponentConflictsResponse struct {
+	Conflicts []ComponentConflict `json:"conflicts" yaml:"conflicts"`
+}
+
+type ResolveComponentConflictRequest struct {
+	ComponentID   uint                      `json:"componentId" yaml:"componentId"`
+	ChangesetName string                    `json:"changesetName" yaml:"changesetName"`
+	Resolution    ConflictResolution        `json:"resolution" yaml:"resolution"`
+	Variables     *map[string]any           `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Others        []ComponentConflictChoice `json:"others,omitempty" yaml:"others,omitempty"`
+}
+
+type ComponentConflictChoice struct {
+	ComponentID uint               `json:"componentId" yaml:"componentId"`
+	Resolution  ConflictResolution `json:"resolution" yaml:"resolution"`
+	Variables   *map[string]any    `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+type ResolveComponentConflictResponse struct {
+	Component  Component `json:"component" yaml:"component"`
+	Plan       Plan      `json:"plan" yaml:"plan"`
+	OtherPlans []Plan    `json:"otherPlans,omitempty" yaml:"otherPlans,omitempty"`
+}
+
+type ComponentRevision struct {
+	Commit        string     `json:"commit" yaml:"commit"`
+	CommitDate    string     `json:"commitDate" yaml:"commitDate"`
+	Message       string     `json:"message" yaml:"message"`
+	ChangesetName string     `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+	Component     *Component `json:"component,omitempty" yaml:"component,omitempty"`
+	Plan          *Plan      `json:"plan,omitempty" yaml:"plan,omitempty"`
+	Apply         *Apply     `json:"apply,omitempty" yaml:"apply,omitempty"`
+}
+
+type ListComponentHistoryRequest struct {
+	PageRequest `yaml:",inline"`
+	ComponentID uint `json:"componentId" yaml:"componentId"`
+}
+
+type ListComponentHistoryResponse struct {
+	Revisions  []ComponentRevision `json:"revisions" yaml:"revisions"`
+	NextCursor string              `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
+}
+
+type RevertComponentToRevisionRequest struct {
+	ComponentID   uint   `json:"componentId" yaml:"componentId"`
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+	Commit        string `json:"commit" yaml:"commit"`
+}
+
+type RevertComponentToRevisionResponse struct {
+	Component Component `json:"component" yaml:"component"`
+	Plan      Plan      `json:"plan" yaml:"plan"`
+}
+
+type ComponentManifest struct {
+	Module    string            `json:"module" yaml:"module"`
+	Version   string            `json:"version,omitempty" yaml:"version,omitempty"`
+	Name      string            `json:"name" yaml:"name"`
+	Variables map[string]any    `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Owner     string            `json:"owner,omitempty" yaml:"owner,omitempty"`
+}
+
+type SyncComponentsRequest struct {
+	ChangesetName string              `json:"changesetName" yaml:"changesetName"`
+	Manifests     []ComponentManifest `json:"manifests" yaml:"manifests"`
+	DryRun        bool                `json:"dryRun" yaml:"dryRun"`
+	Selector      string              `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
+type SyncComponentsResponse struct {
+	Changes []ComponentChange `json:"changes" yaml:"changes"`
+	Plans   []Plan            `json:"plans" yaml:"plans"`
+}
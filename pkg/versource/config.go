@@ -4,12 +4,16 @@ type Config struct {
 	Database  *DatabaseConfig
 	Terraform *TerraformConfig
 	HTTP      *HttpConfig
+	Secrets   *SecretsConfig
 }
 
 type HttpConfig struct {
-	Scheme   string
-	Hostname string
-	Port     string
+	Scheme          string
+	Hostname        string
+	Port            string
+	User            string
+	Token           string
+	TrustUserHeader bool
 }
 
 type DatabaseConfig struct {
@@ -24,3 +28,7 @@ type DatabaseConfig struct {
 type TerraformConfig struct {
 	WorkDir string
 }
+
+type SecretsConfig struct {
+	Key string
+}
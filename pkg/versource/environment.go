@@ -0,0 +1,47 @@
+package versource
+
+type Environment struct {
+	ID       uint   `gorm:"primarykey" json:"id" yaml:"id"`
+	Name     string `gorm:"uniqueIndex;not null" json:"name" yaml:"name"`
+	Position int    `gorm:"not null" json:"position" yaml:"position"`
+}
+
+type ListEnvironmentsRequest struct{}
+
+type ListEnvironmentsResponse struct {
+	Environments []Environment `json:"environments" yaml:"environments"`
+}
+
+type CreateEnvironmentRequest struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type CreateEnvironmentResponse struct {
+	Environment Environment `json:"environment" yaml:"environment"`
+}
+
+type PromotionStage struct {
+	Environment string     `json:"environment" yaml:"environment"`
+	Component   *Component `json:"component,omitempty" yaml:"component,omitempty"`
+	Promoted    bool       `json:"promoted" yaml:"promoted"`
+}
+
+type GetPromotionPathRequest struct {
+	Template string `json:"template" yaml:"template"`
+}
+
+type GetPromotionPathResponse struct {
+	Stages []PromotionStage `json:"stages" yaml:"stages"`
+}
+
+type PromoteComponentRequest struct {
+	Template        string `json:"template" yaml:"template"`
+	FromEnvironment string `json:"fromEnvironment" yaml:"fromEnvironment"`
+	ChangesetName   string `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+}
+
+type PromoteComponentResponse struct {
+	Changeset Changeset `json:"changeset" yaml:"changeset"`
+	Component Component `json:"component" yaml:"component"`
+	Plan      Plan      `json:"plan" yaml:"plan"`
+}
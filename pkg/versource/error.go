@@ -1,10 +1,43 @@
 package versource
 
 import (
+	"errors"
 	"fmt"
 )
 
+type ErrorCode string
+
+const (
+	ErrorCodeNotFound           ErrorCode = "NOT_FOUND"
+	ErrorCodeConflict           ErrorCode = "CONFLICT"
+	ErrorCodeValidation         ErrorCode = "VALIDATION"
+	ErrorCodeForbidden          ErrorCode = "FORBIDDEN"
+	ErrorCodePreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+	ErrorCodeInternal           ErrorCode = "INTERNAL"
+)
+
+func NewError(code ErrorCode, message string) error {
+	switch code {
+	case ErrorCodeNotFound, ErrorCodeConflict, ErrorCodeValidation, ErrorCodeForbidden, ErrorCodePreconditionFailed:
+		return &UserError{Code: code, Message: message}
+	default:
+		return &InternalError{Message: message}
+	}
+}
+
+func ErrorCodeOf(err error) ErrorCode {
+	code := ErrorCodeInternal
+	for err != nil {
+		if codedErr, ok := err.(interface{ ErrorCode() ErrorCode }); ok {
+			code = codedErr.ErrorCode()
+		}
+		err = errors.Unwrap(err)
+	}
+	return code
+}
+
 type UserError struct {
+	Code    ErrorCode
 	Message string
 	Cause   error
 }
@@ -20,21 +53,79 @@ func (e *UserError) Unwrap() error {
 	return e.Cause
 }
 
+func (e *UserError) ErrorCode() ErrorCode {
+	if e.Code == "" {
+		return ErrorCodeValidation
+	}
+	return e.Code
+}
+
 func UserErr(message string) error {
-	return &UserError{Message: message}
+	return &UserError{Code: ErrorCodeValidation, Message: message}
 }
 
 func UserErrf(format string, args ...any) error {
-	return &UserError{Message: fmt.Sprintf(format, args...)}
+	return &UserError{Code: ErrorCodeValidation, Message: fmt.Sprintf(format, args...)}
 }
 
 func UserErrE(message string, cause error) error {
-	return &UserError{Message: message, Cause: cause}
+	return &UserError{Code: ErrorCodeValidation, Message: message, Cause: cause}
 }
 
 func IsUserError(err error) bool {
-	_, ok := err.(*UserError)
-	return ok
+	return ErrorCodeOf(err) != ErrorCodeInternal
+}
+
+func NotFoundErr(message string) error {
+	return &UserError{Code: ErrorCodeNotFound, Message: message}
+}
+
+func NotFoundErrf(format string, args ...any) error {
+	return &UserError{Code: ErrorCodeNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+func NotFoundErrE(message string, cause error) error {
+	return &UserError{Code: ErrorCodeNotFound, Message: message, Cause: cause}
+}
+
+func IsNotFoundError(err error) bool {
+	return ErrorCodeOf(err) == ErrorCodeNotFound
+}
+
+func ConflictErr(message string) error {
+	return &UserError{Code: ErrorCodeConflict, Message: message}
+}
+
+func ConflictErrf(format string, args ...any) error {
+	return &UserError{Code: ErrorCodeConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+func IsConflictError(err error) bool {
+	return ErrorCodeOf(err) == ErrorCodeConflict
+}
+
+func ForbiddenErr(message string) error {
+	return &UserError{Code: ErrorCodeForbidden, Message: message}
+}
+
+func ForbiddenErrf(format string, args ...any) error {
+	return &UserError{Code: ErrorCodeForbidden, Message: fmt.Sprintf(format, args...)}
+}
+
+func IsForbiddenError(err error) bool {
+	return ErrorCodeOf(err) == ErrorCodeForbidden
+}
+
+func PreconditionFailedErr(message string) error {
+	return &UserError{Code: ErrorCodePreconditionFailed, Message: message}
+}
+
+func PreconditionFailedErrf(format string, args ...any) error {
+	return &UserError{Code: ErrorCodePreconditionFailed, Message: fmt.Sprintf(format, args...)}
+}
+
+func IsPreconditionFailedError(err error) bool {
+	return ErrorCodeOf(err) == ErrorCodePreconditionFailed
 }
 
 type InternalError struct {
@@ -53,6 +144,10 @@ func (e *InternalError) Unwrap() error {
 	return e.Cause
 }
 
+func (e *InternalError) ErrorCode() ErrorCode {
+	return ErrorCodeInternal
+}
+
 func InternalErr(message string) error {
 	return &InternalError{Message: message}
 }
@@ -66,6 +161,5 @@ func InternalErrE(message string, cause error) error {
 }
 
 func IsInternalError(err error) bool {
-	_, ok := err.(*InternalError)
-	return ok
+	return ErrorCodeOf(err) == ErrorCodeInternal
 }
@@ -0,0 +1,81 @@
+package versource
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code ErrorCode
+	}{
+		{
+			name: "user error",
+			err:  UserErr("name is required"),
+			code: ErrorCodeValidation,
+		},
+		{
+			name: "not found error",
+			err:  NotFoundErr("changeset not found"),
+			code: ErrorCodeNotFound,
+		},
+		{
+			name: "wrapped conflict error",
+			err:  fmt.Errorf("failed to update component: %w", ConflictErr("component has been modified")),
+			code: ErrorCodeConflict,
+		},
+		{
+			name: "internal error wrapping precondition failed error",
+			err:  InternalErrE("failed to create plan", PreconditionFailedErr("changeset is closed")),
+			code: ErrorCodePreconditionFailed,
+		},
+		{
+			name: "internal error",
+			err:  InternalErr("failed to get component"),
+			code: ErrorCodeInternal,
+		},
+		{
+			name: "plain error",
+			err:  fmt.Errorf("connection refused"),
+			code: ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := ErrorCodeOf(tt.err)
+			if code != tt.code {
+				t.Errorf("expected code %s, got %s", tt.code, code)
+			}
+		})
+	}
+}
+
+func TestNewError(t *testing.T) {
+	codes := []ErrorCode{
+		ErrorCodeNotFound,
+		ErrorCodeConflict,
+		ErrorCodeValidation,
+		ErrorCodeForbidden,
+		ErrorCodePreconditionFailed,
+		ErrorCodeInternal,
+	}
+
+	for _, code := range codes {
+		t.Run(string(code), func(t *testing.T) {
+			err := NewError(code, "message")
+			if ErrorCodeOf(err) != code {
+				t.Errorf("expected code %s, got %s", code, ErrorCodeOf(err))
+			}
+			if err.Error() != "message" {
+				t.Errorf("expected message, got %s", err.Error())
+			}
+		})
+	}
+
+	if ErrorCodeOf(NewError("UNKNOWN", "message")) != ErrorCodeInternal {
+		t.Error("expected unknown code to be internal")
+	}
+}
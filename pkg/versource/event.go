@@ -0,0 +1,34 @@
+package versource
+
+import "time"
+
+type EventType string
+
+const (
+	EventTypePlanStateChanged   EventType = "plan.state_changed"
+	EventTypeApplyStateChanged  EventType = "apply.state_changed"
+	EventTypeMergeStateChanged  EventType = "merge.state_changed"
+	EventTypeRebaseStateChanged EventType = "rebase.state_changed"
+	EventTypeChangesetCreated   EventType = "changeset.created"
+	EventTypeChangesetMerged    EventType = "changeset.merged"
+	EventTypeComponentChanged   EventType = "component.changed"
+)
+
+type Event struct {
+	ID            uint64    `json:"id" yaml:"id"`
+	Type          EventType `json:"type" yaml:"type"`
+	ChangesetName string    `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+	EntityID      uint      `json:"entityId" yaml:"entityId"`
+	State         string    `json:"state,omitempty" yaml:"state,omitempty"`
+	Time          time.Time `json:"time" yaml:"time"`
+}
+
+type SubscribeEventsRequest struct {
+	ChangesetName *string     `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+	Types         []EventType `json:"types,omitempty" yaml:"types,omitempty"`
+	LastEventID   uint64      `json:"lastEventId,omitempty" yaml:"lastEventId,omitempty"`
+}
+
+type SubscribeEventsResponse struct {
+	Events <-chan Event `json:"-" yaml:"-"`
+}
@@ -18,11 +18,24 @@ type Facade interface {
 	ListChangesets(ctx context.Context, req ListChangesetsRequest) (*ListChangesetsResponse, error)
 	CreateChangeset(ctx context.Context, req CreateChangesetRequest) (*CreateChangesetResponse, error)
 	DeleteChangeset(ctx context.Context, req DeleteChangesetRequest) (*DeleteChangesetResponse, error)
+	UpdateChangeset(ctx context.Context, req UpdateChangesetRequest) (*UpdateChangesetResponse, error)
+	CloseChangeset(ctx context.Context, req CloseChangesetRequest) (*CloseChangesetResponse, error)
+	ReopenChangeset(ctx context.Context, req ReopenChangesetRequest) (*ReopenChangesetResponse, error)
+	RevertChangeset(ctx context.Context, req RevertChangesetRequest) (*RevertChangesetResponse, error)
 	EnsureChangeset(ctx context.Context, req EnsureChangesetRequest) (*EnsureChangesetResponse, error)
+	ApproveChangeset(ctx context.Context, req ApproveChangesetRequest) (*ApproveChangesetResponse, error)
+	ListChangesetApprovals(ctx context.Context, req ListChangesetApprovalsRequest) (*ListChangesetApprovalsResponse, error)
+
+	ListTeams(ctx context.Context, req ListTeamsRequest) (*ListTeamsResponse, error)
+	CreateTeam(ctx context.Context, req CreateTeamRequest) (*CreateTeamResponse, error)
+	AddTeamMember(ctx context.Context, req AddTeamMemberRequest) (*AddTeamMemberResponse, error)
+	RemoveTeamMember(ctx context.Context, req RemoveTeamMemberRequest) (*RemoveTeamMemberResponse, error)
 
 	GetMerge(ctx context.Context, req GetMergeRequest) (*GetMergeResponse, error)
 	ListMerges(ctx context.Context, req ListMergesRequest) (*ListMergesResponse, error)
+	ListMergeQueue(ctx context.Context, req ListMergeQueueRequest) (*ListMergeQueueResponse, error)
 	CreateMerge(ctx context.Context, req CreateMergeRequest) (*CreateMergeResponse, error)
+	ValidateMerge(ctx context.Context, req ValidateMergeRequest) (*ValidateMergeResponse, error)
 
 	GetRebase(ctx context.Context, req GetRebaseRequest) (*GetRebaseResponse, error)
 	ListRebases(ctx context.Context, req ListRebasesRequest) (*ListRebasesResponse, error)
@@ -36,6 +49,36 @@ type Facade interface {
 	UpdateComponent(ctx context.Context, req UpdateComponentRequest) (*UpdateComponentResponse, error)
 	DeleteComponent(ctx context.Context, req DeleteComponentRequest) (*DeleteComponentResponse, error)
 	RestoreComponent(ctx context.Context, req RestoreComponentRequest) (*RestoreComponentResponse, error)
+	ListComponentConflicts(ctx context.Context, req ListComponentConflictsRequest) (*ListComponentConflictsResponse, error)
+	ResolveComponentConflict(ctx context.Context, req ResolveComponentConflictRequest) (*ResolveComponentConflictResponse, error)
+	ListComponentHistory(ctx context.Context, req ListComponentHistoryRequest) (*ListComponentHistoryResponse, error)
+	RevertComponentToRevision(ctx context.Context, req RevertComponentToRevisionRequest) (*RevertComponentToRevisionResponse, error)
+	SyncComponents(ctx context.Context, req SyncComponentsRequest) (*SyncComponentsResponse, error)
+
+	GetVariableSet(ctx context.Context, req GetVariableSetRequest) (*GetVariableSetResponse, error)
+	ListVariableSets(ctx context.Context, req ListVariableSetsRequest) (*ListVariableSetsResponse, error)
+	CreateVariableSet(ctx context.Context, req CreateVariableSetRequest) (*CreateVariableSetResponse, error)
+	UpdateVariableSet(ctx context.Context, req UpdateVariableSetRequest) (*UpdateVariableSetResponse, error)
+
+	ListEnvironments(ctx context.Context, req ListEnvironmentsRequest) (*ListEnvironmentsResponse, error)
+	CreateEnvironment(ctx context.Context, req CreateEnvironmentRequest) (*CreateEnvironmentResponse, error)
+	GetPromotionPath(ctx context.Context, req GetPromotionPathRequest) (*GetPromotionPathResponse, error)
+	PromoteComponent(ctx context.Context, req PromoteComponentRequest) (*PromoteComponentResponse, error)
+
+	ListApiTokens(ctx context.Context, req ListApiTokensRequest) (*ListApiTokensResponse, error)
+	CreateApiToken(ctx context.Context, req CreateApiTokenRequest) (*CreateApiTokenResponse, error)
+	RevokeApiToken(ctx context.Context, req RevokeApiTokenRequest) (*RevokeApiTokenResponse, error)
+
+	ListAuditEvents(ctx context.Context, req ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+
+	SubscribeEvents(ctx context.Context, req SubscribeEventsRequest) (*SubscribeEventsResponse, error)
+
+	ListWebhooks(ctx context.Context, req ListWebhooksRequest) (*ListWebhooksResponse, error)
+	CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*CreateWebhookResponse, error)
+	DeleteWebhook(ctx context.Context, req DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, req ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	GetWebhookDelivery(ctx context.Context, req GetWebhookDeliveryRequest) (*GetWebhookDeliveryResponse, error)
+	RedeliverWebhookDelivery(ctx context.Context, req RedeliverWebhookDeliveryRequest) (*RedeliverWebhookDeliveryResponse, error)
 
 	GetPlan(ctx context.Context, req GetPlanRequest) (*GetPlanResponse, error)
 	GetPlanLog(ctx context.Context, req GetPlanLogRequest) (*GetPlanLogResponse, error)
@@ -54,4 +97,7 @@ type Facade interface {
 	ListViewResources(ctx context.Context, req ListViewResourcesRequest) (*ListViewResourcesResponse, error)
 	SaveViewResource(ctx context.Context, req SaveViewResourceRequest) (*SaveViewResourceResponse, error)
 	DeleteViewResource(ctx context.Context, req DeleteViewResourceRequest) (*DeleteViewResourceResponse, error)
+
+	ExportInventory(ctx context.Context, req ExportInventoryRequest) (*ExportInventoryResponse, error)
+	ImportInventory(ctx context.Context, req ImportInventoryRequest) (*ImportInventoryResponse, error)
 }
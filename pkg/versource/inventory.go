@@ -0,0 +1,47 @@
+package versource
+
+const InventoryFormatVersion = 1
+
+type Inventory struct {
+	FormatVersion   int                `json:"formatVersion" yaml:"formatVersion"`
+	Modules         []Module           `json:"modules" yaml:"modules"`
+	ModuleVersions  []ModuleVersion    `json:"moduleVersions" yaml:"moduleVersions"`
+	VariableSets    []VariableSet      `json:"variableSets,omitempty" yaml:"variableSets,omitempty"`
+	Components      []Component        `json:"components" yaml:"components"`
+	Resources       []Resource         `json:"resources" yaml:"resources"`
+	States          []State            `json:"states" yaml:"states"`
+	StateResources  []StateResource    `json:"stateResources" yaml:"stateResources"`
+	ViewResources   []ViewResource     `json:"viewResources" yaml:"viewResources"`
+	TerraformStates []TerraformState   `json:"terraformStates" yaml:"terraformStates"`
+	Secrets         []ComponentSecrets `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	Teams           []Team             `json:"teams,omitempty" yaml:"teams,omitempty"`
+}
+
+type TerraformState struct {
+	ComponentID uint   `json:"componentId" yaml:"componentId"`
+	Content     []byte `json:"content" yaml:"content"`
+}
+
+type ComponentSecrets struct {
+	ComponentID uint              `json:"componentId" yaml:"componentId"`
+	Ciphertexts map[string]string `json:"ciphertexts" yaml:"ciphertexts"`
+}
+
+type ExportInventoryRequest struct{}
+
+type ExportInventoryResponse struct {
+	Inventory Inventory `json:"inventory" yaml:"inventory"`
+}
+
+type ImportInventoryRequest struct {
+	Inventory Inventory `json:"inventory" yaml:"inventory"`
+}
+
+type ImportInventoryResponse struct {
+	Modules        int `json:"modules" yaml:"modules"`
+	ModuleVersions int `json:"moduleVersions" yaml:"moduleVersions"`
+	Components     int `json:"components" yaml:"components"`
+	States         int `json:"states" yaml:"states"`
+	ViewResources  int `json:"viewResources" yaml:"viewResources"`
+	Teams          int `json:"teams" yaml:"teams"`
+}
@@ -1,12 +1,43 @@
 package versource
 
+type MergePhase string
+
+const (
+	MergePhaseWaiting  MergePhase = "Waiting"
+	MergePhaseRebasing MergePhase = "Rebasing"
+	MergePhasePlanning MergePhase = "Planning"
+	MergePhaseMerging  MergePhase = "Merging"
+)
+
 type Merge struct {
-	ID          uint      `gorm:"primarykey" json:"id" yaml:"id"`
-	Changeset   Changeset `gorm:"foreignKey:ChangesetID" json:"changeset" yaml:"changeset"`
-	ChangesetID uint      `json:"changesetId" yaml:"changesetId"`
-	MergeBase   string    `gorm:"column:merge_base" json:"mergeBase" yaml:"mergeBase"`
-	Head        string    `gorm:"column:head" json:"head" yaml:"head"`
-	State       TaskState `gorm:"default:Queued" json:"state" yaml:"state"`
+	ID          uint           `gorm:"primarykey" json:"id" yaml:"id"`
+	Changeset   Changeset      `gorm:"foreignKey:ChangesetID" json:"changeset" yaml:"changeset"`
+	ChangesetID uint           `json:"changesetId" yaml:"changesetId"`
+	MergeBase   string         `gorm:"column:merge_base" json:"mergeBase" yaml:"mergeBase"`
+	Head        string         `gorm:"column:head" json:"head" yaml:"head"`
+	State       TaskState      `gorm:"default:Queued" json:"state" yaml:"state"`
+	Phase       MergePhase     `gorm:"default:Waiting" json:"phase" yaml:"phase"`
+	Findings    []MergeFinding `gorm:"column:findings;serializer:json" json:"findings" yaml:"findings"`
+	RequestedBy string         `gorm:"column:requested_by;not null;default:''" json:"requestedBy,omitempty" yaml:"requestedBy,omitempty"`
+}
+
+type MergeFindingType string
+
+const (
+	MergeFindingCommitsAfterHead   MergeFindingType = "CommitsAfterHead"
+	MergeFindingMergeBaseMoved     MergeFindingType = "MergeBaseMoved"
+	MergeFindingComponentConflicts MergeFindingType = "ComponentConflicts"
+	MergeFindingMissingPlan        MergeFindingType = "MissingPlan"
+	MergeFindingPlanNotSucceeded   MergeFindingType = "PlanNotSucceeded"
+	MergeFindingParentNotMerged    MergeFindingType = "ParentNotMerged"
+	MergeFindingMissingApproval    MergeFindingType = "MissingApproval"
+)
+
+type MergeFinding struct {
+	Type        MergeFindingType `json:"type" yaml:"type"`
+	Message     string           `json:"message" yaml:"message"`
+	ComponentID *uint            `json:"componentId,omitempty" yaml:"componentId,omitempty"`
+	PlanID      *uint            `json:"planId,omitempty" yaml:"planId,omitempty"`
 }
 
 type GetMergeRequest struct {
@@ -19,11 +50,13 @@ type GetMergeResponse struct {
 }
 
 type ListMergesRequest struct {
+	PageRequest   `yaml:",inline"`
 	ChangesetName string `json:"changesetName" yaml:"changesetName"`
 }
 
 type ListMergesResponse struct {
-	Merges []Merge `json:"merges" yaml:"merges"`
+	Merges     []Merge `json:"merges" yaml:"merges"`
+	NextCursor string  `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
 
 type CreateMergeRequest struct {
@@ -33,3 +66,23 @@ type CreateMergeRequest struct {
 type CreateMergeResponse struct {
 	Merge Merge `json:"merge" yaml:"merge"`
 }
+
+type ValidateMergeRequest struct {
+	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+}
+
+type ValidateMergeResponse struct {
+	Valid    bool           `json:"valid" yaml:"valid"`
+	Findings []MergeFinding `json:"findings" yaml:"findings"`
+}
+
+type MergeQueueEntry struct {
+	Position int   `json:"position" yaml:"position"`
+	Merge    Merge `json:"merge" yaml:"merge"`
+}
+
+type ListMergeQueueRequest struct{}
+
+type ListMergeQueueResponse struct {
+	Entries []MergeQueueEntry `json:"entries" yaml:"entries"`
+}
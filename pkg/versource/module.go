@@ -9,6 +9,7 @@ type Module struct {
 	Name         string `gorm:"uniqueIndex;not null" json:"name" yaml:"name"`
 	Source       string `json:"source" yaml:"source"`
 	ExecutorType string `gorm:"not null;default:'terraform-module'" json:"executorType" yaml:"executorType"`
+	Owner        string `gorm:"column:owner;not null;default:''" json:"owner,omitempty" yaml:"owner,omitempty"`
 }
 
 type ModuleVersion struct {
@@ -29,10 +30,14 @@ type GetModuleResponse struct {
 	LatestVersion *ModuleVersion `json:"latestVersion,omitempty" yaml:"latestVersion,omitempty"`
 }
 
-type ListModulesRequest struct{}
+type ListModulesRequest struct {
+	PageRequest `yaml:",inline"`
+	AsOf        *string `json:"asOf,omitempty" yaml:"asOf,omitempty"`
+}
 
 type ListModulesResponse struct {
-	Modules []Module `json:"modules" yaml:"modules"`
+	Modules    []Module `json:"modules" yaml:"modules"`
+	NextCursor string   `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
 
 type CreateModuleRequest struct {
@@ -40,6 +45,7 @@ type CreateModuleRequest struct {
 	Source       string `json:"source" yaml:"source"`
 	Version      string `json:"version" yaml:"version"`
 	ExecutorType string `json:"executorType,omitempty" yaml:"executorType,omitempty"`
+	Owner        string `json:"owner,omitempty" yaml:"owner,omitempty"`
 }
 
 type CreateModuleResponse struct {
@@ -74,9 +80,11 @@ type GetModuleVersionResponse struct {
 }
 
 type ListModuleVersionsRequest struct {
-	ModuleID *uint `json:"moduleId,omitempty" yaml:"moduleId,omitempty"`
+	PageRequest `yaml:",inline"`
+	ModuleID    *uint `json:"moduleId,omitempty" yaml:"moduleId,omitempty"`
 }
 
 type ListModuleVersionsResponse struct {
 	ModuleVersions []ModuleVersion `json:"moduleVersions" yaml:"moduleVersions"`
+	NextCursor     string          `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
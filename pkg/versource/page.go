@@ -0,0 +1,7 @@
+package versource
+
+type PageRequest struct {
+	Cursor string `json:"cursor,omitempty" yaml:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Sort   string `json:"sort,omitempty" yaml:"sort,omitempty"`
+}
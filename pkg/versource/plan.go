@@ -2,6 +2,7 @@ package versource
 
 import (
 	"io"
+	"time"
 )
 
 type Plan struct {
@@ -15,6 +16,8 @@ type Plan struct {
 	Add         *int      `gorm:"column:add" json:"add" yaml:"add"`
 	Change      *int      `gorm:"column:change" json:"change" yaml:"change"`
 	Destroy     *int      `gorm:"column:destroy" json:"destroy" yaml:"destroy"`
+	RequestedBy string    `gorm:"column:requested_by;not null;default:''" json:"requestedBy,omitempty" yaml:"requestedBy,omitempty"`
+	CreatedAt   time.Time `json:"createdAt" yaml:"createdAt"`
 }
 
 type GetPlanRequest struct {
@@ -30,6 +33,7 @@ type GetPlanResponse struct {
 type GetPlanLogRequest struct {
 	ChangesetName *string `json:"changesetName" yaml:"changesetName"`
 	PlanID        uint    `json:"planId" yaml:"planId"`
+	Follow        bool    `json:"follow" yaml:"follow"`
 }
 
 type GetPlanLogResponse struct {
@@ -37,11 +41,17 @@ type GetPlanLogResponse struct {
 }
 
 type ListPlansRequest struct {
-	ChangesetName string `json:"changesetName" yaml:"changesetName"`
+	PageRequest   `yaml:",inline"`
+	ChangesetName string     `json:"changesetName" yaml:"changesetName"`
+	Selector      string     `json:"selector,omitempty" yaml:"selector,omitempty"`
+	State         *TaskState `json:"state,omitempty" yaml:"state,omitempty"`
+	CreatedAfter  *time.Time `json:"createdAfter,omitempty" yaml:"createdAfter,omitempty"`
+	CreatedBefore *time.Time `json:"createdBefore,omitempty" yaml:"createdBefore,omitempty"`
 }
 
 type ListPlansResponse struct {
-	Plans []Plan `json:"plans" yaml:"plans"`
+	Plans      []Plan `json:"plans" yaml:"plans"`
+	NextCursor string `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
 
 type CreatePlanRequest struct {
@@ -19,11 +19,13 @@ type GetRebaseResponse struct {
 }
 
 type ListRebasesRequest struct {
+	PageRequest   `yaml:",inline"`
 	ChangesetName string `json:"changesetName" yaml:"changesetName"`
 }
 
 type ListRebasesResponse struct {
-	Rebases []Rebase `json:"rebases" yaml:"rebases"`
+	Rebases    []Rebase `json:"rebases" yaml:"rebases"`
+	NextCursor string   `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
 
 type CreateRebaseRequest struct {
@@ -43,8 +43,14 @@ type ResourceMapping struct {
 	Add  *[]Resource       `json:"add,omitempty"`
 }
 
-type ListResourcesRequest struct{}
+type ListResourcesRequest struct {
+	PageRequest  `yaml:",inline"`
+	AsOf         *string `json:"asOf,omitempty" yaml:"asOf,omitempty"`
+	Provider     *string `json:"provider,omitempty" yaml:"provider,omitempty"`
+	ResourceType *string `json:"resourceType,omitempty" yaml:"resourceType,omitempty"`
+}
 
 type ListResourcesResponse struct {
-	Resources []Resource `json:"resources" yaml:"resources"`
+	Resources  []Resource `json:"resources" yaml:"resources"`
+	NextCursor string     `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
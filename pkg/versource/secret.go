@@ -0,0 +1,80 @@
+package versource
+
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const SecretMaskPrefix = "sensitive:"
+
+type SecretVariables map[string]string
+
+func (s SecretVariables) Masked() map[string]string {
+	if s == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(s))
+	for name, ciphertext := range s {
+		if strings.HasPrefix(ciphertext, SecretMaskPrefix) {
+			masked[name] = ciphertext
+			continue
+		}
+		sum := sha256.Sum256([]byte(ciphertext))
+		masked[name] = SecretMaskPrefix + hex.EncodeToString(sum[:6])
+	}
+	return masked
+}
+
+func (s SecretVariables) IsMasked() bool {
+	for _, value := range s {
+		if strings.HasPrefix(value, SecretMaskPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s SecretVariables) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Masked())
+}
+
+func (s SecretVariables) MarshalYAML() (any, error) {
+	return s.Masked(), nil
+}
+
+func (s SecretVariables) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(map[string]string(s))
+}
+
+func (s *SecretVariables) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for secret variables: %T", value)
+	}
+	if len(data) == 0 {
+		*s = nil
+		return nil
+	}
+	var secrets map[string]string
+	err := json.Unmarshal(data, &secrets)
+	if err != nil {
+		return err
+	}
+	*s = secrets
+	return nil
+}
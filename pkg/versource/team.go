@@ -0,0 +1,75 @@
+package versource
+
+import (
+	"context"
+	"unicode"
+)
+
+type Team struct {
+	ID      uint         `gorm:"primarykey" json:"id" yaml:"id"`
+	Name    string       `gorm:"uniqueIndex;not null" json:"name" yaml:"name"`
+	Members []TeamMember `gorm:"foreignKey:TeamID" json:"members" yaml:"members"`
+}
+
+type TeamMember struct {
+	ID     uint   `gorm:"primarykey" json:"id" yaml:"id"`
+	TeamID uint   `json:"teamId" yaml:"teamId"`
+	User   string `gorm:"column:username" json:"user" yaml:"user"`
+}
+
+type ListTeamsRequest struct{}
+
+type ListTeamsResponse struct {
+	Teams []Team `json:"teams" yaml:"teams"`
+}
+
+type CreateTeamRequest struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type CreateTeamResponse struct {
+	Team Team `json:"team" yaml:"team"`
+}
+
+type AddTeamMemberRequest struct {
+	TeamName string `json:"teamName" yaml:"teamName"`
+	User     string `json:"user" yaml:"user"`
+}
+
+type AddTeamMemberResponse struct {
+	Team Team `json:"team" yaml:"team"`
+}
+
+type RemoveTeamMemberRequest struct {
+	TeamName string `json:"teamName" yaml:"teamName"`
+	User     string `json:"user" yaml:"user"`
+}
+
+type RemoveTeamMemberResponse struct {
+	Team Team `json:"team" yaml:"team"`
+}
+
+type userContextKey struct{}
+
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+func UserFromContext(ctx context.Context) string {
+	user, _ := ctx.Value(userContextKey{}).(string)
+	return user
+}
+
+// IsValidUser reports whether user can be recorded as a commit author. Angle
+// brackets and control characters would break the "name <email>" format.
+func IsValidUser(user string) bool {
+	if user == "" {
+		return false
+	}
+	for _, r := range user {
+		if r == '<' || r == '>' || unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package versource
+
+import "testing"
+
+func TestIsValidUser(t *testing.T) {
+	tests := []struct {
+		name  string
+		user  string
+		valid bool
+	}{
+		{name: "plain name", user: "alice", valid: true},
+		{name: "name with spaces", user: "Alice Smith", valid: true},
+		{name: "empty", user: "", valid: false},
+		{name: "angle brackets", user: "alice <evil@example.com>", valid: false},
+		{name: "newline", user: "alice\nbob", valid: false},
+		{name: "carriage return", user: "alice\r", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidUser(tt.user); got != tt.valid {
+				t.Errorf("IsValidUser(%q) = %v, want %v", tt.user, got, tt.valid)
+			}
+		})
+	}
+}
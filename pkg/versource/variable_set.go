@@ -0,0 +1,55 @@
+package versource
+
+import (
+	"gorm.io/datatypes"
+)
+
+type VariableSet struct {
+	ID        uint           `gorm:"primarykey" json:"id" yaml:"id"`
+	Name      string         `gorm:"uniqueIndex;not null" json:"name" yaml:"name"`
+	Variables datatypes.JSON `gorm:"type:jsonb" json:"variables" yaml:"variables"`
+	Version   uint           `gorm:"not null;default:1" json:"version" yaml:"version"`
+}
+
+type VariableSetAttachment struct {
+	Name    string `json:"name" yaml:"name"`
+	Version uint   `json:"version" yaml:"version"`
+}
+
+type GetVariableSetRequest struct {
+	Name          string  `json:"name" yaml:"name"`
+	ChangesetName *string `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+}
+
+type GetVariableSetResponse struct {
+	VariableSet VariableSet `json:"variableSet" yaml:"variableSet"`
+}
+
+type ListVariableSetsRequest struct {
+	ChangesetName *string `json:"changesetName,omitempty" yaml:"changesetName,omitempty"`
+}
+
+type ListVariableSetsResponse struct {
+	VariableSets []VariableSet `json:"variableSets" yaml:"variableSets"`
+}
+
+type CreateVariableSetRequest struct {
+	ChangesetName string         `json:"changesetName" yaml:"changesetName"`
+	Name          string         `json:"name" yaml:"name"`
+	Variables     map[string]any `json:"variables" yaml:"variables"`
+}
+
+type CreateVariableSetResponse struct {
+	VariableSet VariableSet `json:"variableSet" yaml:"variableSet"`
+}
+
+type UpdateVariableSetRequest struct {
+	ChangesetName string         `json:"changesetName" yaml:"changesetName"`
+	Name          string         `json:"name" yaml:"name"`
+	Variables     map[string]any `json:"variables" yaml:"variables"`
+}
+
+type UpdateVariableSetResponse struct {
+	VariableSet VariableSet `json:"variableSet" yaml:"variableSet"`
+	Plans       []Plan      `json:"plans" yaml:"plans"`
+}
@@ -14,10 +14,13 @@ type GetViewResourceResponse struct {
 	ViewResource ViewResource `json:"viewResource" yaml:"viewResource"`
 }
 
-type ListViewResourcesRequest struct{}
+type ListViewResourcesRequest struct {
+	PageRequest `yaml:",inline"`
+}
 
 type ListViewResourcesResponse struct {
 	ViewResources []ViewResource `json:"viewResources" yaml:"viewResources"`
+	NextCursor    string         `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
 }
 
 type SaveViewResourceRequest struct {
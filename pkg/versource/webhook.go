@@ -0,0 +1,135 @@
+package versource
+
+import (
+	"slices"
+	"time"
+)
+
+type WebhookTrigger string
+
+const (
+	WebhookTriggerPlanSucceeded    WebhookTrigger = "plan.succeeded"
+	WebhookTriggerPlanFailed       WebhookTrigger = "plan.failed"
+	WebhookTriggerPlanDestroys     WebhookTrigger = "plan.destroys"
+	WebhookTriggerApplySucceeded   WebhookTrigger = "apply.succeeded"
+	WebhookTriggerApplyFailed      WebhookTrigger = "apply.failed"
+	WebhookTriggerMergeSucceeded   WebhookTrigger = "merge.succeeded"
+	WebhookTriggerMergeFailed      WebhookTrigger = "merge.failed"
+	WebhookTriggerMergeRejected    WebhookTrigger = "merge.rejected"
+	WebhookTriggerRebaseFailed     WebhookTrigger = "rebase.failed"
+	WebhookTriggerChangesetCreated WebhookTrigger = "changeset.created"
+	WebhookTriggerChangesetMerged  WebhookTrigger = "changeset.merged"
+)
+
+var WebhookTriggers = []WebhookTrigger{
+	WebhookTriggerPlanSucceeded,
+	WebhookTriggerPlanFailed,
+	WebhookTriggerPlanDestroys,
+	WebhookTriggerApplySucceeded,
+	WebhookTriggerApplyFailed,
+	WebhookTriggerMergeSucceeded,
+	WebhookTriggerMergeFailed,
+	WebhookTriggerMergeRejected,
+	WebhookTriggerRebaseFailed,
+	WebhookTriggerChangesetCreated,
+	WebhookTriggerChangesetMerged,
+}
+
+func IsValidWebhookTrigger(trigger WebhookTrigger) bool {
+	return slices.Contains(WebhookTriggers, trigger)
+}
+
+type Webhook struct {
+	ID        uint             `gorm:"primarykey" json:"id" yaml:"id"`
+	Name      string           `gorm:"uniqueIndex;not null" json:"name" yaml:"name"`
+	URL       string           `gorm:"column:url;not null" json:"url" yaml:"url"`
+	Triggers  []WebhookTrigger `gorm:"column:triggers;serializer:json" json:"triggers" yaml:"triggers"`
+	Secret    string           `gorm:"not null" json:"-" yaml:"-"`
+	CreatedAt time.Time        `json:"createdAt" yaml:"createdAt"`
+}
+
+type WebhookDeliveryState string
+
+const (
+	WebhookDeliveryStatePending   WebhookDeliveryState = "Pending"
+	WebhookDeliveryStateSucceeded WebhookDeliveryState = "Succeeded"
+	WebhookDeliveryStateFailed    WebhookDeliveryState = "Failed"
+)
+
+type WebhookDelivery struct {
+	ID            uint                 `gorm:"primarykey" json:"id" yaml:"id"`
+	Webhook       Webhook              `gorm:"foreignKey:WebhookID" json:"webhook" yaml:"webhook"`
+	WebhookID     uint                 `json:"webhookId" yaml:"webhookId"`
+	Trigger       WebhookTrigger       `gorm:"column:trigger_name" json:"trigger" yaml:"trigger"`
+	Payload       string               `gorm:"type:text" json:"payload" yaml:"payload"`
+	State         WebhookDeliveryState `gorm:"default:Pending" json:"state" yaml:"state"`
+	Attempts      int                  `json:"attempts" yaml:"attempts"`
+	StatusCode    *int                 `json:"statusCode,omitempty" yaml:"statusCode,omitempty"`
+	Error         string               `gorm:"column:error" json:"error,omitempty" yaml:"error,omitempty"`
+	NextAttemptAt *time.Time           `json:"nextAttemptAt,omitempty" yaml:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time            `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt     time.Time            `json:"updatedAt" yaml:"updatedAt"`
+}
+
+type WebhookPayload struct {
+	Trigger WebhookTrigger `json:"trigger" yaml:"trigger"`
+	Event   Event          `json:"event" yaml:"event"`
+	Plan    *Plan          `json:"plan,omitempty" yaml:"plan,omitempty"`
+	Apply   *Apply         `json:"apply,omitempty" yaml:"apply,omitempty"`
+	Merge   *Merge         `json:"merge,omitempty" yaml:"merge,omitempty"`
+	Rebase  *Rebase        `json:"rebase,omitempty" yaml:"rebase,omitempty"`
+}
+
+type ListWebhooksRequest struct{}
+
+type ListWebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks" yaml:"webhooks"`
+}
+
+type CreateWebhookRequest struct {
+	Name     string           `json:"name" yaml:"name"`
+	URL      string           `json:"url" yaml:"url"`
+	Triggers []WebhookTrigger `json:"triggers" yaml:"triggers"`
+	Secret   string           `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+type CreateWebhookResponse struct {
+	Webhook Webhook `json:"webhook" yaml:"webhook"`
+	Secret  string  `json:"secret" yaml:"secret"`
+}
+
+type DeleteWebhookRequest struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type DeleteWebhookResponse struct {
+	Webhook Webhook `json:"webhook" yaml:"webhook"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	PageRequest `yaml:",inline"`
+	WebhookName string `json:"webhookName" yaml:"webhookName"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries" yaml:"deliveries"`
+	NextCursor string            `json:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
+}
+
+type GetWebhookDeliveryRequest struct {
+	WebhookName string `json:"webhookName" yaml:"webhookName"`
+	DeliveryID  uint   `json:"deliveryId" yaml:"deliveryId"`
+}
+
+type GetWebhookDeliveryResponse struct {
+	Delivery WebhookDelivery `json:"delivery" yaml:"delivery"`
+}
+
+type RedeliverWebhookDeliveryRequest struct {
+	WebhookName string `json:"webhookName" yaml:"webhookName"`
+	DeliveryID  uint   `json:"deliveryId" yaml:"deliveryId"`
+}
+
+type RedeliverWebhookDeliveryResponse struct {
+	Delivery WebhookDelivery `json:"delivery" yaml:"delivery"`
+}
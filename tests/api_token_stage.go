@@ -0,0 +1,82 @@
+//go:build e2e
+
+package tests
+
+import (
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Stage) an_api_token_has_been_created(name, user string, role versource.Role) *Stage {
+	return s.an_api_token_is_created(name, user, role).and().
+		the_api_token_creation_has_succeeded()
+}
+
+func (s *Stage) an_api_token_is_created(name, user string, role versource.Role) *Stage {
+	s.a_client_command_is_executed("token", "create", "--name", name, "--for", user, "--role", string(role))
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.CreateApiTokenResponse](s.t, s.LastOutput)
+	s.ApiTokens[name] = response.Token
+	return s
+}
+
+func (s *Stage) an_admin_api_token_has_been_bootstrapped(name, user string) *Stage {
+	return s.an_admin_api_token_is_bootstrapped(name, user).and().
+		the_api_token_creation_has_succeeded()
+}
+
+func (s *Stage) an_admin_api_token_is_bootstrapped(name, user string) *Stage {
+	s.a_command_is_executed("migrate", "versource", "token", "bootstrap", "--name", name, "--for", user, "--output", "json")
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.CreateApiTokenResponse](s.t, s.LastOutput)
+	s.ApiTokens[name] = response.Token
+	return s
+}
+
+func (s *Stage) the_api_token_creation_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_api_token_creation_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) an_api_token_has_been_revoked(name string) *Stage {
+	return s.an_api_token_is_revoked(name).and().
+		the_command_has_succeeded()
+}
+
+func (s *Stage) an_api_token_is_revoked(name string) *Stage {
+	return s.a_client_command_is_executed("token", "revoke", name)
+}
+
+func (s *Stage) the_api_token_is_used(name string) *Stage {
+	token, ok := s.ApiTokens[name]
+	require.True(s.t, ok, "API token %s has not been created", name)
+	s.Token = token
+	return s
+}
+
+func (s *Stage) an_invalid_api_token_is_used() *Stage {
+	s.Token = "vs_invalid"
+	return s
+}
+
+func (s *Stage) no_api_token_is_used() *Stage {
+	s.Token = ""
+	return s
+}
+
+func (s *Stage) the_api_tokens_are_listed() *Stage {
+	return s.a_client_command_is_executed("token", "list")
+}
+
+func (s *Stage) there_are_api_tokens(expected int) *Stage {
+	apiTokens := unmarshalArray[versource.ApiToken](s.t, s.LastOutput)
+	require.Len(s.t, apiTokens, expected)
+	return s
+}
@@ -0,0 +1,211 @@
+//go:build e2e && (all || token)
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func TestRequestWithoutApiTokenIsRejected(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		no_api_token_is_used()
+
+	when.
+		the_changesets_are_listed()
+
+	then.
+		the_command_has_failed()
+}
+
+func TestRequestWithInvalidApiTokenIsRejected(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		an_invalid_api_token_is_used()
+
+	when.
+		the_changesets_are_listed()
+
+	then.
+		the_command_has_failed()
+}
+
+func TestAdminCanListApiTokens(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		the_api_token_is_used("admin").and().
+		an_api_token_has_been_created("viewer", "bob", versource.RoleViewer)
+
+	when.
+		the_api_tokens_are_listed()
+
+	then.
+		the_command_has_succeeded().and().
+		there_are_api_tokens(2)
+}
+
+func TestViewerCanListChangesets(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		the_api_token_is_used("admin").and().
+		an_api_token_has_been_created("viewer", "bob", versource.RoleViewer).and().
+		the_api_token_is_used("viewer")
+
+	when.
+		the_changesets_are_listed()
+
+	then.
+		the_command_has_succeeded()
+}
+
+func TestViewerCannotCreateChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		the_api_token_is_used("admin").and().
+		an_api_token_has_been_created("viewer", "bob", versource.RoleViewer).and().
+		the_api_token_is_used("viewer")
+
+	when.
+		a_changeset_is_created("changeset1")
+
+	then.
+		the_changeset_creation_has_failed()
+}
+
+func TestEditorCannotMergeChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		the_api_token_is_used("admin").and().
+		an_api_token_has_been_created("editor", "bob", versource.RoleEditor).and().
+		the_api_token_is_used("editor").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_changeset_merge_is_requested()
+
+	then.
+		the_changeset_merge_creation_has_failed()
+}
+
+func TestMergerCanMergeChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		the_api_token_is_used("admin").and().
+		an_api_token_has_been_created("merger", "bob", versource.RoleMerger).and().
+		the_api_token_is_used("merger").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_changeset_merge_is_requested()
+
+	then.
+		the_changeset_merge_creation_has_succeeded()
+}
+
+func TestEditorCannotCreateApiToken(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		the_api_token_is_used("admin").and().
+		an_api_token_has_been_created("editor", "bob", versource.RoleEditor).and().
+		the_api_token_is_used("editor")
+
+	when.
+		an_api_token_is_created("viewer", "carol", versource.RoleViewer)
+
+	then.
+		the_api_token_creation_has_failed()
+}
+
+func TestRevokedApiTokenIsRejected(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		the_api_token_is_used("admin").and().
+		an_api_token_has_been_created("viewer", "bob", versource.RoleViewer).and().
+		an_api_token_has_been_revoked("viewer").and().
+		the_api_token_is_used("viewer")
+
+	when.
+		the_changesets_are_listed()
+
+	then.
+		the_command_has_failed()
+}
+
+func TestApiTokenCannotBeCreatedWithoutApiToken(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance)
+
+	when.
+		an_api_token_is_created("admin", "alice", versource.RoleAdmin)
+
+	then.
+		the_api_token_creation_has_failed()
+}
+
+func TestApiTokenCannotBeBootstrappedTwice(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice")
+
+	when.
+		an_admin_api_token_is_bootstrapped("other", "bob")
+
+	then.
+		the_api_token_creation_has_failed()
+}
+
+func TestRevokingLastApiTokenKeepsAuthentication(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_admin_api_token_has_been_bootstrapped("admin", "alice").and().
+		the_api_token_is_used("admin").and().
+		an_api_token_has_been_revoked("admin").and().
+		no_api_token_is_used()
+
+	when.
+		the_changesets_are_listed()
+
+	then.
+		the_command_has_failed()
+}
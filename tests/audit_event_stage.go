@@ -0,0 +1,80 @@
+//go:build e2e
+
+package tests
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Stage) a_changeset_is_created_as(name, user string) *Stage {
+	s.ChangesetName = name
+	return s.a_client_command_is_executed("changeset", "create", "--name", name, "--user", user)
+}
+
+func (s *Stage) a_changeset_has_been_created_as(name, user string) *Stage {
+	return s.a_changeset_is_created_as(name, user).and().
+		the_changeset_creation_has_succeeded()
+}
+
+func (s *Stage) the_audit_events_are_listed() *Stage {
+	return s.a_client_command_is_executed("audit", "list")
+}
+
+func (s *Stage) the_audit_events_are_listed_for_author(author string) *Stage {
+	return s.a_client_command_is_executed("audit", "list", "--author", author)
+}
+
+func (s *Stage) the_audit_events_are_listed_for_author_with_the_limit(author string, limit int) *Stage {
+	return s.a_client_command_is_executed("audit", "list", "--author", author, "--limit", strconv.Itoa(limit))
+}
+
+func (s *Stage) the_next_page_of_audit_events_is_listed_for_author(author string, limit int) *Stage {
+	cursor := nextCursorPattern.FindStringSubmatch(s.LastError)
+	require.NotNil(s.t, cursor, "Expected a next cursor")
+	return s.a_client_command_is_executed("audit", "list", "--author", author, "--limit", strconv.Itoa(limit), "--cursor", cursor[1])
+}
+
+func (s *Stage) the_audit_events_are_listed_for_branch(branch string) *Stage {
+	return s.a_client_command_is_executed("audit", "list", "--branch", branch)
+}
+
+func (s *Stage) there_is_an_audit_event(branch, author, message string) *Stage {
+	events := unmarshalArray[versource.AuditEvent](s.t, s.LastOutput)
+	found := slices.ContainsFunc(events, func(event versource.AuditEvent) bool {
+		return event.Branch == branch && event.Author == author && event.Message == message
+	})
+	require.True(s.t, found, "Expected audit event on %s by %s with message %q", branch, author, message)
+	return s
+}
+
+func (s *Stage) there_are_audit_events(count int) *Stage {
+	events := unmarshalArray[versource.AuditEvent](s.t, s.LastOutput)
+	require.Len(s.t, events, count)
+	return s
+}
+
+func (s *Stage) all_audit_events_are_by(author string) *Stage {
+	events := unmarshalArray[versource.AuditEvent](s.t, s.LastOutput)
+	for _, event := range events {
+		require.Equal(s.t, author, event.Author)
+	}
+	return s
+}
+
+func (s *Stage) a_changeset_has_been_merged_as(name, user string) *Stage {
+	s.ChangesetName = name
+	s.a_client_command_is_executed("changeset", "merge", name, "--user", user)
+	s.the_changeset_merge_creation_has_succeeded()
+	response := unmarshalResponse[versource.CreateMergeResponse](s.t, s.LastOutput)
+	s.MergeID = fmt.Sprintf("%d", response.Merge.ID)
+	return s.the_changeset_merge_has_succeeded()
+}
+
+func (s *Stage) there_is_an_audit_event_completing_the_merge(author string) *Stage {
+	return s.there_is_an_audit_event("admin", author, fmt.Sprintf("complete merge %s", s.MergeID))
+}
@@ -0,0 +1,107 @@
+//go:build e2e && (all || audit)
+
+package tests
+
+import (
+	"testing"
+)
+
+func TestAuditLogRecordsChangesetCreator(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created_as("changeset1", "alice")
+
+	when.
+		the_audit_events_are_listed()
+
+	then.
+		the_command_has_succeeded().and().
+		there_is_an_audit_event("admin", "alice", "create changeset changeset1")
+}
+
+func TestAuditLogFiltersByBranch(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created_as("changeset1", "alice")
+
+	when.
+		the_audit_events_are_listed_for_branch("admin")
+
+	then.
+		the_command_has_succeeded().and().
+		there_is_an_audit_event("admin", "alice", "create changeset changeset1")
+}
+
+func TestAuditLogFiltersByAuthor(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created_as("changeset1", "alice").and().
+		a_changeset_has_been_created_as("changeset2", "bob")
+
+	when.
+		the_audit_events_are_listed_for_author("bob")
+
+	then.
+		the_command_has_succeeded().and().
+		there_is_an_audit_event("admin", "bob", "create changeset changeset2").and().
+		all_audit_events_are_by("bob")
+}
+
+func TestAuditLogRecordsMergeRequester(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged_as("changeset1", "alice")
+
+	when.
+		the_audit_events_are_listed_for_branch("admin")
+
+	then.
+		the_command_has_succeeded().and().
+		there_is_an_audit_event_completing_the_merge("alice")
+}
+
+func TestUserWithAngleBracketsIsRejected(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance)
+
+	when.
+		a_changeset_is_created_as("changeset1", "alice <evil@example.com>")
+
+	then.
+		the_changeset_creation_has_failed()
+}
+
+func TestAuditLogPaginatedByAuthor(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created_as("changeset1", "alice").and().
+		a_changeset_has_been_created_as("changeset2", "alice")
+
+	when.
+		the_audit_events_are_listed_for_author_with_the_limit("alice", 1)
+
+	then.
+		the_command_has_succeeded().and().
+		there_are_audit_events(1).and().
+		there_is_a_next_page(true).and().
+		the_next_page_of_audit_events_is_listed_for_author("alice", 1).and().
+		the_command_has_succeeded().and().
+		there_are_audit_events(1).and().
+		there_is_a_next_page(false)
+}
@@ -4,6 +4,7 @@ package tests
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/marcbran/versource/pkg/versource"
 	"github.com/stretchr/testify/require"
@@ -19,6 +20,16 @@ func (s *Stage) a_changeset_is_created(name string) *Stage {
 	return s.a_client_command_is_executed("changeset", "create", "--name", name)
 }
 
+func (s *Stage) a_stacked_changeset_has_been_created(name string, parent string) *Stage {
+	return s.a_stacked_changeset_is_created(name, parent).and().
+		the_changeset_creation_has_succeeded()
+}
+
+func (s *Stage) a_stacked_changeset_is_created(name string, parent string) *Stage {
+	s.ChangesetName = name
+	return s.a_client_command_is_executed("changeset", "create", "--name", name, "--parent", parent)
+}
+
 func (s *Stage) the_changeset_creation_has_succeeded() *Stage {
 	return s.the_command_has_succeeded()
 }
@@ -51,6 +62,10 @@ func (s *Stage) a_changeset_is_merged(changesetName string) *Stage {
 	return s
 }
 
+func (s *Stage) the_changeset_merge_is_requested() *Stage {
+	return s.a_client_command_is_executed("changeset", "merge", s.ChangesetName)
+}
+
 func (s *Stage) the_changeset_merge_creation_has_succeeded() *Stage {
 	return s.the_command_has_succeeded()
 }
@@ -80,6 +95,60 @@ func (s *Stage) the_changeset_merge_has_completed(expectedState string) *Stage {
 	return s
 }
 
+func (s *Stage) the_changeset_merge_has_a_finding(findingType versource.MergeFindingType) *Stage {
+	s.a_client_command_is_executed("merge", "get", s.MergeID, "--changeset", s.ChangesetName)
+	response := unmarshalResponse[versource.GetMergeResponse](s.t, s.LastOutput)
+	require.True(s.t, hasMergeFinding(response.Merge.Findings, findingType), "Merge has no %s finding", findingType)
+	return s
+}
+
+func (s *Stage) the_merge_is_validated() *Stage {
+	return s.a_client_command_is_executed("merge", "validate", "--changeset", s.ChangesetName)
+}
+
+func (s *Stage) the_merge_validation_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_merge_is_valid(expected bool) *Stage {
+	response := unmarshalResponse[versource.ValidateMergeResponse](s.t, s.LastOutput)
+	require.Equal(s.t, expected, response.Valid, "Merge validity mismatch")
+	return s
+}
+
+func (s *Stage) the_merge_validation_has_a_finding(findingType versource.MergeFindingType) *Stage {
+	response := unmarshalResponse[versource.ValidateMergeResponse](s.t, s.LastOutput)
+	require.True(s.t, hasMergeFinding(response.Findings, findingType), "Merge validation has no %s finding", findingType)
+	return s
+}
+
+func hasMergeFinding(findings []versource.MergeFinding, findingType versource.MergeFindingType) bool {
+	for _, finding := range findings {
+		if finding.Type == findingType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Stage) the_changeset_has_been_rebased_by_the_merge_queue() *Stage {
+	s.a_client_command_is_executed("rebase", "list", "--changeset", s.ChangesetName)
+	rebases := unmarshalArray[versource.Rebase](s.t, s.LastOutput)
+	require.NotEmpty(s.t, rebases, "No rebase recorded for changeset %s", s.ChangesetName)
+	require.Equal(s.t, versource.TaskStateSucceeded, rebases[0].State, "Rebase state mismatch")
+	return s
+}
+
+func (s *Stage) the_merge_queue_is_listed() *Stage {
+	return s.a_client_command_is_executed("merge", "queue")
+}
+
+func (s *Stage) the_merge_queue_has_entries(expectedCount int) *Stage {
+	entries := unmarshalArray[versource.MergeQueueEntry](s.t, s.LastOutput)
+	require.Len(s.t, entries, expectedCount, "Merge queue length mismatch")
+	return s
+}
+
 func (s *Stage) the_changeset_has_been_rebased() *Stage {
 	return s.the_changeset_is_rebased().and().
 		the_changeset_rebase_creation_has_succeeded().and().
@@ -155,6 +224,10 @@ func (s *Stage) the_changeset_changes_are_listed() *Stage {
 	return s.a_client_command_is_executed("changeset", "change", "list", "--changeset", s.ChangesetName)
 }
 
+func (s *Stage) the_changeset_changes_are_listed_with_the_selector(selector string) *Stage {
+	return s.a_client_command_is_executed("changeset", "change", "list", "--changeset", s.ChangesetName, "--selector", selector)
+}
+
 func (s *Stage) there_are_changes(expectedCount int) *Stage {
 	changes := unmarshalArray[versource.ComponentChange](s.t, s.LastOutput)
 	require.Equal(s.t, expectedCount, len(changes), "Unexpected number of changes")
@@ -189,3 +262,180 @@ func (s *Stage) all_changeset_plans_have_completed(expectedState string) *Stage
 
 	return s
 }
+
+func (s *Stage) the_changeset_has_been_closed() *Stage {
+	return s.the_changeset_is_closed().and().
+		the_changeset_closing_has_succeeded()
+}
+
+func (s *Stage) the_changeset_is_closed() *Stage {
+	return s.a_changeset_is_closed(s.ChangesetName)
+}
+
+func (s *Stage) a_changeset_is_closed(changesetName string) *Stage {
+	s.ChangesetName = changesetName
+	return s.a_client_command_is_executed("changeset", "close", changesetName)
+}
+
+func (s *Stage) the_changeset_closing_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_changeset_closing_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_changeset_is_reopened() *Stage {
+	return s.a_changeset_is_reopened(s.ChangesetName)
+}
+
+func (s *Stage) a_changeset_is_reopened(changesetName string) *Stage {
+	s.ChangesetName = changesetName
+	return s.a_client_command_is_executed("changeset", "reopen", changesetName)
+}
+
+func (s *Stage) the_changeset_reopening_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_changeset_reopening_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_changeset_reopening_requires_a_rebase(expected bool) *Stage {
+	response := unmarshalResponse[versource.ReopenChangesetResponse](s.t, s.LastOutput)
+	require.Equal(s.t, expected, response.RebaseRequired, "Rebase required mismatch")
+	return s
+}
+
+func (s *Stage) the_changesets_are_listed() *Stage {
+	return s.a_client_command_is_executed("changeset", "list")
+}
+
+func (s *Stage) all_changesets_are_listed() *Stage {
+	return s.a_client_command_is_executed("changeset", "list", "--include-closed")
+}
+
+func (s *Stage) the_changeset_is_listed(expected bool) *Stage {
+	changesets := unmarshalArray[versource.Changeset](s.t, s.LastOutput)
+	found := false
+	for _, changeset := range changesets {
+		if changeset.Name == s.ChangesetName {
+			found = true
+		}
+	}
+	require.Equal(s.t, expected, found, "Changeset %s listing mismatch", s.ChangesetName)
+	return s
+}
+
+func (s *Stage) the_changeset_auto_rebase_has_been_enabled() *Stage {
+	return s.the_changeset_auto_rebase_is_set(true).and().
+		the_changeset_update_has_succeeded()
+}
+
+func (s *Stage) the_changeset_auto_rebase_is_set(autoRebase bool) *Stage {
+	return s.a_client_command_is_executed("changeset", "update", s.ChangesetName, fmt.Sprintf("--auto-rebase=%t", autoRebase))
+}
+
+func (s *Stage) the_changeset_update_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_changeset_update_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_changeset_has_auto_rebase(expected bool) *Stage {
+	response := unmarshalResponse[versource.UpdateChangesetResponse](s.t, s.LastOutput)
+	require.Equal(s.t, expected, response.Changeset.AutoRebase, "Auto rebase mismatch")
+	return s
+}
+
+func (s *Stage) the_changeset_eventually_becomes_stale() *Stage {
+	changeset := s.the_changeset_eventually_matches(func(changeset versource.Changeset) bool {
+		return changeset.Stale
+	})
+	require.NotEmpty(s.t, changeset.StaleComponentIDs, "No stale components recorded for changeset %s", s.ChangesetName)
+	return s
+}
+
+func (s *Stage) the_changeset_is_eventually_rebased_automatically() *Stage {
+	for attempt := 0; attempt < 30; attempt++ {
+		s.a_client_command_is_executed("rebase", "list", "--changeset", s.ChangesetName, "--wait-for-completion")
+		rebases := unmarshalArray[versource.Rebase](s.t, s.LastOutput)
+		if len(rebases) > 0 {
+			require.Equal(s.t, versource.TaskStateSucceeded, rebases[0].State, "Rebase state mismatch")
+			s.the_changeset_eventually_matches(func(changeset versource.Changeset) bool {
+				return !changeset.Stale
+			})
+			return s
+		}
+		time.Sleep(2 * time.Second)
+	}
+	require.Fail(s.t, "Changeset was not rebased automatically", s.ChangesetName)
+	return s
+}
+
+func (s *Stage) the_changeset_has_a_parent() *Stage {
+	s.the_changeset_eventually_matches(func(changeset versource.Changeset) bool {
+		return changeset.ParentID != nil
+	})
+	return s
+}
+
+func (s *Stage) the_changeset_is_eventually_unstacked() *Stage {
+	s.the_changeset_eventually_matches(func(changeset versource.Changeset) bool {
+		return changeset.ParentID == nil
+	})
+	return s
+}
+
+func (s *Stage) the_changeset_eventually_matches(matches func(versource.Changeset) bool) versource.Changeset {
+	for attempt := 0; attempt < 30; attempt++ {
+		s.the_changesets_are_listed()
+		changesets := unmarshalArray[versource.Changeset](s.t, s.LastOutput)
+		for _, changeset := range changesets {
+			if changeset.Name == s.ChangesetName && matches(changeset) {
+				return changeset
+			}
+		}
+		time.Sleep(2 * time.Second)
+	}
+	require.Fail(s.t, "Changeset did not reach the expected state", s.ChangesetName)
+	return versource.Changeset{}
+}
+
+func (s *Stage) a_changeset_is_reverted(changesetName string) *Stage {
+	return s.a_changeset_is_reverted_with_args(changesetName)
+}
+
+func (s *Stage) a_changeset_is_reverted_with_the_selector(changesetName, selector string) *Stage {
+	return s.a_changeset_is_reverted_with_args(changesetName, "--selector", selector)
+}
+
+func (s *Stage) a_changeset_is_reverted_with_args(changesetName string, args ...string) *Stage {
+	s.a_client_command_is_executed(append([]string{"changeset", "revert", changesetName}, args...)...)
+	response := unmarshalResponse[versource.RevertChangesetResponse](s.t, s.LastOutput)
+	s.ChangesetName = response.Changeset.Name
+	if len(response.Plans) > 0 {
+		s.ComponentID = fmt.Sprintf("%d", response.Plans[0].ComponentID)
+		s.PlanID = fmt.Sprintf("%d", response.Plans[0].ID)
+	}
+	return s
+}
+
+func (s *Stage) the_changeset_revert_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_changeset_revert_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_changeset_has_a_change_of_type(changeType versource.ChangeType) *Stage {
+	s.a_client_command_is_executed("changeset", "change", "list", "--changeset", s.ChangesetName)
+	changes := unmarshalArray[versource.ComponentChange](s.t, s.LastOutput)
+	require.Len(s.t, changes, 1, "Component change count mismatch")
+	require.Equal(s.t, changeType, changes[0].ChangeType, "Change type mismatch")
+	return s
+}
@@ -302,7 +302,8 @@ func TestMergeChangesetWithComponentUpdateConflicts(t *testing.T) {
 
 	then.
 		the_changeset_creation_has_succeeded().and().
-		the_changeset_merge_has_failed()
+		the_changeset_merge_has_succeeded().and().
+		the_changeset_has_been_rebased_by_the_merge_queue()
 }
 
 func TestRebaseChangeset(t *testing.T) {
@@ -354,3 +355,510 @@ func TestMergeChangesetWithComponentUpdateConflictsAfterRebase(t *testing.T) {
 		the_changeset_creation_has_succeeded().and().
 		the_changeset_merge_has_succeeded()
 }
+
+func TestMergeChangesetAfterMainHasMoved(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_created("changeset2").and().
+		a_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_changeset_is_merged("changeset2")
+
+	then.
+		the_changeset_merge_creation_has_succeeded().and().
+		the_changeset_merge_has_succeeded().and().
+		the_changeset_has_been_rebased_by_the_merge_queue()
+}
+
+func TestMergeQueueIsEmptyAfterMerge(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		the_merge_queue_is_listed()
+
+	then.
+		the_merge_queue_has_entries(0)
+}
+
+func TestEnableChangesetAutoRebase(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created("changeset1")
+
+	when.
+		the_changeset_auto_rebase_is_set(true)
+
+	then.
+		the_changeset_update_has_succeeded().and().
+		the_changeset_has_auto_rebase(true)
+}
+
+func TestChangesetBecomesStaleWhenMainMoves(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_created("changeset2").and().
+		a_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		a_changeset_has_been_merged("changeset1").and().
+		the_changeset_name_is("changeset2")
+
+	then.
+		the_changeset_eventually_becomes_stale()
+}
+
+func TestStaleChangesetIsRebasedAutomatically(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_created("changeset2").and().
+		a_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		the_changeset_auto_rebase_has_been_enabled()
+
+	when.
+		a_changeset_has_been_merged("changeset1").and().
+		the_changeset_name_is("changeset2")
+
+	then.
+		the_changeset_is_eventually_rebased_automatically()
+}
+
+func TestCloseChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created("changeset1")
+
+	when.
+		the_changeset_is_closed()
+
+	then.
+		the_changeset_closing_has_succeeded()
+}
+
+func TestCloseChangesetTwice(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created("changeset1").and().
+		the_changeset_has_been_closed()
+
+	when.
+		the_changeset_is_closed()
+
+	then.
+		the_changeset_closing_has_failed()
+}
+
+func TestClosedChangesetIsHiddenFromListing(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created("changeset1").and().
+		the_changeset_has_been_closed()
+
+	when.
+		the_changesets_are_listed()
+
+	then.
+		the_changeset_is_listed(false)
+}
+
+func TestClosedChangesetIsIncludedInFullListing(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created("changeset1").and().
+		the_changeset_has_been_closed()
+
+	when.
+		all_changesets_are_listed()
+
+	then.
+		the_changeset_is_listed(true)
+}
+
+func TestCreateComponentInClosedChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		the_changeset_has_been_closed()
+
+	when.
+		a_component_is_created_for_the_module_and_changeset("component1", `{"name": "component1"}`)
+
+	then.
+		the_component_creation_has_failed()
+}
+
+func TestReopenChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created("changeset1").and().
+		the_changeset_has_been_closed()
+
+	when.
+		the_changeset_is_reopened()
+
+	then.
+		the_changeset_reopening_has_succeeded().and().
+		the_changeset_reopening_requires_a_rebase(false)
+}
+
+func TestReopenChangesetAfterMainHasMoved(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		the_changeset_has_been_closed().and().
+		a_changeset_has_been_created("changeset2").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset2")
+
+	when.
+		a_changeset_is_reopened("changeset1")
+
+	then.
+		the_changeset_reopening_has_succeeded().and().
+		the_changeset_reopening_requires_a_rebase(true)
+}
+
+func TestReopenOpenChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_changeset_has_been_created("changeset1")
+
+	when.
+		the_changeset_is_reopened()
+
+	then.
+		the_changeset_reopening_has_failed()
+}
+
+func TestRevertMergedChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_changeset_is_reverted("changeset1")
+
+	then.
+		the_changeset_revert_has_succeeded().and().
+		the_component_has_the_variables_in_the_changeset(`{"name": "value"}`).and().
+		the_changeset_has_a_change_of_type(versource.ChangeTypeModified).and().
+		the_plan_has_succeeded()
+}
+
+func TestRevertChangesetThatCreatedComponent(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_changeset_is_reverted("changeset1")
+
+	then.
+		the_changeset_revert_has_succeeded().and().
+		the_changeset_has_a_change_of_type(versource.ChangeTypeDeleted)
+}
+
+func TestRevertMergedChangesetWithSelector(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_labeled_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "env=prod").and().
+		the_plan_has_succeeded().and().
+		a_labeled_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`, "env=dev").and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_changeset_is_reverted_with_the_selector("changeset1", "env=prod")
+
+	then.
+		the_changeset_revert_has_succeeded().and().
+		the_changeset_has_a_change_of_type(versource.ChangeTypeDeleted)
+}
+
+func TestRevertOpenChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes)
+
+	when.
+		a_changeset_is_reverted("changeset1")
+
+	then.
+		the_changeset_revert_has_failed()
+}
+
+func TestCreateStackedChangeset(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		a_stacked_changeset_is_created("changeset2", "changeset1")
+
+	then.
+		the_changeset_creation_has_succeeded().and().
+		the_changeset_has_a_parent()
+}
+
+func TestCreateStackedChangesetWithUnknownParent(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance)
+
+	when.
+		a_stacked_changeset_is_created("changeset2", "changeset1")
+
+	then.
+		the_changeset_creation_has_failed()
+}
+
+func TestMergeStackedChangesetBeforeParent(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_stacked_changeset_has_been_created("changeset2", "changeset1").and().
+		the_component_has_been_updated_in_the_changeset(`{"name": "value2"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_changeset_merge_is_requested()
+
+	then.
+		the_changeset_merge_creation_has_failed()
+}
+
+func TestMergeStackedChangesetAfterParent(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_stacked_changeset_has_been_created("changeset2", "changeset1").and().
+		the_component_has_been_updated_in_the_changeset(`{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1").and().
+		the_changeset_name_is("changeset2").and().
+		the_changeset_is_eventually_unstacked().and().
+		the_changeset_is_eventually_rebased_automatically().and().
+		all_changeset_plans_have_succeeded()
+
+	when.
+		the_changeset_is_merged()
+
+	then.
+		the_changeset_merge_creation_has_succeeded().and().
+		the_changeset_merge_has_succeeded()
+}
+
+func TestMergeStackedChangesetAfterParentIsClosed(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_stacked_changeset_has_been_created("changeset2", "changeset1").and().
+		the_component_has_been_updated_in_the_changeset(`{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_is_closed("changeset1").and().
+		the_changeset_closing_has_succeeded().and().
+		the_changeset_name_is("changeset2").and().
+		the_changeset_is_eventually_unstacked().and().
+		the_changeset_is_eventually_rebased_automatically().and().
+		all_changeset_plans_have_succeeded()
+
+	when.
+		the_changeset_is_merged()
+
+	then.
+		the_changeset_merge_creation_has_succeeded().and().
+		the_changeset_merge_has_succeeded()
+}
+
+func TestMergeStackedChangesetAfterParentIsDeleted(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_stacked_changeset_has_been_created("changeset2", "changeset1").and().
+		the_component_has_been_updated_in_the_changeset(`{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_deleted("changeset1").and().
+		the_changeset_name_is("changeset2").and().
+		the_changeset_is_eventually_unstacked().and().
+		the_changeset_is_eventually_rebased_automatically().and().
+		all_changeset_plans_have_succeeded()
+
+	when.
+		the_changeset_is_merged()
+
+	then.
+		the_changeset_merge_creation_has_succeeded().and().
+		the_changeset_merge_has_succeeded()
+}
+
+func TestValidateMerge(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_merge_is_validated()
+
+	then.
+		the_merge_validation_has_succeeded().and().
+		the_merge_is_valid(true)
+}
+
+func TestValidateMergeWithComponentConflicts(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_created("changeset2").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1").and().
+		the_changeset_name_is("changeset2")
+
+	when.
+		the_merge_is_validated()
+
+	then.
+		the_merge_validation_has_succeeded().and().
+		the_merge_is_valid(false).and().
+		the_merge_validation_has_a_finding(versource.MergeFindingComponentConflicts)
+}
+
+func TestValidateMergeOfStackedChangesetBeforeParent(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_stacked_changeset_has_been_created("changeset2", "changeset1").and().
+		the_component_has_been_updated_in_the_changeset(`{"name": "value2"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_merge_is_validated()
+
+	then.
+		the_merge_validation_has_succeeded().and().
+		the_merge_is_valid(false).and().
+		the_merge_validation_has_a_finding(versource.MergeFindingParentNotMerged)
+}
+
+func TestMergeChangesetWithFailedPlanRecordsFinding(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{}`).and().
+		the_plan_has_failed()
+
+	when.
+		the_changeset_is_merged()
+
+	then.
+		the_changeset_merge_has_failed().and().
+		the_changeset_merge_has_a_finding(versource.MergeFindingPlanNotSucceeded)
+}
@@ -3,11 +3,17 @@
 package tests
 
 import (
+	"encoding/base64"
 	"fmt"
+	"regexp"
+	"strconv"
 
 	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
 )
 
+var nextCursorPattern = regexp.MustCompile(`--cursor (\S+)`)
+
 func (s *Stage) a_component_has_been_created_for_the_module_and_changeset(name, variables string) *Stage {
 	return s.a_component_is_created_for_the_module_and_changeset(name, variables).and().
 		the_component_creation_has_succeeded()
@@ -36,6 +42,21 @@ func (s *Stage) a_component_is_created(changeset, moduleID, name, variables stri
 	return s
 }
 
+func (s *Stage) a_labeled_component_has_been_created_for_the_module_and_changeset(name, variables, labels string) *Stage {
+	return s.a_labeled_component_is_created_for_the_module_and_changeset(name, variables, labels).and().
+		the_component_creation_has_succeeded()
+}
+
+func (s *Stage) a_labeled_component_is_created_for_the_module_and_changeset(name, variables, labels string) *Stage {
+	args := []string{"component", "create", "--name", name, "--changeset", s.ChangesetName, "--module-id", s.ModuleID, "--label", labels}
+	args = append(args, parseVariablesToArgs(variables)...)
+	s.a_client_command_is_executed(args...)
+	response := unmarshalResponse[versource.CreateComponentResponse](s.t, s.LastOutput)
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
 func (s *Stage) the_component_creation_has_succeeded() *Stage {
 	return s.the_command_has_succeeded()
 }
@@ -94,6 +115,76 @@ func (s *Stage) a_component_is_updated(componentID, changeset, variables string)
 	return s
 }
 
+func (s *Stage) the_component_has_been_renamed(name string) *Stage {
+	return s.the_component_is_renamed(name).and().
+		the_component_update_has_succeeded()
+}
+
+func (s *Stage) the_component_is_renamed(name string) *Stage {
+	s.a_client_command_is_executed("component", "rename", s.ComponentID, "--changeset", s.ChangesetName, "--name", name)
+	response := unmarshalResponse[versource.UpdateComponentResponse](s.t, s.LastOutput)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_component_is_moved_to_a_module(moduleID string, moves ...string) *Stage {
+	args := []string{"component", "move", s.ComponentID, "--changeset", s.ChangesetName, "--module-id", moduleID}
+	for _, move := range moves {
+		args = append(args, "--move", move)
+	}
+	s.a_client_command_is_executed(args...)
+	response := unmarshalResponse[versource.UpdateComponentResponse](s.t, s.LastOutput)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_component_has_the_name_in_the_changeset(name string) *Stage {
+	s.a_client_command_is_executed("component", "get", s.ComponentID, "--changeset", s.ChangesetName)
+	response := unmarshalResponse[versource.GetComponentResponse](s.t, s.LastOutput)
+	require.Equal(s.t, name, response.Component.Name, "Component name mismatch")
+	return s
+}
+
+func (s *Stage) the_component_labels_have_been_updated(labels string) *Stage {
+	return s.the_component_labels_are_updated(labels).and().
+		the_component_update_has_succeeded()
+}
+
+func (s *Stage) the_component_labels_are_updated(labels string) *Stage {
+	s.a_client_command_is_executed("component", "update", s.ComponentID, "--changeset", s.ChangesetName, "--label", labels)
+	response := unmarshalResponse[versource.UpdateComponentResponse](s.t, s.LastOutput)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_component_has_the_labels_in_the_changeset(labels map[string]string) *Stage {
+	s.a_client_command_is_executed("component", "get", s.ComponentID, "--changeset", s.ChangesetName)
+	response := unmarshalResponse[versource.GetComponentResponse](s.t, s.LastOutput)
+	require.Equal(s.t, labels, response.Component.Labels, "Component labels mismatch")
+	return s
+}
+
+func (s *Stage) the_component_revision_has_been_fetched() *Stage {
+	s.a_client_command_is_executed("component", "get", s.ComponentID, "--changeset", s.ChangesetName)
+	s.the_command_has_succeeded()
+	response := unmarshalResponse[versource.GetComponentResponse](s.t, s.LastOutput)
+	require.NotEmpty(s.t, response.Revision, "Component revision is empty")
+	s.Revision = response.Revision
+	return s
+}
+
+func (s *Stage) the_component_is_updated_with_the_expected_revision(variables string) *Stage {
+	args := []string{"component", "update", s.ComponentID, "--changeset", s.ChangesetName, "--expected-revision", s.Revision}
+	args = append(args, parseVariablesToArgs(variables)...)
+	return s.a_client_command_is_executed(args...)
+}
+
+func (s *Stage) the_component_update_precondition_has_failed() *Stage {
+	s.the_command_has_failed()
+	require.Contains(s.t, s.LastError, "has been modified since revision", "Expected precondition failed error")
+	return s
+}
+
 func (s *Stage) the_component_is_updated_with_no_fields() *Stage {
 	return s.a_client_command_is_executed("component", "update", s.ComponentID, "--changeset", s.ChangesetName)
 }
@@ -209,3 +300,292 @@ func (s *Stage) the_component_restoration_has_succeeded() *Stage {
 func (s *Stage) the_component_restoration_has_failed() *Stage {
 	return s.the_command_has_failed()
 }
+
+func (s *Stage) the_component_conflicts_are_listed() *Stage {
+	return s.the_component_conflicts_of_a_changeset_are_listed(s.ChangesetName)
+}
+
+func (s *Stage) the_component_conflicts_of_a_changeset_are_listed(changesetName string) *Stage {
+	s.ChangesetName = changesetName
+	return s.a_client_command_is_executed("changeset", "conflict", "list", "--changeset", changesetName)
+}
+
+func (s *Stage) the_changeset_has_component_conflicts(expectedCount int) *Stage {
+	conflicts := unmarshalArray[versource.ComponentConflict](s.t, s.LastOutput)
+	require.Len(s.t, conflicts, expectedCount, "Component conflict count mismatch")
+	return s
+}
+
+func (s *Stage) the_component_conflict_shows_both_sides(changesetVariables, mainVariables string) *Stage {
+	conflicts := unmarshalArray[versource.ComponentConflict](s.t, s.LastOutput)
+	require.NotEmpty(s.t, conflicts, "No component conflicts found")
+	require.NotNil(s.t, conflicts[0].Changeset, "Changeset side of conflict is missing")
+	require.NotNil(s.t, conflicts[0].Main, "Main side of conflict is missing")
+	require.JSONEq(s.t, changesetVariables, string(conflicts[0].Changeset.Variables), "Changeset variables mismatch")
+	require.JSONEq(s.t, mainVariables, string(conflicts[0].Main.Variables), "Main variables mismatch")
+	return s
+}
+
+func (s *Stage) a_component_conflict_has_been_resolved(componentID, changeset, resolution, variables string) *Stage {
+	return s.a_component_conflict_is_resolved(componentID, changeset, resolution, variables).and().
+		the_component_conflict_resolution_has_succeeded()
+}
+
+func (s *Stage) a_component_conflict_is_resolved(componentID, changeset, resolution, variables string) *Stage {
+	s.ChangesetName = changeset
+	args := []string{"component", "resolve", componentID, "--changeset", changeset, "--resolution", resolution}
+	args = append(args, parseVariablesToArgs(variables)...)
+	s.a_client_command_is_executed(args...)
+	response := unmarshalResponse[versource.ResolveComponentConflictResponse](s.t, s.LastOutput)
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) two_components_conflict_between_two_changesets() *Stage {
+	return s.an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value"}`).and().
+		the_plan_has_succeeded().and().
+		a_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value"}`).and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_merged().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_updated_in_the_changeset("1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_component_has_been_updated_in_the_changeset("2", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_created("changeset2").and().
+		a_component_has_been_updated_in_the_changeset("1", `{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		a_component_has_been_updated_in_the_changeset("2", `{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1")
+}
+
+func (s *Stage) a_component_conflict_is_resolved_together_with(componentID, changeset, resolution string, others ...string) *Stage {
+	s.ChangesetName = changeset
+	args := []string{"component", "resolve", componentID, "--changeset", changeset, "--resolution", resolution}
+	for _, other := range others {
+		args = append(args, "--other", other)
+	}
+	s.a_client_command_is_executed(args...)
+	response := unmarshalResponse[versource.ResolveComponentConflictResponse](s.t, s.LastOutput)
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) a_component_has_the_variables_in_the_changeset(componentID, variables string) *Stage {
+	s.a_client_command_is_executed("component", "get", componentID, "--changeset", s.ChangesetName)
+	response := unmarshalResponse[versource.GetComponentResponse](s.t, s.LastOutput)
+	require.JSONEq(s.t, variables, string(response.Component.Variables), "Component variables mismatch")
+	return s
+}
+
+func (s *Stage) the_component_has_not_been_found(componentID string) *Stage {
+	s.the_command_has_failed()
+	require.Contains(s.t, s.LastError, fmt.Sprintf("component %s not found", componentID), "Expected not found error")
+	return s
+}
+
+func (s *Stage) the_component_conflict_resolution_has_conflicted() *Stage {
+	s.the_command_has_failed()
+	require.Contains(s.t, s.LastError, "must be resolved together", "Expected unresolved conflicts error")
+	return s
+}
+
+func (s *Stage) the_component_conflict_resolution_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_component_conflict_resolution_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_component_has_the_variables_in_the_changeset(variables string) *Stage {
+	s.a_client_command_is_executed("component", "get", s.ComponentID, "--changeset", s.ChangesetName)
+	response := unmarshalResponse[versource.GetComponentResponse](s.t, s.LastOutput)
+	require.JSONEq(s.t, variables, string(response.Component.Variables), "Component variables mismatch")
+	return s
+}
+
+func (s *Stage) the_component_history_is_listed() *Stage {
+	return s.a_client_command_is_executed("component", "history", s.ComponentID)
+}
+
+func (s *Stage) the_history_of_a_component_is_listed(componentID string) *Stage {
+	s.ComponentID = componentID
+	return s.the_component_history_is_listed()
+}
+
+func (s *Stage) the_component_has_revisions(expectedCount int) *Stage {
+	revisions := unmarshalArray[versource.ComponentRevision](s.t, s.LastOutput)
+	require.Len(s.t, revisions, expectedCount, "Component revision count mismatch")
+	return s
+}
+
+func (s *Stage) the_latest_revision_was_rolled_out_by_changeset(changesetName string) *Stage {
+	revisions := unmarshalArray[versource.ComponentRevision](s.t, s.LastOutput)
+	require.NotEmpty(s.t, revisions, "No component revisions found")
+	require.Equal(s.t, changesetName, revisions[0].ChangesetName, "Changeset of latest revision mismatch")
+	require.NotNil(s.t, revisions[0].Plan, "Plan of latest revision is missing")
+	return s
+}
+
+func (s *Stage) the_component_is_reverted_to_its_first_revision(changesetName string) *Stage {
+	s.the_component_history_is_listed()
+	revisions := unmarshalArray[versource.ComponentRevision](s.t, s.LastOutput)
+	require.NotEmpty(s.t, revisions, "No component revisions found")
+	commit := revisions[len(revisions)-1].Commit
+	return s.a_component_is_reverted_to_a_revision(s.ComponentID, changesetName, commit)
+}
+
+func (s *Stage) a_component_is_reverted_to_a_revision(componentID, changesetName, commit string) *Stage {
+	s.ChangesetName = changesetName
+	s.a_client_command_is_executed("component", "revert", componentID, "--changeset", changesetName, "--commit", commit)
+	response := unmarshalResponse[versource.RevertComponentToRevisionResponse](s.t, s.LastOutput)
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_component_revert_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_component_revert_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) a_component_is_fetched(componentID string) *Stage {
+	s.ComponentID = componentID
+	return s.a_client_command_is_executed("component", "get", componentID)
+}
+
+func (s *Stage) a_component_is_fetched_as_of(componentID, asOf string) *Stage {
+	s.ComponentID = componentID
+	return s.a_client_command_is_executed("component", "get", componentID, "--as-of", asOf)
+}
+
+func (s *Stage) the_component_has_the_variables(variables string) *Stage {
+	response := unmarshalResponse[versource.GetComponentResponse](s.t, s.LastOutput)
+	require.JSONEq(s.t, variables, string(response.Component.Variables), "Component variables mismatch")
+	return s
+}
+
+func (s *Stage) the_components_are_listed_as_of(asOf string) *Stage {
+	return s.a_client_command_is_executed("component", "list", "--as-of", asOf)
+}
+
+func (s *Stage) the_components_of_the_changeset_are_listed_with_the_selector(selector string) *Stage {
+	return s.a_client_command_is_executed("component", "list", "--changeset", s.ChangesetName, "--selector", selector)
+}
+
+func (s *Stage) the_components_of_the_changeset_are_listed_by_name_with_the_limit(limit int) *Stage {
+	return s.a_client_command_is_executed("component", "list", "--changeset", s.ChangesetName, "--sort", "name", "--limit", strconv.Itoa(limit))
+}
+
+func (s *Stage) the_next_page_of_components_of_the_changeset_is_listed_by_name(limit int) *Stage {
+	cursor := nextCursorPattern.FindStringSubmatch(s.LastError)
+	require.NotNil(s.t, cursor, "Expected a next cursor")
+	return s.a_client_command_is_executed("component", "list", "--changeset", s.ChangesetName, "--sort", "name", "--limit", strconv.Itoa(limit), "--cursor", cursor[1])
+}
+
+func (s *Stage) the_components_of_the_changeset_are_listed_with_the_sort(sort string) *Stage {
+	return s.a_client_command_is_executed("component", "list", "--changeset", s.ChangesetName, "--sort", sort)
+}
+
+func (s *Stage) there_is_a_next_page(expected bool) *Stage {
+	require.Equal(s.t, expected, nextCursorPattern.MatchString(s.LastError), "Next page mismatch")
+	return s
+}
+
+func (s *Stage) the_listed_components_are_in_order(names ...string) *Stage {
+	components := unmarshalArray[versource.Component](s.t, s.LastOutput)
+	actual := make([]string, 0, len(components))
+	for _, component := range components {
+		actual = append(actual, component.Name)
+	}
+	require.Equal(s.t, names, actual, "Listed components mismatch")
+	return s
+}
+
+func (s *Stage) the_listed_components_are(names ...string) *Stage {
+	components := unmarshalArray[versource.Component](s.t, s.LastOutput)
+	actual := make([]string, 0, len(components))
+	for _, component := range components {
+		actual = append(actual, component.Name)
+	}
+	require.ElementsMatch(s.t, names, actual, "Listed components mismatch")
+	return s
+}
+
+func (s *Stage) there_are_components(expectedCount int) *Stage {
+	components := unmarshalArray[versource.Component](s.t, s.LastOutput)
+	require.Len(s.t, components, expectedCount, "Component count mismatch")
+	return s
+}
+
+func (s *Stage) the_component_manifests(manifests ...string) *Stage {
+	s.a_command_is_executed("client", "sh", "-c", "rm -rf /tmp/manifests && mkdir -p /tmp/manifests").and().
+		the_command_has_to_succeed()
+	for i, manifest := range manifests {
+		encoded := base64.StdEncoding.EncodeToString([]byte(manifest))
+		script := fmt.Sprintf("echo %s | base64 -d > /tmp/manifests/%d.yaml", encoded, i)
+		s.a_command_is_executed("client", "sh", "-c", script).and().
+			the_command_has_to_succeed()
+	}
+	return s
+}
+
+func (s *Stage) the_manifests_are_synced_into_a_changeset(changesetName string) *Stage {
+	s.ChangesetName = changesetName
+	return s.a_client_command_is_executed("sync", "/tmp/manifests", "--changeset", changesetName)
+}
+
+func (s *Stage) the_manifests_are_synced_into_a_changeset_as_a_dry_run(changesetName string) *Stage {
+	s.ChangesetName = changesetName
+	return s.a_client_command_is_executed("sync", "/tmp/manifests", "--changeset", changesetName, "--dry-run")
+}
+
+func (s *Stage) the_manifests_are_synced_into_a_changeset_with_the_selector(changesetName, selector string) *Stage {
+	s.ChangesetName = changesetName
+	return s.a_client_command_is_executed("sync", "/tmp/manifests", "--changeset", changesetName, "--selector", selector)
+}
+
+func (s *Stage) the_sync_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_sync_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_sync_has_a_change(changeType, componentName string) *Stage {
+	response := unmarshalResponse[versource.SyncComponentsResponse](s.t, s.LastOutput)
+	for _, change := range response.Changes {
+		component := change.ToComponent
+		if component == nil {
+			component = change.FromComponent
+		}
+		if component != nil && component.Name == componentName {
+			require.Equal(s.t, changeType, string(change.ChangeType), "Sync change type mismatch")
+			return s
+		}
+	}
+	require.Fail(s.t, "No sync change for component "+componentName)
+	return s
+}
+
+func (s *Stage) the_sync_has_changes(expectedCount int) *Stage {
+	response := unmarshalResponse[versource.SyncComponentsResponse](s.t, s.LastOutput)
+	require.Len(s.t, response.Changes, expectedCount, "Sync change count mismatch")
+	return s
+}
+
+func (s *Stage) the_sync_has_plans(expectedCount int) *Stage {
+	response := unmarshalResponse[versource.SyncComponentsResponse](s.t, s.LastOutput)
+	require.Len(s.t, response.Plans, expectedCount, "Sync plan count mismatch")
+	return s
+}
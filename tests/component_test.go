@@ -81,6 +81,54 @@ func TestUpdateComponent(t *testing.T) {
 		the_component_update_has_succeeded()
 }
 
+func TestUpdateComponentWithExpectedRevision(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_module_has_been_created("consul-aws", "hashicorp/consul/aws", "0.1.0").and().
+		a_changeset_has_been_created("test1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"key": "value"}`).and().
+		the_component_revision_has_been_fetched()
+
+	when.
+		the_component_is_updated_with_the_expected_revision(`{"key": "updated"}`)
+
+	then.
+		the_component_update_has_succeeded()
+}
+
+func TestUpdateComponentWithStaleRevision(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_module_has_been_created("consul-aws", "hashicorp/consul/aws", "0.1.0").and().
+		a_changeset_has_been_created("test1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"key": "value"}`).and().
+		the_component_revision_has_been_fetched().and().
+		the_component_has_been_updated(`{"key": "other"}`)
+
+	when.
+		the_component_is_updated_with_the_expected_revision(`{"key": "updated"}`)
+
+	then.
+		the_component_update_precondition_has_failed()
+}
+
+func TestGetComponentWithNonexistentID(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance)
+
+	when.
+		a_component_is_fetched("999")
+
+	then.
+		the_component_has_not_been_found("999")
+}
+
 func TestUpdateComponentWithNonexistentID(t *testing.T) {
 	given, when, then := scenario(t)
 
@@ -315,3 +363,534 @@ func TestRestoreDeletedComponent(t *testing.T) {
 	then.
 		the_component_restoration_has_succeeded()
 }
+
+func TestListComponentConflicts(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		the_component_conflicts_of_a_changeset_are_listed("changeset2")
+
+	then.
+		the_command_has_succeeded().and().
+		the_changeset_has_component_conflicts(1).and().
+		the_component_conflict_shows_both_sides(`{"name": "value2"}`, `{"name": "value1"}`)
+}
+
+func TestResolveComponentConflictWithOurs(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_component_conflict_is_resolved("1", "changeset2", "ours", "")
+
+	then.
+		the_component_conflict_resolution_has_succeeded().and().
+		the_component_has_the_variables_in_the_changeset(`{"name": "value2"}`).and().
+		the_component_conflicts_are_listed().and().
+		the_changeset_has_component_conflicts(0)
+}
+
+func TestResolveComponentConflictWithTheirs(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_component_conflict_is_resolved("1", "changeset2", "theirs", "")
+
+	then.
+		the_component_conflict_resolution_has_succeeded().and().
+		the_component_has_the_variables_in_the_changeset(`{"name": "value1"}`).and().
+		the_component_conflicts_are_listed().and().
+		the_changeset_has_component_conflicts(0)
+}
+
+func TestResolveComponentConflictManually(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_component_conflict_is_resolved("1", "changeset2", "manual", `{"name": "value3"}`)
+
+	then.
+		the_component_conflict_resolution_has_succeeded().and().
+		the_component_has_the_variables_in_the_changeset(`{"name": "value3"}`).and().
+		the_plan_has_succeeded()
+}
+
+func TestResolveOneOfTwoComponentConflicts(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		two_components_conflict_between_two_changesets()
+
+	when.
+		a_component_conflict_is_resolved("1", "changeset2", "ours", "")
+
+	then.
+		the_component_conflict_resolution_has_conflicted().and().
+		the_component_conflicts_of_a_changeset_are_listed("changeset2").and().
+		the_changeset_has_component_conflicts(2)
+}
+
+func TestResolveTwoComponentConflictsTogether(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		two_components_conflict_between_two_changesets()
+
+	when.
+		a_component_conflict_is_resolved_together_with("1", "changeset2", "ours", "2=theirs")
+
+	then.
+		the_component_conflict_resolution_has_succeeded().and().
+		a_component_has_the_variables_in_the_changeset("1", `{"name": "value2"}`).and().
+		a_component_has_the_variables_in_the_changeset("2", `{"name": "value1"}`).and().
+		the_component_conflicts_are_listed().and().
+		the_changeset_has_component_conflicts(0)
+}
+
+func TestResolveComponentConflictKeepsLabelsAndOwner(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_team_has_been_created("infra").and().
+		a_team_member_has_been_added("infra", "alice").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset").and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value"}`, "infra").and().
+		the_plan_has_succeeded().and().
+		the_component_labels_have_been_updated("env=prod").and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_approved_by("alice").and().
+		the_changeset_has_been_merged().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_updated_in_the_changeset("1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_created("changeset2").and().
+		a_component_has_been_updated_in_the_changeset("1", `{"name": "value2"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_approved_by("changeset1", "alice").and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_component_conflict_is_resolved("1", "changeset2", "ours", "")
+
+	then.
+		the_component_conflict_resolution_has_succeeded().and().
+		the_component_has_the_variables_in_the_changeset(`{"name": "value2"}`).and().
+		the_component_has_the_labels_in_the_changeset(map[string]string{"env": "prod"}).and().
+		the_component_has_the_owner_in_the_changeset("infra")
+}
+
+func TestResolveComponentConflictWithoutConflict(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes)
+
+	when.
+		a_component_conflict_is_resolved("1", "changeset2", "ours", "")
+
+	then.
+		the_component_conflict_resolution_has_failed()
+}
+
+func TestListComponentHistory(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		the_history_of_a_component_is_listed("1")
+
+	then.
+		the_command_has_succeeded().and().
+		the_component_has_revisions(2).and().
+		the_latest_revision_was_rolled_out_by_changeset("changeset1")
+}
+
+func TestRevertComponentToRevision(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1").and().
+		the_history_of_a_component_is_listed("1")
+
+	when.
+		the_component_is_reverted_to_its_first_revision("revert1")
+
+	then.
+		the_component_revert_has_succeeded().and().
+		the_component_has_the_variables_in_the_changeset(`{"name": "value"}`).and().
+		the_plan_has_succeeded()
+}
+
+func TestRevertComponentToUnknownRevision(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes)
+
+	when.
+		a_component_is_reverted_to_a_revision("1", "revert1", "invalid")
+
+	then.
+		the_component_revert_has_failed()
+}
+
+func TestGetComponentAsOfMergeTag(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1").and().
+		the_component_id_is("1").and().
+		the_component_has_been_updated_in_a_changeset("changeset3", `{"name": "value3"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset3")
+
+	when.
+		a_component_is_fetched_as_of("1", "merge/changeset1")
+
+	then.
+		the_command_has_succeeded().and().
+		the_component_has_the_variables(`{"name": "value1"}`)
+}
+
+func TestListComponentsAsOfMergeTag(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		the_components_are_listed_as_of("merge/changeset1")
+
+	then.
+		the_command_has_succeeded().and().
+		there_are_components(1)
+}
+
+func TestListComponentsAsOfUnknownRevision(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes)
+
+	when.
+		the_components_are_listed_as_of("merge/unknown")
+
+	then.
+		the_command_has_failed()
+}
+
+func TestSyncComponentsDryRun(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		the_component_manifests(
+			"module: jsonnet\nname: component1\nvariables:\n  name: synced\n",
+			"module: jsonnet\nname: component2\nvariables:\n  name: new\n",
+		)
+
+	when.
+		the_manifests_are_synced_into_a_changeset_as_a_dry_run("sync")
+
+	then.
+		the_sync_has_succeeded().and().
+		the_sync_has_changes(2).and().
+		the_sync_has_a_change("Modified", "component1").and().
+		the_sync_has_a_change("Created", "component2").and().
+		the_sync_has_plans(0)
+}
+
+func TestSyncComponents(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		the_component_manifests(
+			"module: jsonnet\nname: component1\nvariables:\n  name: synced\n",
+			"module: jsonnet\nname: component2\nvariables:\n  name: new\n",
+		)
+
+	when.
+		the_manifests_are_synced_into_a_changeset("sync")
+
+	then.
+		the_sync_has_succeeded().and().
+		the_sync_has_plans(2).and().
+		the_component_id_is("1").and().
+		the_component_has_the_variables_in_the_changeset(`{"name": "synced"}`)
+}
+
+func TestSyncComponentsDeletesMissingComponents(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		the_component_manifests(
+			"module: jsonnet\nname: component2\nvariables:\n  name: new\n",
+		)
+
+	when.
+		the_manifests_are_synced_into_a_changeset("sync")
+
+	then.
+		the_sync_has_succeeded().and().
+		the_sync_has_changes(2).and().
+		the_sync_has_a_change("Created", "component2").and().
+		the_sync_has_a_change("Deleted", "component1").and().
+		the_sync_has_plans(2)
+}
+
+func TestSyncComponentsWithSelectorKeepsUnselectedComponents(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		the_component_manifests(
+			"module: jsonnet\nname: component2\nvariables:\n  name: new\nlabels:\n  env: prod\n",
+		)
+
+	when.
+		the_manifests_are_synced_into_a_changeset_with_the_selector("sync", "env=prod")
+
+	then.
+		the_sync_has_succeeded().and().
+		the_sync_has_changes(1).and().
+		the_sync_has_a_change("Created", "component2").and().
+		the_sync_has_plans(1)
+}
+
+func TestSyncComponentsWithManifestOutsideSelector(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		the_component_manifests(
+			"module: jsonnet\nname: component2\nvariables:\n  name: new\nlabels:\n  env: dev\n",
+		)
+
+	when.
+		the_manifests_are_synced_into_a_changeset_with_the_selector("sync", "env=prod")
+
+	then.
+		the_sync_has_failed()
+}
+
+func TestSyncComponentsWithUnknownModule(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		the_component_manifests(
+			"module: unknown\nname: component1\n",
+		)
+
+	when.
+		the_manifests_are_synced_into_a_changeset("sync")
+
+	then.
+		the_sync_has_failed()
+}
+
+func TestRenameComponent(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1").and().
+		a_changeset_has_been_created("changeset2")
+
+	when.
+		the_component_is_renamed("renamed1")
+
+	then.
+		the_component_update_has_succeeded().and().
+		the_plan_has_succeeded().and().
+		the_plan_has_no_destroys().and().
+		the_component_has_the_name_in_the_changeset("renamed1")
+}
+
+func TestRenameComponentToExistingName(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_component_is_renamed("component1")
+
+	then.
+		the_component_update_has_failed()
+}
+
+func TestMoveComponentToModuleWithoutVersions(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_component_is_moved_to_a_module("99", "null_resource.test=null_resource.renamed")
+
+	then.
+		the_component_update_has_failed()
+}
+
+func TestListComponentsWithLabelSelector(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_labeled_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "env=prod,team=infra").and().
+		the_plan_has_succeeded().and().
+		a_labeled_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`, "env=prod,team=data").and().
+		the_plan_has_succeeded().and().
+		a_labeled_component_has_been_created_for_the_module_and_changeset("component3", `{"name": "value3"}`, "env=dev,team=infra").and().
+		the_plan_has_succeeded()
+
+	when.
+		the_components_of_the_changeset_are_listed_with_the_selector("env in (prod,staging),team!=data")
+
+	then.
+		the_command_has_succeeded().and().
+		the_listed_components_are("component1")
+}
+
+func TestListComponentsWithInvalidLabelSelector(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_labeled_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "env=prod").and().
+		the_plan_has_succeeded()
+
+	when.
+		the_components_of_the_changeset_are_listed_with_the_selector("env in (prod")
+
+	then.
+		the_command_has_failed()
+}
+
+func TestCreateComponentWithInvalidLabels(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1")
+
+	when.
+		a_labeled_component_is_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "env=pro!d")
+
+	then.
+		the_component_creation_has_failed()
+}
+
+func TestUpdateComponentLabels(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_labeled_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "env=dev").and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_merged("changeset1").and().
+		a_changeset_has_been_created("changeset2")
+
+	when.
+		the_component_labels_are_updated("env=prod,team=infra")
+
+	then.
+		the_component_update_has_succeeded().and().
+		the_plan_has_succeeded().and().
+		the_component_has_the_labels_in_the_changeset(map[string]string{"env": "prod", "team": "infra"}).and().
+		the_changeset_changes_are_listed_with_the_selector("team=infra").and().
+		there_are_changes(1)
+}
+
+func TestListComponentsPaginated(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component3", `{"name": "value3"}`).and().
+		the_plan_has_succeeded().and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_components_of_the_changeset_are_listed_by_name_with_the_limit(2)
+
+	then.
+		the_command_has_succeeded().and().
+		the_listed_components_are_in_order("component1", "component2").and().
+		there_is_a_next_page(true).and().
+		the_next_page_of_components_of_the_changeset_is_listed_by_name(2).and().
+		the_command_has_succeeded().and().
+		the_listed_components_are_in_order("component3").and().
+		there_is_a_next_page(false)
+}
+
+func TestListComponentsWithInvalidSort(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_components_of_the_changeset_are_listed_with_the_sort("variables")
+
+	then.
+		the_command_has_failed()
+}
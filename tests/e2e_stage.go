@@ -24,9 +24,15 @@ type Stage struct {
 	ModuleID      string
 	ChangesetName string
 	ComponentID   string
+	Revision      string
 	PlanID        string
 	MergeID       string
 	RebaseID      string
+	WebhookName   string
+	DeliveryID    string
+
+	Token     string
+	ApiTokens map[string]string
 
 	LastOutput   string
 	LastError    string
@@ -37,7 +43,8 @@ type Stage struct {
 
 func scenario(t *testing.T) (*Stage, *Stage, *Stage) {
 	stage := &Stage{
-		t: t,
+		t:         t,
+		ApiTokens: make(map[string]string),
 	}
 	return stage, stage, stage
 }
@@ -49,6 +56,10 @@ func (s *Stage) the_stage_is_cleared() *Stage {
 	s.PlanID = ""
 	s.MergeID = ""
 	s.RebaseID = ""
+	s.WebhookName = ""
+	s.DeliveryID = ""
+	s.Token = ""
+	s.ApiTokens = make(map[string]string)
 	s.LastOutput = ""
 	s.LastError = ""
 	s.LastExitCode = 0
@@ -139,6 +150,7 @@ func (s *Stage) the_migrations_are_run() *Stage {
 
 func (s *Stage) the_dataset(dataset Dataset) *Stage {
 	return s.a_dataset_is_cloned(dataset).and().
+		the_migrations_are_run().and().
 		a_restarted_server().and().
 		the_terraform_folder_is_cleared().and().
 		the_stage_is_cleared()
@@ -212,6 +224,9 @@ func (s *Stage) the_command_has_to_failed() *Stage {
 
 func (s *Stage) a_client_command_is_executed(args ...string) *Stage {
 	args = append(args, "--output", "json")
+	if s.Token != "" {
+		args = append(args, "--token", s.Token)
+	}
 	args = append([]string{"versource"}, args...)
 	return s.a_command_is_executed("client", args...)
 }
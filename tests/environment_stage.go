@@ -0,0 +1,76 @@
+//go:build e2e
+
+package tests
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Stage) an_environment_has_been_created(name string) *Stage {
+	return s.an_environment_is_created(name).and().
+		the_command_has_succeeded()
+}
+
+func (s *Stage) an_environment_is_created(name string) *Stage {
+	return s.a_client_command_is_executed("environment", "create", "--name", name)
+}
+
+func (s *Stage) a_templated_component_has_been_created_for_the_module_and_changeset(name, template, environment, variables string) *Stage {
+	return s.a_templated_component_is_created_for_the_module_and_changeset(name, template, environment, variables).and().
+		the_component_creation_has_succeeded()
+}
+
+func (s *Stage) a_templated_component_is_created_for_the_module_and_changeset(name, template, environment, variables string) *Stage {
+	args := []string{"component", "create", "--name", name, "--changeset", s.ChangesetName, "--module-id", s.ModuleID, "--template", template, "--environment", environment}
+	args = append(args, parseVariablesToArgs(variables)...)
+	s.a_client_command_is_executed(args...)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.CreateComponentResponse](s.t, s.LastOutput)
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_template_is_promoted_from(template, environment string) *Stage {
+	s.a_client_command_is_executed("component", "promote", template, "--from", environment)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.PromoteComponentResponse](s.t, s.LastOutput)
+	s.ChangesetName = response.Changeset.Name
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_template_has_been_promoted_from(template, environment string) *Stage {
+	return s.the_template_is_promoted_from(template, environment).and().
+		the_promotion_has_succeeded()
+}
+
+func (s *Stage) the_promotion_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_promotion_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_promotion_path_is_shown(template string) *Stage {
+	return s.a_client_command_is_executed("component", "promotion", template)
+}
+
+func (s *Stage) the_promotion_path_is(expected ...string) *Stage {
+	stages := unmarshalArray[versource.PromotionStage](s.t, s.LastOutput)
+	actual := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		actual = append(actual, fmt.Sprintf("%s=%t", stage.Environment, stage.Promoted))
+	}
+	require.Equal(s.t, expected, actual, "Promotion path mismatch")
+	return s
+}
@@ -0,0 +1,89 @@
+//go:build e2e && (all || environment)
+
+package tests
+
+import (
+	"testing"
+)
+
+func TestCreateComponentInUnknownEnvironment(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1")
+
+	when.
+		a_templated_component_is_created_for_the_module_and_changeset("web-dev", "web", "dev", `{"name": "web"}`)
+
+	then.
+		the_component_creation_has_failed()
+}
+
+func TestPromoteTemplateToNextEnvironment(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_environment_has_been_created("dev").and().
+		an_environment_has_been_created("stage").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_templated_component_has_been_created_for_the_module_and_changeset("web-dev", "web", "dev", `{"name": "web"}`).and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_merged()
+
+	when.
+		the_template_is_promoted_from("web", "dev")
+
+	then.
+		the_promotion_has_succeeded().and().
+		the_plan_has_succeeded().and().
+		the_changeset_changes_are_listed().and().
+		there_are_changes(1)
+}
+
+func TestPromoteTemplateFromLastEnvironment(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_environment_has_been_created("dev").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_templated_component_has_been_created_for_the_module_and_changeset("web-dev", "web", "dev", `{"name": "web"}`).and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_merged()
+
+	when.
+		the_template_is_promoted_from("web", "dev")
+
+	then.
+		the_promotion_has_failed()
+}
+
+func TestShowPromotionPath(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_environment_has_been_created("dev").and().
+		an_environment_has_been_created("stage").and().
+		an_environment_has_been_created("prod").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_templated_component_has_been_created_for_the_module_and_changeset("web-dev", "web", "dev", `{"name": "web"}`).and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_merged().and().
+		the_template_has_been_promoted_from("web", "dev").and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_merged()
+
+	when.
+		the_promotion_path_is_shown("web")
+
+	then.
+		the_command_has_succeeded().and().
+		the_promotion_path_is("dev=true", "stage=true", "prod=false")
+}
@@ -0,0 +1,58 @@
+//go:build e2e
+
+package tests
+
+import (
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Stage) the_inventory_has_been_exported(dir string) *Stage {
+	return s.the_inventory_is_exported(dir).and().
+		the_inventory_export_has_succeeded()
+}
+
+func (s *Stage) the_inventory_is_exported(dir string) *Stage {
+	s.a_command_is_executed("client", "rm", "-rf", dir).and().
+		the_command_has_to_succeed()
+	return s.a_client_command_is_executed("export", dir)
+}
+
+func (s *Stage) the_inventory_export_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_exported_inventory_has_components(expectedCount int) *Stage {
+	inventory := unmarshalResponse[versource.Inventory](s.t, s.LastOutput)
+	require.Len(s.t, inventory.Components, expectedCount, "Exported component count mismatch")
+	return s
+}
+
+func (s *Stage) the_inventory_archive_contains(dir, path string) *Stage {
+	return s.a_command_is_executed("client", "test", "-f", dir+"/"+path).and().
+		the_command_has_succeeded()
+}
+
+func (s *Stage) the_inventory_is_imported(dir string) *Stage {
+	return s.a_client_command_is_executed("import", dir)
+}
+
+func (s *Stage) the_inventory_import_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_inventory_import_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_imported_inventory_has_components(expectedCount int) *Stage {
+	response := unmarshalResponse[versource.ImportInventoryResponse](s.t, s.LastOutput)
+	require.Equal(s.t, expectedCount, response.Components, "Imported component count mismatch")
+	return s
+}
+
+func (s *Stage) the_imported_inventory_has_teams(expectedCount int) *Stage {
+	response := unmarshalResponse[versource.ImportInventoryResponse](s.t, s.LastOutput)
+	require.Equal(s.t, expectedCount, response.Teams, "Imported team count mismatch")
+	return s
+}
@@ -0,0 +1,100 @@
+//go:build e2e
+
+package tests
+
+import (
+	"testing"
+)
+
+func TestExportInventory(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes)
+
+	when.
+		the_inventory_is_exported("/tmp/inventory")
+
+	then.
+		the_inventory_export_has_succeeded().and().
+		the_exported_inventory_has_components(1).and().
+		the_inventory_archive_contains("/tmp/inventory", "inventory.yaml").and().
+		the_inventory_archive_contains("/tmp/inventory", "components/1.yaml")
+}
+
+func TestImportInventory(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		the_inventory_has_been_exported("/tmp/inventory").and().
+		a_clean_slate()
+
+	when.
+		the_inventory_is_imported("/tmp/inventory")
+
+	then.
+		the_inventory_import_has_succeeded().and().
+		the_imported_inventory_has_components(1).and().
+		a_component_is_fetched("1").and().
+		the_component_has_the_variables(`{"name": "value"}`)
+}
+
+func TestImportInventoryIntoNonEmptyInstance(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		the_inventory_has_been_exported("/tmp/inventory")
+
+	when.
+		the_inventory_is_imported("/tmp/inventory")
+
+	then.
+		the_inventory_import_has_failed()
+}
+
+func TestImportInventoryWithTeams(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(two_changesets_with_changes).and().
+		a_team_has_been_created("platform").and().
+		a_team_member_has_been_added("platform", "alice").and().
+		the_inventory_has_been_exported("/tmp/inventory").and().
+		a_clean_slate()
+
+	when.
+		the_inventory_is_imported("/tmp/inventory")
+
+	then.
+		the_inventory_import_has_succeeded().and().
+		the_imported_inventory_has_teams(1).and().
+		the_teams_are_listed().and().
+		the_team_list_contains_the_member("platform", "alice")
+}
+
+func TestImportInventoryWithSecrets(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_with_secrets_has_been_created_for_the_module_and_changeset("component1", "", `{"name": "top-secret-value"}`).and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_merged().and().
+		the_inventory_has_been_exported("/tmp/inventory").and().
+		a_clean_slate()
+
+	when.
+		the_inventory_is_imported("/tmp/inventory")
+
+	then.
+		the_inventory_import_has_succeeded().and().
+		a_component_is_fetched("1").and().
+		the_output_contains_masked_secrets().and().
+		a_changeset_has_been_created("changeset2").and().
+		the_component_has_been_updated_in_the_changeset(`{"other": "value"}`).and().
+		the_plan_has_succeeded()
+}
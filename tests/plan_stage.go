@@ -60,3 +60,26 @@ func (s *Stage) the_plan_has_completed(expectedState string) *Stage {
 
 	return s
 }
+
+func (s *Stage) the_plan_has_no_destroys() *Stage {
+	s.a_client_command_is_executed("plan", "get", s.PlanID, "--changeset", s.ChangesetName)
+	response := unmarshalResponse[versource.GetPlanResponse](s.t, s.LastOutput)
+	require.NotNil(s.t, response.Plan.Destroy, "Plan has no destroy count")
+	require.Equal(s.t, 0, *response.Plan.Destroy, "Plan destroy count mismatch")
+	return s
+}
+
+func (s *Stage) the_plan_logs_are_followed() *Stage {
+	require.NotEqual(s.t, "", s.PlanID, "No plan id")
+	return s.a_client_command_is_executed("plan", "logs", s.PlanID, "--changeset", s.ChangesetName, "--follow")
+}
+
+func (s *Stage) the_plan_logs_are_shown() *Stage {
+	require.NotEqual(s.t, "", s.PlanID, "No plan id")
+	return s.a_client_command_is_executed("plan", "logs", s.PlanID, "--changeset", s.ChangesetName)
+}
+
+func (s *Stage) the_plan_logs_are_not_empty() *Stage {
+	require.NotEmpty(s.t, s.LastOutput, "No plan logs")
+	return s
+}
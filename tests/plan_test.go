@@ -117,3 +117,39 @@ func TestCreatePlanWithoutChangeset(t *testing.T) {
 	then.
 		the_plan_creation_has_failed()
 }
+
+func TestFollowPlanLogsUntilCompletion(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("test1").and().
+		a_component_has_been_created_for_the_module_and_changeset("plan-test-component", `{"key": "value"}`)
+
+	when.
+		the_plan_logs_are_followed()
+
+	then.
+		the_command_has_succeeded().and().
+		the_plan_logs_are_not_empty().and().
+		the_plan_has_succeeded()
+}
+
+func TestShowPlanLogsAfterCompletion(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("test1").and().
+		a_component_has_been_created_for_the_module_and_changeset("plan-test-component", `{"key": "value"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_plan_logs_are_shown()
+
+	then.
+		the_command_has_succeeded().and().
+		the_plan_logs_are_not_empty()
+}
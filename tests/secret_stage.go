@@ -0,0 +1,63 @@
+//go:build e2e
+
+package tests
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Stage) a_component_with_secrets_has_been_created_for_the_module_and_changeset(name, variables, secrets string) *Stage {
+	return s.a_component_with_secrets_is_created_for_the_module_and_changeset(name, variables, secrets).and().
+		the_component_creation_has_succeeded()
+}
+
+func (s *Stage) a_component_with_secrets_is_created_for_the_module_and_changeset(name, variables, secrets string) *Stage {
+	args := []string{"component", "create", "--name", name, "--changeset", s.ChangesetName, "--module-id", s.ModuleID}
+	args = append(args, parseVariablesToArgs(variables)...)
+	args = append(args, parseSecretsToArgs(secrets)...)
+	s.a_client_command_is_executed(args...)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.CreateComponentResponse](s.t, s.LastOutput)
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_component_secrets_are_updated(secrets string) *Stage {
+	args := []string{"component", "update", s.ComponentID, "--changeset", s.ChangesetName}
+	args = append(args, parseSecretsToArgs(secrets)...)
+	s.a_client_command_is_executed(args...)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.UpdateComponentResponse](s.t, s.LastOutput)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_output_does_not_contain(value string) *Stage {
+	require.NotContains(s.t, s.LastOutput, value, "Output reveals a secret value")
+	return s
+}
+
+func (s *Stage) the_output_contains_masked_secrets() *Stage {
+	require.True(s.t, strings.Contains(s.LastOutput, versource.SecretMaskPrefix), "Output does not contain masked secrets")
+	return s
+}
+
+func parseSecretsToArgs(secrets string) []string {
+	var args []string
+	for _, arg := range parseVariablesToArgs(secrets) {
+		if arg == "--variable" {
+			arg = "--secret"
+		}
+		args = append(args, arg)
+	}
+	return args
+}
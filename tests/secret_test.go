@@ -0,0 +1,50 @@
+//go:build e2e && (all || secret)
+
+package tests
+
+import (
+	"testing"
+)
+
+func TestCreateComponentWithSecret(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1")
+
+	when.
+		a_component_with_secrets_is_created_for_the_module_and_changeset("component1", "", `{"name": "top-secret-value"}`)
+
+	then.
+		the_component_creation_has_succeeded().and().
+		the_output_does_not_contain("top-secret-value").and().
+		the_output_contains_masked_secrets().and().
+		the_plan_has_succeeded()
+}
+
+func TestRotateComponentSecret(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_with_secrets_has_been_created_for_the_module_and_changeset("component1", "", `{"name": "first-secret-value"}`).and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_merged().and().
+		a_changeset_has_been_created("changeset2")
+
+	when.
+		the_component_secrets_are_updated(`{"name": "second-secret-value"}`)
+
+	then.
+		the_component_update_has_succeeded().and().
+		the_output_does_not_contain("second-secret-value").and().
+		the_plan_has_succeeded().and().
+		the_changeset_changes_are_listed().and().
+		there_are_changes(1).and().
+		the_output_does_not_contain("first-secret-value").and().
+		the_output_does_not_contain("second-secret-value")
+}
@@ -0,0 +1,102 @@
+//go:build e2e
+
+package tests
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Stage) a_team_has_been_created(name string) *Stage {
+	return s.a_team_is_created(name).and().
+		the_command_has_succeeded()
+}
+
+func (s *Stage) a_team_is_created(name string) *Stage {
+	return s.a_client_command_is_executed("team", "create", "--name", name)
+}
+
+func (s *Stage) a_team_member_has_been_added(teamName, user string) *Stage {
+	return s.a_team_member_is_added(teamName, user).and().
+		the_command_has_succeeded()
+}
+
+func (s *Stage) a_team_member_is_added(teamName, user string) *Stage {
+	return s.a_client_command_is_executed("team", "member", "add", "--team", teamName, "--member", user)
+}
+
+func (s *Stage) an_owned_component_has_been_created_for_the_module_and_changeset(name, variables, owner string) *Stage {
+	return s.an_owned_component_is_created_for_the_module_and_changeset(name, variables, owner).and().
+		the_component_creation_has_succeeded()
+}
+
+func (s *Stage) an_owned_component_is_created_for_the_module_and_changeset(name, variables, owner string) *Stage {
+	args := []string{"component", "create", "--name", name, "--changeset", s.ChangesetName, "--module-id", s.ModuleID, "--owner", owner}
+	args = append(args, parseVariablesToArgs(variables)...)
+	s.a_client_command_is_executed(args...)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.CreateComponentResponse](s.t, s.LastOutput)
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
+
+func (s *Stage) the_changeset_has_been_approved_by(user string) *Stage {
+	return s.the_changeset_is_approved_by(user).and().
+		the_changeset_approval_has_succeeded()
+}
+
+func (s *Stage) the_changeset_is_approved_by(user string) *Stage {
+	return s.a_client_command_is_executed("changeset", "approve", s.ChangesetName, "--user", user)
+}
+
+func (s *Stage) a_changeset_has_been_approved_by(changesetName, user string) *Stage {
+	s.ChangesetName = changesetName
+	return s.the_changeset_has_been_approved_by(user)
+}
+
+func (s *Stage) the_changeset_approval_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_changeset_approval_is_missing_teams(teams ...string) *Stage {
+	response := unmarshalResponse[versource.ApproveChangesetResponse](s.t, s.LastOutput)
+	require.ElementsMatch(s.t, teams, response.MissingApprovals, "Missing approvals mismatch")
+	return s
+}
+
+func (s *Stage) the_components_of_the_changeset_are_listed_with_the_owner(owner string) *Stage {
+	return s.a_client_command_is_executed("component", "list", "--changeset", s.ChangesetName, "--owner", owner)
+}
+
+func (s *Stage) the_component_has_the_owner_in_the_changeset(owner string) *Stage {
+	s.a_client_command_is_executed("component", "get", s.ComponentID, "--changeset", s.ChangesetName)
+	response := unmarshalResponse[versource.GetComponentResponse](s.t, s.LastOutput)
+	require.Equal(s.t, owner, response.Component.Owner, "Component owner mismatch")
+	return s
+}
+
+func (s *Stage) the_teams_are_listed() *Stage {
+	return s.a_client_command_is_executed("team", "list")
+}
+
+func (s *Stage) the_team_list_contains_the_member(teamName, user string) *Stage {
+	response := unmarshalResponse[versource.ListTeamsResponse](s.t, s.LastOutput)
+	for _, team := range response.Teams {
+		if team.Name != teamName {
+			continue
+		}
+		for _, member := range team.Members {
+			if member.User == user {
+				return s
+			}
+		}
+		require.Failf(s.t, "Team member not found", "team %s has no member %s", teamName, user)
+	}
+	require.Failf(s.t, "Team not found", "team %s not found", teamName)
+	return s
+}
@@ -0,0 +1,181 @@
+//go:build e2e && (all || team)
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func TestMergeChangesetRequiresOwnerApproval(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_team_has_been_created("infra").and().
+		a_team_member_has_been_added("infra", "alice").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "infra").and().
+		the_plan_has_succeeded()
+
+	when.
+		the_changeset_merge_is_requested()
+
+	then.
+		the_changeset_merge_creation_has_failed()
+}
+
+func TestMergeChangesetAfterOwnerApproval(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_team_has_been_created("infra").and().
+		a_team_member_has_been_added("infra", "alice").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "infra").and().
+		the_plan_has_succeeded()
+
+	when.
+		the_changeset_is_approved_by("alice")
+
+	then.
+		the_changeset_approval_has_succeeded().and().
+		the_changeset_approval_is_missing_teams().and().
+		the_changeset_has_been_merged()
+}
+
+func TestMergeChangesetWithStaleOwnerApproval(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_team_has_been_created("infra").and().
+		a_team_member_has_been_added("infra", "alice").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "infra").and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_approved_by("alice").and().
+		the_component_has_been_updated_in_the_changeset(`{"name": "value2"}`).and().
+		the_plan_has_succeeded()
+
+	when.
+		the_changeset_merge_is_requested()
+
+	then.
+		the_changeset_merge_creation_has_failed().and().
+		the_changeset_is_approved_by("alice").and().
+		the_changeset_approval_has_succeeded().and().
+		the_changeset_approval_is_missing_teams().and().
+		the_changeset_has_been_merged()
+}
+
+func TestMergeApprovedChangesetAfterMainHasMoved(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_team_has_been_created("infra").and().
+		a_team_member_has_been_added("infra", "alice").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`).and().
+		the_plan_has_succeeded().and().
+		a_changeset_has_been_created("changeset2").and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`, "infra").and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_approved_by("alice").and().
+		a_changeset_has_been_merged("changeset1")
+
+	when.
+		a_changeset_is_merged("changeset2")
+
+	then.
+		the_changeset_merge_creation_has_succeeded().and().
+		the_changeset_merge_has_succeeded().and().
+		the_changeset_has_been_rebased_by_the_merge_queue()
+}
+
+func TestApproveChangesetByNonMember(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_team_has_been_created("infra").and().
+		a_team_member_has_been_added("infra", "alice").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "infra").and().
+		the_plan_has_succeeded()
+
+	when.
+		the_changeset_is_approved_by("bob")
+
+	then.
+		the_changeset_approval_has_succeeded().and().
+		the_changeset_approval_is_missing_teams("infra").and().
+		the_changeset_merge_is_requested().and().
+		the_changeset_merge_creation_has_failed()
+}
+
+func TestListComponentsWithOwner(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_team_has_been_created("infra").and().
+		a_team_has_been_created("data").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "infra").and().
+		the_plan_has_succeeded().and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "value2"}`, "data").and().
+		the_plan_has_succeeded()
+
+	when.
+		the_components_of_the_changeset_are_listed_with_the_owner("infra")
+
+	then.
+		the_command_has_succeeded().and().
+		the_listed_components_are("component1")
+}
+
+func TestCreateComponentWithUnknownOwner(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1")
+
+	when.
+		an_owned_component_is_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "unknown")
+
+	then.
+		the_component_creation_has_failed()
+}
+
+func TestValidateMergeWithoutOwnerApproval(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_team_has_been_created("infra").and().
+		a_team_member_has_been_added("infra", "alice").and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		an_owned_component_has_been_created_for_the_module_and_changeset("component1", `{"name": "value1"}`, "infra").and().
+		the_plan_has_succeeded()
+
+	when.
+		the_merge_is_validated()
+
+	then.
+		the_merge_validation_has_succeeded().and().
+		the_merge_is_valid(false).and().
+		the_merge_validation_has_a_finding(versource.MergeFindingMissingApproval)
+}
@@ -0,0 +1,66 @@
+//go:build e2e
+
+package tests
+
+import (
+	"fmt"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *Stage) a_variable_set_has_been_created(name, variables string) *Stage {
+	return s.a_variable_set_is_created(name, variables).and().
+		the_command_has_succeeded()
+}
+
+func (s *Stage) a_variable_set_is_created(name, variables string) *Stage {
+	args := []string{"variable-set", "create", "--name", name, "--changeset", s.ChangesetName}
+	args = append(args, parseVariablesToArgs(variables)...)
+	return s.a_client_command_is_executed(args...)
+}
+
+func (s *Stage) the_variable_set_is_updated(name, variables string) *Stage {
+	args := []string{"variable-set", "update", name, "--changeset", s.ChangesetName}
+	args = append(args, parseVariablesToArgs(variables)...)
+	s.a_client_command_is_executed(args...)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.UpdateVariableSetResponse](s.t, s.LastOutput)
+	if len(response.Plans) > 0 {
+		s.PlanID = fmt.Sprintf("%d", response.Plans[len(response.Plans)-1].ID)
+	}
+	return s
+}
+
+func (s *Stage) the_variable_set_update_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_variable_set_update_has_queued_plans(expectedCount int) *Stage {
+	response := unmarshalResponse[versource.UpdateVariableSetResponse](s.t, s.LastOutput)
+	require.Len(s.t, response.Plans, expectedCount, "Queued plan count mismatch")
+	return s
+}
+
+func (s *Stage) a_component_with_variable_sets_has_been_created_for_the_module_and_changeset(name, variables string, variableSets ...string) *Stage {
+	return s.a_component_with_variable_sets_is_created_for_the_module_and_changeset(name, variables, variableSets...).and().
+		the_component_creation_has_succeeded()
+}
+
+func (s *Stage) a_component_with_variable_sets_is_created_for_the_module_and_changeset(name, variables string, variableSets ...string) *Stage {
+	args := []string{"component", "create", "--name", name, "--changeset", s.ChangesetName, "--module-id", s.ModuleID}
+	for _, variableSet := range variableSets {
+		args = append(args, "--variable-set", variableSet)
+	}
+	args = append(args, parseVariablesToArgs(variables)...)
+	s.a_client_command_is_executed(args...)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.CreateComponentResponse](s.t, s.LastOutput)
+	s.ComponentID = fmt.Sprintf("%d", response.Component.ID)
+	s.PlanID = fmt.Sprintf("%d", response.Plan.ID)
+	return s
+}
@@ -0,0 +1,65 @@
+//go:build e2e && (all || variableset)
+
+package tests
+
+import (
+	"testing"
+)
+
+func TestCreateComponentWithVariableSet(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_variable_set_has_been_created("shared", `{"name": "shared"}`)
+
+	when.
+		a_component_with_variable_sets_is_created_for_the_module_and_changeset("component1", "", "shared")
+
+	then.
+		the_component_creation_has_succeeded().and().
+		the_plan_has_succeeded()
+}
+
+func TestCreateComponentWithUnknownVariableSet(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1")
+
+	when.
+		a_component_with_variable_sets_is_created_for_the_module_and_changeset("component1", "", "unknown")
+
+	then.
+		the_component_creation_has_failed()
+}
+
+func TestUpdateVariableSetPlansAttachedComponents(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		an_existing_module_has_been_created().and().
+		a_changeset_has_been_created("changeset1").and().
+		a_variable_set_has_been_created("shared", `{"name": "shared"}`).and().
+		a_component_with_variable_sets_has_been_created_for_the_module_and_changeset("component1", "", "shared").and().
+		the_plan_has_succeeded().and().
+		a_component_has_been_created_for_the_module_and_changeset("component2", `{"name": "component2"}`).and().
+		the_plan_has_succeeded().and().
+		the_changeset_has_been_merged().and().
+		a_changeset_has_been_created("changeset2")
+
+	when.
+		the_variable_set_is_updated("shared", `{"name": "updated"}`)
+
+	then.
+		the_variable_set_update_has_succeeded().and().
+		the_variable_set_update_has_queued_plans(1).and().
+		the_plan_has_succeeded().and().
+		the_changeset_changes_are_listed().and().
+		there_are_changes(1)
+}
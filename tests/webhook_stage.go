@@ -0,0 +1,97 @@
+//go:build e2e
+
+package tests
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marcbran/versource/pkg/versource"
+	"github.com/stretchr/testify/require"
+)
+
+const unreachableWebhookURL = "http://localhost:1/hook"
+
+func (s *Stage) a_webhook_has_been_created(name string, triggers ...versource.WebhookTrigger) *Stage {
+	return s.a_webhook_is_created(name, unreachableWebhookURL, triggers...).and().
+		the_webhook_creation_has_succeeded()
+}
+
+func (s *Stage) a_webhook_is_created(name, url string, triggers ...versource.WebhookTrigger) *Stage {
+	s.WebhookName = name
+	args := []string{"webhook", "create", "--name", name, "--url", url}
+	for _, trigger := range triggers {
+		args = append(args, "--trigger", string(trigger))
+	}
+	s.a_client_command_is_executed(args...)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.CreateWebhookResponse](s.t, s.LastOutput)
+	require.NotEmpty(s.t, response.Secret, "Webhook secret is empty")
+	return s
+}
+
+func (s *Stage) the_webhook_creation_has_succeeded() *Stage {
+	return s.the_command_has_succeeded()
+}
+
+func (s *Stage) the_webhook_creation_has_failed() *Stage {
+	return s.the_command_has_failed()
+}
+
+func (s *Stage) the_webhook_is_deleted() *Stage {
+	return s.a_client_command_is_executed("webhook", "delete", s.WebhookName)
+}
+
+func (s *Stage) the_webhooks_are_listed() *Stage {
+	return s.a_client_command_is_executed("webhook", "list")
+}
+
+func (s *Stage) there_are_webhooks(expected int) *Stage {
+	webhooks := unmarshalArray[versource.Webhook](s.t, s.LastOutput)
+	require.Len(s.t, webhooks, expected)
+	return s
+}
+
+func (s *Stage) the_webhook_deliveries_are_listed() *Stage {
+	return s.a_client_command_is_executed("webhook", "delivery", "list", "--webhook", s.WebhookName)
+}
+
+func (s *Stage) a_webhook_delivery_is_eventually_attempted(trigger versource.WebhookTrigger) *Stage {
+	for attempt := 0; attempt < 30; attempt++ {
+		s.the_webhook_deliveries_are_listed()
+		deliveries := unmarshalArray[versource.WebhookDelivery](s.t, s.LastOutput)
+		for _, delivery := range deliveries {
+			if delivery.Trigger == trigger && delivery.Attempts > 0 {
+				s.DeliveryID = fmt.Sprintf("%d", delivery.ID)
+				return s
+			}
+		}
+		time.Sleep(2 * time.Second)
+	}
+	require.Fail(s.t, "Webhook delivery was not attempted", string(trigger))
+	return s
+}
+
+func (s *Stage) the_webhook_delivery_is_retried() *Stage {
+	s.a_client_command_is_executed("webhook", "delivery", "get", s.DeliveryID, "--webhook", s.WebhookName)
+	if s.LastExitCode != 0 {
+		return s
+	}
+	response := unmarshalResponse[versource.GetWebhookDeliveryResponse](s.t, s.LastOutput)
+	require.Equal(s.t, versource.WebhookDeliveryStatePending, response.Delivery.State, "Delivery state mismatch")
+	require.NotEmpty(s.t, response.Delivery.Error, "Delivery error is empty")
+	require.NotNil(s.t, response.Delivery.NextAttemptAt, "Delivery has no next attempt")
+	return s
+}
+
+func (s *Stage) the_webhook_delivery_is_redelivered() *Stage {
+	return s.a_client_command_is_executed("webhook", "delivery", "redeliver", s.DeliveryID, "--webhook", s.WebhookName)
+}
+
+func (s *Stage) there_are_webhook_deliveries(expected int) *Stage {
+	deliveries := unmarshalArray[versource.WebhookDelivery](s.t, s.LastOutput)
+	require.Len(s.t, deliveries, expected)
+	return s
+}
@@ -0,0 +1,99 @@
+//go:build e2e && (all || webhook)
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/marcbran/versource/pkg/versource"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance)
+
+	when.
+		a_webhook_is_created("chatops", "https://example.com/hook", versource.WebhookTriggerPlanDestroys, versource.WebhookTriggerApplyFailed)
+
+	then.
+		the_webhook_creation_has_succeeded().and().
+		the_webhooks_are_listed().and().
+		there_are_webhooks(1)
+}
+
+func TestCreateWebhookWithInvalidTriggerFails(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance)
+
+	when.
+		a_webhook_is_created("chatops", "https://example.com/hook", "plan.exploded")
+
+	then.
+		the_webhook_creation_has_failed()
+}
+
+func TestCreateWebhookWithInvalidURLFails(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance)
+
+	when.
+		a_webhook_is_created("chatops", "example.com/hook", versource.WebhookTriggerApplyFailed)
+
+	then.
+		the_webhook_creation_has_failed()
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_webhook_has_been_created("chatops", versource.WebhookTriggerApplyFailed)
+
+	when.
+		the_webhook_is_deleted()
+
+	then.
+		the_command_has_succeeded().and().
+		the_webhooks_are_listed().and().
+		there_are_webhooks(0)
+}
+
+func TestFailedWebhookDeliveryIsRetried(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_webhook_has_been_created("chatops", versource.WebhookTriggerChangesetCreated)
+
+	when.
+		a_changeset_has_been_created("test1")
+
+	then.
+		a_webhook_delivery_is_eventually_attempted(versource.WebhookTriggerChangesetCreated).and().
+		the_webhook_delivery_is_retried()
+}
+
+func TestWebhookDeliveryCanBeRedelivered(t *testing.T) {
+	given, when, then := scenario(t)
+
+	given.
+		the_dataset(blank_instance).and().
+		a_webhook_has_been_created("chatops", versource.WebhookTriggerChangesetCreated).and().
+		a_changeset_has_been_created("test1").and().
+		a_webhook_delivery_is_eventually_attempted(versource.WebhookTriggerChangesetCreated)
+
+	when.
+		the_webhook_delivery_is_redelivered()
+
+	then.
+		the_command_has_succeeded().and().
+		the_webhook_deliveries_are_listed().and().
+		there_are_webhook_deliveries(2)
+}